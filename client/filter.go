@@ -0,0 +1,52 @@
+package client
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"net/url"
+	"strconv"
+)
+
+type FilterClient interface {
+	Filters(token string) ([]model.SavedFilter, error)
+	FilterTasks(token string, ref string) (*model.SmartList, error)
+	AddFilter(token string, definition model.FilterDefinition) (*model.SavedFilter, error)
+	DeleteFilter(token string, id int) (respCode int, err error)
+}
+
+type filterClient struct {
+}
+
+func NewFilterClient() *filterClient {
+	return &filterClient{}
+}
+
+func (f *filterClient) Filters(token string) ([]model.SavedFilter, error) {
+	var filters []model.SavedFilter
+	if _, err := doJSON(token, "GET", "/api/v1/filter/list", nil, &filters); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+func (f *filterClient) FilterTasks(token string, ref string) (*model.SmartList, error) {
+	var list model.SmartList
+	if _, err := doJSON(token, "GET", "/api/v1/filter/tasks/"+url.PathEscape(ref), nil, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (f *filterClient) AddFilter(token string, definition model.FilterDefinition) (*model.SavedFilter, error) {
+	var filter model.SavedFilter
+	if _, err := doJSON(token, "POST", "/api/v1/filter/add", definition, &filter); err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+func (f *filterClient) DeleteFilter(token string, id int) (respCode int, err error) {
+	return doJSON(token, "DELETE", "/api/v1/filter/delete/"+strconv.Itoa(id), nil, nil)
+}
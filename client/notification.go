@@ -0,0 +1,56 @@
+package client
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"strconv"
+)
+
+type NotificationClient interface {
+	Notifications(token string) ([]model.Notification, error)
+	MarkRead(token string, id int) (respCode int, err error)
+	MarkAllRead(token string) (respCode int, err error)
+	Watches(token string) ([]model.Watch, error)
+	Watch(token string, target model.WatchTarget, id int, watch bool) (respCode int, err error)
+}
+
+type notificationClient struct {
+}
+
+func NewNotificationClient() *notificationClient {
+	return &notificationClient{}
+}
+
+func (n *notificationClient) Notifications(token string) ([]model.Notification, error) {
+	var notifications []model.Notification
+	if _, err := doJSON(token, "GET", "/api/v1/notification/list", nil, &notifications); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (n *notificationClient) MarkRead(token string, id int) (respCode int, err error) {
+	return doJSON(token, "PUT", "/api/v1/notification/read/"+strconv.Itoa(id), nil, nil)
+}
+
+func (n *notificationClient) MarkAllRead(token string) (respCode int, err error) {
+	return doJSON(token, "PUT", "/api/v1/notification/read", nil, nil)
+}
+
+func (n *notificationClient) Watches(token string) ([]model.Watch, error) {
+	var watches []model.Watch
+	if _, err := doJSON(token, "GET", "/api/v1/notification/watches", nil, &watches); err != nil {
+		return nil, err
+	}
+
+	return watches, nil
+}
+
+func (n *notificationClient) Watch(token string, target model.WatchTarget, id int, watch bool) (respCode int, err error) {
+	method := "DELETE"
+	if watch {
+		method = "POST"
+	}
+
+	return doJSON(token, method, "/api/v1/"+string(target)+"/"+strconv.Itoa(id)+"/watch", nil, nil)
+}
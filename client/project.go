@@ -0,0 +1,51 @@
+package client
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"strconv"
+)
+
+type ProjectClient interface {
+	ProjectList(token string) ([]model.Project, error)
+	AddProject(token, name, description string) (respCode int, err error)
+	Overview(token string, id int) (*model.ProjectOverview, error)
+	AddMilestone(token string, id int, title string, dueDate model.Deadline) (respCode int, err error)
+	AddCategory(token string, id, categoryID int) (respCode int, err error)
+}
+
+type projectClient struct {
+}
+
+func NewProjectClient() *projectClient {
+	return &projectClient{}
+}
+
+func (p *projectClient) ProjectList(token string) ([]model.Project, error) {
+	var projects []model.Project
+	if _, err := doJSON(token, "GET", "/api/v1/project/list", nil, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (p *projectClient) AddProject(token, name, description string) (respCode int, err error) {
+	return doJSON(token, "POST", "/api/v1/project/add", model.ProjectRequest{Name: name, Description: description}, nil)
+}
+
+func (p *projectClient) Overview(token string, id int) (*model.ProjectOverview, error) {
+	var overview model.ProjectOverview
+	if _, err := doJSON(token, "GET", "/api/v1/project/"+strconv.Itoa(id)+"/overview", nil, &overview); err != nil {
+		return nil, err
+	}
+
+	return &overview, nil
+}
+
+func (p *projectClient) AddMilestone(token string, id int, title string, dueDate model.Deadline) (respCode int, err error) {
+	return doJSON(token, "POST", "/api/v1/project/"+strconv.Itoa(id)+"/milestones", model.MilestoneRequest{Title: title, DueDate: dueDate}, nil)
+}
+
+func (p *projectClient) AddCategory(token string, id, categoryID int) (respCode int, err error) {
+	return doJSON(token, "PUT", "/api/v1/project/"+strconv.Itoa(id)+"/categories/"+strconv.Itoa(categoryID), nil, nil)
+}
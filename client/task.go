@@ -6,16 +6,50 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 type TaskClient interface {
 	TaskList(token string) ([]*model.Task, error)
+	SearchTasks(token string, query string) ([]*model.Task, error)
+	SearchText(token string, text string) ([]model.SearchHit, error)
 	AddTask(token string, task model.Task) (respCode int, err error)
 	UpdateTask(token string, task model.Task) (respCode int, err error)
 	DeleteTask(token string, id int) (respCode int, err error)
+	TransitionTask(token string, id int, status model.TaskStatus) (respCode int, err error)
+	StatusList(token string) (*model.StatusList, error)
+	TagList(token string) (*model.TagList, error)
+	GetTask(token string, id int) (*model.Task, error)
+	CommentList(token string, id, page int) (*model.CommentPage, error)
+	AddComment(token string, id int, body string) (respCode int, err error)
+	AttachmentList(token string, id int) ([]model.Attachment, error)
+	UploadAttachment(token string, id int, fileName, contentType string, content io.Reader) (respCode int, err error)
+	TaskDependencies(token string, id int) (*model.TaskDependencies, error)
+	AddBlocker(token string, id, blockerID int) (respCode int, err error)
+	TaskTime(token string, id int) (*model.TaskTime, error)
+	RunningTimer(token string) (*model.TimeEntry, error)
+	StartTimer(token string, id int) (respCode int, err error)
+	StopTimer(token string) (respCode int, err error)
+	EstimateRollup(token string) (*model.EstimateRollup, error)
+	Assignees(token string, id int) ([]model.UserProfile, error)
+	AssignedTasks(token string) ([]model.Task, error)
+	AssignTask(token string, id int, email string) (respCode int, err error)
+	AddChecklistItem(token string, id int, title string) (respCode int, err error)
+	ToggleChecklistItem(token string, id, itemID int) (respCode int, err error)
+	Board(token string) (*model.Board, error)
+	MoveTask(token string, id int, move model.MoveRequest) (respCode int, err error)
+	FieldList(token string) ([]model.CustomField, error)
+	SetTaskFields(token string, id int, fields map[int]interface{}) (respCode int, err error)
+	ArchiveTask(token string, id int, archived bool) (respCode int, err error)
+	TaskHistory(token string, id int) ([]model.TaskEvent, error)
 }
 
 type taskClient struct {
@@ -75,6 +109,11 @@ func (t *taskClient) AddTask(token string, task model.Task) (respCode int, err e
 		"status":      task.Status,
 		"category_id": task.CategoryID,
 		"user_id":     task.UserID,
+		"parent_id":   task.ParentID,
+		"recurrence":  task.Recurrence,
+
+		"estimate_hours": task.EstimateHours,
+		"story_points":   task.StoryPoints,
 	}
 
 	data, err := json.Marshal(datajson)
@@ -115,6 +154,11 @@ func (t *taskClient) UpdateTask(token string, task model.Task) (respCode int, er
 		"status":      task.Status,
 		"category_id": task.CategoryID,
 		"user_id":     task.UserID,
+		"parent_id":   task.ParentID,
+		"recurrence":  task.Recurrence,
+
+		"estimate_hours": task.EstimateHours,
+		"story_points":   task.StoryPoints,
 	}
 
 	data, err := json.Marshal(datajson)
@@ -167,3 +211,753 @@ func (t *taskClient) DeleteTask(token string, id int) (respCode int, err error)
 
 	return resp.StatusCode, nil
 }
+
+func (t *taskClient) TransitionTask(token string, id int, status model.TaskStatus) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	data, err := json.Marshal(model.TransitionRequest{Status: status})
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl("/api/v1/task/transition/"+strconv.Itoa(id)), bytes.NewBuffer(data))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var errResp model.ErrorResponse
+		b, _ := ioutil.ReadAll(resp.Body)
+		if json.Unmarshal(b, &errResp) == nil && errResp.Error != "" {
+			return resp.StatusCode, errors.New(errResp.Error)
+		}
+		return resp.StatusCode, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) StatusList(token string) (*model.StatusList, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/status/list"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var statusList model.StatusList
+	err = json.Unmarshal(b, &statusList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statusList, nil
+}
+
+func (t *taskClient) TagList(token string) (*model.TagList, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/tag/list"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var tagList model.TagList
+	err = json.Unmarshal(b, &tagList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tagList, nil
+}
+
+func (t *taskClient) GetTask(token string, id int) (*model.Task, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/get/"+strconv.Itoa(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var task model.Task
+	err = json.Unmarshal(b, &task)
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (t *taskClient) CommentList(token string, id, page int) (*model.CommentPage, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/comments?page="+strconv.Itoa(page)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var comments model.CommentPage
+	err = json.Unmarshal(b, &comments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comments, nil
+}
+
+func (t *taskClient) AddComment(token string, id int, body string) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	data, err := json.Marshal(model.CommentRequest{Body: body})
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/comments"), bytes.NewBuffer(data))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return -1, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) AttachmentList(token string, id int) ([]model.Attachment, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/attachments"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var attachments []model.Attachment
+	err = json.Unmarshal(b, &attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// UploadAttachment forwards the file as a multipart form. Rejected uploads report the reason given by the
+// API, such as a file that is too large or of a type that is not allowed.
+func (t *taskClient) UploadAttachment(token string, id int, fileName, contentType string, content io.Reader) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(fileName)))
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return -1, err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return -1, err
+	}
+	if err := form.Close(); err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/attachments"), &body)
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var errResp model.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error != "" {
+			return resp.StatusCode, errors.New(errResp.Error)
+		}
+		return resp.StatusCode, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) TaskDependencies(token string, id int) (*model.TaskDependencies, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/dependencies"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var dependencies model.TaskDependencies
+	err = json.Unmarshal(b, &dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dependencies, nil
+}
+
+// AddBlocker makes blockerID block the task. Refused dependencies report the reason given by the API,
+// such as a cycle.
+func (t *taskClient) AddBlocker(token string, id, blockerID int) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/blockers/"+strconv.Itoa(blockerID)), nil)
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var errResp model.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error != "" {
+			return resp.StatusCode, errors.New(errResp.Error)
+		}
+		return resp.StatusCode, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) TaskTime(token string, id int) (*model.TaskTime, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/time"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var taskTime model.TaskTime
+	err = json.Unmarshal(b, &taskTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskTime, nil
+}
+
+// RunningTimer returns the running timer of the logged-in user, or nil when none is running.
+func (t *taskClient) RunningTimer(token string) (*model.TimeEntry, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/time/running"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var entry model.TimeEntry
+	err = json.Unmarshal(b, &entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// StartTimer starts a timer on the task. A refused timer reports the reason given by the API, such as
+// another timer still running.
+func (t *taskClient) StartTimer(token string, id int) (respCode int, err error) {
+	return t.timerRequest(token, "/api/v1/task/"+strconv.Itoa(id)+"/timer/start")
+}
+
+func (t *taskClient) StopTimer(token string) (respCode int, err error) {
+	return t.timerRequest(token, "/api/v1/time/stop")
+}
+
+func (t *taskClient) timerRequest(token, path string) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl(path), nil)
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var errResp model.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error != "" {
+			return resp.StatusCode, errors.New(errResp.Error)
+		}
+		return resp.StatusCode, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) EstimateRollup(token string) (*model.EstimateRollup, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/estimates"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var rollup model.EstimateRollup
+	err = json.Unmarshal(b, &rollup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rollup, nil
+}
+
+func (t *taskClient) Assignees(token string, id int) ([]model.UserProfile, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/assignees"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var assignees []model.UserProfile
+	err = json.Unmarshal(b, &assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignees, nil
+}
+
+func (t *taskClient) AssignedTasks(token string) ([]model.Task, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/task/assigned"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var tasks []model.Task
+	err = json.Unmarshal(b, &tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// AssignTask assigns the user with the email address to the task. Refused assignments report the
+// reason given by the API, such as an unknown address.
+func (t *taskClient) AssignTask(token string, id int, email string) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	data, err := json.Marshal(model.AssignRequest{Email: email})
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/assignees"), bytes.NewBuffer(data))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var errResp model.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error != "" {
+			return resp.StatusCode, errors.New(errResp.Error)
+		}
+		return resp.StatusCode, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) AddChecklistItem(token string, id int, title string) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	data, err := json.Marshal(model.ChecklistItem{Title: title})
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/checklist"), bytes.NewBuffer(data))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return -1, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) ToggleChecklistItem(token string, id, itemID int) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("PUT", config.SetUrl("/api/v1/task/"+strconv.Itoa(id)+"/checklist/"+strconv.Itoa(itemID)+"/toggle"), nil)
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return -1, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (t *taskClient) Board(token string) (*model.Board, error) {
+	var board model.Board
+	if _, err := doJSON(token, "GET", "/api/v1/task/board", nil, &board); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+func (t *taskClient) MoveTask(token string, id int, move model.MoveRequest) (respCode int, err error) {
+	return doJSON(token, "PUT", "/api/v1/task/move/"+strconv.Itoa(id), move, nil)
+}
+
+func (t *taskClient) FieldList(token string) ([]model.CustomField, error) {
+	var fields []model.CustomField
+	if _, err := doJSON(token, "GET", "/api/v1/field/list", nil, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func (t *taskClient) SetTaskFields(token string, id int, fields map[int]interface{}) (respCode int, err error) {
+	return doJSON(token, "PUT", "/api/v1/task/"+strconv.Itoa(id)+"/fields", fields, nil)
+}
+
+func (t *taskClient) ArchiveTask(token string, id int, archived bool) (respCode int, err error) {
+	method := "POST"
+	if !archived {
+		method = "DELETE"
+	}
+	return doJSON(token, method, "/api/v1/task/"+strconv.Itoa(id)+"/archive", nil, nil)
+}
+
+func (t *taskClient) TaskHistory(token string, id int) ([]model.TaskEvent, error) {
+	var events []model.TaskEvent
+	if _, err := doJSON(token, "GET", "/api/v1/task/"+strconv.Itoa(id)+"/history", nil, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (t *taskClient) SearchTasks(token string, query string) ([]*model.Task, error) {
+	var tasks []*model.Task
+	if _, err := doJSON(token, "GET", "/api/v1/task/search?q="+url.QueryEscape(query), nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (t *taskClient) SearchText(token string, text string) ([]model.SearchHit, error) {
+	var hits []model.SearchHit
+	if _, err := doJSON(token, "GET", "/api/v1/search?q="+url.QueryEscape(text), nil, &hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
@@ -15,6 +15,10 @@ type UserClient interface {
 	Register(fullname, email, password string) (respCode int, err error)
 
 	GetUserTaskCategory(token string) (*[]model.UserTaskCategory, error)
+	GetProfile(token string) (*model.UserProfile, error)
+	UpdateProfile(token, fullname, timeZone string) (respCode int, err error)
+	DeleteAccount(token string) (respCode int, err error)
+	RestoreAccount(email, password string) (respCode int, err error)
 }
 
 type userClient struct {
@@ -131,3 +135,132 @@ func (u *userClient) GetUserTaskCategory(token string) (*[]model.UserTaskCategor
 
 	return &userTasks, nil
 }
+
+func (u *userClient) GetProfile(token string) (*model.UserProfile, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", config.SetUrl("/api/v1/user/profile"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("status code not 200")
+	}
+
+	var profile model.UserProfile
+	err = json.Unmarshal(b, &profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (u *userClient) UpdateProfile(token, fullname, timeZone string) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	datajson := map[string]string{
+		"fullname":  fullname,
+		"time_zone": timeZone,
+	}
+
+	data, err := json.Marshal(datajson)
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("PUT", config.SetUrl("/api/v1/user/profile"), bytes.NewBuffer(data))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return -1, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (u *userClient) DeleteAccount(token string) (respCode int, err error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("DELETE", config.SetUrl("/api/v1/user/delete"), nil)
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return -1, errors.New("status code not 200")
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (u *userClient) RestoreAccount(email, password string) (respCode int, err error) {
+	datajson := map[string]string{
+		"email":    email,
+		"password": password,
+	}
+
+	data, err := json.Marshal(datajson)
+	if err != nil {
+		return -1, err
+	}
+
+	req, err := http.NewRequest("POST", config.SetUrl("/api/v1/user/restore"), bytes.NewBuffer(data))
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type WorkspaceClient interface {
+	WorkspaceList(token string) ([]model.Membership, error)
+	AddWorkspace(token, name string) (respCode int, err error)
+	SelectWorkspace(token string, id int) (respCode int, err error)
+	Invite(token string, id int, email string, role model.WorkspaceRole) (respCode int, err error)
+	Invitations(token string) ([]model.Invitation, error)
+	RespondInvitation(token string, id int, accept bool) (respCode int, err error)
+}
+
+type workspaceClient struct {
+}
+
+func NewWorkspaceClient() *workspaceClient {
+	return &workspaceClient{}
+}
+
+func (w *workspaceClient) WorkspaceList(token string) ([]model.Membership, error) {
+	var workspaces []model.Membership
+	if _, err := doJSON(token, "GET", "/api/v1/workspace/list", nil, &workspaces); err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+func (w *workspaceClient) AddWorkspace(token, name string) (respCode int, err error) {
+	return doJSON(token, "POST", "/api/v1/workspace/add", model.WorkspaceRequest{Name: name}, nil)
+}
+
+func (w *workspaceClient) SelectWorkspace(token string, id int) (respCode int, err error) {
+	return doJSON(token, "PUT", "/api/v1/workspace/select/"+strconv.Itoa(id), nil, nil)
+}
+
+func (w *workspaceClient) Invite(token string, id int, email string, role model.WorkspaceRole) (respCode int, err error) {
+	return doJSON(token, "POST", "/api/v1/workspace/"+strconv.Itoa(id)+"/invitations", model.InvitationRequest{Email: email, Role: role}, nil)
+}
+
+func (w *workspaceClient) Invitations(token string) ([]model.Invitation, error) {
+	var invitations []model.Invitation
+	if _, err := doJSON(token, "GET", "/api/v1/workspace/invitations", nil, &invitations); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (w *workspaceClient) RespondInvitation(token string, id int, accept bool) (respCode int, err error) {
+	action := "decline"
+	if accept {
+		action = "accept"
+	}
+
+	return doJSON(token, "POST", "/api/v1/workspace/invitations/"+strconv.Itoa(id)+"/"+action, nil, nil)
+}
+
+// doJSON sends a request to the API, encoding body as JSON when given and decoding the response into out when given.
+// The error message of a failed request is passed on so it can be shown to the user.
+func doJSON(token, method, path string, body, out interface{}) (int, error) {
+	client, err := GetClientWithCookie(token)
+	if err != nil {
+		return -1, err
+	}
+
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return -1, err
+		}
+		payload = bytes.NewBuffer(data)
+	}
+
+	req, err := http.NewRequest(method, config.SetUrl(path), payload)
+	if err != nil {
+		return -1, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var errResp model.ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil && errResp.Error != "" {
+			return resp.StatusCode, errors.New(errResp.Error)
+		}
+		return resp.StatusCode, errors.New("status code not 200")
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+
+	return resp.StatusCode, nil
+}
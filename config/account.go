@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// AdminEmails is a comma separated list of users allowed to call the admin endpoints
+	AdminEmails = os.Getenv("ADMIN_EMAILS")
+
+	// DeletionGracePeriod is how long a deleted account can still be restored before it is erased
+	DeletionGracePeriod = os.Getenv("DELETION_GRACE_PERIOD")
+
+	// TrashRetention is how long deleted tasks and categories stay in the trash before they are purged
+	TrashRetention = os.Getenv("TRASH_RETENTION")
+)
+
+func IsAdmin(email string) bool {
+	if email == "" {
+		return false
+	}
+
+	for _, admin := range strings.Split(AdminEmails, ",") {
+		if strings.EqualFold(strings.TrimSpace(admin), email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func GetDeletionGracePeriod() time.Duration {
+	period, err := time.ParseDuration(DeletionGracePeriod)
+	if err != nil || period < 0 {
+		return 30 * 24 * time.Hour
+	}
+
+	return period
+}
+
+func GetTrashRetention() time.Duration {
+	retention, err := time.ParseDuration(TrashRetention)
+	if err != nil || retention < 0 {
+		return 30 * 24 * time.Hour
+	}
+
+	return retention
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// DeadlineReminder is how long before its deadline the people following a task are reminded of it
+var DeadlineReminder = os.Getenv("DEADLINE_REMINDER")
+
+func GetDeadlineReminder() time.Duration {
+	reminder, err := time.ParseDuration(DeadlineReminder)
+	if err != nil || reminder <= 0 {
+		return 24 * time.Hour
+	}
+
+	return reminder
+}
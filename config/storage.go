@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	// BlobStore selects where attachment content is kept: "local" (the default) or "s3"
+	BlobStore = os.Getenv("BLOB_STORE")
+
+	// BlobDir is the directory of the local blob store
+	BlobDir = os.Getenv("BLOB_DIR")
+
+	// S3Endpoint, S3Bucket, S3Region, S3AccessKey and S3SecretKey configure the S3-compatible blob store
+	S3Endpoint  = os.Getenv("S3_ENDPOINT")
+	S3Bucket    = os.Getenv("S3_BUCKET")
+	S3Region    = os.Getenv("S3_REGION")
+	S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+	S3SecretKey = os.Getenv("S3_SECRET_KEY")
+
+	// MaxAttachmentSize is the largest file, in bytes, that can be attached to a task
+	MaxAttachmentSize = os.Getenv("MAX_ATTACHMENT_SIZE")
+
+	// AttachmentTypes is a comma separated list of the MIME types that can be attached to a task
+	AttachmentTypes = os.Getenv("ATTACHMENT_TYPES")
+)
+
+func GetBlobDir() string {
+	if BlobDir == "" {
+		return "attachments"
+	}
+
+	return BlobDir
+}
+
+func GetS3Region() string {
+	if S3Region == "" {
+		return "us-east-1"
+	}
+
+	return S3Region
+}
+
+func GetMaxAttachmentSize() int64 {
+	size, err := strconv.ParseInt(MaxAttachmentSize, 10, 64)
+	if err != nil || size <= 0 {
+		return 10 << 20
+	}
+
+	return size
+}
+
+func GetAttachmentTypes() []string {
+	if AttachmentTypes == "" {
+		return []string{
+			"image/png", "image/jpeg", "image/gif", "image/webp",
+			"application/pdf", "text/plain", "text/csv",
+			"application/msword",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			"application/vnd.ms-excel",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		}
+	}
+
+	var types []string
+	for _, t := range strings.Split(AttachmentTypes, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
@@ -0,0 +1,35 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrBlobNotFound is returned when no content is stored under a key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps the content of uploaded files. Keys are slash separated paths such as
+// "tasks/1/3f2a9c"; implementations map them to files or objects.
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any previous content.
+	Put(key string, r io.Reader, size int64, contentType string) error
+	// Get opens the content stored under key. The caller closes the returned reader.
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key. Deleting a missing key is not an error.
+	Delete(key string) error
+}
+
+// validKey reports whether key is a relative path without empty, "." or ".." segments, so that
+// it cannot escape the directory or bucket it is stored in.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
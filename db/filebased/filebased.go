@@ -1,10 +1,15 @@
 package filebased
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"a21hc3NpZ25tZW50/model"
@@ -39,7 +44,115 @@ func InitDB() (*Data, error) {
 		if err != nil {
 			return fmt.Errorf("create sessions bucket: %v", err)
 		}
-		return nil
+		_, err = tx.CreateBucketIfNotExists([]byte("Audit"))
+		if err != nil {
+			return fmt.Errorf("create audit bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Statuses"))
+		if err != nil {
+			return fmt.Errorf("create statuses bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Transitions"))
+		if err != nil {
+			return fmt.Errorf("create transitions bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Tags"))
+		if err != nil {
+			return fmt.Errorf("create tags bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("TaskTags"))
+		if err != nil {
+			return fmt.Errorf("create task tags bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Comments"))
+		if err != nil {
+			return fmt.Errorf("create comments bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Attachments"))
+		if err != nil {
+			return fmt.Errorf("create attachments bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("OrphanedBlobs"))
+		if err != nil {
+			return fmt.Errorf("create orphaned blobs bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Dependencies"))
+		if err != nil {
+			return fmt.Errorf("create dependencies bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("TimeEntries"))
+		if err != nil {
+			return fmt.Errorf("create time entries bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Assignees"))
+		if err != nil {
+			return fmt.Errorf("create assignees bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Workspaces"))
+		if err != nil {
+			return fmt.Errorf("create workspaces bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("WorkspaceMembers"))
+		if err != nil {
+			return fmt.Errorf("create workspace members bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Invitations"))
+		if err != nil {
+			return fmt.Errorf("create invitations bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Projects"))
+		if err != nil {
+			return fmt.Errorf("create projects bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Milestones"))
+		if err != nil {
+			return fmt.Errorf("create milestones bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Sprints"))
+		if err != nil {
+			return fmt.Errorf("create sprints bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Templates"))
+		if err != nil {
+			return fmt.Errorf("create templates bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("CustomFields"))
+		if err != nil {
+			return fmt.Errorf("create custom fields bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Trash"))
+		if err != nil {
+			return fmt.Errorf("create trash bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("TaskHistory"))
+		if err != nil {
+			return fmt.Errorf("create task history bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Watches"))
+		if err != nil {
+			return fmt.Errorf("create watches bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Notifications"))
+		if err != nil {
+			return fmt.Errorf("create notifications bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("SearchIndex"))
+		if err != nil {
+			return fmt.Errorf("create search index bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("SearchDocs"))
+		if err != nil {
+			return fmt.Errorf("create search docs bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("SearchStats"))
+		if err != nil {
+			return fmt.Errorf("create search stats bucket: %v", err)
+		}
+		_, err = tx.CreateBucketIfNotExists([]byte("Filters"))
+		if err != nil {
+			return fmt.Errorf("create filters bucket: %v", err)
+		}
+		return migrate(tx)
 	})
 	if err != nil {
 		return nil, err
@@ -48,15 +161,132 @@ func InitDB() (*Data, error) {
 	return &Data{DB: db}, nil
 }
 
-func (data *Data) StoreTask(task model.Task) error {
+// StoreTasks stores new tasks on behalf of the actor in a single transaction, so either all of them or none are created.
+// Each task gets the next free ID and a rank after the tasks stored before it, written back to the task.
+func (data *Data) StoreTasks(tasks []*model.Task, actor string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		for _, task := range tasks {
+			task.ID = nextTaskID(tx)
+			task.Rank = model.RankBetween(lastTaskRank(tx), "")
+
+			if err := putTask(tx, *task, actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// StoreTask stores the task under its ID and records the change in its history as made by the actor. A task without
+// an ID gets the next free one, which is written back to task.ID.
+func (data *Data) StoreTask(task *model.Task, actor string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if task.ID == 0 {
+			task.ID = nextTaskID(tx)
+		}
+		if task.Rank == "" {
+			task.Rank = model.RankBetween(lastTaskRank(tx), "")
+		}
+		return putTask(tx, *task, actor)
+	})
+}
+
+// putTask writes the task and records the change in its history on behalf of the actor: a created event for a new
+// task and an updated event listing the changed fields otherwise. Writes that change nothing are not recorded.
+func putTask(tx *bbolt.Tx, task model.Task, actor string) error {
+	b := tx.Bucket([]byte("Tasks"))
+	key := []byte(fmt.Sprintf("%d", task.ID))
+
+	var before *model.Task
+	if v := b.Get(key); v != nil {
+		before = &model.Task{}
+		if err := json.Unmarshal(v, before); err != nil {
+			return err
+		}
+	}
+
 	taskJSON, err := json.Marshal(task)
 	if err != nil {
 		return err
 	}
-	return data.DB.Update(func(tx *bbolt.Tx) error {
-		b := tx.Bucket([]byte("Tasks"))
-		return b.Put([]byte(fmt.Sprintf("%d", task.ID)), taskJSON)
+	if err := b.Put(key, taskJSON); err != nil {
+		return err
+	}
+	if err := noteTaskRank(tx, task.Rank); err != nil {
+		return err
+	}
+
+	if before == nil {
+		return putTaskEvent(tx, model.EventCreated, actor, model.Task{ID: task.ID}, task)
+	}
+	return putTaskEvent(tx, model.EventUpdated, actor, *before, task)
+}
+
+// putTaskEvent appends an event to the history of the task with the fields that differ between before and after.
+// Updated events without changes are skipped. As every change to a task passes through here, the search index is
+// updated here too.
+func putTaskEvent(tx *bbolt.Tx, action model.EventAction, actor string, before, after model.Task) error {
+	if err := indexTask(tx, action, before, after); err != nil {
+		return err
+	}
+
+	changes, err := model.DiffTasks(before, after)
+	if err != nil {
+		return err
+	}
+	if action == model.EventUpdated && len(changes) == 0 {
+		return nil
+	}
+
+	b := tx.Bucket([]byte("TaskHistory"))
+	id, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	event := model.TaskEvent{
+		ID:        int(id),
+		TaskID:    after.ID,
+		Action:    action,
+		Actor:     actor,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshaling task event: %v", err)
+	}
+	return b.Put(itob(event.ID), eventJSON)
+}
+
+// updateTasksWhere applies the update to every task it accepts, like updateWhere, and records the changes in the
+// history of the updated tasks on behalf of the actor.
+func updateTasksWhere(tx *bbolt.Tx, actor string, update func(task *model.Task) bool) error {
+	var befores, afters []model.Task
+	err := updateWhere(tx.Bucket([]byte("Tasks")), func(v []byte) ([]byte, bool) {
+		var before, task model.Task
+		if json.Unmarshal(v, &before) != nil || json.Unmarshal(v, &task) != nil || !update(&task) {
+			return nil, false
+		}
+
+		taskJSON, err := json.Marshal(task)
+		if err != nil {
+			return nil, false
+		}
+		befores = append(befores, before)
+		afters = append(afters, task)
+		return taskJSON, true
 	})
+	if err != nil {
+		return err
+	}
+
+	for i := range afters {
+		if err := putTaskEvent(tx, model.EventUpdated, actor, befores[i], afters[i]); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (data *Data) StoreCategory(category model.Category) error {
@@ -66,29 +296,149 @@ func (data *Data) StoreCategory(category model.Category) error {
 	}
 	return data.DB.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket([]byte("Categories"))
-		return b.Put([]byte(fmt.Sprintf("%d", category.ID)), categoryJSON)
+		if err := b.Put([]byte(fmt.Sprintf("%d", category.ID)), categoryJSON); err != nil {
+			return err
+		}
+		return indexSearchDoc(tx, model.SearchKindCategory, category.ID, category.WorkspaceID, category.Name)
 	})
 }
 
-func (data *Data) UpdateTask(id int, task model.Task) error {
-	return data.StoreTask(task) // Reuse StoreTask as it will replace the existing entry
+func (data *Data) UpdateTask(id int, task model.Task, actor string) error {
+	return data.StoreTask(&task, actor) // Reuse StoreTask as it will replace the existing entry
 }
 
 func (data *Data) UpdateCategory(id int, category model.Category) error {
 	return data.StoreCategory(category) // Reuse StoreCategory as it will replace the existing entry
 }
 
-func (data *Data) DeleteTask(id int) error {
+// DeleteTask deletes the task together with all of its subtasks, at any depth, and their tag
+// assignments, assignees, dependencies, comments, time entries and attachments. The content of the attachments is queued in
+// OrphanedBlobs to be removed from the blob store. A deleted event is recorded in the history of every deleted task on
+// behalf of the actor.
+func (data *Data) DeleteTask(id int, actor string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tasks"))
+		children := childTaskIDs(b)
+
+		deleted := map[int]bool{}
+		pending := []int{id}
+		for len(pending) > 0 {
+			current := pending[0]
+			pending = pending[1:]
+			if deleted[current] {
+				continue
+			}
+			deleted[current] = true
+
+			key := []byte(fmt.Sprintf("%d", current))
+			if v := b.Get(key); v != nil {
+				var task model.Task
+				if err := json.Unmarshal(v, &task); err != nil {
+					return err
+				}
+				if err := putTaskEvent(tx, model.EventDeleted, actor, task, task); err != nil {
+					return err
+				}
+			}
+			if err := b.Delete(key); err != nil {
+				return err
+			}
+			if err := deleteTaskTags(tx, current); err != nil {
+				return err
+			}
+			if err := deleteTaskDependencies(tx, current); err != nil {
+				return err
+			}
+			if err := deleteTaskComments(tx, current); err != nil {
+				return err
+			}
+			if err := deleteTaskTimeEntries(tx, current); err != nil {
+				return err
+			}
+			if err := deleteTaskAssignments(tx, current); err != nil {
+				return err
+			}
+			if err := orphanAttachmentsWhere(tx, func(attachment model.Attachment) bool {
+				return attachment.TaskID == current
+			}); err != nil {
+				return err
+			}
+			pending = append(pending, children[current]...)
+		}
+		return nil
+	})
+}
+
+// DeleteTaskKeepChildren deletes the task with its tag assignments, assignees, dependencies, comments, time entries and attachments and
+// moves its direct subtasks up to the task's own parent, in a single transaction, recording both in the history of the tasks
+// on behalf of the actor.
+func (data *Data) DeleteTaskKeepChildren(id int, actor string) error {
 	return data.DB.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket([]byte("Tasks"))
+		v := b.Get([]byte(fmt.Sprintf("%d", id)))
+		if v == nil {
+			return nil
+		}
+
+		var task model.Task
+		if err := json.Unmarshal(v, &task); err != nil {
+			return err
+		}
+
+		if err := reparentChildren(tx, id, task.ParentID, actor); err != nil {
+			return err
+		}
+		if err := putTaskEvent(tx, model.EventDeleted, actor, task, task); err != nil {
+			return err
+		}
+		if err := deleteTaskTags(tx, id); err != nil {
+			return err
+		}
+		if err := deleteTaskDependencies(tx, id); err != nil {
+			return err
+		}
+		if err := deleteTaskComments(tx, id); err != nil {
+			return err
+		}
+		if err := deleteTaskTimeEntries(tx, id); err != nil {
+			return err
+		}
+		if err := deleteTaskAssignments(tx, id); err != nil {
+			return err
+		}
+		if err := orphanAttachmentsWhere(tx, func(attachment model.Attachment) bool {
+			return attachment.TaskID == id
+		}); err != nil {
+			return err
+		}
 		return b.Delete([]byte(fmt.Sprintf("%d", id)))
 	})
 }
 
+// reparentChildren moves the direct subtasks of the task under the given parent on behalf of the actor.
+func reparentChildren(tx *bbolt.Tx, id, parentID int, actor string) error {
+	b := tx.Bucket([]byte("Tasks"))
+	for _, childID := range childTaskIDs(b)[id] {
+		var child model.Task
+		if err := json.Unmarshal(b.Get([]byte(fmt.Sprintf("%d", childID))), &child); err != nil {
+			return err
+		}
+		child.ParentID = parentID
+
+		if err := putTask(tx, child, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (data *Data) DeleteCategory(id int) error {
 	return data.DB.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket([]byte("Categories"))
-		return b.Delete([]byte(fmt.Sprintf("%d", id)))
+		if err := b.Delete([]byte(fmt.Sprintf("%d", id))); err != nil {
+			return err
+		}
+		return unindexSearchDoc(tx, model.SearchKindCategory, id)
 	})
 }
 
@@ -124,7 +474,17 @@ func (data *Data) GetCategoryByID(id int) (*model.Category, error) {
 	return &category, nil
 }
 
-func (data *Data) GetTasks() ([]model.Task, error) {
+// GetTasks returns the tasks of the workspace.
+func (data *Data) GetTasks(workspaceID int) ([]model.Task, error) {
+	return data.tasksWhere(func(task model.Task) bool { return task.WorkspaceID == workspaceID })
+}
+
+// GetTasksByUser returns the tasks owned by the user, in every workspace.
+func (data *Data) GetTasksByUser(userID int) ([]model.Task, error) {
+	return data.tasksWhere(func(task model.Task) bool { return task.UserID == userID })
+}
+
+func (data *Data) tasksWhere(match func(task model.Task) bool) ([]model.Task, error) {
 	var tasks []model.Task
 	err := data.DB.View(func(tx *bbolt.Tx) error {
 		b := tx.Bucket([]byte("Tasks"))
@@ -134,7 +494,9 @@ func (data *Data) GetTasks() ([]model.Task, error) {
 				log.Println("Error unmarshaling task:", err)
 				return nil // Continue despite error
 			}
-			tasks = append(tasks, task)
+			if match(task) {
+				tasks = append(tasks, task)
+			}
 			return nil
 		})
 	})
@@ -144,79 +506,292 @@ func (data *Data) GetTasks() ([]model.Task, error) {
 	return tasks, nil
 }
 
-func (data *Data) GetCategories() ([]model.Category, error) {
-	var categories []model.Category
-	err := data.DB.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket([]byte("Categories"))
-		return b.ForEach(func(k, v []byte) error {
-			var category model.Category
-			if err := json.Unmarshal(v, &category); err != nil {
-				log.Println("Error unmarshaling category:", err)
-				return nil // Continue despite error
-			}
-			categories = append(categories, category)
-			return nil
-		})
-	})
+// QueryTasks returns the page of the tasks of the workspace that match the query, in its sort order, together with
+// the cursor of the next page. Filtering, sorting and paging happen in one read transaction, so a page never mixes
+// two states of the database.
+func (data *Data) QueryTasks(workspaceID int, query model.TaskQuery) (model.TaskPage, error) {
+	after, paged, err := query.After()
 	if err != nil {
-		return nil, fmt.Errorf("error fetching categories: %v", err)
+		return model.TaskPage{}, err
 	}
-	return categories, nil
-}
 
-func (data *Data) Reset() error {
-	return data.DB.Update(func(tx *bbolt.Tx) error {
-		if err := tx.DeleteBucket([]byte("Tasks")); err != nil {
-			return err
-		}
-		if err := tx.DeleteBucket([]byte("Categories")); err != nil {
-			return err
-		}
-		if err := tx.DeleteBucket([]byte("Users")); err != nil {
-			return err
+	tasks := []model.Task{}
+	err = data.DB.View(func(tx *bbolt.Tx) error {
+		fields := map[int]model.CustomField{}
+		for fieldID := range query.Fields {
+			v := tx.Bucket([]byte("CustomFields")).Get(itob(fieldID))
+			if v == nil {
+				return fmt.Errorf("record not found")
+			}
+			var field model.CustomField
+			if err := json.Unmarshal(v, &field); err != nil {
+				return err
+			}
+			fields[fieldID] = field
 		}
 
-		return nil
-	})
-}
+		return tx.Bucket([]byte("Tasks")).ForEach(func(k, v []byte) error {
+			var task model.Task
+			if err := json.Unmarshal(v, &task); err != nil {
+				log.Println("Error unmarshaling task:", err)
+				return nil // Continue despite error
+			}
+			if task.WorkspaceID != workspaceID || !query.Matches(task) {
+				return nil
+			}
+			if paged && !query.Less(after, task) {
+				return nil
+			}
 
-func (data *Data) CloseDB() error {
-	return data.DB.Close()
-}
+			if len(query.TagIDs) > 0 {
+				assigned := map[int]bool{}
+				for _, tagID := range taskTagIDs(tx, task.ID) {
+					assigned[tagID] = true
+				}
+				for _, tagID := range query.TagIDs {
+					if !assigned[tagID] {
+						return nil
+					}
+				}
+			}
+			for fieldID, value := range query.Fields {
+				ok, err := fields[fieldID].Matches(task.Fields[fieldID], value)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return nil
+				}
+			}
 
-func (data *Data) GetTaskListByCategory(categoryID int) ([]model.TaskCategory, error) {
-	var taskCategories []model.TaskCategory
-	category, err := data.GetCategoryByID(categoryID)
+			tasks = append(tasks, task)
+			return nil
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error fetching category: %v", err)
+		return model.TaskPage{}, err
 	}
 
-	err = data.DB.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket([]byte("Tasks"))
-		if b == nil {
-			return fmt.Errorf("tasks bucket not found")
-		}
-		return b.ForEach(func(k, v []byte) error {
+	sort.Slice(tasks, func(i, j int) bool { return query.Less(tasks[i], tasks[j]) })
+	page := model.TaskPage{Tasks: tasks}
+	if query.Limit > 0 && len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		page.NextCursor = query.NextCursor(page.Tasks[query.Limit-1])
+	}
+	return page, nil
+}
+
+// SearchTasks returns the tasks of the workspace matching the parsed search query, in ID order. The query is
+// evaluated inside one read transaction, looking up the category and tag names it compares with as it goes.
+func (data *Data) SearchTasks(workspaceID int, query model.SearchQuery, now time.Time, loc *time.Location) ([]model.Task, error) {
+	tasks := []model.Task{}
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		env := &searchEnv{tx: tx, now: now, loc: loc, categories: map[int]string{}, tags: map[int]string{}}
+		return tx.Bucket([]byte("Tasks")).ForEach(func(k, v []byte) error {
 			var task model.Task
 			if err := json.Unmarshal(v, &task); err != nil {
-				log.Printf("Error unmarshaling task: %v", err)
-				return nil // Continue processing next item in case of error
+				log.Println("Error unmarshaling task:", err)
+				return nil // Continue despite error
 			}
-			if task.CategoryID == categoryID {
-				taskCategories = append(taskCategories, model.TaskCategory{
-					ID:       task.ID,
-					Title:    task.Title,
-					Category: category.Name,
-				})
+			if task.WorkspaceID == workspaceID && query.Matches(task, env) {
+				tasks = append(tasks, task)
 			}
 			return nil
 		})
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error fetching tasks for category %d: %v", categoryID, err)
+		return nil, fmt.Errorf("error searching tasks: %v", err)
 	}
-	if len(taskCategories) == 0 {
-		return nil, fmt.Errorf("no tasks found for category ID: %d", categoryID)
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// searchEnv implements model.SearchEnv on a read transaction, caching the names it has looked up.
+type searchEnv struct {
+	tx         *bbolt.Tx
+	now        time.Time
+	loc        *time.Location
+	categories map[int]string
+	tags       map[int]string
+}
+
+func (e *searchEnv) CategoryName(id int) string {
+	if name, ok := e.categories[id]; ok {
+		return name
+	}
+
+	var category model.Category
+	if v := e.tx.Bucket([]byte("Categories")).Get([]byte(fmt.Sprintf("%d", id))); v != nil {
+		_ = json.Unmarshal(v, &category)
+	}
+	e.categories[id] = category.Name
+	return category.Name
+}
+
+func (e *searchEnv) TagNames(taskID int) []string {
+	var names []string
+	for _, tagID := range taskTagIDs(e.tx, taskID) {
+		name, ok := e.tags[tagID]
+		if !ok {
+			var tag model.Tag
+			if v := e.tx.Bucket([]byte("Tags")).Get(itob(tagID)); v != nil {
+				_ = json.Unmarshal(v, &tag)
+			}
+			name = tag.Name
+			e.tags[tagID] = name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func (e *searchEnv) Now() time.Time {
+	return e.now
+}
+
+func (e *searchEnv) Location() *time.Location {
+	return e.loc
+}
+
+func (data *Data) GetSubtasks(parentID int) ([]model.Task, error) {
+	var tasks []model.Task
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tasks"))
+		return b.ForEach(func(k, v []byte) error {
+			var task model.Task
+			if err := json.Unmarshal(v, &task); err != nil {
+				log.Println("Error unmarshaling task:", err)
+				return nil // Continue despite error
+			}
+			if task.ParentID == parentID {
+				tasks = append(tasks, task)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching subtasks: %v", err)
+	}
+	return tasks, nil
+}
+
+// GetCategories returns the categories of the workspace.
+func (data *Data) GetCategories(workspaceID int) ([]model.Category, error) {
+	var categories []model.Category
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Categories"))
+		return b.ForEach(func(k, v []byte) error {
+			var category model.Category
+			if err := json.Unmarshal(v, &category); err != nil {
+				log.Println("Error unmarshaling category:", err)
+				return nil // Continue despite error
+			}
+			if category.WorkspaceID == workspaceID {
+				categories = append(categories, category)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching categories: %v", err)
+	}
+	return categories, nil
+}
+
+// QueryCategories returns the page of the categories of the workspace that match the query, in its sort order,
+// together with the cursor of the next page.
+func (data *Data) QueryCategories(workspaceID int, query model.CategoryQuery) (model.CategoryPage, error) {
+	after, paged, err := query.After()
+	if err != nil {
+		return model.CategoryPage{}, err
+	}
+
+	categories := []model.Category{}
+	err = data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Categories")).ForEach(func(k, v []byte) error {
+			var category model.Category
+			if err := json.Unmarshal(v, &category); err != nil {
+				log.Println("Error unmarshaling category:", err)
+				return nil // Continue despite error
+			}
+			if category.WorkspaceID == workspaceID && query.Matches(category) && (!paged || query.Less(after, category)) {
+				categories = append(categories, category)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return model.CategoryPage{}, fmt.Errorf("error fetching categories: %v", err)
+	}
+
+	sort.Slice(categories, func(i, j int) bool { return query.Less(categories[i], categories[j]) })
+	page := model.CategoryPage{Categories: categories}
+	if query.Limit > 0 && len(categories) > query.Limit {
+		page.Categories = categories[:query.Limit]
+		page.NextCursor = query.NextCursor(page.Categories[query.Limit-1])
+	}
+	return page, nil
+}
+
+func (data *Data) Reset() error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket([]byte("Tasks")); err != nil {
+			return err
+		}
+		if err := tx.DeleteBucket([]byte("Categories")); err != nil {
+			return err
+		}
+		if err := tx.DeleteBucket([]byte("Users")); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (data *Data) CloseDB() error {
+	return data.DB.Close()
+}
+
+// GetTaskListByCategory returns the tasks of a category of the workspace. A category of another workspace
+// is reported as not found.
+func (data *Data) GetTaskListByCategory(workspaceID, categoryID int) ([]model.TaskCategory, error) {
+	var taskCategories []model.TaskCategory
+	category, err := data.GetCategoryByID(categoryID)
+	if err == nil && category.WorkspaceID != workspaceID {
+		err = fmt.Errorf("record not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching category: %v", err)
+	}
+
+	err = data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tasks"))
+		if b == nil {
+			return fmt.Errorf("tasks bucket not found")
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var task model.Task
+			if err := json.Unmarshal(v, &task); err != nil {
+				log.Printf("Error unmarshaling task: %v", err)
+				return nil // Continue processing next item in case of error
+			}
+			if task.CategoryID == categoryID && task.WorkspaceID == workspaceID {
+				taskCategories = append(taskCategories, model.TaskCategory{
+					ID:       task.ID,
+					Title:    task.Title,
+					Category: category.Name,
+				})
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tasks for category %d: %v", categoryID, err)
+	}
+	if len(taskCategories) == 0 {
+		return nil, fmt.Errorf("no tasks found for category ID: %d", categoryID)
 	}
 	return taskCategories, nil
 }
@@ -308,13 +883,15 @@ func btoi(b []byte) int {
 	return int(binary.BigEndian.Uint64(b))
 }
 
-func (data *Data) GetUserTaskCategory() ([]model.UserTaskCategory, error) {
+// GetUserTaskCategory lists the tasks of the workspace that are not archived with their owners and categories.
+func (data *Data) GetUserTaskCategory(workspaceID int) ([]model.UserTaskCategory, error) {
 	var results []model.UserTaskCategory
 
 	err := data.DB.View(func(tx *bbolt.Tx) error {
 		usersBucket := tx.Bucket([]byte("Users"))
 		tasksBucket := tx.Bucket([]byte("Tasks"))
 		categoriesBucket := tx.Bucket([]byte("Categories"))
+		tagsBucket := tx.Bucket([]byte("Tags"))
 
 		if usersBucket == nil || tasksBucket == nil || categoriesBucket == nil {
 			return fmt.Errorf("one or more required buckets do not exist")
@@ -333,7 +910,7 @@ func (data *Data) GetUserTaskCategory() ([]model.UserTaskCategory, error) {
 					return err // skip badly formatted task records
 				}
 
-				if task.UserID == user.ID { // Check if the task belongs to the user
+				if task.UserID == user.ID && task.WorkspaceID == workspaceID && task.ArchivedAt == nil { // Check if the task belongs to the user and workspace
 					var category model.Category
 					catValue := categoriesBucket.Get([]byte(fmt.Sprintf("%d", task.CategoryID)))
 					if catValue != nil {
@@ -347,11 +924,18 @@ func (data *Data) GetUserTaskCategory() ([]model.UserTaskCategory, error) {
 						Fullname: user.Fullname,
 						Email:    user.Email,
 						Task:     task.Title,
-						Deadline: task.Deadline,
+						Deadline: task.Deadline.String(),
 						Priority: task.Priority,
-						Status:   task.Status,
+						Status:   string(task.Status),
 						Category: category.Name,
 					}
+
+					for _, tagID := range taskTagIDs(tx, task.ID) {
+						var tag model.Tag
+						if err := json.Unmarshal(tagsBucket.Get(itob(tagID)), &tag); err == nil {
+							result.Tags = append(result.Tags, tag)
+						}
+					}
 					results = append(results, result)
 
 				}
@@ -517,3 +1101,3091 @@ func (data *Data) SessionAvailToken(token string) (model.Session, error) {
 
 	return session, nil // Return the found session
 }
+
+func (data *Data) GetUserByID(id int) (model.User, error) {
+	var user model.User
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Users"))
+		v := b.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &user)
+	})
+	if err != nil {
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+func (data *Data) GetUsers() ([]model.User, error) {
+	var users []model.User
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Users"))
+		return b.ForEach(func(k, v []byte) error {
+			var user model.User
+			if err := json.Unmarshal(v, &user); err != nil {
+				log.Println("Error unmarshaling user:", err)
+				return nil // Continue despite error
+			}
+			users = append(users, user)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching users: %v", err)
+	}
+	return users, nil
+}
+
+func (data *Data) UpdateUser(user model.User) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Users")).Get(itob(user.ID)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		return putUser(tx, user)
+	})
+}
+
+// ScheduleUserDeletion stores the user with its pending deletion, signs it out of every
+// session and writes the audit record, all in a single transaction.
+func (data *Data) ScheduleUserDeletion(user model.User, record model.AuditRecord) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if err := putUser(tx, user); err != nil {
+			return err
+		}
+		if _, err := deleteSessionsByEmail(tx, user.Email); err != nil {
+			return err
+		}
+		return putAuditRecord(tx, record)
+	})
+}
+
+// RestoreUser stores the user with its pending deletion cleared and writes the audit record
+// in a single transaction.
+func (data *Data) RestoreUser(user model.User, record model.AuditRecord) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if err := putUser(tx, user); err != nil {
+			return err
+		}
+		return putAuditRecord(tx, record)
+	})
+}
+
+// EraseUser removes the user together with its sessions, tasks, categories, custom statuses, saved filters, tags,
+// watches and notifications, the status changes, task history events and comments it made, the history of its tasks,
+// the tag assignments, comments, watchers and notifications of its tasks, takes its tasks and categories out of the
+// search index and records the erasure in the audit log. Workspaces the user is the only member of are removed with
+// everything in them, shared workspaces it owns are handed over by releaseWorkspaces. Projects, sprints, templates and
+// custom fields of shared workspaces stay, and the tasks of other users are detached from the erased categories,
+// parent tasks, sprints and milestones. Everything happens in one transaction so a failure leaves the user's data
+// untouched.
+func (data *Data) EraseUser(id int, record model.AuditRecord) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		usersBucket := tx.Bucket([]byte("Users"))
+		v := usersBucket.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		var user model.User
+		if err := json.Unmarshal(v, &user); err != nil {
+			return err
+		}
+
+		personal, err := releaseWorkspaces(tx, id)
+		if err != nil {
+			return err
+		}
+
+		sessions, err := deleteSessionsByEmail(tx, user.Email)
+		if err != nil {
+			return err
+		}
+
+		erasedTasks := map[int]bool{}
+		tasks, err := deleteWhere(tx.Bucket([]byte("Tasks")), func(v []byte) bool {
+			var task model.Task
+			if json.Unmarshal(v, &task) != nil || (task.UserID != id && !personal[task.WorkspaceID]) {
+				return false
+			}
+			erasedTasks[task.ID] = true
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		erasedCategories := map[int]bool{}
+		categories, err := deleteWhere(tx.Bucket([]byte("Categories")), func(v []byte) bool {
+			var category model.Category
+			if json.Unmarshal(v, &category) != nil || (category.UserID != id && !personal[category.WorkspaceID]) {
+				return false
+			}
+			erasedCategories[category.ID] = true
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		for taskID := range erasedTasks {
+			if err := unindexSearchDoc(tx, model.SearchKindTask, taskID); err != nil {
+				return err
+			}
+		}
+		for categoryID := range erasedCategories {
+			if err := unindexSearchDoc(tx, model.SearchKindCategory, categoryID); err != nil {
+				return err
+			}
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Statuses")), func(v []byte) bool {
+			var status model.CustomStatus
+			return json.Unmarshal(v, &status) == nil && status.UserID == id
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Filters")), func(v []byte) bool {
+			var filter model.SavedFilter
+			return json.Unmarshal(v, &filter) == nil && (filter.UserID == id || personal[filter.WorkspaceID])
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Transitions")), func(v []byte) bool {
+			var transition model.StatusTransition
+			return json.Unmarshal(v, &transition) == nil && transition.ChangedBy == user.Email
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Tags")), func(v []byte) bool {
+			var tag model.Tag
+			return json.Unmarshal(v, &tag) == nil && tag.UserID == id
+		})
+		if err != nil {
+			return err
+		}
+
+		tasksBucket, tagsBucket := tx.Bucket([]byte("Tasks")), tx.Bucket([]byte("Tags"))
+		_, err = deleteWhere(tx.Bucket([]byte("Comments")), func(v []byte) bool {
+			var comment model.Comment
+			if json.Unmarshal(v, &comment) != nil {
+				return false
+			}
+			return comment.AuthorID == id || tasksBucket.Get([]byte(fmt.Sprintf("%d", comment.TaskID))) == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = orphanAttachmentsWhere(tx, func(attachment model.Attachment) bool {
+			return attachment.UploaderID == id || tasksBucket.Get([]byte(fmt.Sprintf("%d", attachment.TaskID))) == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Assignees")), func(v []byte) bool {
+			var assignment model.Assignment
+			if json.Unmarshal(v, &assignment) != nil {
+				return false
+			}
+			return assignment.UserID == id || tasksBucket.Get([]byte(fmt.Sprintf("%d", assignment.TaskID))) == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("WorkspaceMembers")), func(v []byte) bool {
+			var member model.WorkspaceMember
+			return json.Unmarshal(v, &member) == nil && (member.UserID == id || personal[member.WorkspaceID])
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Invitations")), func(v []byte) bool {
+			var invitation model.Invitation
+			return json.Unmarshal(v, &invitation) == nil && (strings.EqualFold(invitation.Email, user.Email) || personal[invitation.WorkspaceID])
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("TimeEntries")), func(v []byte) bool {
+			var entry model.TimeEntry
+			if json.Unmarshal(v, &entry) != nil {
+				return false
+			}
+			return entry.UserID == id || tasksBucket.Get([]byte(fmt.Sprintf("%d", entry.TaskID))) == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		projectsBucket := tx.Bucket([]byte("Projects"))
+		_, err = deleteWhere(projectsBucket, func(v []byte) bool {
+			var project model.Project
+			return json.Unmarshal(v, &project) == nil && personal[project.WorkspaceID]
+		})
+		if err != nil {
+			return err
+		}
+
+		erasedMilestones := map[int]bool{}
+		_, err = deleteWhere(tx.Bucket([]byte("Milestones")), func(v []byte) bool {
+			var milestone model.Milestone
+			if json.Unmarshal(v, &milestone) != nil || projectsBucket.Get(itob(milestone.ProjectID)) != nil {
+				return false
+			}
+			erasedMilestones[milestone.ID] = true
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		erasedSprints := map[int]bool{}
+		_, err = deleteWhere(tx.Bucket([]byte("Sprints")), func(v []byte) bool {
+			var sprint model.Sprint
+			if json.Unmarshal(v, &sprint) != nil || !personal[sprint.WorkspaceID] {
+				return false
+			}
+			erasedSprints[sprint.ID] = true
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Templates")), func(v []byte) bool {
+			var template model.Template
+			return json.Unmarshal(v, &template) == nil && personal[template.WorkspaceID]
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Trash")), func(v []byte) bool {
+			var entry trashEntry
+			if json.Unmarshal(v, &entry) != nil || entry.UserID != id {
+				return false
+			}
+			for _, task := range entry.Tasks {
+				erasedTasks[task.ID] = true
+			}
+			if entry.Category != nil {
+				erasedCategories[entry.Category.ID] = true
+			}
+			return orphanTrashedAttachments(tx, entry) == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Watches")), func(v []byte) bool {
+			var watch model.Watch
+			return json.Unmarshal(v, &watch) == nil && (watch.UserID == id || watchesAny(watch, erasedTasks, erasedCategories))
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Notifications")), func(v []byte) bool {
+			var notification model.Notification
+			return json.Unmarshal(v, &notification) == nil && (notification.UserID == id || erasedTasks[notification.TaskID])
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("TaskHistory")), func(v []byte) bool {
+			var event model.TaskEvent
+			return json.Unmarshal(v, &event) == nil && (erasedTasks[event.TaskID] || event.Actor == user.Email)
+		})
+		if err != nil {
+			return err
+		}
+
+		erased := map[int]bool{}
+		_, err = deleteWhere(tx.Bucket([]byte("CustomFields")), func(v []byte) bool {
+			var field model.CustomField
+			if json.Unmarshal(v, &field) != nil || !personal[field.WorkspaceID] {
+				return false
+			}
+			erased[field.ID] = true
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if err := clearTaskFields(tx, erased, record.Actor); err != nil {
+			return err
+		}
+
+		err = updateTasksWhere(tx, record.Actor, func(task *model.Task) bool {
+			detached := false
+			if erasedCategories[task.CategoryID] {
+				task.CategoryID = 0
+				detached = true
+			}
+			if erasedTasks[task.ParentID] {
+				task.ParentID = 0
+				detached = true
+			}
+			if erasedSprints[task.SprintID] {
+				task.SprintID = 0
+				detached = true
+			}
+			if erasedMilestones[task.MilestoneID] {
+				task.MilestoneID = 0
+				detached = true
+			}
+			return detached
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("Dependencies")), func(v []byte) bool {
+			var dependency model.Dependency
+			if json.Unmarshal(v, &dependency) != nil {
+				return false
+			}
+			return tasksBucket.Get([]byte(fmt.Sprintf("%d", dependency.BlockerID))) == nil ||
+				tasksBucket.Get([]byte(fmt.Sprintf("%d", dependency.BlockedID))) == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = deleteWhere(tx.Bucket([]byte("TaskTags")), func(v []byte) bool {
+			var assignment model.TaskTag
+			if json.Unmarshal(v, &assignment) != nil {
+				return false
+			}
+			return tasksBucket.Get([]byte(fmt.Sprintf("%d", assignment.TaskID))) == nil || tagsBucket.Get(itob(assignment.TagID)) == nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := usersBucket.Delete(itob(id)); err != nil {
+			return err
+		}
+
+		record.Detail = fmt.Sprintf("removed %d sessions, %d tasks and %d categories", sessions, tasks, categories)
+		return putAuditRecord(tx, record)
+	})
+}
+
+// releaseWorkspaces removes the workspaces the user owns without other members and returns their IDs. Workspaces the
+// user owns together with others are handed over to the admin who joined first, failing with
+// model.ErrNoWorkspaceSuccessor when there is none.
+func releaseWorkspaces(tx *bbolt.Tx, userID int) (map[int]bool, error) {
+	members := map[int][]model.WorkspaceMember{}
+	err := tx.Bucket([]byte("WorkspaceMembers")).ForEach(func(k, v []byte) error {
+		var member model.WorkspaceMember
+		if json.Unmarshal(v, &member) == nil && member.UserID != userID {
+			members[member.WorkspaceID] = append(members[member.WorkspaceID], member)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b := tx.Bucket([]byte("Workspaces"))
+	var owned []model.Workspace
+	err = b.ForEach(func(k, v []byte) error {
+		var workspace model.Workspace
+		if json.Unmarshal(v, &workspace) == nil && workspace.OwnerID == userID {
+			owned = append(owned, workspace)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	personal := map[int]bool{}
+	for _, workspace := range owned {
+		if len(members[workspace.ID]) == 0 {
+			personal[workspace.ID] = true
+			if err := b.Delete(itob(workspace.ID)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var successor *model.WorkspaceMember
+		for i, member := range members[workspace.ID] {
+			if member.Role == model.RoleAdmin && (successor == nil || member.JoinedAt.Before(successor.JoinedAt)) {
+				successor = &members[workspace.ID][i]
+			}
+		}
+		if successor == nil {
+			return nil, fmt.Errorf("%w: %d", model.ErrNoWorkspaceSuccessor, workspace.ID)
+		}
+
+		workspace.OwnerID = successor.UserID
+		workspaceJSON, err := json.Marshal(workspace)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling workspace: %v", err)
+		}
+		if err := b.Put(itob(workspace.ID), workspaceJSON); err != nil {
+			return nil, err
+		}
+
+		successor.Role = model.RoleOwner
+		if err := putWorkspaceMember(tx, *successor); err != nil {
+			return nil, err
+		}
+	}
+	return personal, nil
+}
+
+func (data *Data) GetAuditRecords() ([]model.AuditRecord, error) {
+	var records []model.AuditRecord
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Audit"))
+		return b.ForEach(func(k, v []byte) error {
+			var record model.AuditRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				log.Println("Error unmarshaling audit record:", err)
+				return nil // Continue despite error
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching audit records: %v", err)
+	}
+	return records, nil
+}
+
+func (data *Data) StoreCustomStatus(status model.CustomStatus) (model.CustomStatus, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Statuses"))
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		status.ID = int(id)
+
+		statusJSON, err := json.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("error marshaling status: %v", err)
+		}
+		return b.Put(itob(status.ID), statusJSON)
+	})
+	if err != nil {
+		return model.CustomStatus{}, err
+	}
+	return status, nil
+}
+
+func (data *Data) GetCustomStatuses(userID int) ([]model.CustomStatus, error) {
+	var statuses []model.CustomStatus
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Statuses"))
+		return b.ForEach(func(k, v []byte) error {
+			var status model.CustomStatus
+			if err := json.Unmarshal(v, &status); err != nil {
+				log.Println("Error unmarshaling status:", err)
+				return nil // Continue despite error
+			}
+			if status.UserID == userID {
+				statuses = append(statuses, status)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching statuses: %v", err)
+	}
+	return statuses, nil
+}
+
+func (data *Data) DeleteCustomStatus(id, userID int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Statuses"))
+		v := b.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		var status model.CustomStatus
+		if err := json.Unmarshal(v, &status); err != nil {
+			return err
+		}
+		if status.UserID != userID {
+			return fmt.Errorf("record not found")
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+// TransitionTask stores the task with its new status and records the transition in a single
+// transaction, so the history never disagrees with the stored status. Both are recorded as changed
+// by transition.ChangedBy.
+func (data *Data) TransitionTask(task model.Task, transition model.StatusTransition) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if err := putTask(tx, task, transition.ChangedBy); err != nil {
+			return err
+		}
+
+		b := tx.Bucket([]byte("Transitions"))
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		transition.ID = int(id)
+
+		transitionJSON, err := json.Marshal(transition)
+		if err != nil {
+			return fmt.Errorf("error marshaling transition: %v", err)
+		}
+		return b.Put(itob(transition.ID), transitionJSON)
+	})
+}
+
+func (data *Data) GetStatusTransitions(taskID int) ([]model.StatusTransition, error) {
+	var transitions []model.StatusTransition
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Transitions"))
+		return b.ForEach(func(k, v []byte) error {
+			var transition model.StatusTransition
+			if err := json.Unmarshal(v, &transition); err != nil {
+				log.Println("Error unmarshaling transition:", err)
+				return nil // Continue despite error
+			}
+			if transition.TaskID == taskID {
+				transitions = append(transitions, transition)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching transitions: %v", err)
+	}
+	return transitions, nil
+}
+
+// GetTaskHistory returns the events recorded for the task, oldest first.
+func (data *Data) GetTaskHistory(taskID int) ([]model.TaskEvent, error) {
+	var events []model.TaskEvent
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("TaskHistory")).ForEach(func(k, v []byte) error {
+			var event model.TaskEvent
+			if err := json.Unmarshal(v, &event); err != nil {
+				log.Println("Error unmarshaling task event:", err)
+				return nil // Continue despite error
+			}
+			if event.TaskID == taskID {
+				events = append(events, event)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching task history: %v", err)
+	}
+	return events, nil
+}
+
+// StoreTag stores the tag, giving a tag without an ID the next one from the bucket sequence.
+func (data *Data) StoreTag(tag model.Tag) (model.Tag, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tags"))
+		if tag.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			tag.ID = int(id)
+		}
+
+		tagJSON, err := json.Marshal(tag)
+		if err != nil {
+			return fmt.Errorf("error marshaling tag: %v", err)
+		}
+		return b.Put(itob(tag.ID), tagJSON)
+	})
+	if err != nil {
+		return model.Tag{}, err
+	}
+	return tag, nil
+}
+
+func (data *Data) GetTagByID(id int) (*model.Tag, error) {
+	var tag model.Tag
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Tags")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &tag)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (data *Data) GetTags(userID int) ([]model.Tag, error) {
+	var tags []model.Tag
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tags"))
+		return b.ForEach(func(k, v []byte) error {
+			var tag model.Tag
+			if err := json.Unmarshal(v, &tag); err != nil {
+				log.Println("Error unmarshaling tag:", err)
+				return nil // Continue despite error
+			}
+			if tag.UserID == userID {
+				tags = append(tags, tag)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tags: %v", err)
+	}
+	return tags, nil
+}
+
+// DeleteTag deletes the tag of the user and removes it from every task in a single transaction.
+func (data *Data) DeleteTag(id, userID int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tags"))
+		v := b.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		var tag model.Tag
+		if err := json.Unmarshal(v, &tag); err != nil {
+			return err
+		}
+		if tag.UserID != userID {
+			return fmt.Errorf("record not found")
+		}
+
+		_, err := deleteWhere(tx.Bucket([]byte("TaskTags")), func(v []byte) bool {
+			var assignment model.TaskTag
+			return json.Unmarshal(v, &assignment) == nil && assignment.TagID == id
+		})
+		if err != nil {
+			return err
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+// AssignTag puts the tag on the task. Assigning a tag twice keeps a single assignment.
+func (data *Data) AssignTag(assignment model.TaskTag) error {
+	assignmentJSON, err := json.Marshal(assignment)
+	if err != nil {
+		return err
+	}
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Tasks")).Get([]byte(fmt.Sprintf("%d", assignment.TaskID))) == nil {
+			return fmt.Errorf("record not found")
+		}
+		if tx.Bucket([]byte("Tags")).Get(itob(assignment.TagID)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		return tx.Bucket([]byte("TaskTags")).Put(taskTagKey(assignment.TaskID, assignment.TagID), assignmentJSON)
+	})
+}
+
+func (data *Data) UnassignTag(assignment model.TaskTag) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("TaskTags")).Delete(taskTagKey(assignment.TaskID, assignment.TagID))
+	})
+}
+
+func (data *Data) GetTagAssignments() ([]model.TaskTag, error) {
+	var assignments []model.TaskTag
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("TaskTags"))
+		return b.ForEach(func(k, v []byte) error {
+			var assignment model.TaskTag
+			if err := json.Unmarshal(v, &assignment); err != nil {
+				log.Println("Error unmarshaling tag assignment:", err)
+				return nil // Continue despite error
+			}
+			assignments = append(assignments, assignment)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tag assignments: %v", err)
+	}
+	return assignments, nil
+}
+
+func (data *Data) GetTaskTags(taskID int) ([]model.Tag, error) {
+	var tags []model.Tag
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tags"))
+		for _, tagID := range taskTagIDs(tx, taskID) {
+			var tag model.Tag
+			if err := json.Unmarshal(b.Get(itob(tagID)), &tag); err != nil {
+				log.Println("Error unmarshaling tag:", err)
+				continue
+			}
+			tags = append(tags, tag)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tags: %v", err)
+	}
+	return tags, nil
+}
+
+// GetTasksByTags returns the tasks of the workspace carrying every one of the given tags.
+func (data *Data) GetTasksByTags(workspaceID int, tagIDs []int) ([]model.Task, error) {
+	var tasks []model.Task
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tasks"))
+		return b.ForEach(func(k, v []byte) error {
+			var task model.Task
+			if err := json.Unmarshal(v, &task); err != nil {
+				log.Println("Error unmarshaling task:", err)
+				return nil // Continue despite error
+			}
+			if task.WorkspaceID != workspaceID {
+				return nil
+			}
+
+			assigned := map[int]bool{}
+			for _, tagID := range taskTagIDs(tx, task.ID) {
+				assigned[tagID] = true
+			}
+			for _, tagID := range tagIDs {
+				if !assigned[tagID] {
+					return nil
+				}
+			}
+			tasks = append(tasks, task)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tasks: %v", err)
+	}
+	return tasks, nil
+}
+
+// StoreComment stores the comment, giving a comment without an ID the next one from the bucket sequence.
+func (data *Data) StoreComment(comment model.Comment) (model.Comment, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Comments"))
+		if comment.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			comment.ID = int(id)
+		}
+
+		commentJSON, err := json.Marshal(comment)
+		if err != nil {
+			return fmt.Errorf("error marshaling comment: %v", err)
+		}
+		return b.Put(itob(comment.ID), commentJSON)
+	})
+	if err != nil {
+		return model.Comment{}, err
+	}
+	return comment, nil
+}
+
+func (data *Data) GetCommentByID(id int) (*model.Comment, error) {
+	var comment model.Comment
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Comments")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetComments returns the comments of the task, oldest first.
+func (data *Data) GetComments(taskID int) ([]model.Comment, error) {
+	var comments []model.Comment
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Comments"))
+		return b.ForEach(func(k, v []byte) error {
+			var comment model.Comment
+			if err := json.Unmarshal(v, &comment); err != nil {
+				log.Println("Error unmarshaling comment:", err)
+				return nil // Continue despite error
+			}
+			if comment.TaskID == taskID {
+				comments = append(comments, comment)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching comments: %v", err)
+	}
+	return comments, nil
+}
+
+func deleteTaskComments(tx *bbolt.Tx, taskID int) error {
+	_, err := deleteWhere(tx.Bucket([]byte("Comments")), func(v []byte) bool {
+		var comment model.Comment
+		return json.Unmarshal(v, &comment) == nil && comment.TaskID == taskID
+	})
+	return err
+}
+
+// StoreAttachment stores the metadata of an attachment, giving an attachment without an ID the next
+// one from the bucket sequence. It fails when the task does not exist, so that a file uploaded
+// while its task is being deleted is not left behind.
+func (data *Data) StoreAttachment(attachment model.Attachment) (model.Attachment, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Tasks")).Get([]byte(fmt.Sprintf("%d", attachment.TaskID))) == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		b := tx.Bucket([]byte("Attachments"))
+		if attachment.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			attachment.ID = int(id)
+		}
+
+		attachmentJSON, err := json.Marshal(attachment)
+		if err != nil {
+			return fmt.Errorf("error marshaling attachment: %v", err)
+		}
+		return b.Put(itob(attachment.ID), attachmentJSON)
+	})
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func (data *Data) GetAttachmentByID(id int) (*model.Attachment, error) {
+	var attachment model.Attachment
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Attachments")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &attachment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetAttachments returns the attachments of the task, oldest first.
+func (data *Data) GetAttachments(taskID int) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Attachments"))
+		return b.ForEach(func(k, v []byte) error {
+			var attachment model.Attachment
+			if err := json.Unmarshal(v, &attachment); err != nil {
+				log.Println("Error unmarshaling attachment:", err)
+				return nil // Continue despite error
+			}
+			if attachment.TaskID == taskID {
+				attachments = append(attachments, attachment)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching attachments: %v", err)
+	}
+	return attachments, nil
+}
+
+// DeleteAttachment deletes the metadata of an attachment and queues its content in OrphanedBlobs.
+func (data *Data) DeleteAttachment(id int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return orphanAttachmentsWhere(tx, func(attachment model.Attachment) bool {
+			return attachment.ID == id
+		})
+	})
+}
+
+// GetOrphanedBlobs returns the blob keys of deleted attachments whose content has not been
+// removed from the blob store yet.
+func (data *Data) GetOrphanedBlobs() ([]string, error) {
+	var keys []string
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("OrphanedBlobs")).ForEach(func(k, v []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching orphaned blobs: %v", err)
+	}
+	return keys, nil
+}
+
+// ForgetOrphanedBlob removes a key from OrphanedBlobs once its content is gone from the blob store.
+func (data *Data) ForgetOrphanedBlob(key string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("OrphanedBlobs")).Delete([]byte(key))
+	})
+}
+
+// orphanAttachmentsWhere deletes the matching attachments and queues their blob keys in
+// OrphanedBlobs, in the caller's transaction.
+func orphanAttachmentsWhere(tx *bbolt.Tx, match func(attachment model.Attachment) bool) error {
+	orphans := tx.Bucket([]byte("OrphanedBlobs"))
+	_, err := deleteWhere(tx.Bucket([]byte("Attachments")), func(v []byte) bool {
+		var attachment model.Attachment
+		if json.Unmarshal(v, &attachment) != nil || !match(attachment) {
+			return false
+		}
+		return orphans.Put([]byte(attachment.Key), []byte(time.Now().Format(time.RFC3339))) == nil
+	})
+	return err
+}
+
+// AddDependency stores a dependency between two tasks. Both tasks must exist; adding an existing
+// dependency again changes nothing.
+func (data *Data) AddDependency(dependency model.Dependency) error {
+	dependencyJSON, err := json.Marshal(dependency)
+	if err != nil {
+		return fmt.Errorf("error marshaling dependency: %v", err)
+	}
+
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		tasks := tx.Bucket([]byte("Tasks"))
+		if tasks.Get([]byte(fmt.Sprintf("%d", dependency.BlockerID))) == nil ||
+			tasks.Get([]byte(fmt.Sprintf("%d", dependency.BlockedID))) == nil {
+			return fmt.Errorf("record not found")
+		}
+		return tx.Bucket([]byte("Dependencies")).Put(dependencyKey(dependency), dependencyJSON)
+	})
+}
+
+func (data *Data) RemoveDependency(dependency model.Dependency) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Dependencies")).Delete(dependencyKey(dependency))
+	})
+}
+
+func (data *Data) GetDependencies() ([]model.Dependency, error) {
+	var dependencies []model.Dependency
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Dependencies")).ForEach(func(k, v []byte) error {
+			var dependency model.Dependency
+			if err := json.Unmarshal(v, &dependency); err != nil {
+				log.Println("Error unmarshaling dependency:", err)
+				return nil // Continue despite error
+			}
+			dependencies = append(dependencies, dependency)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching dependencies: %v", err)
+	}
+	return dependencies, nil
+}
+
+// GetBlockers returns the tasks blocking the task, in ID order.
+func (data *Data) GetBlockers(taskID int) ([]model.Task, error) {
+	var tasks []model.Task
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tasks"))
+		return tx.Bucket([]byte("Dependencies")).ForEach(func(k, v []byte) error {
+			var dependency model.Dependency
+			if err := json.Unmarshal(v, &dependency); err != nil || dependency.BlockedID != taskID {
+				return nil
+			}
+
+			taskJSON := b.Get([]byte(fmt.Sprintf("%d", dependency.BlockerID)))
+			if taskJSON == nil {
+				return nil
+			}
+			var task model.Task
+			if err := json.Unmarshal(taskJSON, &task); err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching blockers: %v", err)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+func dependencyKey(dependency model.Dependency) []byte {
+	return []byte(fmt.Sprintf("%d:%d", dependency.BlockerID, dependency.BlockedID))
+}
+
+func deleteTaskDependencies(tx *bbolt.Tx, taskID int) error {
+	_, err := deleteWhere(tx.Bucket([]byte("Dependencies")), func(v []byte) bool {
+		var dependency model.Dependency
+		return json.Unmarshal(v, &dependency) == nil && (dependency.BlockerID == taskID || dependency.BlockedID == taskID)
+	})
+	return err
+}
+
+// StartTimer stores a running time entry for the user, giving it the next ID from the bucket
+// sequence. A user can only have one running timer, so the check and the write share a single
+// transaction: when a timer is already running nothing is stored and that entry is returned
+// instead. It fails when the task does not exist.
+func (data *Data) StartTimer(entry model.TimeEntry) (model.TimeEntry, *model.TimeEntry, error) {
+	var running *model.TimeEntry
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Tasks")).Get([]byte(fmt.Sprintf("%d", entry.TaskID))) == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		b := tx.Bucket([]byte("TimeEntries"))
+		current, err := runningTimeEntry(b, entry.UserID)
+		if err != nil || current != nil {
+			running = current
+			return err
+		}
+		return putTimeEntry(b, &entry)
+	})
+	if err != nil {
+		return model.TimeEntry{}, nil, err
+	}
+	return entry, running, nil
+}
+
+// StopTimer ends the user's running time entry at end and returns it, or returns nil when no
+// timer is running.
+func (data *Data) StopTimer(userID int, end time.Time) (*model.TimeEntry, error) {
+	var running *model.TimeEntry
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("TimeEntries"))
+		current, err := runningTimeEntry(b, userID)
+		if err != nil || current == nil {
+			return err
+		}
+
+		if end.Before(current.Start) {
+			end = current.Start
+		}
+		current.End = &end
+		running = current
+		return putTimeEntry(b, current)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return running, nil
+}
+
+// StoreTimeEntry stores a time entry, giving an entry without an ID the next one from the bucket
+// sequence. It fails when the task does not exist.
+func (data *Data) StoreTimeEntry(entry model.TimeEntry) (model.TimeEntry, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Tasks")).Get([]byte(fmt.Sprintf("%d", entry.TaskID))) == nil {
+			return fmt.Errorf("record not found")
+		}
+		return putTimeEntry(tx.Bucket([]byte("TimeEntries")), &entry)
+	})
+	if err != nil {
+		return model.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (data *Data) GetTimeEntryByID(id int) (*model.TimeEntry, error) {
+	var entry model.TimeEntry
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("TimeEntries")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (data *Data) DeleteTimeEntry(id int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("TimeEntries")).Delete(itob(id))
+	})
+}
+
+// GetTimeEntries returns the time entries matching the filter, oldest first. A zero taskID or
+// userID matches every task or user.
+func (data *Data) GetTimeEntries(taskID, userID int) ([]model.TimeEntry, error) {
+	var entries []model.TimeEntry
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("TimeEntries")).ForEach(func(k, v []byte) error {
+			var entry model.TimeEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				log.Println("Error unmarshaling time entry:", err)
+				return nil // Continue despite error
+			}
+			if (taskID == 0 || entry.TaskID == taskID) && (userID == 0 || entry.UserID == userID) {
+				entries = append(entries, entry)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching time entries: %v", err)
+	}
+	return entries, nil
+}
+
+func runningTimeEntry(b *bbolt.Bucket, userID int) (*model.TimeEntry, error) {
+	var running *model.TimeEntry
+	err := b.ForEach(func(k, v []byte) error {
+		var entry model.TimeEntry
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return nil
+		}
+		if entry.UserID == userID && entry.Running() {
+			running = &entry
+		}
+		return nil
+	})
+	return running, err
+}
+
+func putTimeEntry(b *bbolt.Bucket, entry *model.TimeEntry) error {
+	if entry.ID == 0 {
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		entry.ID = int(id)
+	}
+
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshaling time entry: %v", err)
+	}
+	return b.Put(itob(entry.ID), entryJSON)
+}
+
+func deleteTaskTimeEntries(tx *bbolt.Tx, taskID int) error {
+	_, err := deleteWhere(tx.Bucket([]byte("TimeEntries")), func(v []byte) bool {
+		var entry model.TimeEntry
+		return json.Unmarshal(v, &entry) == nil && entry.TaskID == taskID
+	})
+	return err
+}
+
+// AssignTask stores the assignment of a user to a task. Both the task and the user must exist;
+// assigning a user again keeps the original assignment.
+func (data *Data) AssignTask(assignment model.Assignment) error {
+	assignmentJSON, err := json.Marshal(assignment)
+	if err != nil {
+		return fmt.Errorf("error marshaling assignment: %v", err)
+	}
+
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Tasks")).Get([]byte(fmt.Sprintf("%d", assignment.TaskID))) == nil {
+			return fmt.Errorf("record not found")
+		}
+		if tx.Bucket([]byte("Users")).Get(itob(assignment.UserID)) == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		b := tx.Bucket([]byte("Assignees"))
+		key := assigneeKey(assignment.TaskID, assignment.UserID)
+		if b.Get(key) != nil {
+			return nil
+		}
+		return b.Put(key, assignmentJSON)
+	})
+}
+
+func (data *Data) UnassignTask(taskID, userID int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Assignees")).Delete(assigneeKey(taskID, userID))
+	})
+}
+
+// GetAssignments returns the assignments matching the filter. A zero taskID or userID matches every
+// task or user.
+func (data *Data) GetAssignments(taskID, userID int) ([]model.Assignment, error) {
+	var assignments []model.Assignment
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Assignees")).ForEach(func(k, v []byte) error {
+			var assignment model.Assignment
+			if err := json.Unmarshal(v, &assignment); err != nil {
+				log.Println("Error unmarshaling assignment:", err)
+				return nil // Continue despite error
+			}
+			if (taskID == 0 || assignment.TaskID == taskID) && (userID == 0 || assignment.UserID == userID) {
+				assignments = append(assignments, assignment)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching assignments: %v", err)
+	}
+	return assignments, nil
+}
+
+// GetAssignedTasks returns the tasks of the workspace the user is assigned to, in ID order.
+func (data *Data) GetAssignedTasks(workspaceID, userID int) ([]model.Task, error) {
+	var tasks []model.Task
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tasks"))
+		return tx.Bucket([]byte("Assignees")).ForEach(func(k, v []byte) error {
+			var assignment model.Assignment
+			if err := json.Unmarshal(v, &assignment); err != nil || assignment.UserID != userID {
+				return nil
+			}
+
+			taskJSON := b.Get([]byte(fmt.Sprintf("%d", assignment.TaskID)))
+			if taskJSON == nil {
+				return nil
+			}
+			var task model.Task
+			if err := json.Unmarshal(taskJSON, &task); err != nil {
+				return err
+			}
+			if task.WorkspaceID == workspaceID {
+				tasks = append(tasks, task)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching assigned tasks: %v", err)
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+func assigneeKey(taskID, userID int) []byte {
+	return []byte(fmt.Sprintf("%d:%d", taskID, userID))
+}
+
+func deleteTaskAssignments(tx *bbolt.Tx, taskID int) error {
+	_, err := deleteWhere(tx.Bucket([]byte("Assignees")), func(v []byte) bool {
+		var assignment model.Assignment
+		return json.Unmarshal(v, &assignment) == nil && assignment.TaskID == taskID
+	})
+	return err
+}
+
+// StoreWorkspace stores a new workspace under the next ID from the bucket sequence and makes its owner
+// a member with the owner role, in one transaction.
+func (data *Data) StoreWorkspace(workspace model.Workspace) (model.Workspace, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Workspaces"))
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		workspace.ID = int(id)
+
+		workspaceJSON, err := json.Marshal(workspace)
+		if err != nil {
+			return fmt.Errorf("error marshaling workspace: %v", err)
+		}
+		if err := b.Put(itob(workspace.ID), workspaceJSON); err != nil {
+			return err
+		}
+
+		return putWorkspaceMember(tx, model.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        model.RoleOwner,
+			JoinedAt:    workspace.CreatedAt,
+		})
+	})
+	if err != nil {
+		return model.Workspace{}, err
+	}
+	return workspace, nil
+}
+
+func (data *Data) GetWorkspaceByID(id int) (*model.Workspace, error) {
+	var workspace model.Workspace
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Workspaces")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &workspace)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// PutWorkspaceMember stores the membership, replacing the role of a user who is already a member.
+func (data *Data) PutWorkspaceMember(member model.WorkspaceMember) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return putWorkspaceMember(tx, member)
+	})
+}
+
+func (data *Data) DeleteWorkspaceMember(workspaceID, userID int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("WorkspaceMembers")).Delete(workspaceMemberKey(workspaceID, userID))
+	})
+}
+
+// GetWorkspaceMembers returns the memberships matching the filter. A zero workspaceID or userID matches
+// every workspace or user.
+func (data *Data) GetWorkspaceMembers(workspaceID, userID int) ([]model.WorkspaceMember, error) {
+	var members []model.WorkspaceMember
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("WorkspaceMembers")).ForEach(func(k, v []byte) error {
+			var member model.WorkspaceMember
+			if err := json.Unmarshal(v, &member); err != nil {
+				log.Println("Error unmarshaling workspace member:", err)
+				return nil // Continue despite error
+			}
+			if (workspaceID == 0 || member.WorkspaceID == workspaceID) && (userID == 0 || member.UserID == userID) {
+				members = append(members, member)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching workspace members: %v", err)
+	}
+	return members, nil
+}
+
+// StoreInvitation stores the invitation, giving an invitation without an ID the next one from the bucket sequence.
+func (data *Data) StoreInvitation(invitation model.Invitation) (model.Invitation, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		return putInvitation(tx, &invitation)
+	})
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	return invitation, nil
+}
+
+func (data *Data) GetInvitationByID(id int) (*model.Invitation, error) {
+	var invitation model.Invitation
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Invitations")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetInvitations returns the invitations matching the filter, oldest first. A zero workspaceID or an empty
+// email matches every workspace or address; addresses are compared without regard to case.
+func (data *Data) GetInvitations(workspaceID int, email string) ([]model.Invitation, error) {
+	var invitations []model.Invitation
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Invitations")).ForEach(func(k, v []byte) error {
+			var invitation model.Invitation
+			if err := json.Unmarshal(v, &invitation); err != nil {
+				log.Println("Error unmarshaling invitation:", err)
+				return nil // Continue despite error
+			}
+			if (workspaceID == 0 || invitation.WorkspaceID == workspaceID) && (email == "" || strings.EqualFold(invitation.Email, email)) {
+				invitations = append(invitations, invitation)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching invitations: %v", err)
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation stores the answered invitation together with the membership it grants, in one transaction.
+func (data *Data) AcceptInvitation(invitation model.Invitation, member model.WorkspaceMember) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Workspaces")).Get(itob(member.WorkspaceID)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		if err := putInvitation(tx, &invitation); err != nil {
+			return err
+		}
+		return putWorkspaceMember(tx, member)
+	})
+}
+
+// StoreProject stores the project, giving a project without an ID the next one from the bucket sequence.
+func (data *Data) StoreProject(project model.Project) (model.Project, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Projects"))
+		if project.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			project.ID = int(id)
+		}
+
+		projectJSON, err := json.Marshal(project)
+		if err != nil {
+			return fmt.Errorf("error marshaling project: %v", err)
+		}
+		return b.Put(itob(project.ID), projectJSON)
+	})
+	if err != nil {
+		return model.Project{}, err
+	}
+	return project, nil
+}
+
+func (data *Data) GetProjectByID(id int) (*model.Project, error) {
+	var project model.Project
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Projects")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &project)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// GetProjects returns the projects of the workspace in ID order.
+func (data *Data) GetProjects(workspaceID int) ([]model.Project, error) {
+	var projects []model.Project
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Projects")).ForEach(func(k, v []byte) error {
+			var project model.Project
+			if err := json.Unmarshal(v, &project); err != nil {
+				log.Println("Error unmarshaling project:", err)
+				return nil // Continue despite error
+			}
+			if project.WorkspaceID == workspaceID {
+				projects = append(projects, project)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching projects: %v", err)
+	}
+	return projects, nil
+}
+
+// DeleteProject deletes the project and its milestones in a single transaction. The categories of the
+// project and the tasks of its milestones are kept, no longer grouped under it, with the detachment recorded on behalf of the actor.
+func (data *Data) DeleteProject(id int, actor string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Projects"))
+		if b.Get(itob(id)) == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		milestones := map[int]bool{}
+		_, err := deleteWhere(tx.Bucket([]byte("Milestones")), func(v []byte) bool {
+			var milestone model.Milestone
+			if json.Unmarshal(v, &milestone) != nil || milestone.ProjectID != id {
+				return false
+			}
+			milestones[milestone.ID] = true
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if err := detachMilestoneTasks(tx, milestones, actor); err != nil {
+			return err
+		}
+
+		err = updateWhere(tx.Bucket([]byte("Categories")), func(v []byte) ([]byte, bool) {
+			var category model.Category
+			if json.Unmarshal(v, &category) != nil || category.ProjectID != id {
+				return nil, false
+			}
+			category.ProjectID = 0
+
+			categoryJSON, err := json.Marshal(category)
+			return categoryJSON, err == nil
+		})
+		if err != nil {
+			return err
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+// StoreMilestone stores the milestone, giving a milestone without an ID the next one from the bucket sequence.
+// The project of the milestone must exist.
+func (data *Data) StoreMilestone(milestone model.Milestone) (model.Milestone, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte("Projects")).Get(itob(milestone.ProjectID)) == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		b := tx.Bucket([]byte("Milestones"))
+		if milestone.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			milestone.ID = int(id)
+		}
+
+		milestoneJSON, err := json.Marshal(milestone)
+		if err != nil {
+			return fmt.Errorf("error marshaling milestone: %v", err)
+		}
+		return b.Put(itob(milestone.ID), milestoneJSON)
+	})
+	if err != nil {
+		return model.Milestone{}, err
+	}
+	return milestone, nil
+}
+
+func (data *Data) GetMilestoneByID(id int) (*model.Milestone, error) {
+	var milestone model.Milestone
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Milestones")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &milestone)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+// GetMilestones returns the milestones of the project in ID order.
+func (data *Data) GetMilestones(projectID int) ([]model.Milestone, error) {
+	var milestones []model.Milestone
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Milestones")).ForEach(func(k, v []byte) error {
+			var milestone model.Milestone
+			if err := json.Unmarshal(v, &milestone); err != nil {
+				log.Println("Error unmarshaling milestone:", err)
+				return nil // Continue despite error
+			}
+			if milestone.ProjectID == projectID {
+				milestones = append(milestones, milestone)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching milestones: %v", err)
+	}
+	return milestones, nil
+}
+
+// DeleteMilestone deletes the milestone and detaches its tasks on behalf of the actor in a single transaction.
+func (data *Data) DeleteMilestone(id int, actor string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Milestones"))
+		if b.Get(itob(id)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		if err := detachMilestoneTasks(tx, map[int]bool{id: true}, actor); err != nil {
+			return err
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+// detachMilestoneTasks clears the milestone of every task attached to one of the given milestones on behalf of the actor.
+func detachMilestoneTasks(tx *bbolt.Tx, milestones map[int]bool, actor string) error {
+	return updateTasksWhere(tx, actor, func(task *model.Task) bool {
+		if !milestones[task.MilestoneID] {
+			return false
+		}
+		task.MilestoneID = 0
+		return true
+	})
+}
+
+// StoreSprint stores the sprint, giving a sprint without an ID the next one from the bucket sequence.
+func (data *Data) StoreSprint(sprint model.Sprint) (model.Sprint, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		return putSprint(tx.Bucket([]byte("Sprints")), &sprint)
+	})
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+func (data *Data) GetSprintByID(id int) (*model.Sprint, error) {
+	var sprint model.Sprint
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Sprints")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &sprint)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+// GetSprints returns the sprints of the workspace in ID order.
+func (data *Data) GetSprints(workspaceID int) ([]model.Sprint, error) {
+	var sprints []model.Sprint
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Sprints")).ForEach(func(k, v []byte) error {
+			var sprint model.Sprint
+			if err := json.Unmarshal(v, &sprint); err != nil {
+				log.Println("Error unmarshaling sprint:", err)
+				return nil // Continue despite error
+			}
+			if sprint.WorkspaceID == workspaceID {
+				sprints = append(sprints, sprint)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching sprints: %v", err)
+	}
+	return sprints, nil
+}
+
+// DeleteSprint deletes the sprint and returns its tasks to the backlog on behalf of the actor in a single transaction.
+func (data *Data) DeleteSprint(id int, actor string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Sprints"))
+		if b.Get(itob(id)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		if _, err := moveSprintTasks(tx, id, 0, false, actor); err != nil {
+			return err
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+// CloseSprint stores the closed sprint and moves its tasks that are not completed to the next sprint,
+// or to the backlog when nextSprintID is 0, in a single transaction. The IDs of the moved tasks are
+// recorded in the CarriedOver field of the returned sprint and the move in their history on behalf of the actor.
+func (data *Data) CloseSprint(sprint model.Sprint, nextSprintID int, actor string) (model.Sprint, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Sprints"))
+		if nextSprintID != 0 && b.Get(itob(nextSprintID)) == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		carried, err := moveSprintTasks(tx, sprint.ID, nextSprintID, true, actor)
+		if err != nil {
+			return err
+		}
+		sprint.CarriedOver = append(sprint.CarriedOver, carried...)
+		sort.Ints(sprint.CarriedOver)
+		return putSprint(b, &sprint)
+	})
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+func putSprint(b *bbolt.Bucket, sprint *model.Sprint) error {
+	if sprint.ID == 0 {
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		sprint.ID = int(id)
+	}
+
+	sprintJSON, err := json.Marshal(sprint)
+	if err != nil {
+		return fmt.Errorf("error marshaling sprint: %v", err)
+	}
+	return b.Put(itob(sprint.ID), sprintJSON)
+}
+
+// moveSprintTasks moves the tasks of a sprint to another sprint, 0 being the backlog, and returns their IDs.
+// With unfinishedOnly, completed tasks stay in the sprint. The move is recorded on behalf of the actor.
+func moveSprintTasks(tx *bbolt.Tx, from, to int, unfinishedOnly bool, actor string) ([]int, error) {
+	var moved []int
+	err := updateTasksWhere(tx, actor, func(task *model.Task) bool {
+		if task.SprintID != from || unfinishedOnly && task.Status == model.StatusCompleted {
+			return false
+		}
+		task.SprintID = to
+		moved = append(moved, task.ID)
+		return true
+	})
+	return moved, err
+}
+
+func workspaceMemberKey(workspaceID, userID int) []byte {
+	return []byte(fmt.Sprintf("%d:%d", workspaceID, userID))
+}
+
+func putWorkspaceMember(tx *bbolt.Tx, member model.WorkspaceMember) error {
+	memberJSON, err := json.Marshal(member)
+	if err != nil {
+		return fmt.Errorf("error marshaling workspace member: %v", err)
+	}
+	return tx.Bucket([]byte("WorkspaceMembers")).Put(workspaceMemberKey(member.WorkspaceID, member.UserID), memberJSON)
+}
+
+func putInvitation(tx *bbolt.Tx, invitation *model.Invitation) error {
+	b := tx.Bucket([]byte("Invitations"))
+	if invitation.ID == 0 {
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		invitation.ID = int(id)
+	}
+
+	invitationJSON, err := json.Marshal(invitation)
+	if err != nil {
+		return fmt.Errorf("error marshaling invitation: %v", err)
+	}
+	return b.Put(itob(invitation.ID), invitationJSON)
+}
+
+// taskTagKey builds the key of a tag assignment. Keys start with the task ID so that the
+// assignments of a task can be found with a prefix scan.
+func taskTagKey(taskID, tagID int) []byte {
+	return []byte(fmt.Sprintf("%d:%d", taskID, tagID))
+}
+
+// taskTagIDs returns the IDs of the tags assigned to the task.
+func taskTagIDs(tx *bbolt.Tx, taskID int) []int {
+	var tagIDs []int
+	prefix := []byte(fmt.Sprintf("%d:", taskID))
+	c := tx.Bucket([]byte("TaskTags")).Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		var assignment model.TaskTag
+		if err := json.Unmarshal(v, &assignment); err == nil {
+			tagIDs = append(tagIDs, assignment.TagID)
+		}
+	}
+	return tagIDs
+}
+
+func deleteTaskTags(tx *bbolt.Tx, taskID int) error {
+	c := tx.Bucket([]byte("TaskTags")).Cursor()
+	prefix := []byte(fmt.Sprintf("%d:", taskID))
+
+	var keys [][]byte
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	for _, k := range keys {
+		if err := tx.Bucket([]byte("TaskTags")).Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextTaskID returns one more than the highest task ID in the Tasks bucket or the trash, so that a
+// trashed task can always be restored under its own ID. Task keys are decimal strings, so they are
+// compared as numbers rather than relying on the key order.
+func nextTaskID(tx *bbolt.Tx) int {
+	highest := 0
+	tx.Bucket([]byte("Tasks")).ForEach(func(k, v []byte) error {
+		if id, err := strconv.Atoi(string(k)); err == nil && id > highest {
+			highest = id
+		}
+		return nil
+	})
+	tx.Bucket([]byte("Trash")).ForEach(func(k, v []byte) error {
+		var entry trashEntry
+		if json.Unmarshal(v, &entry) == nil {
+			for _, task := range entry.Tasks {
+				if task.ID > highest {
+					highest = task.ID
+				}
+			}
+		}
+		return nil
+	})
+	return highest + 1
+}
+
+// lastTaskRank returns the highest rank given to a task, kept under the taskRank key of the Meta bucket. Databases
+// written before the key existed are scanned once.
+func lastTaskRank(tx *bbolt.Tx) string {
+	if v := tx.Bucket([]byte("Meta")).Get([]byte("taskRank")); v != nil {
+		return string(v)
+	}
+
+	last := ""
+	tx.Bucket([]byte("Tasks")).ForEach(func(k, v []byte) error {
+		var task model.Task
+		if err := json.Unmarshal(v, &task); err == nil && task.Rank > last {
+			last = task.Rank
+		}
+		return nil
+	})
+	return last
+}
+
+// noteTaskRank keeps the highest rank up to date after a task was written with the given rank, and spreads the ranks
+// of all tasks out again once the rank has grown longer than model.MaxRankLength.
+func noteTaskRank(tx *bbolt.Tx, rank string) error {
+	if len(rank) > model.MaxRankLength {
+		return spreadTaskRanks(tx)
+	}
+
+	meta := tx.Bucket([]byte("Meta"))
+	if v := meta.Get([]byte("taskRank")); v != nil && string(v) >= rank {
+		return nil
+	}
+	if last := lastTaskRank(tx); last > rank {
+		rank = last
+	}
+	return meta.Put([]byte("taskRank"), []byte(rank))
+}
+
+// spreadTaskRanks gives every ranked task an evenly spaced rank in its current order, ties broken by ID. The order of
+// every column is kept, so the change is not recorded in the history of the tasks.
+func spreadTaskRanks(tx *bbolt.Tx) error {
+	b := tx.Bucket([]byte("Tasks"))
+
+	var tasks []model.Task
+	err := b.ForEach(func(k, v []byte) error {
+		var task model.Task
+		if err := json.Unmarshal(v, &task); err == nil && task.Rank != "" {
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	model.SortByRank(tasks)
+
+	ranks := model.SpreadRanks(len(tasks))
+	for i, task := range tasks {
+		task.Rank = ranks[i]
+		taskJSON, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+		if err := b.Put([]byte(fmt.Sprintf("%d", task.ID)), taskJSON); err != nil {
+			return err
+		}
+	}
+
+	last := ""
+	if len(ranks) > 0 {
+		last = ranks[len(ranks)-1]
+	}
+	return tx.Bucket([]byte("Meta")).Put([]byte("taskRank"), []byte(last))
+}
+
+// childTaskIDs maps every task ID to the IDs of its direct subtasks.
+func childTaskIDs(b *bbolt.Bucket) map[int][]int {
+	children := map[int][]int{}
+	b.ForEach(func(k, v []byte) error {
+		var task model.Task
+		if err := json.Unmarshal(v, &task); err == nil && task.ParentID != 0 {
+			children[task.ParentID] = append(children[task.ParentID], task.ID)
+		}
+		return nil
+	})
+	return children
+}
+
+func putUser(tx *bbolt.Tx, user model.User) error {
+	userJSON, err := json.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("error marshaling user: %v", err)
+	}
+	return tx.Bucket([]byte("Users")).Put(itob(user.ID), userJSON)
+}
+
+func putAuditRecord(tx *bbolt.Tx, record model.AuditRecord) error {
+	b := tx.Bucket([]byte("Audit"))
+	id, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	record.ID = int(id)
+
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error marshaling audit record: %v", err)
+	}
+	return b.Put(itob(record.ID), recordJSON)
+}
+
+func deleteSessionsByEmail(tx *bbolt.Tx, email string) (int, error) {
+	return deleteWhere(tx.Bucket([]byte("Sessions")), func(v []byte) bool {
+		var session model.Session
+		return json.Unmarshal(v, &session) == nil && session.Email == email
+	})
+}
+
+// updateWhere replaces every record of the bucket for which update returns a new value. Like deleteWhere,
+// the changes are collected first because bbolt does not allow modifying a bucket while iterating.
+func updateWhere(b *bbolt.Bucket, update func(v []byte) ([]byte, bool)) error {
+	updates := map[string][]byte{}
+	err := b.ForEach(func(k, v []byte) error {
+		if updated, ok := update(v); ok {
+			updates[string(k)] = updated
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for k, v := range updates {
+		if err := b.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteWhere removes every record of the bucket matching the predicate and returns how many
+// were removed. Keys are collected first because bbolt does not allow deleting while iterating.
+func deleteWhere(b *bbolt.Bucket, match func(v []byte) bool) (int, error) {
+	var keys [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		if match(v) {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return 0, err
+		}
+	}
+	return len(keys), nil
+}
+
+func (data *Data) StoreTemplate(template model.Template) (model.Template, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Templates"))
+		if template.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			template.ID = int(id)
+		}
+
+		templateJSON, err := json.Marshal(template)
+		if err != nil {
+			return fmt.Errorf("error marshaling template: %v", err)
+		}
+		return b.Put(itob(template.ID), templateJSON)
+	})
+	if err != nil {
+		return model.Template{}, err
+	}
+	return template, nil
+}
+
+func (data *Data) GetTemplateByID(id int) (*model.Template, error) {
+	var template model.Template
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Templates")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &template)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplates returns the templates of the workspace in ID order.
+func (data *Data) GetTemplates(workspaceID int) ([]model.Template, error) {
+	var templates []model.Template
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Templates")).ForEach(func(k, v []byte) error {
+			var template model.Template
+			if err := json.Unmarshal(v, &template); err != nil {
+				log.Println("Error unmarshaling template:", err)
+				return nil // Continue despite error
+			}
+			if template.WorkspaceID == workspaceID {
+				templates = append(templates, template)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching templates: %v", err)
+	}
+	return templates, nil
+}
+
+func (data *Data) DeleteTemplate(id int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Templates"))
+		if b.Get(itob(id)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+func (data *Data) StoreField(field model.CustomField) (model.CustomField, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("CustomFields"))
+		if field.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			field.ID = int(id)
+		}
+
+		fieldJSON, err := json.Marshal(field)
+		if err != nil {
+			return fmt.Errorf("error marshaling custom field: %v", err)
+		}
+		return b.Put(itob(field.ID), fieldJSON)
+	})
+	if err != nil {
+		return model.CustomField{}, err
+	}
+	return field, nil
+}
+
+func (data *Data) GetFieldByID(id int) (*model.CustomField, error) {
+	var field model.CustomField
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("CustomFields")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &field)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+// GetFields returns the custom fields of the workspace in ID order.
+func (data *Data) GetFields(workspaceID int) ([]model.CustomField, error) {
+	var fields []model.CustomField
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("CustomFields")).ForEach(func(k, v []byte) error {
+			var field model.CustomField
+			if err := json.Unmarshal(v, &field); err != nil {
+				log.Println("Error unmarshaling custom field:", err)
+				return nil // Continue despite error
+			}
+			if field.WorkspaceID == workspaceID {
+				fields = append(fields, field)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching custom fields: %v", err)
+	}
+	return fields, nil
+}
+
+// DeleteField removes the custom field and its values from every task, on behalf of the actor, in one transaction.
+func (data *Data) DeleteField(id int, actor string) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("CustomFields"))
+		if b.Get(itob(id)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		if err := clearTaskFields(tx, map[int]bool{id: true}, actor); err != nil {
+			return err
+		}
+		return b.Delete(itob(id))
+	})
+}
+
+// clearTaskFields removes the values of the given custom fields from every task on behalf of the actor.
+func clearTaskFields(tx *bbolt.Tx, fields map[int]bool, actor string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return updateTasksWhere(tx, actor, func(task *model.Task) bool {
+		cleared := false
+		for id := range task.Fields {
+			if fields[id] {
+				delete(task.Fields, id)
+				cleared = true
+			}
+		}
+		return cleared
+	})
+}
+
+// trashEntry is how a trash item is stored: the item together with the records of related buckets, such as comments
+// and tag assignments, that were taken out of those buckets when its tasks were trashed.
+type trashEntry struct {
+	model.TrashItem
+	Records []trashRecord `json:"records,omitempty"`
+}
+
+type trashRecord struct {
+	Bucket string          `json:"bucket"`
+	Key    []byte          `json:"key"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// taskRecordBuckets are the buckets holding records that belong to a task and move to the trash with it.
+var taskRecordBuckets = []string{"TaskTags", "Dependencies", "Comments", "TimeEntries", "Assignees", "Attachments"}
+
+// TrashTask moves the task to the trash, together with all of its subtasks unless keepChildren moves its
+// direct subtasks up to its parent instead, and the tag assignments, dependencies, comments, time entries,
+// assignees and attachments of the trashed tasks, in a single transaction. The actor is recorded in the history of
+// the trashed and reparented tasks.
+func (data *Data) TrashTask(id int, keepChildren bool, actor string, deletedAt time.Time) (model.TrashItem, error) {
+	var entry trashEntry
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Tasks"))
+		v := b.Get([]byte(fmt.Sprintf("%d", id)))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		var task model.Task
+		if err := json.Unmarshal(v, &task); err != nil {
+			return err
+		}
+
+		trashed := map[int]bool{id: true}
+		tasks := []model.Task{task}
+		if keepChildren {
+			if err := reparentChildren(tx, id, task.ParentID, actor); err != nil {
+				return err
+			}
+		} else {
+			children := childTaskIDs(b)
+			for i := 0; i < len(tasks); i++ {
+				for _, childID := range children[tasks[i].ID] {
+					if trashed[childID] {
+						continue
+					}
+					var child model.Task
+					if err := json.Unmarshal(b.Get([]byte(fmt.Sprintf("%d", childID))), &child); err != nil {
+						return err
+					}
+					trashed[childID] = true
+					tasks = append(tasks, child)
+				}
+			}
+		}
+
+		for _, trashedTask := range tasks {
+			if err := b.Delete([]byte(fmt.Sprintf("%d", trashedTask.ID))); err != nil {
+				return err
+			}
+			if err := putTaskEvent(tx, model.EventDeleted, actor, trashedTask, trashedTask); err != nil {
+				return err
+			}
+		}
+		records, err := takeTaskRecords(tx, trashed)
+		if err != nil {
+			return err
+		}
+
+		entry = trashEntry{
+			TrashItem: model.TrashItem{
+				Kind:        model.TrashTask,
+				Title:       task.Title,
+				UserID:      task.UserID,
+				WorkspaceID: task.WorkspaceID,
+				DeletedAt:   deletedAt,
+				Tasks:       tasks,
+			},
+			Records: records,
+		}
+		return putTrashEntry(tx, &entry)
+	})
+	if err != nil {
+		return model.TrashItem{}, err
+	}
+	return entry.TrashItem, nil
+}
+
+// TrashCategory moves the category to the trash. Its tasks keep pointing at it, so they are back in the
+// category once it is restored.
+func (data *Data) TrashCategory(id int, deletedAt time.Time) (model.TrashItem, error) {
+	var entry trashEntry
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Categories"))
+		key := []byte(fmt.Sprintf("%d", id))
+		v := b.Get(key)
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		var category model.Category
+		if err := json.Unmarshal(v, &category); err != nil {
+			return err
+		}
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+		if err := unindexSearchDoc(tx, model.SearchKindCategory, id); err != nil {
+			return err
+		}
+
+		entry = trashEntry{TrashItem: model.TrashItem{
+			Kind:        model.TrashCategory,
+			Title:       category.Name,
+			UserID:      category.UserID,
+			WorkspaceID: category.WorkspaceID,
+			DeletedAt:   deletedAt,
+			Category:    &category,
+		}}
+		return putTrashEntry(tx, &entry)
+	})
+	if err != nil {
+		return model.TrashItem{}, err
+	}
+	return entry.TrashItem, nil
+}
+
+func (data *Data) GetTrashItemByID(id int) (*model.TrashItem, error) {
+	var entry trashEntry
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Trash")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry.TrashItem, nil
+}
+
+// GetTrashItems returns the trash items of the workspace in the order they were deleted.
+func (data *Data) GetTrashItems(workspaceID int) ([]model.TrashItem, error) {
+	var items []model.TrashItem
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Trash")).ForEach(func(k, v []byte) error {
+			var entry trashEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				log.Println("Error unmarshaling trash item:", err)
+				return nil // Continue despite error
+			}
+			if entry.WorkspaceID == workspaceID {
+				items = append(items, entry.TrashItem)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching trash: %v", err)
+	}
+	return items, nil
+}
+
+// RestoreTrashItem moves a trash item back in a single transaction. Restored tasks whose parent, sprint or
+// milestone is gone are detached from it, and records of other buckets are only put back when every task,
+// tag and user they refer to exists. A category is only restored while its ID is free. Restored tasks get a restored
+// event in their history, on behalf of the actor, listing the references that were detached.
+func (data *Data) RestoreTrashItem(id int, actor string) (model.TrashItem, error) {
+	var entry trashEntry
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		trash := tx.Bucket([]byte("Trash"))
+		v := trash.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return err
+		}
+
+		if entry.Category != nil {
+			b := tx.Bucket([]byte("Categories"))
+			key := []byte(fmt.Sprintf("%d", entry.Category.ID))
+			if b.Get(key) != nil {
+				return fmt.Errorf("record already exists")
+			}
+			categoryJSON, err := json.Marshal(entry.Category)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(key, categoryJSON); err != nil {
+				return err
+			}
+			err = indexSearchDoc(tx, model.SearchKindCategory, entry.Category.ID, entry.Category.WorkspaceID, entry.Category.Name)
+			if err != nil {
+				return err
+			}
+		}
+
+		b := tx.Bucket([]byte("Tasks"))
+		restored := map[int]bool{}
+		for _, task := range entry.Tasks {
+			restored[task.ID] = true
+		}
+		for i := range entry.Tasks {
+			task := &entry.Tasks[i]
+			key := []byte(fmt.Sprintf("%d", task.ID))
+			if b.Get(key) != nil {
+				return fmt.Errorf("record already exists")
+			}
+			trashed := *task
+			if task.ParentID != 0 && !restored[task.ParentID] && b.Get([]byte(fmt.Sprintf("%d", task.ParentID))) == nil {
+				task.ParentID = 0
+			}
+			if task.SprintID != 0 && tx.Bucket([]byte("Sprints")).Get(itob(task.SprintID)) == nil {
+				task.SprintID = 0
+			}
+			if task.MilestoneID != 0 && tx.Bucket([]byte("Milestones")).Get(itob(task.MilestoneID)) == nil {
+				task.MilestoneID = 0
+			}
+
+			taskJSON, err := json.Marshal(task)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(key, taskJSON); err != nil {
+				return err
+			}
+			if err := putTaskEvent(tx, model.EventRestored, actor, trashed, *task); err != nil {
+				return err
+			}
+		}
+
+		for _, record := range entry.Records {
+			if !taskRecordRestorable(tx, record) {
+				continue
+			}
+			if err := tx.Bucket([]byte(record.Bucket)).Put(record.Key, record.Value); err != nil {
+				return err
+			}
+		}
+		return trash.Delete(itob(id))
+	})
+	if err != nil {
+		return model.TrashItem{}, err
+	}
+	return entry.TrashItem, nil
+}
+
+// PurgeTrashItem removes a trash item for good, queueing the content of its attachments in OrphanedBlobs and
+// removing the watches of its tasks or category, which are kept while it can still be restored.
+func (data *Data) PurgeTrashItem(id int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		trash := tx.Bucket([]byte("Trash"))
+		v := trash.Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		var entry trashEntry
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return err
+		}
+		if err := orphanTrashedAttachments(tx, entry); err != nil {
+			return err
+		}
+		if err := deleteTrashedWatches(tx, entry); err != nil {
+			return err
+		}
+		return trash.Delete(itob(id))
+	})
+}
+
+// PurgeTrashBefore removes every trash item deleted before the cutoff, in every workspace, and returns how many
+// were removed.
+func (data *Data) PurgeTrashBefore(cutoff time.Time) (int, error) {
+	var purged int
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		var err error
+		purged, err = deleteWhere(tx.Bucket([]byte("Trash")), func(v []byte) bool {
+			var entry trashEntry
+			if json.Unmarshal(v, &entry) != nil || !entry.DeletedAt.Before(cutoff) {
+				return false
+			}
+			return orphanTrashedAttachments(tx, entry) == nil && deleteTrashedWatches(tx, entry) == nil
+		})
+		return err
+	})
+	return purged, err
+}
+
+func putTrashEntry(tx *bbolt.Tx, entry *trashEntry) error {
+	b := tx.Bucket([]byte("Trash"))
+	id, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	entry.ID = int(id)
+
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshaling trash item: %v", err)
+	}
+	return b.Put(itob(entry.ID), entryJSON)
+}
+
+// takeTaskRecords removes the records of the given tasks from the buckets in taskRecordBuckets and returns them.
+func takeTaskRecords(tx *bbolt.Tx, taskIDs map[int]bool) ([]trashRecord, error) {
+	var records []trashRecord
+	for _, name := range taskRecordBuckets {
+		b := tx.Bucket([]byte(name))
+		var taken []trashRecord
+		err := b.ForEach(func(k, v []byte) error {
+			for _, taskID := range recordTaskIDs(v) {
+				if taskIDs[taskID] {
+					taken = append(taken, trashRecord{
+						Bucket: name,
+						Key:    append([]byte(nil), k...),
+						Value:  append(json.RawMessage(nil), v...),
+					})
+					break
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range taken {
+			if err := b.Delete(record.Key); err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, taken...)
+	}
+	return records, nil
+}
+
+// recordTaskIDs returns the IDs of the tasks a record of one of the taskRecordBuckets refers to.
+func recordTaskIDs(v []byte) []int {
+	var refs struct {
+		TaskID    int `json:"task_id"`
+		BlockerID int `json:"blocker_id"`
+		BlockedID int `json:"blocked_id"`
+	}
+	if json.Unmarshal(v, &refs) != nil {
+		return nil
+	}
+
+	var ids []int
+	for _, id := range []int{refs.TaskID, refs.BlockerID, refs.BlockedID} {
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// taskRecordRestorable reports whether every task, tag and user a trashed record refers to still exists.
+func taskRecordRestorable(tx *bbolt.Tx, record trashRecord) bool {
+	tasks := tx.Bucket([]byte("Tasks"))
+	for _, taskID := range recordTaskIDs(record.Value) {
+		if tasks.Get([]byte(fmt.Sprintf("%d", taskID))) == nil {
+			return false
+		}
+	}
+
+	var refs struct {
+		TagID  int `json:"tag_id"`
+		UserID int `json:"user_id"`
+	}
+	if json.Unmarshal(record.Value, &refs) != nil {
+		return false
+	}
+	if refs.TagID != 0 && tx.Bucket([]byte("Tags")).Get(itob(refs.TagID)) == nil {
+		return false
+	}
+	return refs.UserID == 0 || tx.Bucket([]byte("Users")).Get(itob(refs.UserID)) != nil
+}
+
+// orphanTrashedAttachments queues the blob keys of the attachments kept with a trash entry in OrphanedBlobs.
+func orphanTrashedAttachments(tx *bbolt.Tx, entry trashEntry) error {
+	orphans := tx.Bucket([]byte("OrphanedBlobs"))
+	for _, record := range entry.Records {
+		if record.Bucket != "Attachments" {
+			continue
+		}
+		var attachment model.Attachment
+		if err := json.Unmarshal(record.Value, &attachment); err != nil {
+			return err
+		}
+		if err := orphans.Put([]byte(attachment.Key), []byte(time.Now().Format(time.RFC3339))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteTrashedWatches removes the watches of the tasks or the category of a trash item.
+func deleteTrashedWatches(tx *bbolt.Tx, entry trashEntry) error {
+	tasks, categories := map[int]bool{}, map[int]bool{}
+	for _, task := range entry.Tasks {
+		tasks[task.ID] = true
+	}
+	if entry.Category != nil {
+		categories[entry.Category.ID] = true
+	}
+
+	_, err := deleteWhere(tx.Bucket([]byte("Watches")), func(v []byte) bool {
+		var watch model.Watch
+		return json.Unmarshal(v, &watch) == nil && watchesAny(watch, tasks, categories)
+	})
+	return err
+}
+
+// watchesAny reports whether the watch is on one of the given tasks or categories.
+func watchesAny(watch model.Watch, tasks, categories map[int]bool) bool {
+	switch watch.Target {
+	case model.WatchTask:
+		return tasks[watch.TargetID]
+	case model.WatchCategory:
+		return categories[watch.TargetID]
+	}
+	return false
+}
+
+// StoreWatch stores a watch on an existing task or category under the next ID from the bucket sequence, written
+// back to watch.ID. Watching a record again keeps the original watch, which is written back instead.
+func (data *Data) StoreWatch(watch *model.Watch) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		bucket := map[model.WatchTarget]string{model.WatchTask: "Tasks", model.WatchCategory: "Categories"}[watch.Target]
+		if bucket == "" || tx.Bucket([]byte(bucket)).Get([]byte(fmt.Sprintf("%d", watch.TargetID))) == nil {
+			return fmt.Errorf("record not found")
+		}
+
+		b := tx.Bucket([]byte("Watches"))
+		err := b.ForEach(func(k, v []byte) error {
+			var existing model.Watch
+			if json.Unmarshal(v, &existing) == nil && existing.UserID == watch.UserID &&
+				existing.Target == watch.Target && existing.TargetID == watch.TargetID {
+				*watch = existing
+			}
+			return nil
+		})
+		if err != nil || watch.ID != 0 {
+			return err
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		watch.ID = int(id)
+
+		watchJSON, err := json.Marshal(watch)
+		if err != nil {
+			return fmt.Errorf("error marshaling watch: %v", err)
+		}
+		return b.Put(itob(watch.ID), watchJSON)
+	})
+}
+
+// DeleteWatch removes the watch of the user on the task or category, if any.
+func (data *Data) DeleteWatch(userID int, target model.WatchTarget, targetID int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		_, err := deleteWhere(tx.Bucket([]byte("Watches")), func(v []byte) bool {
+			var watch model.Watch
+			return json.Unmarshal(v, &watch) == nil && watch.UserID == userID && watch.Target == target && watch.TargetID == targetID
+		})
+		return err
+	})
+}
+
+// GetWatches returns the watches matching the filter, in ID order. A zero userID matches every user and an empty
+// target every task and category, in which case targetID is ignored.
+func (data *Data) GetWatches(userID int, target model.WatchTarget, targetID int) ([]model.Watch, error) {
+	var watches []model.Watch
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Watches")).ForEach(func(k, v []byte) error {
+			var watch model.Watch
+			if err := json.Unmarshal(v, &watch); err != nil {
+				log.Println("Error unmarshaling watch:", err)
+				return nil // Continue despite error
+			}
+			if (userID == 0 || watch.UserID == userID) && (target == "" || watch.Target == target && watch.TargetID == targetID) {
+				watches = append(watches, watch)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching watches: %v", err)
+	}
+	return watches, nil
+}
+
+// StoreNotifications stores new notifications in a single transaction, each under the next ID from the bucket
+// sequence, which is written back to the notification.
+func (data *Data) StoreNotifications(notifications []model.Notification) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Notifications"))
+		for i := range notifications {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			notifications[i].ID = int(id)
+
+			notificationJSON, err := json.Marshal(notifications[i])
+			if err != nil {
+				return fmt.Errorf("error marshaling notification: %v", err)
+			}
+			if err := b.Put(itob(notifications[i].ID), notificationJSON); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetNotifications returns the notifications sent to the user, in ID order.
+func (data *Data) GetNotifications(userID int) ([]model.Notification, error) {
+	var notifications []model.Notification
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Notifications")).ForEach(func(k, v []byte) error {
+			var notification model.Notification
+			if err := json.Unmarshal(v, &notification); err != nil {
+				log.Println("Error unmarshaling notification:", err)
+				return nil // Continue despite error
+			}
+			if notification.UserID == userID {
+				notifications = append(notifications, notification)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching notifications: %v", err)
+	}
+	return notifications, nil
+}
+
+// MarkNotificationsRead sets the read time of the unread notification with the ID, or of every unread notification
+// of the user when id is 0. A notification sent to another user is reported as not found.
+func (data *Data) MarkNotificationsRead(userID, id int, readAt time.Time) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Notifications"))
+		if id != 0 {
+			v := b.Get(itob(id))
+			var notification model.Notification
+			if v == nil || json.Unmarshal(v, &notification) != nil || notification.UserID != userID {
+				return fmt.Errorf("record not found")
+			}
+		}
+
+		return updateWhere(b, func(v []byte) ([]byte, bool) {
+			var notification model.Notification
+			if json.Unmarshal(v, &notification) != nil || notification.UserID != userID ||
+				(id != 0 && notification.ID != id) || notification.ReadAt != nil {
+				return nil, false
+			}
+
+			notification.ReadAt = &readAt
+			notificationJSON, err := json.Marshal(notification)
+			if err != nil {
+				return nil, false
+			}
+			return notificationJSON, true
+		})
+	})
+}
+
+// searchBuckets hold the full-text search index: SearchIndex maps "<term>\x00<kind>:<id>" to the number of times the
+// term occurs in the task or category, SearchDocs keeps what was indexed for every record, so its postings can be
+// taken out again, and SearchStats the number and total length of the indexed records of every workspace.
+var searchBuckets = []string{"SearchIndex", "SearchDocs", "SearchStats"}
+
+// BM25 parameters of the full-text search ranking.
+const (
+	searchK1 = 1.2
+	searchB  = 0.75
+)
+
+type searchDoc struct {
+	WorkspaceID int            `json:"workspace_id"`
+	Text        string         `json:"text"`
+	Length      int            `json:"length"`
+	Terms       map[string]int `json:"terms"`
+}
+
+type searchStats struct {
+	Docs   int `json:"docs"`
+	Length int `json:"length"`
+}
+
+func searchDocKey(kind string, id int) []byte {
+	return []byte(fmt.Sprintf("%s:%d", kind, id))
+}
+
+func searchPostingPrefix(term string) []byte {
+	return append([]byte(term), 0)
+}
+
+// indexSearchDoc indexes the title of a task or the name of a category, replacing what was indexed for it before.
+func indexSearchDoc(tx *bbolt.Tx, kind string, id, workspaceID int, text string) error {
+	if err := unindexSearchDoc(tx, kind, id); err != nil {
+		return err
+	}
+
+	key := searchDocKey(kind, id)
+	terms, length := model.AnalyzeText(text)
+	index := tx.Bucket([]byte("SearchIndex"))
+	for term, count := range terms {
+		if err := index.Put(append(searchPostingPrefix(term), key...), []byte(strconv.Itoa(count))); err != nil {
+			return err
+		}
+	}
+
+	docJSON, err := json.Marshal(searchDoc{WorkspaceID: workspaceID, Text: text, Length: length, Terms: terms})
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket([]byte("SearchDocs")).Put(key, docJSON); err != nil {
+		return err
+	}
+	return addSearchStats(tx, workspaceID, 1, length)
+}
+
+// unindexSearchDoc takes a task or category out of the search index. Records that are not indexed are left alone.
+func unindexSearchDoc(tx *bbolt.Tx, kind string, id int) error {
+	docs := tx.Bucket([]byte("SearchDocs"))
+	key := searchDocKey(kind, id)
+	v := docs.Get(key)
+	if v == nil {
+		return nil
+	}
+
+	var doc searchDoc
+	if err := json.Unmarshal(v, &doc); err != nil {
+		return err
+	}
+	index := tx.Bucket([]byte("SearchIndex"))
+	for term := range doc.Terms {
+		if err := index.Delete(append(searchPostingPrefix(term), key...)); err != nil {
+			return err
+		}
+	}
+	if err := docs.Delete(key); err != nil {
+		return err
+	}
+	return addSearchStats(tx, doc.WorkspaceID, -1, -doc.Length)
+}
+
+func addSearchStats(tx *bbolt.Tx, workspaceID, docs, length int) error {
+	b := tx.Bucket([]byte("SearchStats"))
+	stats := getSearchStats(tx, workspaceID)
+	stats.Docs += docs
+	stats.Length += length
+	if stats.Docs <= 0 {
+		return b.Delete(itob(workspaceID))
+	}
+
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	return b.Put(itob(workspaceID), statsJSON)
+}
+
+func getSearchStats(tx *bbolt.Tx, workspaceID int) searchStats {
+	var stats searchStats
+	if v := tx.Bucket([]byte("SearchStats")).Get(itob(workspaceID)); v != nil {
+		json.Unmarshal(v, &stats)
+	}
+	return stats
+}
+
+// indexTask keeps the search index in step with a change to a task: deleted tasks are taken out of it, and others are
+// indexed again unless their title and workspace stayed the same.
+func indexTask(tx *bbolt.Tx, action model.EventAction, before, after model.Task) error {
+	switch {
+	case action == model.EventDeleted:
+		return unindexSearchDoc(tx, model.SearchKindTask, after.ID)
+	case action == model.EventUpdated && before.Title == after.Title && before.WorkspaceID == after.WorkspaceID:
+		return nil
+	}
+	return indexSearchDoc(tx, model.SearchKindTask, after.ID, after.WorkspaceID, after.Title)
+}
+
+// rebuildSearchIndex empties the search index and indexes every task and category again, returning how many records
+// were indexed.
+func rebuildSearchIndex(tx *bbolt.Tx) (int, error) {
+	for _, name := range searchBuckets {
+		if err := tx.DeleteBucket([]byte(name)); err != nil && err != bbolt.ErrBucketNotFound {
+			return 0, err
+		}
+		if _, err := tx.CreateBucket([]byte(name)); err != nil {
+			return 0, err
+		}
+	}
+
+	indexed := 0
+	err := tx.Bucket([]byte("Tasks")).ForEach(func(k, v []byte) error {
+		var task model.Task
+		if err := json.Unmarshal(v, &task); err != nil {
+			return err
+		}
+		indexed++
+		return indexSearchDoc(tx, model.SearchKindTask, task.ID, task.WorkspaceID, task.Title)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Bucket([]byte("Categories")).ForEach(func(k, v []byte) error {
+		var category model.Category
+		if err := json.Unmarshal(v, &category); err != nil {
+			return err
+		}
+		indexed++
+		return indexSearchDoc(tx, model.SearchKindCategory, category.ID, category.WorkspaceID, category.Name)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return indexed, nil
+}
+
+// RebuildSearchIndex indexes every task and category again in one transaction, for data written before the index
+// existed or after it was damaged. It returns how many records were indexed.
+func (data *Data) RebuildSearchIndex() (int, error) {
+	var indexed int
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		var err error
+		indexed, err = rebuildSearchIndex(tx)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error rebuilding search index: %v", err)
+	}
+	return indexed, nil
+}
+
+// SearchText ranks the tasks and categories of the workspace matching any word of the query with BM25 over the
+// workspace, scaled by the share of the query words they match, and returns the best limit of them, all of them when
+// limit is 0. The words of their title or name matching the query are highlighted.
+func (data *Data) SearchText(workspaceID int, query model.TextQuery, limit int) ([]model.SearchHit, error) {
+	hits := []model.SearchHit{}
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		stats := getSearchStats(tx, workspaceID)
+		if stats.Docs == 0 {
+			return nil
+		}
+		avgLength := float64(stats.Length) / float64(stats.Docs)
+		if avgLength == 0 {
+			avgLength = 1
+		}
+
+		docs := map[string]*searchDoc{}
+		loadDoc := func(key string) (*searchDoc, error) {
+			if doc, ok := docs[key]; ok {
+				return doc, nil
+			}
+			var doc *searchDoc
+			if v := tx.Bucket([]byte("SearchDocs")).Get([]byte(key)); v != nil {
+				doc = &searchDoc{}
+				if err := json.Unmarshal(v, doc); err != nil {
+					return nil, err
+				}
+			}
+			docs[key] = doc
+			return doc, nil
+		}
+
+		scores := map[string]float64{}
+		matched := map[string]int{}
+		c := tx.Bucket([]byte("SearchIndex")).Cursor()
+		for _, word := range query.Words {
+			best := map[string]float64{}
+			for _, term := range word {
+				counts := map[string]int{}
+				prefix := searchPostingPrefix(term)
+				for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+					key := string(k[len(prefix):])
+					doc, err := loadDoc(key)
+					if err != nil {
+						return err
+					}
+					if doc == nil || doc.WorkspaceID != workspaceID {
+						continue
+					}
+					counts[key], _ = strconv.Atoi(string(v))
+				}
+
+				df := float64(len(counts))
+				idf := math.Log(1 + (float64(stats.Docs)-df+0.5)/(df+0.5))
+				for key, count := range counts {
+					tf := float64(count)
+					norm := 1 - searchB + searchB*float64(docs[key].Length)/avgLength
+					if score := idf * tf * (searchK1 + 1) / (tf + searchK1*norm); score > best[key] {
+						best[key] = score
+					}
+				}
+			}
+			for key, score := range best {
+				scores[key] += score
+				matched[key]++
+			}
+		}
+
+		terms := query.Terms()
+		for key, score := range scores {
+			separator := strings.LastIndexByte(key, ':')
+			id, err := strconv.Atoi(key[separator+1:])
+			if err != nil {
+				continue
+			}
+
+			doc := docs[key]
+			highlighted, highlights := model.Highlight(doc.Text, terms)
+			hits = append(hits, model.SearchHit{
+				Kind:        key[:separator],
+				ID:          id,
+				Text:        doc.Text,
+				Highlighted: highlighted,
+				Highlights:  highlights,
+				Score:       score * float64(matched[key]) / float64(len(query.Words)),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching text: %v", err)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind > hits[j].Kind
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+func (data *Data) StoreFilter(filter model.SavedFilter) (model.SavedFilter, error) {
+	err := data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Filters"))
+		if filter.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			filter.ID = int(id)
+		}
+
+		filterJSON, err := json.Marshal(filter)
+		if err != nil {
+			return fmt.Errorf("error marshaling filter: %v", err)
+		}
+		return b.Put(itob(filter.ID), filterJSON)
+	})
+	if err != nil {
+		return model.SavedFilter{}, err
+	}
+	return filter, nil
+}
+
+func (data *Data) GetFilterByID(id int) (*model.SavedFilter, error) {
+	var filter model.SavedFilter
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		v := tx.Bucket([]byte("Filters")).Get(itob(id))
+		if v == nil {
+			return fmt.Errorf("record not found")
+		}
+		return json.Unmarshal(v, &filter)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
+// GetFilters returns the filters the user saved in the workspace in ID order.
+func (data *Data) GetFilters(userID, workspaceID int) ([]model.SavedFilter, error) {
+	var filters []model.SavedFilter
+	err := data.DB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("Filters")).ForEach(func(k, v []byte) error {
+			var filter model.SavedFilter
+			if err := json.Unmarshal(v, &filter); err != nil {
+				log.Println("Error unmarshaling filter:", err)
+				return nil // Continue despite error
+			}
+			if filter.UserID == userID && filter.WorkspaceID == workspaceID {
+				filters = append(filters, filter)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching filters: %v", err)
+	}
+	return filters, nil
+}
+
+func (data *Data) DeleteFilter(id int) error {
+	return data.DB.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte("Filters"))
+		if b.Get(itob(id)) == nil {
+			return fmt.Errorf("record not found")
+		}
+		return b.Delete(itob(id))
+	})
+}
@@ -0,0 +1,256 @@
+package filebased
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"a21hc3NpZ25tZW50/model"
+
+	"go.etcd.io/bbolt"
+)
+
+// migrations upgrade records written by older versions of the application. They run in order,
+// once per database, and the number of applied migrations is kept in the Meta bucket.
+var migrations = []func(tx *bbolt.Tx) error{
+	migrateTaskDeadlines,
+	migrateTaskStatuses,
+	migrateTaskRanks,
+	migrateSearchIndex,
+}
+
+// legacyDeadlineLayouts are the formats seen in deadlines that were stored as free-form strings.
+var legacyDeadlineLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006/01/02",
+	"02/01/2006",
+	"02-01-2006",
+	"2 January 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+}
+
+// legacyStatuses maps the lowercased spellings seen in free-form statuses to the built-in statuses.
+var legacyStatuses = map[string]model.TaskStatus{
+	"":            model.StatusTodo,
+	"todo":        model.StatusTodo,
+	"to do":       model.StatusTodo,
+	"pending":     model.StatusTodo,
+	"not started": model.StatusTodo,
+	"in progress": model.StatusInProgress,
+	"on progress": model.StatusInProgress,
+	"progress":    model.StatusInProgress,
+	"doing":       model.StatusInProgress,
+	"review":      model.StatusReview,
+	"in review":   model.StatusReview,
+	"completed":   model.StatusCompleted,
+	"complete":    model.StatusCompleted,
+	"done":        model.StatusCompleted,
+	"finished":    model.StatusCompleted,
+}
+
+func migrate(tx *bbolt.Tx) error {
+	meta, err := tx.CreateBucketIfNotExists([]byte("Meta"))
+	if err != nil {
+		return fmt.Errorf("create meta bucket: %v", err)
+	}
+
+	applied := 0
+	if v := meta.Get([]byte("migrations")); v != nil {
+		applied, err = strconv.Atoi(string(v))
+		if err != nil {
+			return fmt.Errorf("read applied migrations: %v", err)
+		}
+	}
+
+	for i := applied; i < len(migrations); i++ {
+		if err := migrations[i](tx); err != nil {
+			return fmt.Errorf("migration %d: %v", i+1, err)
+		}
+	}
+
+	return meta.Put([]byte("migrations"), []byte(strconv.Itoa(len(migrations))))
+}
+
+// migrateTaskDeadlines rewrites free-form deadline strings into the canonical form understood
+// by model.Deadline. Values that cannot be recognised are moved to legacy_deadline so the task
+// stays readable without losing them.
+func migrateTaskDeadlines(tx *bbolt.Tx) error {
+	b := tx.Bucket([]byte("Tasks"))
+
+	updates := map[string][]byte{}
+	err := b.ForEach(func(k, v []byte) error {
+		var task map[string]json.RawMessage
+		if err := json.Unmarshal(v, &task); err != nil {
+			log.Println("Error unmarshaling task:", err)
+			return nil // Continue despite error
+		}
+
+		var value string
+		if err := json.Unmarshal(task["deadline"], &value); err != nil {
+			return nil // Not a string, nothing to migrate
+		}
+
+		deadline, ok := parseLegacyDeadline(value)
+		if !ok {
+			log.Printf("Moving unrecognised deadline %q of task %s to legacy_deadline", value, k)
+			task["legacy_deadline"], _ = json.Marshal(value)
+		}
+		if deadline.String() == value {
+			return nil
+		}
+
+		task["deadline"], _ = json.Marshal(deadline)
+		taskJSON, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+		updates[string(k)] = taskJSON
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for k, v := range updates {
+		if err := b.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateTaskStatuses rewrites free-form statuses into the built-in statuses of the workflow.
+// Statuses it does not recognise are kept, the workflow lets such tasks move to any status.
+func migrateTaskStatuses(tx *bbolt.Tx) error {
+	b := tx.Bucket([]byte("Tasks"))
+
+	updates := map[string][]byte{}
+	err := b.ForEach(func(k, v []byte) error {
+		var task map[string]json.RawMessage
+		if err := json.Unmarshal(v, &task); err != nil {
+			log.Println("Error unmarshaling task:", err)
+			return nil // Continue despite error
+		}
+
+		var value string
+		if raw, ok := task["status"]; ok {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil // Not a string, nothing to migrate
+			}
+		}
+
+		status, ok := legacyStatuses[strings.ToLower(strings.TrimSpace(value))]
+		if !ok || string(status) == value {
+			return nil
+		}
+
+		task["status"], _ = json.Marshal(status)
+		taskJSON, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+		updates[string(k)] = taskJSON
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for k, v := range updates {
+		if err := b.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateTaskRanks gives every task stored before tasks were ranked a rank, in ID order and after the
+// tasks that already have one, so the board keeps showing them in the order they were created.
+func migrateTaskRanks(tx *bbolt.Tx) error {
+	b := tx.Bucket([]byte("Tasks"))
+
+	last := ""
+	unranked := map[int]map[string]json.RawMessage{}
+	err := b.ForEach(func(k, v []byte) error {
+		var task map[string]json.RawMessage
+		if err := json.Unmarshal(v, &task); err != nil {
+			log.Println("Error unmarshaling task:", err)
+			return nil // Continue despite error
+		}
+
+		var rank string
+		if raw, ok := task["rank"]; ok {
+			json.Unmarshal(raw, &rank)
+		}
+		if rank != "" {
+			if rank > last {
+				last = rank
+			}
+			return nil
+		}
+
+		id, err := strconv.Atoi(string(k))
+		if err != nil {
+			return nil // Not a task key, nothing to migrate
+		}
+		unranked[id] = task
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0, len(unranked))
+	for id := range unranked {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		last = model.RankBetween(last, "")
+		task := unranked[id]
+		task["rank"], _ = json.Marshal(last)
+		taskJSON, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+		if err := b.Put([]byte(strconv.Itoa(id)), taskJSON); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateSearchIndex builds the full-text search index over the tasks and categories stored before it existed.
+func migrateSearchIndex(tx *bbolt.Tx) error {
+	_, err := rebuildSearchIndex(tx)
+	return err
+}
+
+func parseLegacyDeadline(value string) (model.Deadline, bool) {
+	if deadline, err := model.ParseDeadline(value, nil); err == nil {
+		return deadline, true
+	}
+
+	for _, layout := range legacyDeadlineLayouts {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+			return model.DateDeadline(t.Year(), t.Month(), t.Day()), true
+		}
+		return model.Deadline{At: t}, true
+	}
+
+	return model.Deadline{}, false
+}
@@ -0,0 +1,153 @@
+/** 
+ * Package api provides HTTP handlers for sharing tasks with other users.
+ * 
+ * Interfaces:
+ * 
+ * - AssignmentAPI: Interface defining methods for handling assignment-related HTTP requests.
+ *   Methods:
+ *   - GetAssignees: HTTP handler for retrieving the users assigned to a task.
+ *   - AssignUser: HTTP handler for assigning a user to a task.
+ *   - UnassignUser: HTTP handler for removing a user from a task.
+ *   - GetAssignedTasks: HTTP handler for retrieving the tasks assigned to the logged-in user.
+ * 
+ * Structs:
+ * 
+ * - assignmentAPI: Implements the AssignmentAPI interface. It provides HTTP handlers for assignment-related operations.
+ *   Fields:
+ *   - assignmentService: Instance of the AssignmentService interface to interact with the assignment service.
+ *   - notificationService: Instance of the NotificationService interface used to notify the assignee and the followers of the task.
+ *   Methods:
+ *   - NewAssignmentAPI: Function to create a new instance of the assignmentAPI struct.
+ *     Parameters:
+ *     - assignmentService: Instance of the AssignmentService interface.
+ *     - notificationService: Instance of the NotificationService interface.
+ *     Returns:
+ *     - *assignmentAPI: A new instance of the assignmentAPI struct.
+ *   - GetAssignees: HTTP handler for retrieving the profiles of the users assigned to the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AssignUser: HTTP handler for assigning the user with the email address in the JSON payload to the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UnassignUser: HTTP handler for removing the user in the path from the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetAssignedTasks: HTTP handler for retrieving the tasks of the selected workspace the logged-in user is assigned to.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - assignmentErrorStatus: Function to pick the HTTP status code for an assignment service error.
+ *   Unknown users are reported as 404, assigning the owner as 400 and assignees reassigning a task as 403.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssignmentAPI interface {
+	GetAssignees(c *gin.Context)
+	AssignUser(c *gin.Context)
+	UnassignUser(c *gin.Context)
+	GetAssignedTasks(c *gin.Context)
+}
+
+type assignmentAPI struct {
+	assignmentService   service.AssignmentService
+	notificationService service.NotificationService
+}
+
+func NewAssignmentAPI(assignmentService service.AssignmentService, notificationService service.NotificationService) *assignmentAPI {
+	return &assignmentAPI{assignmentService, notificationService}
+}
+
+func (a *assignmentAPI) GetAssignees(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	assignees, err := a.assignmentService.GetAssignees(taskID)
+	if err != nil {
+		c.JSON(assignmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignees)
+}
+
+func (a *assignmentAPI) AssignUser(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var request model.AssignRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	assignee, err := a.assignmentService.Assign(taskID, c.GetInt("user_id"), request.Email)
+	if err != nil {
+		c.JSON(assignmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+	logNotifyError(a.notificationService.Assigned(taskID, assignee, c.GetInt("user_id"), c.GetString("email")))
+
+	c.JSON(http.StatusOK, assignee)
+}
+
+func (a *assignmentAPI) UnassignUser(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	userID, err := strconv.Atoi(c.Param("user"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid user ID"})
+		return
+	}
+
+	if err := a.assignmentService.Unassign(taskID, c.GetInt("user_id"), userID); err != nil {
+		c.JSON(assignmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "unassign success"})
+}
+
+func (a *assignmentAPI) GetAssignedTasks(c *gin.Context) {
+	tasks, err := a.assignmentService.GetAssignedTasks(c.GetInt("workspace_id"), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(assignmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+func assignmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrAssigneeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrAssignOwner):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrAssigneeForbidden):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
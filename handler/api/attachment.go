@@ -0,0 +1,198 @@
+/** 
+ * Package api provides HTTP handlers for the files attached to tasks.
+ * 
+ * Interfaces:
+ * 
+ * - AttachmentAPI: Interface defining methods for handling attachment-related HTTP requests.
+ *   Methods:
+ *   - GetAttachments: HTTP handler for retrieving the attachments of a task.
+ *   - UploadAttachment: HTTP handler for attaching a file to a task.
+ *   - DownloadAttachment: HTTP handler for downloading an attachment.
+ *   - DeleteAttachment: HTTP handler for deleting an attachment.
+ * 
+ * Structs:
+ * 
+ * - attachmentAPI: Implements the AttachmentAPI interface. It provides HTTP handlers for attachment-related operations.
+ *   Fields:
+ *   - attachmentService: Instance of the AttachmentService interface to interact with the attachment service.
+ *   Methods:
+ *   - NewAttachmentAPI: Function to create a new instance of the attachmentAPI struct.
+ *     Parameters:
+ *     - attachmentService: Instance of the AttachmentService interface.
+ *     Returns:
+ *     - *attachmentAPI: A new instance of the attachmentAPI struct.
+ *   - GetAttachments: HTTP handler for retrieving the metadata of the attachments of the task in the path, oldest first.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UploadAttachment: HTTP handler for uploading the file in the "file" field of a multipart form to the task in the path.
+ *     Requests larger than the attachment size limit are refused with 413 before the form is read.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DownloadAttachment: HTTP handler for streaming the content of an attachment. Images are shown inline, other files
+ *     are downloaded under their original name.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteAttachment: HTTP handler for deleting an attachment uploaded by the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - attachmentErrorStatus: Function to pick the HTTP status code for an attachment service error.
+ *   Unknown attachments are reported as 404, attachments of other users as 403, files over the size limit as 413
+ *   and files of a type that is not allowed as 415.
+ * 
+ * - taskAndAttachmentID: Function to parse the task ID and attachment ID path parameters, answering 400 when either is invalid.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for the boundaries and headers of the form on top of the file itself.
+const multipartOverhead = 1 << 20
+
+type AttachmentAPI interface {
+	GetAttachments(c *gin.Context)
+	UploadAttachment(c *gin.Context)
+	DownloadAttachment(c *gin.Context)
+	DeleteAttachment(c *gin.Context)
+}
+
+type attachmentAPI struct {
+	attachmentService service.AttachmentService
+}
+
+func NewAttachmentAPI(attachmentService service.AttachmentService) *attachmentAPI {
+	return &attachmentAPI{attachmentService}
+}
+
+func (a *attachmentAPI) GetAttachments(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	attachments, err := a.attachmentService.GetList(taskID)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+func (a *attachmentAPI) UploadAttachment(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	limit := config.GetMaxAttachmentSize() + multipartOverhead
+	if c.Request.ContentLength > limit {
+		c.JSON(http.StatusRequestEntityTooLarge, model.ErrorResponse{Error: model.ErrAttachmentTooLarge.Error()})
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := a.attachmentService.Upload(taskID, c.GetInt("user_id"), header.Filename, header.Header.Get("Content-Type"), header.Size, file)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachment)
+}
+
+func (a *attachmentAPI) DownloadAttachment(c *gin.Context) {
+	taskID, attachmentID, ok := taskAndAttachmentID(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := a.attachmentService.Open(taskID, attachmentID)
+	if err != nil {
+		c.JSON(attachmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer content.Close()
+
+	disposition := "attachment"
+	if strings.HasPrefix(attachment.ContentType, "image/") {
+		disposition = "inline"
+	}
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (a *attachmentAPI) DeleteAttachment(c *gin.Context) {
+	taskID, attachmentID, ok := taskAndAttachmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := a.attachmentService.Delete(taskID, attachmentID, c.GetInt("user_id")); err != nil {
+		c.JSON(attachmentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "attachment delete success"})
+}
+
+func attachmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrAttachmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrNotAttachmentUploader):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, model.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusInternalServerError
+}
+
+func taskAndAttachmentID(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	attachmentID, err := strconv.Atoi(c.Param("attachment"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid attachment ID"})
+		return 0, 0, false
+	}
+	return taskID, attachmentID, true
+}
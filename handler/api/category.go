@@ -9,7 +9,7 @@
  *   - UpdateCategory: HTTP handler for updating an existing category.
  *   - DeleteCategory: HTTP handler for deleting a category.
  *   - GetCategoryByID: HTTP handler for retrieving a category by its ID.
- *   - GetCategoryList: HTTP handler for retrieving a list of all categories.
+ *   - GetCategoryList: HTTP handler for retrieving the categories of the selected workspace.
  * 
  * Structs:
  * 
@@ -22,7 +22,7 @@
  *     - categoryRepo: Instance of the CategoryService interface.
  *     Returns:
  *     - *categoryAPI: A new instance of the categoryAPI struct.
- *   - AddCategory: HTTP handler for adding a new category.
+ *   - AddCategory: HTTP handler for adding a new category owned by the logged-in user to the workspace the user selected.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
  *   - UpdateCategory: HTTP handler for updating an existing category.
@@ -34,9 +34,15 @@
  *   - GetCategoryByID: HTTP handler for retrieving a category by its ID.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
- *   - GetCategoryList: HTTP handler for retrieving a list of all categories.
+ *   - GetCategoryList: HTTP handler for retrieving the categories of the selected workspace, filtered by the user, project and name
+ *     query parameters, ordered by sort and paged with limit and cursor. The X-Next-Cursor header carries the cursor of the next page.
+ *     Invalid queries are answered with 400.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - categoryQuery: Function to read the filters, sort order and page of a category list from the query string.
  */
 
 package api
@@ -44,6 +50,7 @@ package api
 import (
 	"a21hc3NpZ25tZW50/model"
 	"a21hc3NpZ25tZW50/service"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -73,6 +80,8 @@ func (ct *categoryAPI) AddCategory(c *gin.Context) {
 		return
 	}
 
+	newCategory.UserID = c.GetInt("user_id")
+	newCategory.WorkspaceID = c.GetInt("workspace_id")
 	err := ct.categoryService.Store(&newCategory)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
@@ -138,11 +147,45 @@ func (ct *categoryAPI) GetCategoryByID(c *gin.Context) {
 }
 
 func (ct *categoryAPI) GetCategoryList(c *gin.Context) {
-	categories, err := ct.categoryService.GetList()
+	query, err := categoryQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	page, err := ct.categoryService.Query(c.GetInt("workspace_id"), query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, model.ErrInvalidQuery) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, model.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, categories)
+	if page.NextCursor != "" {
+		c.Header("X-Next-Cursor", page.NextCursor)
+	}
+	c.JSON(http.StatusOK, page.Categories)
+}
+
+// categoryQuery reads the filters, sort order and page of a category list from the query string.
+func categoryQuery(c *gin.Context) (model.CategoryQuery, error) {
+	query := model.CategoryQuery{
+		Name:   c.Query("name"),
+		Sort:   c.Query("sort"),
+		Cursor: c.Query("cursor"),
+	}
+
+	var err error
+	if query.UserID, err = queryInt(c, "user"); err != nil {
+		return model.CategoryQuery{}, err
+	}
+	if query.ProjectID, err = queryInt(c, "project"); err != nil {
+		return model.CategoryQuery{}, err
+	}
+	if query.Limit, err = queryInt(c, "limit"); err != nil {
+		return model.CategoryQuery{}, err
+	}
+	return query, nil
 }
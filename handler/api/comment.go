@@ -0,0 +1,179 @@
+/** 
+ * Package api provides HTTP handlers for the discussion threads of tasks.
+ * 
+ * Interfaces:
+ * 
+ * - CommentAPI: Interface defining methods for handling comment-related HTTP requests.
+ *   Methods:
+ *   - GetComments: HTTP handler for retrieving a page of a task's comments.
+ *   - AddComment: HTTP handler for commenting on a task.
+ *   - EditComment: HTTP handler for editing a comment.
+ *   - DeleteComment: HTTP handler for deleting a comment.
+ * 
+ * Structs:
+ * 
+ * - commentAPI: Implements the CommentAPI interface. It provides HTTP handlers for comment-related operations.
+ *   Fields:
+ *   - commentService: Instance of the CommentService interface to interact with the comment service.
+ *   - notificationService: Instance of the NotificationService interface used to notify the followers of a commented task.
+ *   Methods:
+ *   - NewCommentAPI: Function to create a new instance of the commentAPI struct.
+ *     Parameters:
+ *     - commentService: Instance of the CommentService interface.
+ *     - notificationService: Instance of the NotificationService interface.
+ *     Returns:
+ *     - *commentAPI: A new instance of the commentAPI struct.
+ *   - GetComments: HTTP handler for retrieving a page of the comments of the task in the path, oldest first.
+ *     The page and page_size query parameters select the page.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddComment: HTTP handler for adding a comment of the logged-in user to the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - EditComment: HTTP handler for editing a comment of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteComment: HTTP handler for soft deleting a comment of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - commentErrorStatus: Function to pick the HTTP status code for a comment service error.
+ *   Unknown comments are reported as 404, comments of other users as 403 and blank or deleted comments as 400.
+ * 
+ * - taskAndCommentID: Function to parse the task ID and comment ID path parameters, answering 400 when either is invalid.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentAPI interface {
+	GetComments(c *gin.Context)
+	AddComment(c *gin.Context)
+	EditComment(c *gin.Context)
+	DeleteComment(c *gin.Context)
+}
+
+type commentAPI struct {
+	commentService      service.CommentService
+	notificationService service.NotificationService
+}
+
+func NewCommentAPI(commentService service.CommentService, notificationService service.NotificationService) *commentAPI {
+	return &commentAPI{commentService, notificationService}
+}
+
+func (a *commentAPI) GetComments(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(model.DefaultCommentPageSize)))
+
+	comments, err := a.commentService.GetPage(taskID, page, pageSize)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (a *commentAPI) AddComment(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var request model.CommentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	comment, err := a.commentService.Add(taskID, c.GetInt("user_id"), c.GetString("email"), request.Body)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+	logNotifyError(a.notificationService.Commented(*comment))
+
+	c.JSON(http.StatusOK, comment)
+}
+
+func (a *commentAPI) EditComment(c *gin.Context) {
+	taskID, commentID, ok := taskAndCommentID(c)
+	if !ok {
+		return
+	}
+
+	var request model.CommentRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	comment, err := a.commentService.Edit(taskID, commentID, c.GetInt("user_id"), request.Body)
+	if err != nil {
+		c.JSON(commentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+func (a *commentAPI) DeleteComment(c *gin.Context) {
+	taskID, commentID, ok := taskAndCommentID(c)
+	if !ok {
+		return
+	}
+
+	if err := a.commentService.Delete(taskID, commentID, c.GetInt("user_id")); err != nil {
+		c.JSON(commentErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "comment delete success"})
+}
+
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrCommentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrNotCommentAuthor):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrEmptyComment),
+		errors.Is(err, model.ErrCommentDeleted):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func taskAndCommentID(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.Atoi(c.Param("comment"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid comment ID"})
+		return 0, 0, false
+	}
+	return taskID, commentID, true
+}
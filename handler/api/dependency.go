@@ -0,0 +1,157 @@
+/** 
+ * Package api provides HTTP handlers for the dependencies between tasks.
+ * 
+ * Interfaces:
+ * 
+ * - DependencyAPI: Interface defining methods for handling dependency-related HTTP requests.
+ *   Methods:
+ *   - GetTaskDependencies: HTTP handler for retrieving the dependencies of a task.
+ *   - AddBlocker: HTTP handler for making a task wait for another one.
+ *   - RemoveBlocker: HTTP handler for removing a dependency.
+ *   - GetDependencyGraph: HTTP handler for retrieving the dependency graph of a category.
+ * 
+ * Structs:
+ * 
+ * - dependencyAPI: Implements the DependencyAPI interface. It provides HTTP handlers for dependency-related operations.
+ *   Fields:
+ *   - dependencyService: Instance of the DependencyService interface to interact with the dependency service.
+ *   Methods:
+ *   - NewDependencyAPI: Function to create a new instance of the dependencyAPI struct.
+ *     Parameters:
+ *     - dependencyService: Instance of the DependencyService interface.
+ *     Returns:
+ *     - *dependencyAPI: A new instance of the dependencyAPI struct.
+ *   - GetTaskDependencies: HTTP handler for retrieving the tasks blocking the task in the path and the tasks it blocks.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddBlocker: HTTP handler for making the blocker in the path block the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - RemoveBlocker: HTTP handler for removing the dependency of the task in the path on the blocker in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetDependencyGraph: HTTP handler for retrieving the tasks of the category in the path with their dependencies,
+ *     topological order and critical path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - dependencyErrorStatus: Function to pick the HTTP status code for a dependency service error.
+ *   Unknown tasks are reported as 404 and dependencies on the task itself or creating a cycle as 400.
+ * 
+ * - taskAndBlockerID: Function to parse the task ID and blocker ID path parameters, answering 400 when either is invalid.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DependencyAPI interface {
+	GetTaskDependencies(c *gin.Context)
+	AddBlocker(c *gin.Context)
+	RemoveBlocker(c *gin.Context)
+	GetDependencyGraph(c *gin.Context)
+}
+
+type dependencyAPI struct {
+	dependencyService service.DependencyService
+}
+
+func NewDependencyAPI(dependencyService service.DependencyService) *dependencyAPI {
+	return &dependencyAPI{dependencyService}
+}
+
+func (d *dependencyAPI) GetTaskDependencies(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	dependencies, err := d.dependencyService.GetTaskDependencies(taskID)
+	if err != nil {
+		c.JSON(dependencyErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dependencies)
+}
+
+func (d *dependencyAPI) AddBlocker(c *gin.Context) {
+	taskID, blockerID, ok := taskAndBlockerID(c)
+	if !ok {
+		return
+	}
+
+	if err := d.dependencyService.Block(blockerID, taskID); err != nil {
+		c.JSON(dependencyErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "blocker add success"})
+}
+
+func (d *dependencyAPI) RemoveBlocker(c *gin.Context) {
+	taskID, blockerID, ok := taskAndBlockerID(c)
+	if !ok {
+		return
+	}
+
+	if err := d.dependencyService.Unblock(blockerID, taskID); err != nil {
+		c.JSON(dependencyErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "blocker remove success"})
+}
+
+func (d *dependencyAPI) GetDependencyGraph(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid category ID"})
+		return
+	}
+
+	graph, err := d.dependencyService.GetGraph(c.GetInt("workspace_id"), categoryID)
+	if err != nil {
+		c.JSON(dependencyErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, graph)
+}
+
+func dependencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrDependencyTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrSelfDependency),
+		errors.Is(err, model.ErrDependencyCycle):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func taskAndBlockerID(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	blockerID, err := strconv.Atoi(c.Param("blocker"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid blocker ID"})
+		return 0, 0, false
+	}
+	return taskID, blockerID, true
+}
@@ -0,0 +1,57 @@
+/** 
+ * Package api provides HTTP handlers for task estimates.
+ * 
+ * Interfaces:
+ * 
+ * - EstimateAPI: Interface defining methods for handling estimate-related HTTP requests.
+ *   Methods:
+ *   - GetEstimates: HTTP handler for retrieving the estimate roll-up.
+ * 
+ * Structs:
+ * 
+ * - estimateAPI: Implements the EstimateAPI interface. It provides HTTP handlers for estimate-related operations.
+ *   Fields:
+ *   - estimateService: Instance of the EstimateService interface to interact with the estimate service.
+ *   Methods:
+ *   - NewEstimateAPI: Function to create a new instance of the estimateAPI struct.
+ *     Parameters:
+ *     - estimateService: Instance of the EstimateService interface.
+ *     Returns:
+ *     - *estimateAPI: A new instance of the estimateAPI struct.
+ *   - GetEstimates: HTTP handler for retrieving the estimated and completed hours and story points of the tasks
+ *     of the selected workspace per category and per user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EstimateAPI interface {
+	GetEstimates(c *gin.Context)
+}
+
+type estimateAPI struct {
+	estimateService service.EstimateService
+}
+
+func NewEstimateAPI(estimateService service.EstimateService) *estimateAPI {
+	return &estimateAPI{estimateService}
+}
+
+func (e *estimateAPI) GetEstimates(c *gin.Context) {
+	rollup, err := e.estimateService.GetRollup(c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rollup)
+}
@@ -0,0 +1,166 @@
+/** 
+ * Package api provides HTTP handlers for the custom fields of a workspace.
+ * 
+ * Interfaces:
+ * 
+ * - FieldAPI: Interface defining methods for handling custom field-related HTTP requests.
+ *   Methods:
+ *   - GetFields: HTTP handler for retrieving the custom fields of the selected workspace.
+ *   - AddField: HTTP handler for defining a custom field.
+ *   - GetField: HTTP handler for retrieving a custom field.
+ *   - UpdateField: HTTP handler for updating a custom field.
+ *   - DeleteField: HTTP handler for deleting a custom field.
+ * 
+ * Structs:
+ * 
+ * - fieldAPI: Implements the FieldAPI interface. It provides HTTP handlers for custom field-related operations.
+ *   Fields:
+ *   - fieldService: Instance of the FieldService interface to interact with the custom field service.
+ *   Methods:
+ *   - NewFieldAPI: Function to create a new instance of the fieldAPI struct.
+ *     Parameters:
+ *     - fieldService: Instance of the FieldService interface.
+ *     Returns:
+ *     - *fieldAPI: A new instance of the fieldAPI struct.
+ *   - GetFields: HTTP handler for retrieving the custom fields of the selected workspace.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddField: HTTP handler for defining a custom field in the selected workspace from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetField: HTTP handler for retrieving the custom field in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateField: HTTP handler for renaming the custom field in the path, or changing its options, from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteField: HTTP handler for deleting the custom field in the path together with its values on tasks.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - fieldErrorStatus: Function to pick the HTTP status code for a custom field service error.
+ *   Unknown fields are reported as 404, invalid definitions as 400 and duplicate names as 409.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FieldAPI interface {
+	GetFields(c *gin.Context)
+	AddField(c *gin.Context)
+	GetField(c *gin.Context)
+	UpdateField(c *gin.Context)
+	DeleteField(c *gin.Context)
+}
+
+type fieldAPI struct {
+	fieldService service.FieldService
+}
+
+func NewFieldAPI(fieldService service.FieldService) *fieldAPI {
+	return &fieldAPI{fieldService}
+}
+
+func (f *fieldAPI) GetFields(c *gin.Context) {
+	fields, err := f.fieldService.GetList(c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(fieldErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, fields)
+}
+
+func (f *fieldAPI) AddField(c *gin.Context) {
+	var request model.FieldRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	field, err := f.fieldService.Create(model.CustomField{
+		Name:        request.Name,
+		Type:        request.Type,
+		Options:     request.Options,
+		UserID:      c.GetInt("user_id"),
+		WorkspaceID: c.GetInt("workspace_id"),
+	})
+	if err != nil {
+		c.JSON(fieldErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, field)
+}
+
+func (f *fieldAPI) GetField(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	field, err := f.fieldService.GetByID(ids[0], c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(fieldErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, field)
+}
+
+func (f *fieldAPI) UpdateField(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var request model.FieldRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	field, err := f.fieldService.Update(ids[0], c.GetInt("workspace_id"), request)
+	if err != nil {
+		c.JSON(fieldErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, field)
+}
+
+func (f *fieldAPI) DeleteField(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := f.fieldService.Delete(ids[0], c.GetInt("workspace_id"), c.GetString("email")); err != nil {
+		c.JSON(fieldErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "custom field delete success"})
+}
+
+func fieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrFieldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidField):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrDuplicateField):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
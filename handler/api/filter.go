@@ -0,0 +1,161 @@
+/**
+ * Package api provides HTTP handlers for saved filters and the smart lists of tasks they define.
+ *
+ * Interfaces:
+ *
+ * - FilterAPI: Interface defining methods for handling filter-related HTTP requests.
+ *   Methods:
+ *   - GetFilters: HTTP handler for retrieving the lists of the logged-in user.
+ *   - AddFilter: HTTP handler for saving a filter.
+ *   - UpdateFilter: HTTP handler for changing a saved filter.
+ *   - DeleteFilter: HTTP handler for deleting a saved filter.
+ *   - GetFilterTasks: HTTP handler for retrieving the tasks of a list.
+ *
+ * Structs:
+ *
+ * - filterAPI: Implements the FilterAPI interface. It provides HTTP handlers for filter-related operations.
+ *   Fields:
+ *   - filterService: Instance of the FilterService interface to interact with the filter service.
+ *   Methods:
+ *   - NewFilterAPI: Function to create a new instance of the filterAPI struct.
+ *     Parameters:
+ *     - filterService: Instance of the FilterService interface.
+ *     Returns:
+ *     - *filterAPI: A new instance of the filterAPI struct.
+ *   - GetFilters: HTTP handler for retrieving the built-in lists followed by the filters the logged-in user saved in the
+ *     selected workspace.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddFilter: HTTP handler for saving the filter in the JSON payload for the logged-in user and the selected workspace.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateFilter: HTTP handler for replacing the name and criteria of the saved filter in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteFilter: HTTP handler for deleting the saved filter in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetFilterTasks: HTTP handler for retrieving the list in the path, the key of a built-in list or the ID of a saved
+ *     filter, with the tasks it currently contains.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *
+ * Functions:
+ *
+ * - filterErrorStatus: Function to pick the HTTP status code for a filter service error.
+ *   Unknown filters are reported as 404, invalid criteria as 400 and duplicate names as 409.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FilterAPI interface {
+	GetFilters(c *gin.Context)
+	AddFilter(c *gin.Context)
+	UpdateFilter(c *gin.Context)
+	DeleteFilter(c *gin.Context)
+	GetFilterTasks(c *gin.Context)
+}
+
+type filterAPI struct {
+	filterService service.FilterService
+}
+
+func NewFilterAPI(filterService service.FilterService) *filterAPI {
+	return &filterAPI{filterService}
+}
+
+func (f *filterAPI) GetFilters(c *gin.Context) {
+	filters, err := f.filterService.GetList(c.GetInt("user_id"), c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(filterErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filters)
+}
+
+func (f *filterAPI) AddFilter(c *gin.Context) {
+	var definition model.FilterDefinition
+	if err := c.ShouldBindJSON(&definition); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	filter, err := f.filterService.Create(model.SavedFilter{
+		UserID:           c.GetInt("user_id"),
+		WorkspaceID:      c.GetInt("workspace_id"),
+		FilterDefinition: definition,
+	})
+	if err != nil {
+		c.JSON(filterErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
+
+func (f *filterAPI) UpdateFilter(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var definition model.FilterDefinition
+	if err := c.ShouldBindJSON(&definition); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	filter, err := f.filterService.Update(ids[0], c.GetInt("user_id"), c.GetInt("workspace_id"), definition)
+	if err != nil {
+		c.JSON(filterErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, filter)
+}
+
+func (f *filterAPI) DeleteFilter(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := f.filterService.Delete(ids[0], c.GetInt("user_id"), c.GetInt("workspace_id")); err != nil {
+		c.JSON(filterErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "filter delete success"})
+}
+
+func (f *filterAPI) GetFilterTasks(c *gin.Context) {
+	list, err := f.filterService.Tasks(c.Param("ref"), c.GetInt("user_id"), c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(filterErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func filterErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrFilterNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidFilter), errors.Is(err, model.ErrInvalidQuery):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrDuplicateFilter):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,207 @@
+/**
+ * Package api provides HTTP handlers for watchers and in-app notifications.
+ *
+ * Interfaces:
+ *
+ * - NotificationAPI: Interface defining methods for handling watch and notification HTTP requests.
+ *   Methods:
+ *   - WatchTask: HTTP handler for watching a task.
+ *   - UnwatchTask: HTTP handler for no longer watching a task.
+ *   - WatchCategory: HTTP handler for watching a category.
+ *   - UnwatchCategory: HTTP handler for no longer watching a category.
+ *   - GetWatches: HTTP handler for retrieving what the logged-in user watches.
+ *   - GetNotifications: HTTP handler for retrieving the notifications of the logged-in user.
+ *   - GetUnreadCount: HTTP handler for counting the unread notifications of the logged-in user.
+ *   - MarkRead: HTTP handler for marking a notification as read.
+ *   - MarkAllRead: HTTP handler for marking every notification as read.
+ *
+ * Structs:
+ *
+ * - notificationAPI: Implements the NotificationAPI interface. It provides HTTP handlers for watch and notification operations.
+ *   Fields:
+ *   - notificationService: Instance of the NotificationService interface to interact with the notification service.
+ *   Methods:
+ *   - NewNotificationAPI: Function to create a new instance of the notificationAPI struct.
+ *     Parameters:
+ *     - notificationService: Instance of the NotificationService interface.
+ *     Returns:
+ *     - *notificationAPI: A new instance of the notificationAPI struct.
+ *   - WatchTask / WatchCategory: HTTP handlers for making the logged-in user watch the task or category in the path,
+ *     responding with the watch.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UnwatchTask / UnwatchCategory: HTTP handlers for making the logged-in user stop watching the task or category in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetWatches: HTTP handler for retrieving the watches of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetNotifications: HTTP handler for retrieving the notifications of the logged-in user, newest first. With
+ *     ?unread=true only the unread ones are returned.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetUnreadCount: HTTP handler for retrieving the number of unread notifications of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - MarkRead: HTTP handler for marking the notification in the path as read.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - MarkAllRead: HTTP handler for marking every unread notification of the logged-in user as read.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - watch / unwatch: Methods shared by the handlers watching and unwatching tasks and categories.
+ *
+ * Functions:
+ *
+ * - notificationErrorStatus: Function to pick the HTTP status code for a notification service error.
+ *   Notifications of other users are reported as 404 and unknown watch targets as 400.
+ *
+ * - logNotifyError: Function to log notifications that could not be sent by the task, comment and assignment handlers.
+ *   The change they are about has already been saved, so the request still succeeds.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationAPI interface {
+	WatchTask(c *gin.Context)
+	UnwatchTask(c *gin.Context)
+	WatchCategory(c *gin.Context)
+	UnwatchCategory(c *gin.Context)
+	GetWatches(c *gin.Context)
+	GetNotifications(c *gin.Context)
+	GetUnreadCount(c *gin.Context)
+	MarkRead(c *gin.Context)
+	MarkAllRead(c *gin.Context)
+}
+
+type notificationAPI struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationAPI(notificationService service.NotificationService) *notificationAPI {
+	return &notificationAPI{notificationService}
+}
+
+func (n *notificationAPI) WatchTask(c *gin.Context) {
+	n.watch(c, model.WatchTask)
+}
+
+func (n *notificationAPI) UnwatchTask(c *gin.Context) {
+	n.unwatch(c, model.WatchTask)
+}
+
+func (n *notificationAPI) WatchCategory(c *gin.Context) {
+	n.watch(c, model.WatchCategory)
+}
+
+func (n *notificationAPI) UnwatchCategory(c *gin.Context) {
+	n.unwatch(c, model.WatchCategory)
+}
+
+func (n *notificationAPI) GetWatches(c *gin.Context) {
+	watches, err := n.notificationService.GetWatches(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(notificationErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, watches)
+}
+
+func (n *notificationAPI) GetNotifications(c *gin.Context) {
+	notifications, err := n.notificationService.GetList(c.GetInt("user_id"), c.Query("unread") == "true")
+	if err != nil {
+		c.JSON(notificationErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (n *notificationAPI) GetUnreadCount(c *gin.Context) {
+	unread, err := n.notificationService.CountUnread(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(notificationErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.UnreadCount{Unread: unread})
+}
+
+func (n *notificationAPI) MarkRead(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := n.notificationService.MarkRead(c.GetInt("user_id"), ids[0]); err != nil {
+		c.JSON(notificationErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "notification read success"})
+}
+
+func (n *notificationAPI) MarkAllRead(c *gin.Context) {
+	if err := n.notificationService.MarkAllRead(c.GetInt("user_id")); err != nil {
+		c.JSON(notificationErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "notifications read success"})
+}
+
+func (n *notificationAPI) watch(c *gin.Context, target model.WatchTarget) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	watch, err := n.notificationService.Watch(c.GetInt("user_id"), target, ids[0])
+	if err != nil {
+		c.JSON(notificationErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, watch)
+}
+
+func (n *notificationAPI) unwatch(c *gin.Context, target model.WatchTarget) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := n.notificationService.Unwatch(c.GetInt("user_id"), target, ids[0]); err != nil {
+		c.JSON(notificationErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "unwatch success"})
+}
+
+func notificationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrNotificationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidWatchTarget):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func logNotifyError(err error) {
+	if err != nil {
+		log.Println("Error sending notifications:", err)
+	}
+}
@@ -0,0 +1,349 @@
+/** 
+ * Package api provides HTTP handlers for projects, their milestones and their overview.
+ * 
+ * Interfaces:
+ * 
+ * - ProjectAPI: Interface defining methods for handling project-related HTTP requests.
+ *   Methods:
+ *   - GetProjects: HTTP handler for retrieving the projects of the selected workspace.
+ *   - AddProject: HTTP handler for creating a project.
+ *   - GetProject: HTTP handler for retrieving a project.
+ *   - UpdateProject: HTTP handler for updating a project.
+ *   - DeleteProject: HTTP handler for deleting a project.
+ *   - GetOverview: HTTP handler for retrieving the progress of a project.
+ *   - AddCategory: HTTP handler for grouping a category under a project.
+ *   - RemoveCategory: HTTP handler for taking a category out of a project.
+ *   - AddMilestone: HTTP handler for adding a milestone to a project.
+ *   - UpdateMilestone: HTTP handler for updating a milestone.
+ *   - DeleteMilestone: HTTP handler for deleting a milestone.
+ *   - AttachTask: HTTP handler for attaching a task to a milestone.
+ *   - DetachTask: HTTP handler for detaching a task from a milestone.
+ * 
+ * Structs:
+ * 
+ * - projectAPI: Implements the ProjectAPI interface. It provides HTTP handlers for project-related operations.
+ *   Fields:
+ *   - projectService: Instance of the ProjectService interface to interact with the project service.
+ *   Methods:
+ *   - NewProjectAPI: Function to create a new instance of the projectAPI struct.
+ *     Parameters:
+ *     - projectService: Instance of the ProjectService interface.
+ *     Returns:
+ *     - *projectAPI: A new instance of the projectAPI struct.
+ *   - GetProjects: HTTP handler for retrieving the projects of the selected workspace.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddProject: HTTP handler for creating a project in the selected workspace from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetProject: HTTP handler for retrieving the project in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateProject: HTTP handler for renaming the project in the path or changing its description from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteProject: HTTP handler for deleting the project in the path with its milestones, keeping its categories and tasks.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetOverview: HTTP handler for retrieving the completion of the project in the path, its categories and its milestones,
+ *     with overdue milestones flagged in the time zone of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddCategory: HTTP handler for grouping the category in the path under the project in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - RemoveCategory: HTTP handler for taking the category in the path out of the project in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddMilestone: HTTP handler for adding a milestone from the JSON payload to the project in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateMilestone: HTTP handler for changing the title and due date of the milestone in the path from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteMilestone: HTTP handler for deleting the milestone in the path, detaching its tasks.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AttachTask: HTTP handler for attaching the task in the path to the milestone in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DetachTask: HTTP handler for detaching the task in the path from the milestone in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - pathIDs: Function to parse the named path parameters as IDs, responding with a bad request when one is invalid.
+ * - projectErrorStatus: Function to pick the HTTP status code for a project service error.
+ *   Unknown projects and milestones, and categories and tasks outside the project, are reported as 404,
+ *   missing names and titles as 400.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectAPI interface {
+	GetProjects(c *gin.Context)
+	AddProject(c *gin.Context)
+	GetProject(c *gin.Context)
+	UpdateProject(c *gin.Context)
+	DeleteProject(c *gin.Context)
+	GetOverview(c *gin.Context)
+	AddCategory(c *gin.Context)
+	RemoveCategory(c *gin.Context)
+	AddMilestone(c *gin.Context)
+	UpdateMilestone(c *gin.Context)
+	DeleteMilestone(c *gin.Context)
+	AttachTask(c *gin.Context)
+	DetachTask(c *gin.Context)
+}
+
+type projectAPI struct {
+	projectService service.ProjectService
+}
+
+func NewProjectAPI(projectService service.ProjectService) *projectAPI {
+	return &projectAPI{projectService}
+}
+
+func (p *projectAPI) GetProjects(c *gin.Context) {
+	projects, err := p.projectService.GetList(c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+func (p *projectAPI) AddProject(c *gin.Context) {
+	var request model.ProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	project, err := p.projectService.Create(model.Project{
+		Name:        request.Name,
+		Description: request.Description,
+		UserID:      c.GetInt("user_id"),
+		WorkspaceID: c.GetInt("workspace_id"),
+	})
+	if err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (p *projectAPI) GetProject(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	project, err := p.projectService.GetByID(ids[0], c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (p *projectAPI) UpdateProject(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var request model.ProjectRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	project, err := p.projectService.Update(ids[0], c.GetInt("workspace_id"), request)
+	if err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (p *projectAPI) DeleteProject(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := p.projectService.Delete(ids[0], c.GetInt("workspace_id"), c.GetString("email")); err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "project delete success"})
+}
+
+func (p *projectAPI) GetOverview(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	overview, err := p.projectService.GetOverview(ids[0], c.GetInt("workspace_id"), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+func (p *projectAPI) AddCategory(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "category")
+	if !ok {
+		return
+	}
+
+	if err := p.projectService.AddCategory(ids[0], c.GetInt("workspace_id"), ids[1]); err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "add category success"})
+}
+
+func (p *projectAPI) RemoveCategory(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "category")
+	if !ok {
+		return
+	}
+
+	if err := p.projectService.RemoveCategory(ids[0], c.GetInt("workspace_id"), ids[1]); err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "remove category success"})
+}
+
+func (p *projectAPI) AddMilestone(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var request model.MilestoneRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	milestone, err := p.projectService.AddMilestone(ids[0], c.GetInt("workspace_id"), request)
+	if err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestone)
+}
+
+func (p *projectAPI) UpdateMilestone(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "milestone")
+	if !ok {
+		return
+	}
+
+	var request model.MilestoneRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	milestone, err := p.projectService.UpdateMilestone(ids[0], c.GetInt("workspace_id"), ids[1], request)
+	if err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, milestone)
+}
+
+func (p *projectAPI) DeleteMilestone(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "milestone")
+	if !ok {
+		return
+	}
+
+	if err := p.projectService.DeleteMilestone(ids[0], c.GetInt("workspace_id"), ids[1], c.GetString("email")); err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "milestone delete success"})
+}
+
+func (p *projectAPI) AttachTask(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "milestone", "task")
+	if !ok {
+		return
+	}
+
+	if err := p.projectService.AttachTask(ids[0], c.GetInt("workspace_id"), ids[1], ids[2], c.GetString("email")); err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "attach task success"})
+}
+
+func (p *projectAPI) DetachTask(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "milestone", "task")
+	if !ok {
+		return
+	}
+
+	if err := p.projectService.DetachTask(ids[0], c.GetInt("workspace_id"), ids[1], ids[2], c.GetString("email")); err != nil {
+		c.JSON(projectErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "detach task success"})
+}
+
+func pathIDs(c *gin.Context, names ...string) ([]int, bool) {
+	ids := make([]int, len(names))
+	for i, name := range names {
+		id, err := strconv.Atoi(c.Param(name))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid " + name + " ID"})
+			return nil, false
+		}
+		ids[i] = id
+	}
+	return ids, true
+}
+
+func projectErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrProjectNotFound),
+		errors.Is(err, model.ErrMilestoneNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidProject),
+		errors.Is(err, model.ErrInvalidMilestone):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,92 @@
+/**
+ * Package api provides HTTP handlers for the full-text search over task titles and category names.
+ *
+ * Interfaces:
+ *
+ * - SearchAPI: Interface defining methods for handling search-related HTTP requests.
+ *   Methods:
+ *   - Search: HTTP handler for searching the tasks and categories of the selected workspace.
+ *   - RebuildIndex: HTTP handler for rebuilding the search index.
+ *
+ * Structs:
+ *
+ * - searchAPI: Implements the SearchAPI interface. It provides HTTP handlers for search-related operations.
+ *   Fields:
+ *   - searchService: Instance of the SearchService interface to interact with the search service.
+ *   Methods:
+ *   - NewSearchAPI: Function to create a new instance of the searchAPI struct.
+ *     Parameters:
+ *     - searchService: Instance of the SearchService interface.
+ *     Returns:
+ *     - *searchAPI: A new instance of the searchAPI struct.
+ *   - Search: HTTP handler for ranking the tasks and categories matching the text of ?q=, best first, with the matching
+ *     words highlighted. ?limit= caps the number of hits.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - RebuildIndex: HTTP handler for indexing every task and category again, responding with how many were indexed.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *
+ * Functions:
+ *
+ * - searchErrorStatus: Function to pick the HTTP status code for a search service error.
+ *   Texts without words to search for and invalid limits are reported as 400.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchAPI interface {
+	Search(c *gin.Context)
+	RebuildIndex(c *gin.Context)
+}
+
+type searchAPI struct {
+	searchService service.SearchService
+}
+
+func NewSearchAPI(searchService service.SearchService) *searchAPI {
+	return &searchAPI{searchService}
+}
+
+func (s *searchAPI) Search(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	hits, err := s.searchService.Search(c.GetInt("workspace_id"), c.Query("q"), limit)
+	if err != nil {
+		c.JSON(searchErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hits)
+}
+
+func (s *searchAPI) RebuildIndex(c *gin.Context) {
+	indexed, err := s.searchService.Rebuild()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SearchRebuildResponse{Message: "search index rebuilt", Indexed: indexed})
+}
+
+func searchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrEmptyTextSearch), errors.Is(err, model.ErrInvalidQuery):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,277 @@
+/** 
+ * Package api provides HTTP handlers for sprints and their burndown.
+ * 
+ * Interfaces:
+ * 
+ * - SprintAPI: Interface defining methods for handling sprint-related HTTP requests.
+ *   Methods:
+ *   - GetSprints: HTTP handler for retrieving the sprints of the selected workspace.
+ *   - AddSprint: HTTP handler for creating a sprint.
+ *   - GetSprint: HTTP handler for retrieving a sprint.
+ *   - UpdateSprint: HTTP handler for updating a sprint.
+ *   - DeleteSprint: HTTP handler for deleting a sprint.
+ *   - PlanTask: HTTP handler for planning a task into a sprint.
+ *   - UnplanTask: HTTP handler for returning a task of a sprint to the backlog.
+ *   - StartSprint: HTTP handler for starting a sprint.
+ *   - CloseSprint: HTTP handler for closing a sprint.
+ *   - GetBurndown: HTTP handler for retrieving the burndown of a sprint.
+ * 
+ * Structs:
+ * 
+ * - sprintAPI: Implements the SprintAPI interface. It provides HTTP handlers for sprint-related operations.
+ *   Fields:
+ *   - sprintService: Instance of the SprintService interface to interact with the sprint service.
+ *   Methods:
+ *   - NewSprintAPI: Function to create a new instance of the sprintAPI struct.
+ *     Parameters:
+ *     - sprintService: Instance of the SprintService interface.
+ *     Returns:
+ *     - *sprintAPI: A new instance of the sprintAPI struct.
+ *   - GetSprints: HTTP handler for retrieving the sprints of the selected workspace.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddSprint: HTTP handler for creating a planned sprint in the selected workspace from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetSprint: HTTP handler for retrieving the sprint in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateSprint: HTTP handler for changing the name, goal and dates of the sprint in the path from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteSprint: HTTP handler for deleting the sprint in the path, returning its tasks to the backlog.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - PlanTask: HTTP handler for planning the task in the path into the sprint in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UnplanTask: HTTP handler for returning the task in the path from the sprint in the path to the backlog.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - StartSprint: HTTP handler for starting the sprint in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - CloseSprint: HTTP handler for closing the sprint in the path, carrying its unfinished tasks over to the sprint
+ *     named in the optional JSON payload or to the backlog.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetBurndown: HTTP handler for retrieving the remaining and ideal work of each day of the sprint in the path,
+ *     with days counted in the time zone of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - sprintErrorStatus: Function to pick the HTTP status code for a sprint service error.
+ *   Unknown sprints, and tasks outside the sprint, are reported as 404, invalid names and dates as 400,
+ *   operations that do not fit the state of the sprint and starting a second active sprint as 409.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SprintAPI interface {
+	GetSprints(c *gin.Context)
+	AddSprint(c *gin.Context)
+	GetSprint(c *gin.Context)
+	UpdateSprint(c *gin.Context)
+	DeleteSprint(c *gin.Context)
+	PlanTask(c *gin.Context)
+	UnplanTask(c *gin.Context)
+	StartSprint(c *gin.Context)
+	CloseSprint(c *gin.Context)
+	GetBurndown(c *gin.Context)
+}
+
+type sprintAPI struct {
+	sprintService service.SprintService
+}
+
+func NewSprintAPI(sprintService service.SprintService) *sprintAPI {
+	return &sprintAPI{sprintService}
+}
+
+func (s *sprintAPI) GetSprints(c *gin.Context) {
+	sprints, err := s.sprintService.GetList(c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprints)
+}
+
+func (s *sprintAPI) AddSprint(c *gin.Context) {
+	var request model.SprintRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	sprint, err := s.sprintService.Create(model.Sprint{
+		Name:        request.Name,
+		Goal:        request.Goal,
+		StartDate:   request.StartDate,
+		EndDate:     request.EndDate,
+		UserID:      c.GetInt("user_id"),
+		WorkspaceID: c.GetInt("workspace_id"),
+	})
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+func (s *sprintAPI) GetSprint(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	sprint, err := s.sprintService.GetByID(ids[0], c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+func (s *sprintAPI) UpdateSprint(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var request model.SprintRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	sprint, err := s.sprintService.Update(ids[0], c.GetInt("workspace_id"), request)
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+func (s *sprintAPI) DeleteSprint(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := s.sprintService.Delete(ids[0], c.GetInt("workspace_id"), c.GetString("email")); err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "sprint delete success"})
+}
+
+func (s *sprintAPI) PlanTask(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	if err := s.sprintService.PlanTask(ids[0], c.GetInt("workspace_id"), ids[1], c.GetString("email")); err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "plan task success"})
+}
+
+func (s *sprintAPI) UnplanTask(c *gin.Context) {
+	ids, ok := pathIDs(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	if err := s.sprintService.UnplanTask(ids[0], c.GetInt("workspace_id"), ids[1], c.GetString("email")); err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "unplan task success"})
+}
+
+func (s *sprintAPI) StartSprint(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	sprint, err := s.sprintService.Start(ids[0], c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+func (s *sprintAPI) CloseSprint(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var request model.CloseSprintRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	sprint, err := s.sprintService.Close(ids[0], c.GetInt("workspace_id"), request.NextSprintID, c.GetString("email"))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sprint)
+}
+
+func (s *sprintAPI) GetBurndown(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	burndown, err := s.sprintService.GetBurndown(ids[0], c.GetInt("workspace_id"), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(sprintErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, burndown)
+}
+
+func sprintErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrSprintNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidSprint):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrSprintState),
+		errors.Is(err, model.ErrSprintActive):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
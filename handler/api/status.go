@@ -0,0 +1,98 @@
+/** 
+ * Package api provides HTTP handlers for the task status workflow.
+ * 
+ * Interfaces:
+ * 
+ * - StatusAPI: Interface defining methods for handling status-related HTTP requests.
+ *   Methods:
+ *   - GetStatusList: HTTP handler for retrieving the workflow of the logged-in user.
+ *   - AddStatus: HTTP handler for adding a custom status.
+ *   - DeleteStatus: HTTP handler for deleting a custom status.
+ * 
+ * Structs:
+ * 
+ * - statusAPI: Implements the StatusAPI interface. It provides HTTP handlers for status-related operations.
+ *   Fields:
+ *   - statusService: Instance of the StatusService interface to interact with the status service.
+ *   Methods:
+ *   - NewStatusAPI: Function to create a new instance of the statusAPI struct.
+ *     Parameters:
+ *     - statusService: Instance of the StatusService interface.
+ *     Returns:
+ *     - *statusAPI: A new instance of the statusAPI struct.
+ *   - GetStatusList: HTTP handler for retrieving the statuses, allowed transitions and custom statuses of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddStatus: HTTP handler for adding a custom status owned by the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteStatus: HTTP handler for deleting a custom status owned by the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatusAPI interface {
+	GetStatusList(c *gin.Context)
+	AddStatus(c *gin.Context)
+	DeleteStatus(c *gin.Context)
+}
+
+type statusAPI struct {
+	statusService service.StatusService
+}
+
+func NewStatusAPI(statusService service.StatusService) *statusAPI {
+	return &statusAPI{statusService}
+}
+
+func (s *statusAPI) GetStatusList(c *gin.Context) {
+	statusList, err := s.statusService.GetList(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, statusList)
+}
+
+func (s *statusAPI) AddStatus(c *gin.Context) {
+	var newStatus model.CustomStatus
+	if err := c.ShouldBindJSON(&newStatus); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	newStatus.UserID = c.GetInt("user_id")
+	if err := s.statusService.Store(&newStatus); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newStatus)
+}
+
+func (s *statusAPI) DeleteStatus(c *gin.Context) {
+	statusID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid status ID"})
+		return
+	}
+
+	if err := s.statusService.Delete(statusID, c.GetInt("user_id")); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "status delete success"})
+}
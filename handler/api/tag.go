@@ -0,0 +1,217 @@
+/** 
+ * Package api provides HTTP handlers for task tags.
+ * 
+ * Interfaces:
+ * 
+ * - TagAPI: Interface defining methods for handling tag-related HTTP requests.
+ *   Methods:
+ *   - GetTagList: HTTP handler for retrieving the tags of the logged-in user.
+ *   - AddTag: HTTP handler for adding a tag.
+ *   - UpdateTag: HTTP handler for renaming or recoloring a tag.
+ *   - DeleteTag: HTTP handler for deleting a tag.
+ *   - GetTaskTags: HTTP handler for retrieving the tags of a task.
+ *   - AssignTag: HTTP handler for putting a tag on a task.
+ *   - UnassignTag: HTTP handler for removing a tag from a task.
+ * 
+ * Structs:
+ * 
+ * - tagAPI: Implements the TagAPI interface. It provides HTTP handlers for tag-related operations.
+ *   Fields:
+ *   - tagService: Instance of the TagService interface to interact with the tag service.
+ *   Methods:
+ *   - NewTagAPI: Function to create a new instance of the tagAPI struct.
+ *     Parameters:
+ *     - tagService: Instance of the TagService interface.
+ *     Returns:
+ *     - *tagAPI: A new instance of the tagAPI struct.
+ *   - GetTagList: HTTP handler for retrieving the tags of the logged-in user together with the tasks they are assigned to.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddTag: HTTP handler for adding a tag owned by the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateTag: HTTP handler for renaming or recoloring a tag owned by the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteTag: HTTP handler for deleting a tag owned by the logged-in user, removing it from every task.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTaskTags: HTTP handler for retrieving the tags of the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AssignTag: HTTP handler for putting a tag of the logged-in user on the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UnassignTag: HTTP handler for removing a tag of the logged-in user from the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - tagErrorStatus: Function to pick the HTTP status code for a tag service error. Unknown tags are reported as 404,
+ *   any other refusal as 400.
+ * 
+ * - taskAndTagID: Function to parse the task ID and tag ID path parameters, answering 400 when either is invalid.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagAPI interface {
+	GetTagList(c *gin.Context)
+	AddTag(c *gin.Context)
+	UpdateTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
+	GetTaskTags(c *gin.Context)
+	AssignTag(c *gin.Context)
+	UnassignTag(c *gin.Context)
+}
+
+type tagAPI struct {
+	tagService service.TagService
+}
+
+func NewTagAPI(tagService service.TagService) *tagAPI {
+	return &tagAPI{tagService}
+}
+
+func (t *tagAPI) GetTagList(c *gin.Context) {
+	tagList, err := t.tagService.GetList(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tagList)
+}
+
+func (t *tagAPI) AddTag(c *gin.Context) {
+	var newTag model.Tag
+	if err := c.ShouldBindJSON(&newTag); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	newTag.UserID = c.GetInt("user_id")
+	if err := t.tagService.Store(&newTag); err != nil {
+		c.JSON(tagErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTag)
+}
+
+func (t *tagAPI) UpdateTag(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid tag ID"})
+		return
+	}
+
+	var tag model.Tag
+	if err := c.ShouldBindJSON(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tag.UserID = c.GetInt("user_id")
+	if err := t.tagService.Update(tagID, &tag); err != nil {
+		c.JSON(tagErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+func (t *tagAPI) DeleteTag(c *gin.Context) {
+	tagID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid tag ID"})
+		return
+	}
+
+	if err := t.tagService.Delete(tagID, c.GetInt("user_id")); err != nil {
+		c.JSON(tagErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "tag delete success"})
+}
+
+func (t *tagAPI) GetTaskTags(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	tags, err := t.tagService.GetTaskTags(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if tags == nil {
+		tags = []model.Tag{}
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+func (t *tagAPI) AssignTag(c *gin.Context) {
+	taskID, tagID, ok := taskAndTagID(c)
+	if !ok {
+		return
+	}
+
+	if err := t.tagService.Assign(taskID, tagID, c.GetInt("user_id")); err != nil {
+		c.JSON(tagErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "tag assign success"})
+}
+
+func (t *tagAPI) UnassignTag(c *gin.Context) {
+	taskID, tagID, ok := taskAndTagID(c)
+	if !ok {
+		return
+	}
+
+	if err := t.tagService.Unassign(taskID, tagID, c.GetInt("user_id")); err != nil {
+		c.JSON(tagErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "tag unassign success"})
+}
+
+func tagErrorStatus(err error) int {
+	if errors.Is(err, model.ErrTagNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+func taskAndTagID(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	tagID, err := strconv.Atoi(c.Param("tag"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid tag ID"})
+		return 0, 0, false
+	}
+	return taskID, tagID, true
+}
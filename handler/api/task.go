@@ -6,41 +6,162 @@
  * - TaskAPI: Interface defining methods for handling task-related HTTP requests.
  *   Methods:
  *   - AddTask: HTTP handler for adding a new task.
- *   - UpdateTask: HTTP handler for updating an existing task.
+ *   - UpdateTask: HTTP handler for updating an existing task. Users who may not edit the task are refused with 403.
  *   - DeleteTask: HTTP handler for deleting a task.
  *   - GetTaskByID: HTTP handler for retrieving a task by its ID.
- *   - GetTaskList: HTTP handler for retrieving a list of all tasks.
- *   - GetTaskListByCategory: HTTP handler for retrieving a list of tasks by category.
+ *   - GetTaskList: HTTP handler for retrieving the tasks of the selected workspace.
+ *   - SearchTasks: HTTP handler for searching the tasks of the selected workspace with a query.
+ *   - GetTaskListByCategory: HTTP handler for retrieving the tasks of the selected workspace by category.
+ *   - TransitionTask: HTTP handler for moving a task to another status.
+ *   - GetTaskTransitions: HTTP handler for retrieving the status history of a task.
+ *   - GetSubtasks: HTTP handler for retrieving the direct subtasks of a task.
+ *   - AddSubtask: HTTP handler for adding a subtask to a task.
+ *   - DetachSubtask: HTTP handler for making a subtask a top-level task.
+ *   - GetTaskProgress: HTTP handler for retrieving the progress of a task.
+ *   - AddChecklistItem: HTTP handler for adding an item to a task's checklist.
+ *   - ToggleChecklistItem: HTTP handler for checking or unchecking a checklist item.
+ *   - ReorderChecklist: HTTP handler for reordering a task's checklist.
+ *   - DeleteChecklistItem: HTTP handler for removing a checklist item.
+ *   - UpdateOccurrence: HTTP handler for updating one occurrence of a recurring task.
+ *   - UpdateSeries: HTTP handler for updating an occurrence of a recurring task and the occurrences following it.
+ *   - GetSeries: HTTP handler for retrieving every occurrence of a recurring task.
+ *   - MoveTask: HTTP handler for moving a task on the Kanban board.
+ *   - GetBoard: HTTP handler for retrieving the Kanban board of the selected workspace.
+ *   - SetTaskFields: HTTP handler for setting custom field values of a task.
+ *   - ArchiveTask: HTTP handler for archiving a completed task.
+ *   - GetTaskHistory: HTTP handler for retrieving the activity history of a task.
+ *   - UnarchiveTask: HTTP handler for bringing an archived task back to the task lists.
  * 
  * Structs:
  * 
  * - taskAPI: Implements the TaskAPI interface. It provides HTTP handlers for task-related operations.
+ *   Changes are made on behalf of the logged-in user, who is recorded as their author in the history of the task.
  *   Fields:
  *   - taskService: Instance of the TaskService interface to interact with the task service.
+ *   - assignmentService: Instance of the AssignmentService interface used to check who may change, delete or transition a task.
+ *   - notificationService: Instance of the NotificationService interface used to notify the followers of a task of status changes.
  *   Methods:
  *   - NewTaskAPI: Function to create a new instance of the taskAPI struct.
  *     Parameters:
  *     - taskRepo: Instance of the TaskService interface.
+ *     - assignmentService: Instance of the AssignmentService interface.
+ *     - notificationService: Instance of the NotificationService interface.
  *     Returns:
  *     - *taskAPI: A new instance of the taskAPI struct.
- *   - AddTask: HTTP handler for adding a new task.
+ *   - AddTask: HTTP handler for adding a new task, owned by the logged-in user, to the workspace they selected.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
- *   - UpdateTask: HTTP handler for updating an existing task.
+ *   - UpdateTask: HTTP handler for updating an existing task. Users who may not edit the task are refused with 403.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
- *   - DeleteTask: HTTP handler for deleting a task.
+ *   - DeleteTask: HTTP handler for moving a task to the trash. Its subtasks are deleted too, unless the query parameter
+ *     children=keep asks to move them up to the task's parent. Only the owner of the task and the admins of its workspace may delete it,
+ *     others are refused with 403.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
  *   - GetTaskByID: HTTP handler for retrieving a task by its ID.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
- *   - GetTaskList: HTTP handler for retrieving a list of all tasks.
+ *   - GetTaskList: HTTP handler for retrieving the tasks of the selected workspace. One or more tag query parameters, e.g. ?tag=1&tag=2,
+ *     only keep the tasks carrying all of these tags. Custom field query parameters, e.g. ?field[3]=high, only keep the tasks whose
+ *     value of the field equals the given value.
+ *     Archived tasks are left out, unless archived=true asks for the archived tasks only. The status, priority_min, priority_max, category,
+ *     user, deadline_after, deadline_before and title parameters filter the tasks further, and sort orders them. With limit the tasks
+ *     are returned a page at a time; the X-Next-Cursor header carries the cursor parameter of the next page. Filtering, sorting and
+ *     paging all happen in the repository.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - SearchTasks: HTTP handler for retrieving the tasks of the selected workspace matching the search query in the q query
+ *     parameter, see model.ParseSearch. Queries that cannot be parsed are answered with 400 and a model.SearchErrorResponse
+ *     pointing at the offending token.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTaskListByCategory: HTTP handler for retrieving the tasks of the selected workspace by category.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - TransitionTask: HTTP handler for moving a task to another status, recording the logged-in user as the one who changed it.
+ *     Users who may not transition the task are refused with 403.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTaskTransitions: HTTP handler for retrieving the status history of a task.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetSubtasks: HTTP handler for retrieving the direct subtasks of a task.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddSubtask: HTTP handler for adding a subtask, owned by the logged-in user, under the task in the path. Users who may not
+ *     edit the parent are refused with 403 and categories of other workspaces with 400.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DetachSubtask: HTTP handler for taking the task in the path out from under its parent. Users who may not edit the task are refused with 403.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTaskProgress: HTTP handler for retrieving the progress of a task computed from its subtasks and checklist.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddChecklistItem: HTTP handler for adding an item to a task's checklist.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - ToggleChecklistItem: HTTP handler for checking or unchecking a checklist item.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - ReorderChecklist: HTTP handler for reordering a task's checklist. Expects the IDs of every item in their new order.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteChecklistItem: HTTP handler for removing a checklist item.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
- *   - GetTaskListByCategory: HTTP handler for retrieving a list of tasks by category.
+ *   - UpdateOccurrence: HTTP handler for updating only the occurrence in the path, keeping the recurrence rule of its series.
+ *     Users who may not edit the task are refused with 403.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
+ *   - UpdateSeries: HTTP handler for updating the occurrence in the path and the later, not yet completed occurrences of its series.
+ *     Responds with the updated occurrences. Users who may not edit the task are refused with 403.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetSeries: HTTP handler for retrieving every occurrence of the series the task in the path belongs to.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - MoveTask: HTTP handler for placing the task in the path right after another task of a status column, or at its top.
+ *     A move to another column is recorded in the status history like TransitionTask. Users who may not transition the task are refused with 403.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetBoard: HTTP handler for retrieving the tasks of the selected workspace grouped by status and ordered by rank,
+ *     with the columns in the order of the logged-in user's workflow.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - SetTaskFields: HTTP handler for changing the custom field values of the task in the path given in the JSON payload, keyed
+ *     by field ID. Values not in the payload are kept and empty ones are cleared. Users who may not edit the task are refused with 403.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - ArchiveTask: HTTP handler for archiving the completed task in the path. Users who may not edit the task are refused with 403.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UnarchiveTask: HTTP handler for unarchiving the task in the path. Users who may not edit the task are refused with 403.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTaskHistory: HTTP handler for retrieving the events recorded for the task in the path, oldest first, each with the
+ *     changed fields, the user who made the change and when.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - setArchived: Method shared by ArchiveTask and UnarchiveTask to authorize the logged-in user and apply the change to the task in the path.
+ *   - authorize: Method to check an action of the logged-in user on a task with AssignmentService.Authorize, answering refusals itself.
+ *   - notifyStatusChange: Method shared by UpdateTask, TransitionTask and MoveTask to notify the followers of the task when
+ *     the change moved it to another status.
+ * 
+ * Functions:
+ * 
+ * - taskErrorStatus: Function to pick the HTTP status code for a task service error.
+ *   Statuses outside the workflow, transitions it does not allow, invalid parents, unknown checklist items, invalid
+ *   recurrence rules, negative estimates, invalid board positions, invalid custom field values, archiving a task that is not completed, invalid list queries
+ *   and categories of other workspaces are client errors. Completing a task that is still blocked is a conflict, actions the user's relation to the
+ *   task does not allow are forbidden and missing tasks are not found.
+ * 
+ * - taskAndItemID: Function to parse the task ID and checklist item ID path parameters, answering 400 when either is invalid.
+ * 
+ * - taskQuery: Function to read the filters, sort order and page of a task list from the query string.
+ * 
+ * - queryInt: Function to read an integer query parameter, 0 when it is missing. The category list uses it too.
  */
 
 package api
@@ -48,6 +169,8 @@ package api
 import (
 	"a21hc3NpZ25tZW50/model"
 	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -60,15 +183,37 @@ type TaskAPI interface {
 	DeleteTask(c *gin.Context)
 	GetTaskByID(c *gin.Context)
 	GetTaskList(c *gin.Context)
+	SearchTasks(c *gin.Context)
 	GetTaskListByCategory(c *gin.Context)
+	TransitionTask(c *gin.Context)
+	GetTaskTransitions(c *gin.Context)
+	GetSubtasks(c *gin.Context)
+	AddSubtask(c *gin.Context)
+	DetachSubtask(c *gin.Context)
+	GetTaskProgress(c *gin.Context)
+	AddChecklistItem(c *gin.Context)
+	ToggleChecklistItem(c *gin.Context)
+	ReorderChecklist(c *gin.Context)
+	DeleteChecklistItem(c *gin.Context)
+	UpdateOccurrence(c *gin.Context)
+	UpdateSeries(c *gin.Context)
+	GetSeries(c *gin.Context)
+	MoveTask(c *gin.Context)
+	GetBoard(c *gin.Context)
+	SetTaskFields(c *gin.Context)
+	ArchiveTask(c *gin.Context)
+	UnarchiveTask(c *gin.Context)
+	GetTaskHistory(c *gin.Context)
 }
 
 type taskAPI struct {
-	taskService service.TaskService
+	taskService         service.TaskService
+	assignmentService   service.AssignmentService
+	notificationService service.NotificationService
 }
 
-func NewTaskAPI(taskRepo service.TaskService) *taskAPI {
-	return &taskAPI{taskRepo}
+func NewTaskAPI(taskRepo service.TaskService, assignmentService service.AssignmentService, notificationService service.NotificationService) *taskAPI {
+	return &taskAPI{taskRepo, assignmentService, notificationService}
 }
 
 func (t *taskAPI) AddTask(c *gin.Context) {
@@ -78,9 +223,11 @@ func (t *taskAPI) AddTask(c *gin.Context) {
 		return
 	}
 
-	err := t.taskService.Store(&newTask)
+	newTask.UserID = c.GetInt("user_id")
+	newTask.WorkspaceID = c.GetInt("workspace_id")
+	err := t.taskService.As(c.GetString("email")).Store(&newTask)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -100,12 +247,18 @@ func (t *taskAPI) UpdateTask(c *gin.Context) {
 		return
 	}
 
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	before, _ := t.taskService.GetByID(taskID)
 	task.ID = taskID
-	err = t.taskService.Update(taskID, &task)
+	err = t.taskService.As(c.GetString("email")).Update(taskID, &task)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
 		return
 	}
+	t.notifyStatusChange(c, before, task)
 
 	c.JSON(http.StatusOK, model.SuccessResponse{Message: "update task success"})
 }
@@ -117,7 +270,15 @@ func (t *taskAPI) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	err = t.taskService.Delete(taskID)
+	if !t.authorize(c, taskID, model.TaskActionDelete) {
+		return
+	}
+
+	if c.Query("children") == "keep" {
+		err = t.taskService.As(c.GetString("email")).DeleteKeepChildren(taskID)
+	} else {
+		err = t.taskService.As(c.GetString("email")).Delete(taskID)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
 		return
@@ -143,9 +304,33 @@ func (t *taskAPI) GetTaskByID(c *gin.Context) {
 }
 
 func (t *taskAPI) GetTaskList(c *gin.Context) {
-	tasks, err := t.taskService.GetList()
+	query, err := taskQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	page, err := t.taskService.Query(c.GetInt("workspace_id"), query)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if page.NextCursor != "" {
+		c.Header("X-Next-Cursor", page.NextCursor)
+	}
+	c.JSON(http.StatusOK, page.Tasks)
+}
+
+func (t *taskAPI) SearchTasks(c *gin.Context) {
+	tasks, err := t.taskService.Search(c.GetInt("workspace_id"), c.GetInt("user_id"), c.Query("q"))
+	if err != nil {
+		var parseErr *model.ParseError
+		if errors.As(err, &parseErr) {
+			c.JSON(http.StatusBadRequest, model.SearchErrorResponse{Error: err.Error(), Column: parseErr.Column, Token: parseErr.Token})
+			return
+		}
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
 		return
 	}
 
@@ -159,7 +344,7 @@ func (t *taskAPI) GetTaskListByCategory(c *gin.Context) {
 		return
 	}
 
-	tasks, err := t.taskService.GetTaskCategory(categoryID)
+	tasks, err := t.taskService.GetTaskCategory(c.GetInt("workspace_id"), categoryID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
 		return
@@ -167,3 +352,516 @@ func (t *taskAPI) GetTaskListByCategory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, tasks)
 }
+
+func (t *taskAPI) TransitionTask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var request model.TransitionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionTransition) {
+		return
+	}
+
+	before, _ := t.taskService.GetByID(taskID)
+	task, err := t.taskService.As(c.GetString("email")).Transition(taskID, request.Status)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+	t.notifyStatusChange(c, before, *task)
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) GetTaskTransitions(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	transitions, err := t.taskService.GetTransitions(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
+
+func (t *taskAPI) GetSubtasks(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	subtasks, err := t.taskService.GetSubtasks(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subtasks)
+}
+
+func (t *taskAPI) AddSubtask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var subtask model.Task
+	if err := c.ShouldBindJSON(&subtask); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	subtask.ParentID = taskID
+	subtask.UserID = c.GetInt("user_id")
+	if err := t.taskService.As(c.GetString("email")).Store(&subtask); err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subtask)
+}
+
+func (t *taskAPI) DetachSubtask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	task, err := t.taskService.As(c.GetString("email")).Detach(taskID)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) GetTaskProgress(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	progress, err := t.taskService.GetProgress(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+func (t *taskAPI) AddChecklistItem(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var item model.ChecklistItem
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	task, err := t.taskService.As(c.GetString("email")).AddChecklistItem(taskID, item)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) ToggleChecklistItem(c *gin.Context) {
+	taskID, itemID, ok := taskAndItemID(c)
+	if !ok {
+		return
+	}
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	task, err := t.taskService.As(c.GetString("email")).ToggleChecklistItem(taskID, itemID)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) ReorderChecklist(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var order model.ChecklistOrder
+	if err := c.ShouldBindJSON(&order); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	task, err := t.taskService.As(c.GetString("email")).ReorderChecklist(taskID, order.IDs)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) DeleteChecklistItem(c *gin.Context) {
+	taskID, itemID, ok := taskAndItemID(c)
+	if !ok {
+		return
+	}
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	task, err := t.taskService.As(c.GetString("email")).DeleteChecklistItem(taskID, itemID)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) UpdateOccurrence(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var task model.Task
+	if err := c.ShouldBindJSON(&task); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	task.ID = taskID
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	if err := t.taskService.As(c.GetString("email")).UpdateOccurrence(taskID, &task); err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "update occurrence success"})
+}
+
+func (t *taskAPI) UpdateSeries(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var task model.Task
+	if err := c.ShouldBindJSON(&task); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	updated, err := t.taskService.As(c.GetString("email")).UpdateSeries(taskID, &task)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (t *taskAPI) GetSeries(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	series, err := t.taskService.GetSeries(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func (t *taskAPI) MoveTask(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var request model.MoveRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionTransition) {
+		return
+	}
+
+	before, _ := t.taskService.GetByID(taskID)
+	task, err := t.taskService.As(c.GetString("email")).Move(taskID, request)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+	t.notifyStatusChange(c, before, *task)
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) GetBoard(c *gin.Context) {
+	board, err := t.taskService.GetBoard(c.GetInt("workspace_id"), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func (t *taskAPI) SetTaskFields(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var fields map[int]interface{}
+	if err := c.ShouldBindJSON(&fields); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	task, err := t.taskService.As(c.GetString("email")).SetFields(taskID, fields)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+func (t *taskAPI) ArchiveTask(c *gin.Context) {
+	t.setArchived(c, t.taskService.As(c.GetString("email")).Archive)
+}
+
+func (t *taskAPI) UnarchiveTask(c *gin.Context) {
+	t.setArchived(c, t.taskService.As(c.GetString("email")).Unarchive)
+}
+
+func (t *taskAPI) GetTaskHistory(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	events, err := t.taskService.GetHistory(taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+func (t *taskAPI) setArchived(c *gin.Context, change func(id int) (*model.Task, error)) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	if !t.authorize(c, taskID, model.TaskActionEdit) {
+		return
+	}
+
+	task, err := change(taskID)
+	if err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// authorize checks the action of the logged-in user on the task, answering with the status of the error when it is refused.
+func (t *taskAPI) authorize(c *gin.Context, taskID int, action model.TaskAction) bool {
+	if err := t.assignmentService.Authorize(taskID, c.GetInt("user_id"), action); err != nil {
+		c.JSON(taskErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return false
+	}
+	return true
+}
+
+func (t *taskAPI) notifyStatusChange(c *gin.Context, before *model.Task, after model.Task) {
+	if before == nil || before.Status == after.Status {
+		return
+	}
+
+	logNotifyError(t.notificationService.StatusChanged(after, before.Status, c.GetInt("user_id"), c.GetString("email")))
+}
+
+func taskErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrUnknownStatus),
+		errors.Is(err, model.ErrInvalidTransition),
+		errors.Is(err, model.ErrInvalidParent),
+		errors.Is(err, model.ErrChecklistItemNotFound),
+		errors.Is(err, model.ErrInvalidChecklistOrder),
+		errors.Is(err, model.ErrInvalidRecurrence),
+		errors.Is(err, model.ErrInvalidEstimate),
+		errors.Is(err, model.ErrInvalidMove),
+		errors.Is(err, model.ErrInvalidFieldValue),
+		errors.Is(err, model.ErrNotArchivable),
+		errors.Is(err, model.ErrInvalidQuery),
+		errors.Is(err, model.ErrInvalidCategory):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrTaskBlocked):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrAssigneeForbidden),
+		errors.Is(err, model.ErrTaskForbidden):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// taskQuery reads the filters, sort order and page of a task list from the query string.
+func taskQuery(c *gin.Context) (model.TaskQuery, error) {
+	query := model.TaskQuery{
+		Title:    c.Query("title"),
+		Archived: c.Query("archived") == "true",
+		Sort:     c.Query("sort"),
+		Cursor:   c.Query("cursor"),
+		Fields:   map[int]string{},
+	}
+	for _, status := range c.QueryArray("status") {
+		query.Statuses = append(query.Statuses, model.TaskStatus(status))
+	}
+
+	var err error
+	for _, value := range c.QueryArray("tag") {
+		tagID, err := strconv.Atoi(value)
+		if err != nil {
+			return model.TaskQuery{}, fmt.Errorf("%w: invalid tag ID", model.ErrInvalidQuery)
+		}
+		query.TagIDs = append(query.TagIDs, tagID)
+	}
+	for key, value := range c.QueryMap("field") {
+		fieldID, err := strconv.Atoi(key)
+		if err != nil {
+			return model.TaskQuery{}, fmt.Errorf("%w: invalid field ID", model.ErrInvalidQuery)
+		}
+		query.Fields[fieldID] = value
+	}
+
+	if query.MinPriority, err = queryInt(c, "priority_min"); err != nil {
+		return model.TaskQuery{}, err
+	}
+	if query.MaxPriority, err = queryInt(c, "priority_max"); err != nil {
+		return model.TaskQuery{}, err
+	}
+	if query.CategoryID, err = queryInt(c, "category"); err != nil {
+		return model.TaskQuery{}, err
+	}
+	if query.UserID, err = queryInt(c, "user"); err != nil {
+		return model.TaskQuery{}, err
+	}
+	if query.Limit, err = queryInt(c, "limit"); err != nil {
+		return model.TaskQuery{}, err
+	}
+	if query.DeadlineAfter, err = model.ParseDeadline(c.Query("deadline_after"), nil); err != nil {
+		return model.TaskQuery{}, err
+	}
+	if query.DeadlineBefore, err = model.ParseDeadline(c.Query("deadline_before"), nil); err != nil {
+		return model.TaskQuery{}, err
+	}
+	return query, nil
+}
+
+// queryInt reads an integer query parameter, 0 when it is missing.
+func queryInt(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s must be a number", model.ErrInvalidQuery, name)
+	}
+	return number, nil
+}
+
+func taskAndItemID(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid checklist item ID"})
+		return 0, 0, false
+	}
+
+	return taskID, itemID, true
+}
@@ -0,0 +1,192 @@
+/** 
+ * Package api provides HTTP handlers for task templates.
+ * 
+ * Interfaces:
+ * 
+ * - TemplateAPI: Interface defining methods for handling template-related HTTP requests.
+ *   Methods:
+ *   - GetTemplates: HTTP handler for retrieving the templates of the selected workspace.
+ *   - AddTemplate: HTTP handler for creating a template.
+ *   - GetTemplate: HTTP handler for retrieving a template.
+ *   - UpdateTemplate: HTTP handler for updating a template.
+ *   - DeleteTemplate: HTTP handler for deleting a template.
+ *   - InstantiateTemplate: HTTP handler for creating the tasks of a template.
+ * 
+ * Structs:
+ * 
+ * - templateAPI: Implements the TemplateAPI interface. It provides HTTP handlers for template-related operations.
+ *   Fields:
+ *   - templateService: Instance of the TemplateService interface to interact with the template service.
+ *   Methods:
+ *   - NewTemplateAPI: Function to create a new instance of the templateAPI struct.
+ *     Parameters:
+ *     - templateService: Instance of the TemplateService interface.
+ *     Returns:
+ *     - *templateAPI: A new instance of the templateAPI struct.
+ *   - GetTemplates: HTTP handler for retrieving the templates of the selected workspace.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddTemplate: HTTP handler for creating a template in the selected workspace from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTemplate: HTTP handler for retrieving the template in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateTemplate: HTTP handler for replacing the name, description and tasks of the template in the path from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteTemplate: HTTP handler for deleting the template in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - InstantiateTemplate: HTTP handler for creating the tasks of the template in the path for and on behalf of the logged-in user,
+ *     with deadlines counted from the start date in the JSON payload. Responds with the created tasks.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - templateErrorStatus: Function to pick the HTTP status code for a template service error.
+ *   Unknown templates are reported as 404 and invalid templates or start dates as 400. Errors of the created tasks are
+ *   reported like in the task handlers.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TemplateAPI interface {
+	GetTemplates(c *gin.Context)
+	AddTemplate(c *gin.Context)
+	GetTemplate(c *gin.Context)
+	UpdateTemplate(c *gin.Context)
+	DeleteTemplate(c *gin.Context)
+	InstantiateTemplate(c *gin.Context)
+}
+
+type templateAPI struct {
+	templateService service.TemplateService
+}
+
+func NewTemplateAPI(templateService service.TemplateService) *templateAPI {
+	return &templateAPI{templateService}
+}
+
+func (t *templateAPI) GetTemplates(c *gin.Context) {
+	templates, err := t.templateService.GetList(c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(templateErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+func (t *templateAPI) AddTemplate(c *gin.Context) {
+	var request model.TemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	template, err := t.templateService.Create(model.Template{
+		Name:        request.Name,
+		Description: request.Description,
+		Tasks:       request.Tasks,
+		UserID:      c.GetInt("user_id"),
+		WorkspaceID: c.GetInt("workspace_id"),
+	})
+	if err != nil {
+		c.JSON(templateErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (t *templateAPI) GetTemplate(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	template, err := t.templateService.GetByID(ids[0], c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(templateErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (t *templateAPI) UpdateTemplate(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var request model.TemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	template, err := t.templateService.Update(ids[0], c.GetInt("workspace_id"), request)
+	if err != nil {
+		c.JSON(templateErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (t *templateAPI) DeleteTemplate(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := t.templateService.Delete(ids[0], c.GetInt("workspace_id")); err != nil {
+		c.JSON(templateErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "template delete success"})
+}
+
+func (t *templateAPI) InstantiateTemplate(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	var request model.InstantiateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tasks, err := t.templateService.Instantiate(ids[0], c.GetInt("workspace_id"), c.GetInt("user_id"), c.GetString("email"), request)
+	if err != nil {
+		c.JSON(templateErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidTemplate):
+		return http.StatusBadRequest
+	}
+	return taskErrorStatus(err)
+}
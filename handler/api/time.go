@@ -0,0 +1,260 @@
+/** 
+ * Package api provides HTTP handlers for tracking the time spent on tasks.
+ * 
+ * Interfaces:
+ * 
+ * - TimeAPI: Interface defining methods for handling time tracking HTTP requests.
+ *   Methods:
+ *   - StartTimer: HTTP handler for starting a timer on a task.
+ *   - StopTimer: HTTP handler for stopping the running timer.
+ *   - GetRunningTimer: HTTP handler for retrieving the running timer.
+ *   - AddTimeEntry: HTTP handler for entering time spent on a task by hand.
+ *   - GetTaskTime: HTTP handler for retrieving the time spent on a task.
+ *   - DeleteTimeEntry: HTTP handler for deleting a time entry.
+ *   - GetTimesheet: HTTP handler for retrieving a timesheet.
+ *   - ExportTimesheet: HTTP handler for downloading a timesheet as CSV.
+ * 
+ * Structs:
+ * 
+ * - timeAPI: Implements the TimeAPI interface. It provides HTTP handlers for time tracking operations.
+ *   Fields:
+ *   - timeService: Instance of the TimeService interface to interact with the time service.
+ *   Methods:
+ *   - NewTimeAPI: Function to create a new instance of the timeAPI struct.
+ *     Parameters:
+ *     - timeService: Instance of the TimeService interface.
+ *     Returns:
+ *     - *timeAPI: A new instance of the timeAPI struct.
+ *   - StartTimer: HTTP handler for starting a timer for the logged-in user on the task in the path. Accepts an optional
+ *     JSON payload with a note. Answers 409 while another timer of the user is running.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - StopTimer: HTTP handler for stopping the running timer of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetRunningTimer: HTTP handler for retrieving the running timer of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddTimeEntry: HTTP handler for entering time spent by the logged-in user on the task in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTaskTime: HTTP handler for retrieving the time entries of the task in the path with their total.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteTimeEntry: HTTP handler for deleting a time entry of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTimesheet: HTTP handler for retrieving the timesheet of the logged-in user between the from and to query
+ *     parameters (YYYY-MM-DD, both included, the last seven days by default).
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - ExportTimesheet: HTTP handler for downloading the entries of the same timesheet as a CSV file, one row per entry.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - timeErrorStatus: Function to pick the HTTP status code for a time service error.
+ *   A second running timer is reported as 409, a missing timer or entry as 404 and invalid times or days as 400.
+ * 
+ * - csvCell: Function to keep a spreadsheet from reading a cell of user text as a formula.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeAPI interface {
+	StartTimer(c *gin.Context)
+	StopTimer(c *gin.Context)
+	GetRunningTimer(c *gin.Context)
+	AddTimeEntry(c *gin.Context)
+	GetTaskTime(c *gin.Context)
+	DeleteTimeEntry(c *gin.Context)
+	GetTimesheet(c *gin.Context)
+	ExportTimesheet(c *gin.Context)
+}
+
+type timeAPI struct {
+	timeService service.TimeService
+}
+
+func NewTimeAPI(timeService service.TimeService) *timeAPI {
+	return &timeAPI{timeService}
+}
+
+func (t *timeAPI) StartTimer(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var request struct {
+		Note string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	entry, err := t.timeService.Start(taskID, c.GetInt("user_id"), request.Note)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (t *timeAPI) StopTimer(c *gin.Context) {
+	entry, err := t.timeService.Stop(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (t *timeAPI) GetRunningTimer(c *gin.Context) {
+	entry, err := t.timeService.GetRunning(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (t *timeAPI) AddTimeEntry(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	var request model.TimeEntryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	entry, err := t.timeService.AddEntry(taskID, c.GetInt("user_id"), request)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (t *timeAPI) GetTaskTime(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "Invalid task ID"})
+		return
+	}
+
+	taskTime, err := t.timeService.GetTaskTime(taskID)
+	if err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, taskTime)
+}
+
+func (t *timeAPI) DeleteTimeEntry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid time entry ID"})
+		return
+	}
+
+	if err := t.timeService.DeleteEntry(id, c.GetInt("user_id")); err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "time entry delete success"})
+}
+
+func (t *timeAPI) GetTimesheet(c *gin.Context) {
+	sheet, err := t.timeService.Report(c.GetInt("user_id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sheet)
+}
+
+func (t *timeAPI) ExportTimesheet(c *gin.Context) {
+	sheet, err := t.timeService.Report(c.GetInt("user_id"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(timeErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	loc := model.LoadLocation(sheet.TimeZone)
+	now := time.Now()
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="timesheet-%s-%s.csv"`, sheet.From, sheet.To))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"entry_id", "date", "task_id", "start", "end", "seconds", "manual", "note"})
+	for _, entry := range sheet.Entries {
+		end := ""
+		if entry.End != nil {
+			end = entry.End.In(loc).Format(time.RFC3339)
+		}
+		w.Write([]string{
+			strconv.Itoa(entry.ID),
+			entry.Start.In(loc).Format(model.DateLayout),
+			strconv.Itoa(entry.TaskID),
+			entry.Start.In(loc).Format(time.RFC3339),
+			end,
+			strconv.FormatInt(int64(entry.Duration(now).Seconds()), 10),
+			strconv.FormatBool(entry.Manual),
+			csvCell(entry.Note),
+		})
+	}
+	w.Flush()
+}
+
+func timeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrTimerRunning):
+		return http.StatusConflict
+	case errors.Is(err, model.ErrNoRunningTimer),
+		errors.Is(err, model.ErrTimeEntryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrInvalidTimeEntry),
+		errors.Is(err, model.ErrInvalidTimeRange):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
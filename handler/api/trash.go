@@ -0,0 +1,132 @@
+/** 
+ * Package api provides HTTP handlers for the trash of a workspace.
+ * 
+ * Interfaces:
+ * 
+ * - TrashAPI: Interface defining methods for handling trash-related HTTP requests.
+ *   Methods:
+ *   - GetTrash: HTTP handler for retrieving the deleted tasks and categories of the selected workspace.
+ *   - GetTrashItem: HTTP handler for retrieving a trash item.
+ *   - RestoreTrashItem: HTTP handler for restoring a trash item.
+ *   - PurgeTrashItem: HTTP handler for removing a trash item for good.
+ * 
+ * Structs:
+ * 
+ * - trashAPI: Implements the TrashAPI interface. It provides HTTP handlers for trash-related operations.
+ *   Fields:
+ *   - trashService: Instance of the TrashService interface to interact with the trash service.
+ *   Methods:
+ *   - NewTrashAPI: Function to create a new instance of the trashAPI struct.
+ *     Parameters:
+ *     - trashService: Instance of the TrashService interface.
+ *     Returns:
+ *     - *trashAPI: A new instance of the trashAPI struct.
+ *   - GetTrash: HTTP handler for retrieving the trash items of the selected workspace, most recently deleted first,
+ *     with the time each of them is purged.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetTrashItem: HTTP handler for retrieving the trash item in the path with the deleted task and subtasks or category.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - RestoreTrashItem: HTTP handler for moving the trash item in the path back, responding with the restored item.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - PurgeTrashItem: HTTP handler for removing the trash item in the path for good.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - trashErrorStatus: Function to pick the HTTP status code for a trash service error.
+ *   Unknown trash items are reported as 404 and categories whose ID has been taken as 409.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashAPI interface {
+	GetTrash(c *gin.Context)
+	GetTrashItem(c *gin.Context)
+	RestoreTrashItem(c *gin.Context)
+	PurgeTrashItem(c *gin.Context)
+}
+
+type trashAPI struct {
+	trashService service.TrashService
+}
+
+func NewTrashAPI(trashService service.TrashService) *trashAPI {
+	return &trashAPI{trashService}
+}
+
+func (t *trashAPI) GetTrash(c *gin.Context) {
+	items, err := t.trashService.GetList(c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(trashErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (t *trashAPI) GetTrashItem(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	item, err := t.trashService.GetByID(ids[0], c.GetInt("workspace_id"))
+	if err != nil {
+		c.JSON(trashErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (t *trashAPI) RestoreTrashItem(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	item, err := t.trashService.Restore(ids[0], c.GetInt("workspace_id"), c.GetString("email"))
+	if err != nil {
+		c.JSON(trashErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (t *trashAPI) PurgeTrashItem(c *gin.Context) {
+	ids, ok := pathIDs(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := t.trashService.Purge(ids[0], c.GetInt("workspace_id")); err != nil {
+		c.JSON(trashErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "trash item purge success"})
+}
+
+func trashErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrTrashItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrRestoreConflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -8,6 +8,13 @@
  *   - Register: HTTP handler for user registration.
  *   - Login: HTTP handler for user login.
  *   - GetUserTaskCategory: HTTP handler for retrieving user task categories.
+ *   - GetProfile: HTTP handler for retrieving the logged-in user's profile.
+ *   - UpdateProfile: HTTP handler for updating the logged-in user's full name and time zone.
+ *   - DeleteAccount: HTTP handler for scheduling the deletion of the logged-in user's account.
+ *   - RestoreAccount: HTTP handler for cancelling a pending account deletion with the account's credentials.
+ *   - DeleteUser: HTTP handler for scheduling the deletion of any user's account, used by admins.
+ *   - RestoreUser: HTTP handler for cancelling the pending deletion of any user's account, used by admins.
+ *   - GetAuditLog: HTTP handler for retrieving the audit log, used by admins.
  * 
  * Structs:
  * 
@@ -26,9 +33,35 @@
  *   - Login: HTTP handler for user login.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
- *   - GetUserTaskCategory: HTTP handler for retrieving user task categories.
+ *   - GetUserTaskCategory: HTTP handler for retrieving the user task categories of the selected workspace.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetProfile: HTTP handler for retrieving the logged-in user's profile.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - UpdateProfile: HTTP handler for updating the logged-in user's profile. Expects a JSON payload with fullname and time_zone, an IANA time zone name.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteAccount: HTTP handler for scheduling the deletion of the logged-in user's account and clearing its session cookie.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - RestoreAccount: HTTP handler for cancelling a pending account deletion. Expects the same JSON payload as Login.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeleteUser: HTTP handler for scheduling the deletion of the user identified by the id path parameter.
  *     Parameters:
  *     - c: Context object representing the HTTP request.
+ *   - RestoreUser: HTTP handler for cancelling the pending deletion of the user identified by the id path parameter.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetAuditLog: HTTP handler for retrieving the audit log.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - userErrorStatus: Function to pick the HTTP status code for an account deletion or restore error.
+ *   Unknown users are reported as 404, a wrong password as 401 and deleting or restoring an account twice as 409.
  */
 
 package api
@@ -36,7 +69,9 @@ package api
 import (
 	"a21hc3NpZ25tZW50/model"
 	"a21hc3NpZ25tZW50/service"
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -47,6 +82,13 @@ type UserAPI interface {
 	Register(c *gin.Context)
 	Login(c *gin.Context)
 	GetUserTaskCategory(c *gin.Context)
+	GetProfile(c *gin.Context)
+	UpdateProfile(c *gin.Context)
+	DeleteAccount(c *gin.Context)
+	RestoreAccount(c *gin.Context)
+	DeleteUser(c *gin.Context)
+	RestoreUser(c *gin.Context)
+	GetAuditLog(c *gin.Context)
 }
 
 type userAPI struct {
@@ -135,7 +177,7 @@ func (u *userAPI) Login(c *gin.Context) {
 }
 
 func (u *userAPI) GetUserTaskCategory(c *gin.Context) {
-	categories, err := u.userService.GetUserTaskCategory()
+	categories, err := u.userService.GetUserTaskCategory(c.GetInt("workspace_id"))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, model.NewErrorResponse("error internal server"))
 		return
@@ -143,3 +185,116 @@ func (u *userAPI) GetUserTaskCategory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, categories)
 }
+
+func (u *userAPI) GetProfile(c *gin.Context) {
+	profile, err := u.userService.GetProfile(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (u *userAPI) UpdateProfile(c *gin.Context) {
+	var profile model.UserProfile
+
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse("invalid decode json"))
+		return
+	}
+
+	profile, err := u.userService.UpdateProfile(c.GetInt("user_id"), profile)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (u *userAPI) DeleteAccount(c *gin.Context) {
+	user, err := u.userService.RequestDeletion(c.GetInt("user_id"), c.GetString("email"))
+	if err != nil {
+		c.JSON(userErrorStatus(err), model.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.SetCookie("session_token", "", -1, "/", "", false, false)
+	c.JSON(http.StatusOK, model.NewSuccessResponse("account will be deleted after "+user.DeletionDueAt.Format(time.RFC3339)))
+}
+
+func (u *userAPI) RestoreAccount(c *gin.Context) {
+	var user model.UserLogin
+
+	if err := c.BindJSON(&user); err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse("invalid decode json"))
+		return
+	}
+
+	var recordUser = model.User{
+		Email:    user.Email,
+		Password: user.Password,
+	}
+
+	if _, err := u.userService.RestoreAccount(&recordUser); err != nil {
+		c.JSON(userErrorStatus(err), model.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewSuccessResponse("account restored"))
+}
+
+func (u *userAPI) DeleteUser(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse("invalid user ID"))
+		return
+	}
+
+	user, err := u.userService.RequestDeletion(userID, c.GetString("email"))
+	if err != nil {
+		c.JSON(userErrorStatus(err), model.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewSuccessResponse("account will be deleted after "+user.DeletionDueAt.Format(time.RFC3339)))
+}
+
+func (u *userAPI) RestoreUser(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.NewErrorResponse("invalid user ID"))
+		return
+	}
+
+	if _, err := u.userService.RestoreAccountByID(userID, c.GetString("email")); err != nil {
+		c.JSON(userErrorStatus(err), model.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.NewSuccessResponse("account restored"))
+}
+
+func (u *userAPI) GetAuditLog(c *gin.Context) {
+	records, err := u.userService.GetAuditLog()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.NewErrorResponse("error internal server"))
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrWrongCredentials):
+		return http.StatusUnauthorized
+	case errors.Is(err, model.ErrDeletionScheduled),
+		errors.Is(err, model.ErrDeletionNotScheduled):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
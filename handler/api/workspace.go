@@ -0,0 +1,279 @@
+/** 
+ * Package api provides HTTP handlers for workspaces, their members and invitations.
+ * 
+ * Interfaces:
+ * 
+ * - WorkspaceAPI: Interface defining methods for handling workspace-related HTTP requests.
+ *   Methods:
+ *   - GetWorkspaces: HTTP handler for retrieving the workspaces of the logged-in user.
+ *   - AddWorkspace: HTTP handler for creating a workspace.
+ *   - SelectWorkspace: HTTP handler for selecting the workspace to work in.
+ *   - GetMembers: HTTP handler for retrieving the members of a workspace.
+ *   - Invite: HTTP handler for inviting a user to a workspace by email.
+ *   - ChangeRole: HTTP handler for changing the role of a member.
+ *   - RemoveMember: HTTP handler for removing a member from a workspace.
+ *   - GetInvitations: HTTP handler for retrieving the pending invitations of the logged-in user.
+ *   - AcceptInvitation: HTTP handler for accepting an invitation.
+ *   - DeclineInvitation: HTTP handler for declining an invitation.
+ * 
+ * Structs:
+ * 
+ * - workspaceAPI: Implements the WorkspaceAPI interface. It provides HTTP handlers for workspace-related operations.
+ *   Fields:
+ *   - workspaceService: Instance of the WorkspaceService interface to interact with the workspace service.
+ *   Methods:
+ *   - NewWorkspaceAPI: Function to create a new instance of the workspaceAPI struct.
+ *     Parameters:
+ *     - workspaceService: Instance of the WorkspaceService interface.
+ *     Returns:
+ *     - *workspaceAPI: A new instance of the workspaceAPI struct.
+ *   - GetWorkspaces: HTTP handler for retrieving the workspaces the logged-in user is a member of, the default one first.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AddWorkspace: HTTP handler for creating a workspace owned by the logged-in user from the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - SelectWorkspace: HTTP handler for making the workspace in the path the one the logged-in user works in.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetMembers: HTTP handler for retrieving the profiles and roles of the members of the workspace in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - Invite: HTTP handler for inviting the email address in the JSON payload to the workspace in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - ChangeRole: HTTP handler for giving the member in the path the role in the JSON payload.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - RemoveMember: HTTP handler for removing the member in the path from the workspace in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - GetInvitations: HTTP handler for retrieving the pending invitations sent to the email address of the logged-in user.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - AcceptInvitation: HTTP handler for accepting the invitation in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ *   - DeclineInvitation: HTTP handler for declining the invitation in the path.
+ *     Parameters:
+ *     - c: Context object representing the HTTP request.
+ * 
+ * Functions:
+ * 
+ * - respondInvitation: Function to accept or decline the invitation in the path.
+ * - workspaceErrorStatus: Function to pick the HTTP status code for a workspace service error.
+ *   Unknown workspaces, members and invitations are reported as 404, roles that do not allow the action as 403,
+ *   invalid names and roles as 400 and inviting a member as 409.
+ */
+
+package api
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceAPI interface {
+	GetWorkspaces(c *gin.Context)
+	AddWorkspace(c *gin.Context)
+	SelectWorkspace(c *gin.Context)
+	GetMembers(c *gin.Context)
+	Invite(c *gin.Context)
+	ChangeRole(c *gin.Context)
+	RemoveMember(c *gin.Context)
+	GetInvitations(c *gin.Context)
+	AcceptInvitation(c *gin.Context)
+	DeclineInvitation(c *gin.Context)
+}
+
+type workspaceAPI struct {
+	workspaceService service.WorkspaceService
+}
+
+func NewWorkspaceAPI(workspaceService service.WorkspaceService) *workspaceAPI {
+	return &workspaceAPI{workspaceService}
+}
+
+func (w *workspaceAPI) GetWorkspaces(c *gin.Context) {
+	workspaces, err := w.workspaceService.GetList(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspaces)
+}
+
+func (w *workspaceAPI) AddWorkspace(c *gin.Context) {
+	var request model.WorkspaceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	workspace, err := w.workspaceService.Create(request.Name, c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+func (w *workspaceAPI) SelectWorkspace(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid workspace ID"})
+		return
+	}
+
+	if err := w.workspaceService.Select(c.GetInt("user_id"), workspaceID); err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "select workspace success"})
+}
+
+func (w *workspaceAPI) GetMembers(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid workspace ID"})
+		return
+	}
+
+	members, err := w.workspaceService.GetMembers(workspaceID, c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (w *workspaceAPI) Invite(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid workspace ID"})
+		return
+	}
+
+	var request model.InvitationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	invitation, err := w.workspaceService.Invite(workspaceID, c.GetInt("user_id"), request.Email, request.Role)
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+func (w *workspaceAPI) ChangeRole(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid workspace ID"})
+		return
+	}
+
+	userID, err := strconv.Atoi(c.Param("user"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid user ID"})
+		return
+	}
+
+	var request model.RoleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := w.workspaceService.ChangeRole(workspaceID, c.GetInt("user_id"), userID, request.Role); err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "change role success"})
+}
+
+func (w *workspaceAPI) RemoveMember(c *gin.Context) {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid workspace ID"})
+		return
+	}
+
+	userID, err := strconv.Atoi(c.Param("user"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid user ID"})
+		return
+	}
+
+	if err := w.workspaceService.RemoveMember(workspaceID, c.GetInt("user_id"), userID); err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "remove member success"})
+}
+
+func (w *workspaceAPI) GetInvitations(c *gin.Context) {
+	invitations, err := w.workspaceService.GetInvitations(c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (w *workspaceAPI) AcceptInvitation(c *gin.Context) {
+	w.respondInvitation(c, true)
+}
+
+func (w *workspaceAPI) DeclineInvitation(c *gin.Context) {
+	w.respondInvitation(c, false)
+}
+
+func (w *workspaceAPI) respondInvitation(c *gin.Context, accept bool) {
+	invitationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Error: "invalid invitation ID"})
+		return
+	}
+
+	invitation, err := w.workspaceService.RespondInvitation(invitationID, c.GetInt("user_id"), accept)
+	if err != nil {
+		c.JSON(workspaceErrorStatus(err), model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+func workspaceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrWorkspaceNotFound),
+		errors.Is(err, model.ErrMemberNotFound),
+		errors.Is(err, model.ErrInvitationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrInvalidWorkspace),
+		errors.Is(err, model.ErrInvalidWorkspaceRole),
+		errors.Is(err, model.ErrWorkspaceOwner):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrAlreadyMember):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
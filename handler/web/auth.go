@@ -10,6 +10,8 @@
  *   - Register: HTTP handler for rendering the registration page.
  *   - RegisterProcess: HTTP handler for processing user registration.
  *   - Logout: HTTP handler for user logout.
+ *   - Restore: HTTP handler for rendering the account restore page.
+ *   - RestoreProcess: HTTP handler for processing account restore requests.
  * 
  * Structs:
  * 
@@ -53,6 +55,16 @@
  *   - Method: GET
  *   - Handler: Logout
  *   - Description: Handles user logout.
+ * 
+ * - /client/restore: 
+ *   - Method: GET
+ *   - Handler: Restore
+ *   - Description: Renders the account restore page.
+ * 
+ * - /client/restore/process: 
+ *   - Method: POST
+ *   - Handler: RestoreProcess
+ *   - Description: Cancels a pending account deletion and redirects to the login page.
  */
 
 package web
@@ -74,6 +86,8 @@ type AuthWeb interface {
 	Register(c *gin.Context)
 	RegisterProcess(c *gin.Context)
 	Logout(c *gin.Context)
+	Restore(c *gin.Context)
+	RestoreProcess(c *gin.Context)
 }
 
 type authWeb struct {
@@ -171,3 +185,36 @@ func (a *authWeb) Logout(c *gin.Context) {
 	c.SetCookie("session_token", "", -1, "/", "", false, false)
 	c.Redirect(http.StatusSeeOther, "/client/dashboard")
 }
+
+func (a *authWeb) Restore(c *gin.Context) {
+	var header = path.Join("views", "general", "header.html")
+	var filepath = path.Join("views", "auth", "restore.html")
+
+	var tmpl, err = template.ParseFS(a.embed, filepath, header)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	err = tmpl.Execute(c.Writer, nil)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+	}
+}
+
+func (a *authWeb) RestoreProcess(c *gin.Context) {
+	email := c.Request.FormValue("email")
+	password := c.Request.FormValue("password")
+
+	status, err := a.userClient.RestoreAccount(email, password)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	if status == 200 {
+		c.Redirect(http.StatusSeeOther, "/client/login")
+	} else {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Restore Account Failed!")
+	}
+}
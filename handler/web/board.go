@@ -0,0 +1,178 @@
+/** 
+ * Package web provides HTTP handlers for the Kanban board of the web client.
+ * 
+ * Interfaces:
+ * 
+ * - BoardWeb: Interface defining methods for handling the Kanban board.
+ *   Methods:
+ *   - BoardPage: HTTP handler for rendering the board page.
+ *   - BoardMoveProcess: HTTP handler for processing tasks dropped on the board.
+ * 
+ * Structs:
+ * 
+ * - boardWeb: Implements the BoardWeb interface. It provides HTTP handlers for the Kanban board.
+ *   Fields:
+ *   - taskClient: Instance of the TaskClient interface for loading the board and moving tasks.
+ *   - userClient: Instance of the UserClient interface for loading the time zone deadlines are rendered in.
+ *   - workspaceClient: Instance of the WorkspaceClient interface for listing the workspaces in the selector.
+ *   - notificationClient: Instance of the NotificationClient interface for the notifications dropdown of the page header.
+ *   - sessionService: Instance of the SessionService interface for managing user sessions.
+ *   - embed: Embed.FS for embedding static files.
+ *   Methods:
+ *   - NewBoardWeb: Function to create a new instance of the boardWeb struct.
+ *     Parameters:
+ *     - taskClient: Instance of the TaskClient interface.
+ *     - userClient: Instance of the UserClient interface.
+ *     - workspaceClient: Instance of the WorkspaceClient interface.
+ *     - notificationClient: Instance of the NotificationClient interface.
+ *     - sessionService: Instance of the SessionService interface.
+ *     - embed: Embed.FS for embedding static files.
+ *     Returns:
+ *     - *boardWeb: A new instance of the boardWeb struct.
+ * 
+ * Functions:
+ * 
+ * - BoardPage: HTTP handler function for rendering the board page.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session and renders the tasks of the selected workspace as one column
+ *     per status, in the order of the user's workflow and with the tasks of each column in their manual order.
+ *     Cards can be dragged within a column or onto another column.
+ * 
+ * - BoardMoveProcess: HTTP handler function for processing tasks dropped on the board.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function moves the task in the form data to the status in the form data, right after the task
+ *     named by after_id or at the top of the column when it is empty, and redirects back to the board on success,
+ *     otherwise to a modal page with the reason the move was refused.
+ */
+
+package web
+
+import (
+	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"embed"
+	"net/http"
+	"path"
+	"strconv"
+	"text/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoardWeb interface {
+	BoardPage(c *gin.Context)
+	BoardMoveProcess(c *gin.Context)
+}
+
+type boardWeb struct {
+	taskClient         client.TaskClient
+	userClient         client.UserClient
+	workspaceClient    client.WorkspaceClient
+	notificationClient client.NotificationClient
+	sessionService     service.SessionService
+	embed              embed.FS
+}
+
+func NewBoardWeb(taskClient client.TaskClient, userClient client.UserClient, workspaceClient client.WorkspaceClient, notificationClient client.NotificationClient, sessionService service.SessionService, embed embed.FS) *boardWeb {
+	return &boardWeb{taskClient, userClient, workspaceClient, notificationClient, sessionService, embed}
+}
+
+func (b *boardWeb) BoardPage(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := b.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	board, err := b.taskClient.Board(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	workspaces, err := b.workspaceClient.WorkspaceList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	menu, err := loadNotificationMenu(b.notificationClient, session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	var dataTemplate = map[string]interface{}{
+		"email":         email,
+		"workspaces":    workspaces,
+		"notifications": menu,
+		"board":         board,
+	}
+
+	var header = path.Join("views", "general", "header.html")
+	var workspace = path.Join("views", "general", "workspace.html")
+	var notifications = path.Join("views", "general", "notifications.html")
+	var filepath = path.Join("views", "main", "board.html")
+
+	t, err := template.New("board.html").Funcs(taskFuncs(userLocation(b.userClient, session.Token))).ParseFS(b.embed, filepath, header, workspace, notifications)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	err = t.Execute(c.Writer, dataTemplate)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+	}
+}
+
+func (b *boardWeb) BoardMoveProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := b.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	var afterID int
+	if value := c.Request.FormValue("after_id"); value != "" {
+		afterID, err = strconv.Atoi(value)
+		if err != nil {
+			c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+			return
+		}
+	}
+
+	_, err = b.taskClient.MoveTask(session.Token, id, model.MoveRequest{
+		Status:  model.TaskStatus(c.Request.FormValue("status")),
+		AfterID: afterID,
+	})
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/board")
+}
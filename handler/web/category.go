@@ -12,12 +12,16 @@
  * - categoryWeb: Implements the CategoryWeb interface. It provides HTTP handlers for web-based category management.
  *   Fields:
  *   - categoryClient: Instance of the CategoryClient interface for communicating with the category service.
+ *   - workspaceClient: Instance of the WorkspaceClient interface for listing the workspaces in the selector.
+ *   - notificationClient: Instance of the NotificationClient interface for the notifications dropdown of the page header.
  *   - sessionService: Instance of the SessionService interface for managing user sessions.
  *   - embed: Embed.FS for embedding static files.
  *   Methods:
  *   - NewCategoryWeb: Function to create a new instance of the categoryWeb struct.
  *     Parameters:
  *     - categoryClient: Instance of the CategoryClient interface.
+ *     - workspaceClient: Instance of the WorkspaceClient interface.
+ *     - notificationClient: Instance of the NotificationClient interface.
  *     - sessionService: Instance of the SessionService interface.
  *     - embed: Embed.FS for embedding static files.
  *     Returns:
@@ -50,13 +54,15 @@ type CategoryWeb interface {
 }
 
 type categoryWeb struct {
-	categoryClient client.CategoryClient
-	sessionService service.SessionService
-	embed          embed.FS
+	categoryClient     client.CategoryClient
+	workspaceClient    client.WorkspaceClient
+	notificationClient client.NotificationClient
+	sessionService     service.SessionService
+	embed              embed.FS
 }
 
-func NewCategoryWeb(categoryClient client.CategoryClient, sessionService service.SessionService, embed embed.FS) *categoryWeb {
-	return &categoryWeb{categoryClient, sessionService, embed}
+func NewCategoryWeb(categoryClient client.CategoryClient, workspaceClient client.WorkspaceClient, notificationClient client.NotificationClient, sessionService service.SessionService, embed embed.FS) *categoryWeb {
+	return &categoryWeb{categoryClient, workspaceClient, notificationClient, sessionService, embed}
 }
 
 func (c *categoryWeb) Category(ctx *gin.Context) {
@@ -79,9 +85,23 @@ func (c *categoryWeb) Category(ctx *gin.Context) {
 		return
 	}
 
+	workspaces, err := c.workspaceClient.WorkspaceList(session.Token)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	menu, err := loadNotificationMenu(c.notificationClient, session.Token)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
+		return
+	}
+
 	var dataTemplate = map[string]interface{}{
-		"email":      email,
-		"categories": categories,
+		"email":         email,
+		"workspaces":    workspaces,
+		"notifications": menu,
+		"categories":    categories,
 	}
 
 	var funcMap = template.FuncMap{
@@ -91,9 +111,11 @@ func (c *categoryWeb) Category(ctx *gin.Context) {
 	}
 
 	var header = path.Join("views", "general", "header.html")
+	var workspace = path.Join("views", "general", "workspace.html")
+	var notifications = path.Join("views", "general", "notifications.html")
 	var filepath = path.Join("views", "main", "category.html")
 
-	t, err := template.New("category.html").Funcs(funcMap).ParseFS(c.embed, filepath, header)
+	t, err := template.New("category.html").Funcs(funcMap).ParseFS(c.embed, filepath, header, workspace, notifications)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, model.ErrorResponse{Error: err.Error()})
 		return
@@ -12,12 +12,18 @@
  * - dashboardWeb: Implements the DashboardWeb interface. It provides HTTP handlers for web-based dashboard functionalities.
  *   Fields:
  *   - userClient: Instance of the UserClient interface for communicating with the user service.
+ *   - taskClient: Instance of the TaskClient interface for retrieving the estimate roll-up.
+ *   - workspaceClient: Instance of the WorkspaceClient interface for listing the workspaces in the selector.
+ *   - notificationClient: Instance of the NotificationClient interface for the notifications dropdown of the page header.
  *   - sessionService: Instance of the SessionService interface for managing user sessions.
  *   - embed: Embed.FS for embedding static files.
  *   Methods:
  *   - NewDashboardWeb: Function to create a new instance of the dashboardWeb struct.
  *     Parameters:
  *     - userClient: Instance of the UserClient interface.
+ *     - taskClient: Instance of the TaskClient interface.
+ *     - workspaceClient: Instance of the WorkspaceClient interface.
+ *     - notificationClient: Instance of the NotificationClient interface.
  *     - sessionService: Instance of the SessionService interface.
  *     - embed: Embed.FS for embedding static files.
  *     Returns:
@@ -28,7 +34,7 @@
  * - Dashboard: HTTP handler function for rendering the dashboard page.
  *   Parameters:
  *   - c: Context provided by Gin framework.
- *   Description: This function retrieves the user's email from the context, fetches the user's session, and then fetches the user's task categories. It then renders the dashboard page using a template, passing the retrieved user task categories and user email as data.
+ *   Description: This function retrieves the user's email from the context, fetches the user's session, and then fetches the user's task categories and the estimate roll-up. It then renders the dashboard page using a template, passing the retrieved user task categories with their tags, the estimated and completed hours and story points per category and per user, and user email as data. Deadlines are rendered in the time zone stored on the user's profile.
  */
 package web
 
@@ -48,13 +54,16 @@ type DashboardWeb interface {
 }
 
 type dashboardWeb struct {
-	userClient     client.UserClient
-	sessionService service.SessionService
-	embed          embed.FS
+	userClient         client.UserClient
+	taskClient         client.TaskClient
+	workspaceClient    client.WorkspaceClient
+	notificationClient client.NotificationClient
+	sessionService     service.SessionService
+	embed              embed.FS
 }
 
-func NewDashboardWeb(userClient client.UserClient, sessionService service.SessionService, embed embed.FS) *dashboardWeb {
-	return &dashboardWeb{userClient, sessionService, embed}
+func NewDashboardWeb(userClient client.UserClient, taskClient client.TaskClient, workspaceClient client.WorkspaceClient, notificationClient client.NotificationClient, sessionService service.SessionService, embed embed.FS) *dashboardWeb {
+	return &dashboardWeb{userClient, taskClient, workspaceClient, notificationClient, sessionService, embed}
 }
 
 func (d *dashboardWeb) Dashboard(c *gin.Context) {
@@ -77,21 +86,40 @@ func (d *dashboardWeb) Dashboard(c *gin.Context) {
 		return
 	}
 
+	estimates, err := d.taskClient.EstimateRollup(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	workspaces, err := d.workspaceClient.WorkspaceList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	menu, err := loadNotificationMenu(d.notificationClient, session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
 	var dataTemplate = map[string]interface{}{
 		"email":                email,
+		"workspaces":           workspaces,
+		"notifications":        menu,
 		"user_task_categories": userTaskCategories,
+		"estimates":            estimates,
 	}
 
-	var funcMap = template.FuncMap{
-		"exampleFunc": func() int {
-			return 0
-		},
-	}
+	var funcMap = taskFuncs(userLocation(d.userClient, session.Token))
 
 	var header = path.Join("views", "general", "header.html")
+	var workspace = path.Join("views", "general", "workspace.html")
+	var notifications = path.Join("views", "general", "notifications.html")
 	var filepath = path.Join("views", "main", "dashboard.html")
 
-	t, err := template.New("dashboard.html").Funcs(funcMap).ParseFS(d.embed, filepath, header)
+	t, err := template.New("dashboard.html").Funcs(funcMap).ParseFS(d.embed, filepath, header, workspace, notifications)
 	if err != nil {
 		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
 		return
@@ -0,0 +1,135 @@
+/**
+ * Package web provides functionality for saving and deleting smart lists from the web client using the Gin web framework.
+ *
+ * Interfaces:
+ *
+ * - FilterWeb: Interface defining methods for handling smart list web functionalities.
+ *   Methods:
+ *   - AddProcess: Method for processing requests saving a smart list.
+ *   - DeleteProcess: Method for processing requests deleting a saved smart list.
+ *
+ * Structs:
+ *
+ * - filterWeb: Implements the FilterWeb interface and contains dependencies for handling smart list web functionalities.
+ *   Fields:
+ *   - filterClient: Instance of the FilterClient interface for communicating with the filter service.
+ *   - sessionService: Instance of the SessionService interface for managing user sessions.
+ *   Methods:
+ *   - NewFilterWeb: Function to create a new instance of the filterWeb struct.
+ *     Parameters:
+ *     - filterClient: Instance of the FilterClient interface.
+ *     - sessionService: Instance of the SessionService interface.
+ *     Returns:
+ *     - *filterWeb: A new instance of the filterWeb struct.
+ *
+ * Functions:
+ *
+ * - AddProcess: HTTP handler function for processing requests saving a smart list.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, builds a filter from the name, status, open, min_priority,
+ *     max_priority, deadline, category_id and title form values, empty ones left out, and saves it using the filter client.
+ *     It redirects to the task page showing the new list on success, otherwise to a modal page with the reason the filter
+ *     was refused.
+ *
+ * - DeleteProcess: HTTP handler function for processing requests deleting a saved smart list.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session and deletes the filter with the ID in the form data using the
+ *     filter client. It redirects to the task page on success, otherwise to a modal page with an error message.
+ */
+
+package web
+
+import (
+	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FilterWeb interface {
+	AddProcess(c *gin.Context)
+	DeleteProcess(c *gin.Context)
+}
+
+type filterWeb struct {
+	filterClient   client.FilterClient
+	sessionService service.SessionService
+}
+
+func NewFilterWeb(filterClient client.FilterClient, sessionService service.SessionService) *filterWeb {
+	return &filterWeb{filterClient, sessionService}
+}
+
+func (f *filterWeb) AddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := f.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	minPriority, _ := strconv.Atoi(c.Request.FormValue("min_priority"))
+	maxPriority, _ := strconv.Atoi(c.Request.FormValue("max_priority"))
+	categoryID, _ := strconv.Atoi(c.Request.FormValue("category_id"))
+
+	definition := model.FilterDefinition{
+		Name:        c.Request.FormValue("name"),
+		Open:        c.Request.FormValue("open") == "true",
+		MinPriority: minPriority,
+		MaxPriority: maxPriority,
+		Deadline:    model.DeadlineWindow(c.Request.FormValue("deadline")),
+		CategoryID:  categoryID,
+		Title:       c.Request.FormValue("title"),
+		Sort:        "deadline",
+	}
+	if status := c.Request.FormValue("status"); status != "" {
+		definition.Statuses = []model.TaskStatus{model.TaskStatus(status)}
+	}
+
+	filter, err := f.filterClient.AddFilter(session.Token, definition)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task?list="+filter.Ref())
+}
+
+func (f *filterWeb) DeleteProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := f.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid filter ID")
+		return
+	}
+
+	if _, err := f.filterClient.DeleteFilter(session.Token, id); err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task")
+}
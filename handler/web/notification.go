@@ -0,0 +1,173 @@
+/**
+ * Package web provides functionality for reading notifications and watching tasks from the web client using the Gin web framework.
+ *
+ * Interfaces:
+ *
+ * - NotificationWeb: Interface defining methods for handling notification web functionalities.
+ *   Methods:
+ *   - ReadProcess: Method for processing requests marking notifications as read.
+ *   - WatchProcess: Method for processing requests watching or unwatching a task or category.
+ *
+ * Structs:
+ *
+ * - notificationWeb: Implements the NotificationWeb interface and contains dependencies for handling notification web functionalities.
+ *   Fields:
+ *   - notificationClient: Instance of the NotificationClient interface for communicating with the notification service.
+ *   - sessionService: Instance of the SessionService interface for managing user sessions.
+ *   Methods:
+ *   - NewNotificationWeb: Function to create a new instance of the notificationWeb struct.
+ *     Parameters:
+ *     - notificationClient: Instance of the NotificationClient interface.
+ *     - sessionService: Instance of the SessionService interface.
+ *     Returns:
+ *     - *notificationWeb: A new instance of the notificationWeb struct.
+ *
+ * - notificationMenu: Struct holding what the notifications dropdown of the page header shows.
+ *   Fields:
+ *   - Unread: Number of unread notifications, shown on the bell.
+ *     Type: int
+ *   - Notifications: Latest notifications, newest first.
+ *     Type: []model.Notification
+ *
+ * Functions:
+ *
+ * - ReadProcess: HTTP handler function for processing requests marking notifications as read.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session and marks the notification in the form data as read, then opens the task
+ *     it is about. Without a notification ID it marks every notification as read and redirects back to the page the form was
+ *     submitted from. Errors redirect to a modal page with an error message.
+ *
+ * - WatchProcess: HTTP handler function for processing requests watching or unwatching a task or category.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, watches the task or category in the form data, or stops watching it when
+ *     watch is false, and redirects back to the page the form was submitted from, otherwise to a modal page with an error message.
+ *
+ * - loadNotificationMenu: Function to fetch the notifications dropdown of the page header, with the latest menuSize notifications.
+ */
+
+package web
+
+import (
+	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const menuSize = 10
+
+type NotificationWeb interface {
+	ReadProcess(c *gin.Context)
+	WatchProcess(c *gin.Context)
+}
+
+type notificationWeb struct {
+	notificationClient client.NotificationClient
+	sessionService     service.SessionService
+}
+
+type notificationMenu struct {
+	Unread        int
+	Notifications []model.Notification
+}
+
+func NewNotificationWeb(notificationClient client.NotificationClient, sessionService service.SessionService) *notificationWeb {
+	return &notificationWeb{notificationClient, sessionService}
+}
+
+func (n *notificationWeb) ReadProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := n.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	if c.Request.FormValue("id") == "" {
+		if _, err := n.notificationClient.MarkAllRead(session.Token); err != nil {
+			c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+			return
+		}
+
+		c.Redirect(http.StatusSeeOther, refererPath(c, "/client/dashboard"))
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid notification ID")
+		return
+	}
+
+	if _, err := n.notificationClient.MarkRead(session.Token, id); err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Request.FormValue("task_id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, refererPath(c, "/client/dashboard"))
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(taskID))
+}
+
+func (n *notificationWeb) WatchProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := n.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid ID")
+		return
+	}
+
+	target := model.WatchTarget(c.Request.FormValue("target"))
+	watch := c.Request.FormValue("watch") == "true"
+	if _, err := n.notificationClient.Watch(session.Token, target, id, watch); err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, refererPath(c, "/client/dashboard"))
+}
+
+func loadNotificationMenu(notificationClient client.NotificationClient, token string) (notificationMenu, error) {
+	notifications, err := notificationClient.Notifications(token)
+	if err != nil {
+		return notificationMenu{}, err
+	}
+
+	menu := notificationMenu{Notifications: notifications}
+	for _, notification := range notifications {
+		if notification.ReadAt == nil {
+			menu.Unread++
+		}
+	}
+	if len(menu.Notifications) > menuSize {
+		menu.Notifications = menu.Notifications[:menuSize]
+	}
+	return menu, nil
+}
@@ -0,0 +1,273 @@
+/** 
+ * Package web provides HTTP handlers for the project pages of the web client.
+ * 
+ * Interfaces:
+ * 
+ * - ProjectWeb: Interface defining methods for handling web-based project management.
+ *   Methods:
+ *   - ProjectPage: HTTP handler for rendering the project page.
+ *   - ProjectAddProcess: HTTP handler for processing project creation requests.
+ *   - ProjectCategoryAddProcess: HTTP handler for processing requests grouping a category under a project.
+ *   - ProjectMilestoneAddProcess: HTTP handler for processing milestone creation requests.
+ * 
+ * Structs:
+ * 
+ * - projectWeb: Implements the ProjectWeb interface. It provides HTTP handlers for web-based project management.
+ *   Fields:
+ *   - projectClient: Instance of the ProjectClient interface for communicating with the project service.
+ *   - categoryClient: Instance of the CategoryClient interface for listing the categories that can be added to a project.
+ *   - userClient: Instance of the UserClient interface for loading the time zone due dates are rendered and read in.
+ *   - workspaceClient: Instance of the WorkspaceClient interface for listing the workspaces in the selector.
+ *   - notificationClient: Instance of the NotificationClient interface for the notifications dropdown of the page header.
+ *   - sessionService: Instance of the SessionService interface for managing user sessions.
+ *   - embed: Embed.FS for embedding static files.
+ *   Methods:
+ *   - NewProjectWeb: Function to create a new instance of the projectWeb struct.
+ *     Parameters:
+ *     - projectClient: Instance of the ProjectClient interface.
+ *     - categoryClient: Instance of the CategoryClient interface.
+ *     - userClient: Instance of the UserClient interface.
+ *     - workspaceClient: Instance of the WorkspaceClient interface.
+ *     - notificationClient: Instance of the NotificationClient interface.
+ *     - sessionService: Instance of the SessionService interface.
+ *     - embed: Embed.FS for embedding static files.
+ *     Returns:
+ *     - *projectWeb: A new instance of the projectWeb struct.
+ * 
+ * Functions:
+ * 
+ * - ProjectPage: HTTP handler function for rendering the project page.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session and lists the projects of the selected workspace.
+ *     When the id query parameter names one of them, the overview of that project is rendered as well,
+ *     with the progress of its categories and milestones and the overdue milestones highlighted.
+ * 
+ * - ProjectAddProcess: HTTP handler function for processing project creation requests.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function creates a project with the name and description in the form data
+ *     and redirects to the project page on success, otherwise to a modal page with an error message.
+ * 
+ * - ProjectCategoryAddProcess: HTTP handler function for processing requests grouping a category under a project.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function adds the category in the form data to the project in the form data
+ *     and redirects to the overview of the project on success, otherwise to a modal page with an error message.
+ * 
+ * - ProjectMilestoneAddProcess: HTTP handler function for processing milestone creation requests.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function adds a milestone with the title and due date in the form data to the project in the form data,
+ *     reading date-times without an offset in the user's time zone, and redirects to the overview of the project on success,
+ *     otherwise to a modal page with an error message.
+ */
+
+package web
+
+import (
+	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"embed"
+	"net/http"
+	"path"
+	"strconv"
+	"text/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectWeb interface {
+	ProjectPage(c *gin.Context)
+	ProjectAddProcess(c *gin.Context)
+	ProjectCategoryAddProcess(c *gin.Context)
+	ProjectMilestoneAddProcess(c *gin.Context)
+}
+
+type projectWeb struct {
+	projectClient      client.ProjectClient
+	categoryClient     client.CategoryClient
+	userClient         client.UserClient
+	workspaceClient    client.WorkspaceClient
+	notificationClient client.NotificationClient
+	sessionService     service.SessionService
+	embed              embed.FS
+}
+
+func NewProjectWeb(projectClient client.ProjectClient, categoryClient client.CategoryClient, userClient client.UserClient, workspaceClient client.WorkspaceClient, notificationClient client.NotificationClient, sessionService service.SessionService, embed embed.FS) *projectWeb {
+	return &projectWeb{projectClient, categoryClient, userClient, workspaceClient, notificationClient, sessionService, embed}
+}
+
+func (p *projectWeb) ProjectPage(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := p.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	projects, err := p.projectClient.ProjectList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	workspaces, err := p.workspaceClient.WorkspaceList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	menu, err := loadNotificationMenu(p.notificationClient, session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	var dataTemplate = map[string]interface{}{
+		"email":         email,
+		"workspaces":    workspaces,
+		"notifications": menu,
+		"projects":      projects,
+	}
+
+	if id, err := strconv.Atoi(c.Query("id")); err == nil {
+		overview, err := p.projectClient.Overview(session.Token, id)
+		if err != nil {
+			c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+			return
+		}
+
+		categories, err := p.categoryClient.CategoryList(session.Token)
+		if err != nil {
+			c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+			return
+		}
+
+		var available []*model.Category
+		for _, category := range categories {
+			if category.ProjectID != id {
+				available = append(available, category)
+			}
+		}
+
+		dataTemplate["overview"] = overview
+		dataTemplate["categories"] = available
+	}
+
+	var header = path.Join("views", "general", "header.html")
+	var workspace = path.Join("views", "general", "workspace.html")
+	var notifications = path.Join("views", "general", "notifications.html")
+	var filepath = path.Join("views", "main", "project.html")
+
+	t, err := template.New("project.html").Funcs(taskFuncs(userLocation(p.userClient, session.Token))).ParseFS(p.embed, filepath, header, workspace, notifications)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	err = t.Execute(c.Writer, dataTemplate)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+	}
+}
+
+func (p *projectWeb) ProjectAddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := p.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	_, err = p.projectClient.AddProject(session.Token, c.Request.FormValue("name"), c.Request.FormValue("description"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/project")
+}
+
+func (p *projectWeb) ProjectCategoryAddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := p.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("project_id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid project ID")
+		return
+	}
+
+	categoryID, err := strconv.Atoi(c.Request.FormValue("category_id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid category ID")
+		return
+	}
+
+	_, err = p.projectClient.AddCategory(session.Token, id, categoryID)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/project?id="+strconv.Itoa(id))
+}
+
+func (p *projectWeb) ProjectMilestoneAddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := p.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("project_id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid project ID")
+		return
+	}
+
+	dueDate, err := model.ParseDeadline(c.Request.FormValue("due_date"), userLocation(p.userClient, session.Token))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	_, err = p.projectClient.AddMilestone(session.Token, id, c.Request.FormValue("title"), dueDate)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/project?id="+strconv.Itoa(id))
+}
@@ -0,0 +1,192 @@
+/** 
+ * Package web provides functionality for serving web pages related to account settings using the Gin web framework.
+ * 
+ * Interfaces:
+ * 
+ * - SettingsWeb: Interface defining methods for handling account settings web functionalities.
+ *   Methods:
+ *   - Settings: Method for rendering the settings page.
+ *   - ProfileProcess: Method for processing profile update requests.
+ *   - DeleteAccountProcess: Method for processing account deletion requests.
+ * 
+ * Structs:
+ * 
+ * - settingsWeb: Implements the SettingsWeb interface and contains dependencies for handling account settings web functionalities.
+ *   Fields:
+ *   - userClient: Instance of the UserClient interface for communicating with the user service.
+ *   - workspaceClient: Instance of the WorkspaceClient interface for listing the workspaces in the selector.
+ *   - notificationClient: Instance of the NotificationClient interface for the notifications dropdown of the page header.
+ *   - sessionService: Instance of the SessionService interface for managing user sessions.
+ *   - embed: Embed.FS for embedding static files.
+ *   Methods:
+ *   - NewSettingsWeb: Function to create a new instance of the settingsWeb struct.
+ *     Parameters:
+ *     - userClient: Instance of the UserClient interface.
+ *     - workspaceClient: Instance of the WorkspaceClient interface.
+ *     - notificationClient: Instance of the NotificationClient interface.
+ *     - sessionService: Instance of the SessionService interface.
+ *     - embed: Embed.FS for embedding static files.
+ *     Returns:
+ *     - *settingsWeb: A new instance of the settingsWeb struct.
+ * 
+ * Functions:
+ * 
+ * - Settings: HTTP handler function for rendering the settings page.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's email from the context, fetches the user's profile, and renders the settings page using a template. 
+ *     If an error occurs during template execution, it redirects the user to a modal page with the error message.
+ * 
+ * - ProfileProcess: HTTP handler function for processing profile update requests.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the time zone from the form data and updates the profile using the user client. 
+ *     It redirects back to the settings page on success, otherwise to a modal page with an error message.
+ * 
+ * - DeleteAccountProcess: HTTP handler function for processing account deletion requests.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, asks the user service to schedule the account for deletion, 
+ *     clears the session cookie and redirects to a modal page telling the user the account can still be restored during the grace period.
+ */
+
+package web
+
+import (
+	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/service"
+	"embed"
+	"net/http"
+	"path"
+	"text/template"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettingsWeb interface {
+	Settings(c *gin.Context)
+	ProfileProcess(c *gin.Context)
+	DeleteAccountProcess(c *gin.Context)
+}
+
+type settingsWeb struct {
+	userClient         client.UserClient
+	workspaceClient    client.WorkspaceClient
+	notificationClient client.NotificationClient
+	sessionService     service.SessionService
+	embed              embed.FS
+}
+
+func NewSettingsWeb(userClient client.UserClient, workspaceClient client.WorkspaceClient, notificationClient client.NotificationClient, sessionService service.SessionService, embed embed.FS) *settingsWeb {
+	return &settingsWeb{userClient, workspaceClient, notificationClient, sessionService, embed}
+}
+
+func (s *settingsWeb) Settings(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := s.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	profile, err := s.userClient.GetProfile(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	workspaces, err := s.workspaceClient.WorkspaceList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	menu, err := loadNotificationMenu(s.notificationClient, session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	invitations, err := s.workspaceClient.Invitations(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	var dataTemplate = map[string]interface{}{
+		"email":         email,
+		"workspaces":    workspaces,
+		"notifications": menu,
+		"profile":       profile,
+		"invitations":   invitations,
+	}
+
+	var header = path.Join("views", "general", "header.html")
+	var workspace = path.Join("views", "general", "workspace.html")
+	var notifications = path.Join("views", "general", "notifications.html")
+	var filepath = path.Join("views", "main", "settings.html")
+
+	t, err := template.New("settings.html").ParseFS(s.embed, filepath, header, workspace, notifications)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	err = t.Execute(c.Writer, dataTemplate)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+	}
+}
+
+func (s *settingsWeb) ProfileProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := s.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	_, err = s.userClient.UpdateProfile(session.Token, c.Request.FormValue("fullname"), c.Request.FormValue("time_zone"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Update Profile Failed!")
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/settings")
+}
+
+func (s *settingsWeb) DeleteAccountProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := s.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	_, err = s.userClient.DeleteAccount(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Delete Account Failed!")
+		return
+	}
+
+	c.SetCookie("session_token", "", -1, "/", "", false, false)
+	c.Redirect(http.StatusSeeOther, "/client/modal?status=success&message=Your account is scheduled for deletion. You can restore it from the login page until the grace period ends.")
+}
@@ -7,18 +7,37 @@
  *   Methods:
  *   - TaskPage: Method for rendering the task page.
  *   - TaskAddProcess: Method for processing task addition requests.
+ *   - TaskTransitionProcess: Method for processing task status changes.
+ *   - TaskChecklistAddProcess: Method for processing checklist item additions.
+ *   - TaskChecklistToggleProcess: Method for processing checklist item toggles.
+ *   - TaskDetailPage: Method for rendering the detail page of a task with its discussion thread.
+ *   - TaskCommentAddProcess: Method for processing new comments.
+ *   - TaskAttachmentUploadProcess: Method for processing file uploads.
+ *   - TaskBlockerAddProcess: Method for processing new dependencies.
+ *   - TaskTimerProcess: Method for starting and stopping timers.
+ *   - TaskAssignProcess: Method for processing new assignees.
+ *   - TaskFieldsProcess: Method for processing custom field values.
+ *   - TaskArchiveProcess: Method for archiving and unarchiving completed tasks.
  * 
  * Structs:
  * 
  * - taskWeb: Implements the TaskWeb interface and contains dependencies for handling task-related web functionalities.
  *   Fields:
  *   - taskClient: Instance of the TaskClient interface for communicating with the task service.
+ *   - userClient: Instance of the UserClient interface for loading the user's time zone.
+ *   - workspaceClient: Instance of the WorkspaceClient interface for listing the workspaces in the selector.
+ *   - notificationClient: Instance of the NotificationClient interface for the notifications dropdown of the page header.
+ *   - filterClient: Instance of the FilterClient interface for the smart lists of the sidebar.
  *   - sessionService: Instance of the SessionService interface for managing user sessions.
  *   - embed: Embed.FS for embedding static files.
  *   Methods:
  *   - NewTaskWeb: Function to create a new instance of the taskWeb struct.
  *     Parameters:
  *     - taskClient: Instance of the TaskClient interface.
+ *     - userClient: Instance of the UserClient interface.
+ *     - workspaceClient: Instance of the WorkspaceClient interface.
+ *     - notificationClient: Instance of the NotificationClient interface.
+ *     - filterClient: Instance of the FilterClient interface.
  *     - sessionService: Instance of the SessionService interface.
  *     - embed: Embed.FS for embedding static files.
  *     Returns:
@@ -30,7 +49,15 @@
  *   Parameters:
  *   - c: Context provided by Gin framework.
  *   Description: This function retrieves the user's email from the context, fetches the user's session, 
- *     retrieves the user's tasks, and renders the task page using a template, passing the retrieved tasks and user email as data. 
+ *     retrieves the user's tasks, workflow, tags and the custom fields of the workspace, and renders the task page using a template, passing the retrieved tasks 
+ *     arranged as a hierarchy with their progress, the statuses each task can move to, the tags of each task, the tasks assigned 
+ *     to the user and user email as data. 
+ *     The tag query parameter only keeps the tasks carrying that tag. The q query parameter, filled by the search box, only keeps
+ *     the tasks matching it as a search query; a query that cannot be parsed is shown again with its error and a caret under the
+ *     offending token, and no tasks.
+ *     The smart lists of the user, built-in and saved, are listed in a sidebar. The list query parameter, the key or ID of one
+ *     of them, shows the tasks of that list instead.
+ *     Deadlines are rendered in the time zone stored on the user's profile. 
  *     If an error occurs during template execution, it redirects the user to a modal page with the error message.
  * 
  * - TaskAddProcess: HTTP handler function for processing task addition requests.
@@ -38,8 +65,92 @@
  *   - c: Context provided by Gin framework.
  *   Description: This function retrieves the user's email from the context, fetches the user's session, 
  *     parses form data to create a new task, and adds the task using the task client. 
+ *     Custom field values are read from the field_<id> inputs of the custom fields of the workspace. 
+ *     Deadlines without an offset are interpreted in the time zone stored on the user's profile. 
  *     It then redirects the user to the login page if the task addition is successful, 
  *     otherwise redirects to a modal page with an error message.
+ * 
+ * - TaskTransitionProcess: HTTP handler function for processing task status changes.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the new status from the form data, 
+ *     and moves the task to that status using the task client. It redirects back to the task page on success, 
+ *     otherwise to a modal page with the reason the change was refused.
+ * 
+ * - TaskChecklistAddProcess: HTTP handler function for processing checklist item additions.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the item title from the form data, 
+ *     and adds the item to the task's checklist using the task client. It redirects back to the task page on success, 
+ *     otherwise to a modal page with an error message.
+ * 
+ * - TaskChecklistToggleProcess: HTTP handler function for processing checklist item toggles.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the item ID from the form data, 
+ *     and checks or unchecks the item using the task client. It redirects back to the task page on success, 
+ *     otherwise to a modal page with an error message.
+ * 
+ * - TaskDetailPage: HTTP handler function for rendering the detail page of a task.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, fetches the task in the path, its assignees, its dependencies, its attachments, 
+ *     the time spent on it, the user's running timer, the custom fields of the workspace, its activity history, whether the user watches it and the page of its comments selected by the page query parameter, and renders the task detail page.
+ *     The history is shown as a timeline, newest event first. Comment bodies are rendered from the HTML 
+ *     produced by the API, which escapes any raw HTML of the Markdown source. 
+ *     If an error occurs, it redirects the user to a modal page with the error message.
+ * 
+ * - TaskCommentAddProcess: HTTP handler function for processing new comments.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the Markdown body from the form data, 
+ *     and comments on the task using the task client. It redirects back to the task detail page on success, 
+ *     otherwise to a modal page with an error message.
+ * 
+ * - TaskAttachmentUploadProcess: HTTP handler function for processing file uploads.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, reads the task ID and the file from the multipart form data, 
+ *     and attaches the file to the task using the task client. It redirects back to the task detail page on success, 
+ *     otherwise to a modal page with the reason the upload was refused.
+ * 
+ * - TaskBlockerAddProcess: HTTP handler function for processing new dependencies.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the ID of the blocking task from the form 
+ *     data, and makes the task wait for the blocker using the task client. It redirects back to the task detail page on success, 
+ *     otherwise to a modal page with the reason the dependency was refused.
+ * 
+ * - TaskTimerProcess: HTTP handler function for starting and stopping timers.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the action ("start" or "stop") from the form 
+ *     data, and starts a timer on the task or stops the user's running timer using the task client. It redirects back to the task 
+ *     detail page on success, otherwise to a modal page with the reason the timer was refused.
+ * 
+ * - TaskAssignProcess: HTTP handler function for processing new assignees.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the email address of the user to assign from 
+ *     the form data, and assigns the user to the task using the task client. It redirects back to the task detail page on success, 
+ *     otherwise to a modal page with the reason the assignment was refused.
+ * 
+ * - TaskFieldsProcess: HTTP handler function for processing custom field values.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the field_<id> inputs of every custom field 
+ *     of the workspace from the form data, and sets them on the task using the task client, so emptied inputs and unchecked 
+ *     checkboxes clear their field. It redirects back to the task detail page on success, 
+ *     otherwise to a modal page with the reason the values were refused.
+ * 
+ * - TaskArchiveProcess: HTTP handler function for archiving and unarchiving completed tasks.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, parses the task ID and the archived flag from the form data, 
+ *     and archives or unarchives the task using the task client. It redirects back to the task detail page on success, 
+ *     otherwise to a modal page with the reason the change was refused.
+ * 
+ * - formFields: Function to collect the field_<id> form values of the given custom fields, keyed by field ID.
  */
 
 package web
@@ -49,9 +160,11 @@ import (
 	"a21hc3NpZ25tZW50/model"
 	"a21hc3NpZ25tZW50/service"
 	"embed"
+	"errors"
 	"net/http"
 	"path"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/gin-gonic/gin"
@@ -60,16 +173,31 @@ import (
 type TaskWeb interface {
 	TaskPage(c *gin.Context)
 	TaskAddProcess(c *gin.Context)
+	TaskTransitionProcess(c *gin.Context)
+	TaskChecklistAddProcess(c *gin.Context)
+	TaskChecklistToggleProcess(c *gin.Context)
+	TaskDetailPage(c *gin.Context)
+	TaskCommentAddProcess(c *gin.Context)
+	TaskAttachmentUploadProcess(c *gin.Context)
+	TaskBlockerAddProcess(c *gin.Context)
+	TaskTimerProcess(c *gin.Context)
+	TaskAssignProcess(c *gin.Context)
+	TaskFieldsProcess(c *gin.Context)
+	TaskArchiveProcess(c *gin.Context)
 }
 
 type taskWeb struct {
-	taskClient     client.TaskClient
-	sessionService service.SessionService
-	embed          embed.FS
+	taskClient         client.TaskClient
+	userClient         client.UserClient
+	workspaceClient    client.WorkspaceClient
+	notificationClient client.NotificationClient
+	filterClient       client.FilterClient
+	sessionService     service.SessionService
+	embed              embed.FS
 }
 
-func NewTaskWeb(taskClient client.TaskClient, sessionService service.SessionService, embed embed.FS) *taskWeb {
-	return &taskWeb{taskClient, sessionService, embed}
+func NewTaskWeb(taskClient client.TaskClient, userClient client.UserClient, workspaceClient client.WorkspaceClient, notificationClient client.NotificationClient, filterClient client.FilterClient, sessionService service.SessionService, embed embed.FS) *taskWeb {
+	return &taskWeb{taskClient, userClient, workspaceClient, notificationClient, filterClient, sessionService, embed}
 }
 
 func (t *taskWeb) TaskPage(c *gin.Context) {
@@ -86,27 +214,142 @@ func (t *taskWeb) TaskPage(c *gin.Context) {
 		return
 	}
 
-	tasks, err := t.taskClient.TaskList(session.Token)
+	search := c.Query("q")
+	var searchErr *model.ParseError
+	var tasks []*model.Task
+	if search == "" {
+		tasks, err = t.taskClient.TaskList(session.Token)
+	} else if _, err = model.ParseSearch(search); !errors.As(err, &searchErr) {
+		tasks, err = t.taskClient.SearchTasks(session.Token, search)
+	}
+	if err != nil && searchErr == nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	var list *model.SmartList
+	if ref := c.Query("list"); ref != "" {
+		list, err = t.filterClient.FilterTasks(session.Token, ref)
+		if err != nil {
+			c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+			return
+		}
+
+		tasks = make([]*model.Task, len(list.Tasks))
+		for i := range list.Tasks {
+			tasks[i] = &list.Tasks[i]
+		}
+	}
+
+	lists, err := t.filterClient.Filters(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	caret := ""
+	if searchErr != nil {
+		caret = strings.Repeat(" ", searchErr.Column-1) + "^"
+	}
+
+	text := c.Query("text")
+	var hits []model.SearchHit
+	var textErr error
+	if text != "" {
+		if _, textErr = model.ParseTextSearch(text); textErr == nil {
+			hits, err = t.taskClient.SearchText(session.Token, text)
+			if err != nil {
+				c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+				return
+			}
+		}
+	}
+
+	statusList, err := t.taskClient.StatusList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	tagList, err := t.taskClient.TagList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+	tagsByTask := tagList.ByTask()
+
+	assigned, err := t.taskClient.AssignedTasks(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	if tagID, err := strconv.Atoi(c.Query("tag")); err == nil {
+		var tagged []*model.Task
+		for _, task := range tasks {
+			for _, tag := range tagsByTask[task.ID] {
+				if tag.ID == tagID {
+					tagged = append(tagged, task)
+				}
+			}
+		}
+		tasks = tagged
+	}
+
+	fields, err := t.taskClient.FieldList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	workspaces, err := t.workspaceClient.WorkspaceList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	menu, err := loadNotificationMenu(t.notificationClient, session.Token)
 	if err != nil {
 		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
 		return
 	}
 
 	var dataTemplate = map[string]interface{}{
-		"email": email,
-		"tasks": tasks,
+		"email":         email,
+		"workspaces":    workspaces,
+		"notifications": menu,
+		"fields":        fields,
+		"tasks":         tasks,
+		"task_tree":     model.BuildTaskTree(tasks),
+		"assigned":      assigned,
+		"statuses":      statusList.Statuses,
+		"tags":          tagList.Tags,
+		"tag_filter":    c.Query("tag"),
+		"search":        search,
+		"search_error":  searchErr,
+		"search_caret":  caret,
+		"text":          text,
+		"text_error":    textErr,
+		"hits":          hits,
+		"lists":         lists,
+		"list":          list,
+		"windows":       []model.DeadlineWindow{model.WindowToday, model.WindowTomorrow, "next 7 days", "next 30 days", model.WindowOverdue, model.WindowNone},
 	}
 
-	var funcMap = template.FuncMap{
-		"exampleFunc": func() int {
-			return 0
-		},
+	var funcMap = taskFuncs(userLocation(t.userClient, session.Token))
+	funcMap["nextStatuses"] = func(status model.TaskStatus) []model.TaskStatus {
+		return statusList.Transitions[status]
+	}
+	funcMap["tagsOf"] = func(taskID int) []model.Tag {
+		return tagsByTask[taskID]
 	}
 
 	var header = path.Join("views", "general", "header.html")
+	var workspace = path.Join("views", "general", "workspace.html")
+	var notifications = path.Join("views", "general", "notifications.html")
 	var filepath = path.Join("views", "main", "task.html")
 
-	temp, err := template.New("task.html").Funcs(funcMap).ParseFS(t.embed, filepath, header)
+	temp, err := template.New("task.html").Funcs(funcMap).ParseFS(t.embed, filepath, header, workspace, notifications)
 	if err != nil {
 		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
 		return
@@ -132,16 +375,38 @@ func (t *taskWeb) TaskAddProcess(c *gin.Context) {
 		return
 	}
 
+	deadline, err := model.ParseDeadline(c.Request.FormValue("deadline"), userLocation(t.userClient, session.Token))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
 	priority, _ := strconv.Atoi(c.Request.FormValue("priority"))
 	categoryID, _ := strconv.Atoi(c.Request.FormValue("category_id"))
 	userID, _ := strconv.Atoi(c.Request.FormValue("user_id"))
+	parentID, _ := strconv.Atoi(c.Request.FormValue("parent_id"))
+	estimateHours, _ := strconv.ParseFloat(c.Request.FormValue("estimate_hours"), 64)
+	storyPoints, _ := strconv.Atoi(c.Request.FormValue("story_points"))
+
+	fields, err := t.taskClient.FieldList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
 	task := model.Task{
 		Title:      c.Request.FormValue("title"),
-		Deadline:   c.Request.FormValue("deadline"),
+		Deadline:   deadline,
 		Priority:   priority,
-		Status:     c.Request.FormValue("status"),
+		Status:     model.TaskStatus(c.Request.FormValue("status")),
 		CategoryID: categoryID,
 		UserID:     userID,
+		ParentID:   parentID,
+		Recurrence: c.Request.FormValue("recurrence"),
+
+		EstimateHours: estimateHours,
+		StoryPoints:   storyPoints,
+		Fields:        formFields(c, fields),
 	}
 
 	status, err := t.taskClient.AddTask(session.Token, task)
@@ -156,3 +421,481 @@ func (t *taskWeb) TaskAddProcess(c *gin.Context) {
 		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Add Task Failed!")
 	}
 }
+
+func (t *taskWeb) TaskTransitionProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	_, err = t.taskClient.TransitionTask(session.Token, id, model.TaskStatus(c.Request.FormValue("status")))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task")
+}
+
+func (t *taskWeb) TaskChecklistAddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	_, err = t.taskClient.AddChecklistItem(session.Token, id, c.Request.FormValue("title"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task")
+}
+
+func (t *taskWeb) TaskChecklistToggleProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Request.FormValue("item"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid checklist item ID")
+		return
+	}
+
+	_, err = t.taskClient.ToggleChecklistItem(session.Token, id, itemID)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task")
+}
+
+func (t *taskWeb) TaskDetailPage(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	task, err := t.taskClient.GetTask(session.Token, id)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	comments, err := t.taskClient.CommentList(session.Token, id, page)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	attachments, err := t.taskClient.AttachmentList(session.Token, id)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	dependencies, err := t.taskClient.TaskDependencies(session.Token, id)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	taskTime, err := t.taskClient.TaskTime(session.Token, id)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	timer, err := t.taskClient.RunningTimer(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	assignees, err := t.taskClient.Assignees(session.Token, id)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	fields, err := t.taskClient.FieldList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	history, err := t.taskClient.TaskHistory(session.Token, id)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	workspaces, err := t.workspaceClient.WorkspaceList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	menu, err := loadNotificationMenu(t.notificationClient, session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	watches, err := t.notificationClient.Watches(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	watching := false
+	for _, watch := range watches {
+		if watch.Target == model.WatchTask && watch.TargetID == id {
+			watching = true
+		}
+	}
+
+	var dataTemplate = map[string]interface{}{
+		"email":         email,
+		"workspaces":    workspaces,
+		"notifications": menu,
+		"task":          task,
+		"watching":      watching,
+		"attachments":   attachments,
+		"blocked_by":    dependencies.BlockedBy,
+		"blocks":        dependencies.Blocks,
+		"time":          taskTime,
+		"timer":         timer,
+		"assignees":     assignees,
+		"fields":        fields,
+		"history":       history,
+		"comments":      comments,
+		"prev_page":     comments.Page - 1,
+		"next_page":     nextCommentPage(comments),
+	}
+
+	var header = path.Join("views", "general", "header.html")
+	var workspace = path.Join("views", "general", "workspace.html")
+	var notifications = path.Join("views", "general", "notifications.html")
+	var filepath = path.Join("views", "main", "task_detail.html")
+
+	temp, err := template.New("task_detail.html").Funcs(taskFuncs(userLocation(t.userClient, session.Token))).ParseFS(t.embed, filepath, header, workspace, notifications)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	err = temp.Execute(c.Writer, dataTemplate)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+	}
+}
+
+func (t *taskWeb) TaskCommentAddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	_, err = t.taskClient.AddComment(session.Token, id, c.Request.FormValue("body"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(id))
+}
+
+func (t *taskWeb) TaskAttachmentUploadProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+	defer file.Close()
+
+	_, err = t.taskClient.UploadAttachment(session.Token, id, header.Filename, header.Header.Get("Content-Type"), file)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(id))
+}
+
+func (t *taskWeb) TaskBlockerAddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	blockerID, err := strconv.Atoi(c.Request.FormValue("blocker"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid blocker ID")
+		return
+	}
+
+	_, err = t.taskClient.AddBlocker(session.Token, id, blockerID)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(id))
+}
+
+func (t *taskWeb) TaskTimerProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	if c.Request.FormValue("action") == "stop" {
+		_, err = t.taskClient.StopTimer(session.Token)
+	} else {
+		_, err = t.taskClient.StartTimer(session.Token, id)
+	}
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(id))
+}
+
+func (t *taskWeb) TaskAssignProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	_, err = t.taskClient.AssignTask(session.Token, id, c.Request.FormValue("email"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(id))
+}
+
+func (t *taskWeb) TaskFieldsProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	fields, err := t.taskClient.FieldList(session.Token)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	_, err = t.taskClient.SetTaskFields(session.Token, id, formFields(c, fields))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(id))
+}
+
+func (t *taskWeb) TaskArchiveProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := t.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid task ID")
+		return
+	}
+
+	_, err = t.taskClient.ArchiveTask(session.Token, id, c.Request.FormValue("archived") == "true")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/task/detail/"+strconv.Itoa(id))
+}
+
+// formFields reads one form value per custom field. Missing inputs, such as unchecked checkboxes, give an empty value.
+func formFields(c *gin.Context, fields []model.CustomField) map[int]interface{} {
+	values := make(map[int]interface{}, len(fields))
+	for _, field := range fields {
+		values[field.ID] = c.Request.FormValue("field_" + strconv.Itoa(field.ID))
+	}
+	return values
+}
+
+// nextCommentPage returns the number of the page after the given one, or 0 when it is the last page.
+func nextCommentPage(comments *model.CommentPage) int {
+	if comments.Page*comments.PageSize >= comments.Total {
+		return 0
+	}
+	return comments.Page + 1
+}
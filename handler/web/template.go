@@ -0,0 +1,115 @@
+/** 
+ * Package web provides helpers shared by the page handlers.
+ * 
+ * Functions:
+ * 
+ * - userLocation: Function to load the time zone stored on the logged-in user's profile.
+ *   Parameters:
+ *   - userClient: Instance of the UserClient interface.
+ *   - token: Session token of the logged-in user.
+ *   Returns:
+ *   - *time.Location: The user's time zone, or UTC when the profile cannot be loaded or has no time zone.
+ * 
+ * - taskFuncs: Function to create the template functions used to render tasks.
+ *   Parameters:
+ *   - loc: Time zone the deadlines are rendered in.
+ *   Returns:
+ *   - template.FuncMap: Functions "deadline", rendering a model.Deadline or a canonical deadline string localized to loc, 
+ *     "overdue", reporting whether such a deadline has passed, "statusColor", returning the Tailwind color of a status, 
+ *     "timestamp", rendering a time.Time or *time.Time localized to loc, "fileSize", rendering a size in bytes 
+ *     as B, KB or MB, "duration", rendering a number of seconds as hours and minutes, and "changeValue", rendering
+ *     a value of a task's history, "none" when it is empty.
+ * 
+ * - statusColor: Function to pick the Tailwind color a status is shown with. Custom statuses are shown in gray.
+ */
+
+package web
+
+import (
+	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/model"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+func userLocation(userClient client.UserClient, token string) *time.Location {
+	profile, err := userClient.GetProfile(token)
+	if err != nil {
+		return time.UTC
+	}
+
+	return model.LoadLocation(profile.TimeZone)
+}
+
+func taskFuncs(loc *time.Location) template.FuncMap {
+	toDeadline := func(value interface{}) model.Deadline {
+		switch v := value.(type) {
+		case model.Deadline:
+			return v
+		case string:
+			deadline, _ := model.ParseDeadline(v, nil)
+			return deadline
+		}
+		return model.Deadline{}
+	}
+
+	return template.FuncMap{
+		"deadline": func(value interface{}) string {
+			return toDeadline(value).Format(loc)
+		},
+		"overdue": func(value interface{}) bool {
+			return toDeadline(value).Overdue(time.Now(), loc)
+		},
+		"statusColor": statusColor,
+		"timestamp": func(value interface{}) string {
+			switch v := value.(type) {
+			case time.Time:
+				return v.In(loc).Format("2006-01-02 15:04")
+			case *time.Time:
+				if v != nil {
+					return v.In(loc).Format("2006-01-02 15:04")
+				}
+			}
+			return ""
+		},
+		"fileSize": func(size int64) string {
+			switch {
+			case size < 1<<10:
+				return fmt.Sprintf("%d B", size)
+			case size < 1<<20:
+				return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+			}
+			return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+		},
+		"duration": func(seconds int64) string {
+			return fmt.Sprintf("%dh %02dm", seconds/3600, seconds%3600/60)
+		},
+		"changeValue": func(value interface{}) string {
+			switch v := value.(type) {
+			case nil:
+				return "none"
+			case string:
+				return v
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Sprint(value)
+			}
+			return string(data)
+		},
+	}
+}
+
+func statusColor(status interface{}) string {
+	switch model.TaskStatus(fmt.Sprint(status)) {
+	case model.StatusCompleted:
+		return "emerald"
+	case model.StatusReview:
+		return "indigo"
+	case model.StatusInProgress:
+		return "yellow"
+	}
+	return "gray"
+}
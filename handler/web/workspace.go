@@ -0,0 +1,208 @@
+/** 
+ * Package web provides functionality for selecting and managing workspaces from the web client using the Gin web framework.
+ * 
+ * Interfaces:
+ * 
+ * - WorkspaceWeb: Interface defining methods for handling workspace web functionalities.
+ *   Methods:
+ *   - SelectProcess: Method for processing workspace selection requests.
+ *   - AddProcess: Method for processing workspace creation requests.
+ *   - InviteProcess: Method for processing invitation requests.
+ *   - InvitationProcess: Method for processing answers to invitations.
+ * 
+ * Structs:
+ * 
+ * - workspaceWeb: Implements the WorkspaceWeb interface and contains dependencies for handling workspace web functionalities.
+ *   Fields:
+ *   - workspaceClient: Instance of the WorkspaceClient interface for communicating with the workspace service.
+ *   - sessionService: Instance of the SessionService interface for managing user sessions.
+ *   Methods:
+ *   - NewWorkspaceWeb: Function to create a new instance of the workspaceWeb struct.
+ *     Parameters:
+ *     - workspaceClient: Instance of the WorkspaceClient interface.
+ *     - sessionService: Instance of the SessionService interface.
+ *     Returns:
+ *     - *workspaceWeb: A new instance of the workspaceWeb struct.
+ * 
+ * Functions:
+ * 
+ * - SelectProcess: HTTP handler function for processing workspace selection requests.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, selects the workspace in the form data using the workspace client
+ *     and redirects back to the page the selector was submitted from, otherwise to a modal page with an error message.
+ * 
+ * - AddProcess: HTTP handler function for processing workspace creation requests.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, creates a workspace with the name in the form data
+ *     and redirects to the settings page on success, otherwise to a modal page with an error message.
+ * 
+ * - InviteProcess: HTTP handler function for processing invitation requests.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, invites the email address in the form data to the workspace in the form data
+ *     with the chosen role and redirects to the settings page on success, otherwise to a modal page with an error message.
+ * 
+ * - InvitationProcess: HTTP handler function for processing answers to invitations.
+ *   Parameters:
+ *   - c: Context provided by Gin framework.
+ *   Description: This function retrieves the user's session, accepts or declines the invitation in the form data depending on the action
+ *     and redirects to the settings page on success, otherwise to a modal page with an error message.
+ * 
+ * - refererPath: Function returning the path of the client page a form was submitted from, or the fallback when the Referer header
+ *   is missing or points outside the web client.
+ */
+
+package web
+
+import (
+	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceWeb interface {
+	SelectProcess(c *gin.Context)
+	AddProcess(c *gin.Context)
+	InviteProcess(c *gin.Context)
+	InvitationProcess(c *gin.Context)
+}
+
+type workspaceWeb struct {
+	workspaceClient client.WorkspaceClient
+	sessionService  service.SessionService
+}
+
+func NewWorkspaceWeb(workspaceClient client.WorkspaceClient, sessionService service.SessionService) *workspaceWeb {
+	return &workspaceWeb{workspaceClient, sessionService}
+}
+
+func (w *workspaceWeb) SelectProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := w.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("workspace_id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid workspace ID")
+		return
+	}
+
+	_, err = w.workspaceClient.SelectWorkspace(session.Token, id)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, refererPath(c, "/client/dashboard"))
+}
+
+func (w *workspaceWeb) AddProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := w.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	_, err = w.workspaceClient.AddWorkspace(session.Token, c.Request.FormValue("name"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/settings")
+}
+
+func (w *workspaceWeb) InviteProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := w.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("workspace_id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid workspace ID")
+		return
+	}
+
+	role := model.WorkspaceRole(c.Request.FormValue("role"))
+	_, err = w.workspaceClient.Invite(session.Token, id, c.Request.FormValue("email"), role)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/settings")
+}
+
+func (w *workspaceWeb) InvitationProcess(c *gin.Context) {
+	var email string
+	if temp, ok := c.Get("email"); ok {
+		if contextData, ok := temp.(string); ok {
+			email = contextData
+		}
+	}
+
+	session, err := w.sessionService.GetSessionByEmail(email)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	id, err := strconv.Atoi(c.Request.FormValue("id"))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message=Invalid invitation ID")
+		return
+	}
+
+	_, err = w.workspaceClient.RespondInvitation(session.Token, id, c.Request.FormValue("action") == "accept")
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/client/modal?status=error&message="+err.Error())
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/client/settings")
+}
+
+func refererPath(c *gin.Context, fallback string) string {
+	referer, err := url.Parse(c.Request.Referer())
+	if err != nil || !strings.HasPrefix(referer.Path, "/client/") {
+		return fallback
+	}
+
+	if referer.RawQuery != "" {
+		return referer.Path + "?" + referer.RawQuery
+	}
+	return referer.Path
+}
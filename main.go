@@ -12,6 +12,23 @@
  *   - UserAPIHandler: Handles user-related API requests.
  *   - CategoryAPIHandler: Handles category-related API requests.
  *   - TaskAPIHandler: Handles task-related API requests.
+ *   - StatusAPIHandler: Handles requests for the task status workflow.
+ *   - TagAPIHandler: Handles requests for task tags.
+ *   - CommentAPIHandler: Handles requests for task comments.
+ *   - AttachmentAPIHandler: Handles requests for task attachments.
+ *   - DependencyAPIHandler: Handles requests for dependencies between tasks.
+ *   - TimeAPIHandler: Handles requests for time tracking.
+ *   - EstimateAPIHandler: Handles requests for task estimates.
+ *   - AssignmentAPIHandler: Handles requests for the users assigned to tasks.
+ *   - WorkspaceAPIHandler: Handles requests for workspaces, their members and invitations.
+ *   - ProjectAPIHandler: Handles requests for projects, their milestones and their overview.
+ *   - SprintAPIHandler: Handles requests for sprints and their burndown.
+ *   - TemplateAPIHandler: Handles requests for task templates and their instantiation.
+ *   - FieldAPIHandler: Handles requests for the custom fields of a workspace.
+ *   - TrashAPIHandler: Handles requests for the deleted tasks and categories of a workspace.
+ *   - NotificationAPIHandler: Handles requests for watching tasks and categories and for the notifications of a user.
+ *   - SearchAPIHandler: Handles requests for the full-text search and for rebuilding its index.
+ *   - FilterAPIHandler: Handles requests for saved filters and the smart lists of tasks they define.
  *
  * - ClientHandler: Contains the web client handlers for authentication, home, dashboard, tasks, categories, and modals.
  *   Fields:
@@ -21,6 +38,12 @@
  *   - TaskWeb: Handles requests for the task page.
  *   - CategoryWeb: Handles requests for the category page.
  *   - ModalWeb: Handles requests for modals.
+ *   - SettingsWeb: Handles requests for the account settings page.
+ *   - WorkspaceWeb: Handles requests for selecting and managing workspaces.
+ *   - ProjectWeb: Handles requests for the project page.
+ *   - BoardWeb: Handles requests for the Kanban board.
+ *   - NotificationWeb: Handles requests for reading notifications and watching tasks.
+ *   - FilterWeb: Handles requests for saving and deleting smart lists.
  *
  * Embedded Files:
  *
@@ -29,6 +52,10 @@
  * Functions:
  *
  * - main: The main function that sets up and starts the HTTP server. It initializes the file-based database and configures the routes for both API and web client.
+ *   Run with the reindex argument, e.g. go run . reindex, it rebuilds the search index with RunReindex instead.
+ *
+ * - RunReindex: Indexes every task and category of the file-based database again for the full-text search, for data
+ *   written before the index existed, and prints how many were indexed.
  *
  * - RunServer: Sets up the API routes. It initializes the repositories and services for users, categories, and tasks, and registers the respective routes.
  *   Parameters:
@@ -37,6 +64,17 @@
  *   Returns:
  *   - *gin.Engine: The configured Gin engine instance.
  *
+ * - RunScheduler: Runs the periodic background jobs, such as erasing accounts whose deletion grace period has ended,
+ *   purging trash items older than TRASH_RETENTION, removing the content of deleted attachments from the blob store and
+ *   reminding the followers of tasks due within DEADLINE_REMINDER.
+ *   Parameters:
+ *   - filebasedDb: The file-based database instance.
+ *
+ * - NewBlobStore: Creates the blob store attachments are kept in, as selected by config.BlobStore: an S3-compatible
+ *   bucket for "s3", otherwise a directory of the local file system.
+ *   Returns:
+ *   - blobstore.BlobStore: The configured blob store.
+ *
  * - RunClient: Sets up the web client routes. It initializes the client handlers for authentication, home, dashboard, tasks, categories, and modals, and registers the respective routes.
  *   Parameters:
  *   - gin: The Gin engine instance.
@@ -50,22 +88,183 @@
  * User Routes:
  * - POST /api/v1/user/login: Endpoint to handle user login. Expects a JSON payload with username and password. Returns a JSON response with user details and authentication token.
  * - POST /api/v1/user/register: Endpoint to handle user registration. Expects a JSON payload with user details such as username, password, and email. Returns a JSON response with the registered user's details.
- * - GET /api/v1/user/tasks: Protected endpoint to retrieve tasks associated with the logged-in user. Requires a valid authentication token. Returns a JSON response with the list of tasks categorized.
+ * - GET /api/v1/user/tasks: Protected endpoint to retrieve the tasks of the selected workspace that are not archived, with their owners and categories. Requires a valid authentication token. Returns a JSON response with the list of tasks categorized.
+ * - GET /api/v1/user/profile: Protected endpoint to get the logged-in user's profile, including the time zone deadlines are rendered in.
+ * - PUT /api/v1/user/profile: Protected endpoint to update the logged-in user's full name and IANA time zone.
+ * - DELETE /api/v1/user/delete: Protected endpoint to schedule the deletion of the logged-in user's account. The account, its sessions, tasks and categories are erased once the grace period ends.
+ * - POST /api/v1/user/restore: Endpoint to cancel a pending account deletion. Expects a JSON payload with email and password.
+ * 
+ * Task and category routes are scoped to the workspace the logged-in user selected: lists only return its records,
+ * new records are added to it, records of other workspaces are reported as not found and viewers can only send GET requests.
  * 
  * Task Routes:
- * - POST /api/v1/task/add: Protected endpoint to add a new task. Expects a JSON payload with task details. Returns a JSON response with the added task's details.
+ * - POST /api/v1/task/add: Protected endpoint to add a new task. Expects a JSON payload with task details, optionally estimate_hours and story_points, which cannot be negative. Returns a JSON response with the added task's details.
  * - GET /api/v1/task/get/:id: Protected endpoint to get a task by its ID. Requires a valid authentication token. Returns a JSON response with the task details.
- * - PUT /api/v1/task/update/:id: Protected endpoint to update a task by its ID. Expects a JSON payload with updated task details. Returns a JSON response with the updated task's details.
- * - DELETE /api/v1/task/delete/:id: Protected endpoint to delete a task by its ID. Requires a valid authentication token. Returns a JSON response indicating the success of the operation.
- * - GET /api/v1/task/list: Protected endpoint to get the list of all tasks. Requires a valid authentication token. Returns a JSON response with the list of tasks.
+ * - PUT /api/v1/task/update/:id: Protected endpoint to update a task by its ID. Expects a JSON payload with updated task details; a missing parent_id keeps the current parent. A status change is recorded in the task's status history with the time it was made. Returns a JSON response with the updated task's details.
+ * - DELETE /api/v1/task/delete/:id: Protected endpoint to move a task by its ID to the trash together with its subtasks, or with ?children=keep moving them up to its parent. Requires a valid authentication token. Returns a JSON response indicating the success of the operation.
+ * - GET /api/v1/task/assigned: Protected endpoint to get the tasks assigned to the logged-in user.
+ * - GET /api/v1/task/estimates: Protected endpoint to get the estimated and completed hours and story points of the tasks, rolled up per category and per user.
+ * - GET /api/v1/task/list: Protected endpoint to get the list of all tasks. Requires a valid authentication token. Returns a JSON response with the list of tasks. With ?tag=<id>, repeatable, only the tasks carrying all of the given tags are returned. With ?field[<field id>]=<value>, repeatable, only the tasks whose custom field has the given value are returned; checkbox fields match true or false. Archived tasks are left out; ?archived=true returns only the archived tasks, most recently archived first. ?status=<status> (repeatable), ?priority_min=<n>, ?priority_max=<n>, ?category=<id>, ?user=<id>, ?deadline_after=<date>, ?deadline_before=<date> and ?title=<text> filter the tasks further, and ?sort=deadline|priority|created|archived, prefixed with - for descending order, sorts them; tasks without a deadline come last when sorting by deadline. With ?limit=<n>, at most 100, only the first n tasks are returned and the X-Next-Cursor response header carries the cursor to pass as ?cursor=<cursor> for the next page; the header is left out on the last page. Invalid filters, sort keys, limits and cursors return 400.
+ * - GET /api/v1/task/search: Protected endpoint to search the tasks of the selected workspace with the query language of ?q=, e.g. status:open priority>=3 due<2026-11-01 category:"Work" report. Terms must all match; OR, a leading - or NOT, and parentheses combine them. The fields are status (open, done or a status name), priority, due (YYYY-MM-DD or none), category (a name or none), tag, title and is (archived or overdue); bare words match titles. Returns the matching tasks in ID order. A query that cannot be parsed returns 400 with the error message and the column and token it points at.
  * - GET /api/v1/task/category/:id: Protected endpoint to get tasks by category ID. Requires a valid authentication token. Returns a JSON response with the list of tasks in the specified category.
+ * - POST /api/v1/task/transition/:id: Protected endpoint to move a task to another status. Expects a JSON payload with the new status. The change must be allowed by the workflow and is recorded with the logged-in user and the time.
+ * - GET /api/v1/task/transitions/:id: Protected endpoint to get the status history of a task.
+ * - POST /api/v1/task/:id/watch: Protected endpoint to watch a task. Watchers are notified of its status changes, comments, assignments and approaching deadline, like its owner and assignees.
+ * - DELETE /api/v1/task/:id/watch: Protected endpoint to stop watching a task.
+ * - GET /api/v1/task/:id/history: Protected endpoint to get the activity history of a task, oldest first. Every change to a task is recorded together with it as an event with the action (created, updated, deleted or restored), the changed fields with their old and new values, the email of the user who made it, empty for changes made by the system, and the time.
+ * - GET /api/v1/task/board: Protected endpoint to get the Kanban board: the tasks grouped into one column per status of the logged-in user's workflow, each column in its manual order.
+ * - PUT /api/v1/task/move/:id: Protected endpoint to move a task on the board. Expects a JSON payload with the target status, empty for the current one, and after_id, the task it is placed right after or 0 for the top of the column. Only the moved task is rewritten; a status change must be allowed by the workflow and is recorded like a transition.
+ * - PUT /api/v1/task/:id/fields: Protected endpoint to set custom field values of a task. Expects a JSON object mapping field IDs to values: text, a number, a YYYY-MM-DD date, one of the options of a select field or a boolean. Values not in the payload are kept and empty ones clear the field. Returns the updated task.
+ * - POST /api/v1/task/:id/archive: Protected endpoint to archive a completed task, hiding it from the task list and the board. Other tasks are refused with 400.
+ * - DELETE /api/v1/task/:id/archive: Protected endpoint to unarchive a task. Moving an archived task out of the completed status unarchives it too.
+ * - GET /api/v1/task/:id/subtasks: Protected endpoint to get the direct subtasks of a task.
+ * - POST /api/v1/task/:id/subtasks: Protected endpoint to add a subtask, owned by the logged-in user, under a task they may edit. Expects a JSON payload with task details; the category defaults to the parent's and must belong to the workspace.
+ * - DELETE /api/v1/task/:id/parent: Protected endpoint to make a subtask a top-level task. Returns the updated task.
+ * - GET /api/v1/task/:id/progress: Protected endpoint to get the progress of a task, counting completed subtasks and checked checklist items.
+ * - POST /api/v1/task/:id/checklist: Protected endpoint to add a checklist item. Expects a JSON payload with the item title.
+ * - PUT /api/v1/task/:id/checklist/order: Protected endpoint to reorder the checklist. Expects a JSON payload with the IDs of every item in their new order.
+ * - PUT /api/v1/task/:id/checklist/:item/toggle: Protected endpoint to check or uncheck a checklist item.
+ * - DELETE /api/v1/task/:id/checklist/:item: Protected endpoint to remove a checklist item.
+ * - PUT /api/v1/task/:id/occurrence: Protected endpoint to edit only this occurrence of a recurring task. Expects a JSON payload with task details; the recurrence rule is kept.
+ * - PUT /api/v1/task/:id/series: Protected endpoint to edit this and the following not completed occurrences of a recurring task. Expects a JSON payload with the title, priority, category, recurrence rule and deadline; a new deadline shifts every occurrence by the same amount.
+ * - GET /api/v1/task/:id/series: Protected endpoint to get every occurrence of the series a recurring task belongs to.
+ * - GET /api/v1/task/:id/tags: Protected endpoint to get the tags of a task.
+ * - POST /api/v1/task/:id/tags/:tag: Protected endpoint to put a tag of the logged-in user on a task.
+ * - DELETE /api/v1/task/:id/tags/:tag: Protected endpoint to remove a tag of the logged-in user from a task.
+ * - GET /api/v1/task/:id/comments: Protected endpoint to get a page of a task's comments, oldest first. Accepts the page (from 1) and page_size (default 20, at most 100) query parameters. Deleted comments are listed without their body.
+ * - POST /api/v1/task/:id/comments: Protected endpoint to comment on a task. Expects a JSON payload with the Markdown body; the response carries the rendered HTML.
+ * - PUT /api/v1/task/:id/comments/:comment: Protected endpoint for the author to edit a comment. The previous body is kept in the comment's edit history.
+ * - DELETE /api/v1/task/:id/comments/:comment: Protected endpoint for the author to delete a comment.
+ * - GET /api/v1/task/:id/attachments: Protected endpoint to get the metadata of the files attached to a task.
+ * - POST /api/v1/task/:id/attachments: Protected endpoint to attach a file to a task. Expects a multipart form with the file in the "file" field. Files over MAX_ATTACHMENT_SIZE (default 10 MiB) are refused with 413 and files whose detected type is not in ATTACHMENT_TYPES with 415.
+ * - GET /api/v1/task/:id/attachments/:attachment: Protected endpoint to download an attachment.
+ * - DELETE /api/v1/task/:id/attachments/:attachment: Protected endpoint for the uploader to delete an attachment.
+ * - GET /api/v1/task/:id/dependencies: Protected endpoint to get the tasks blocking a task and the tasks it blocks.
+ * - POST /api/v1/task/:id/blockers/:blocker: Protected endpoint to make a task wait for another one. Dependencies creating a cycle are refused. A task cannot be completed while one of its blockers is open.
+ * - DELETE /api/v1/task/:id/blockers/:blocker: Protected endpoint to remove the dependency of a task on a blocker.
+ * - GET /api/v1/task/:id/assignees: Protected endpoint to get the profiles of the users assigned to a task.
+ * - POST /api/v1/task/:id/assignees: Protected endpoint to assign a user to a task. Expects a JSON payload with the user's email. Assignees can move a task through the workflow but cannot edit, delete or reassign it.
+ * - DELETE /api/v1/task/:id/assignees/:user: Protected endpoint to remove a user from a task. Assignees can only remove themselves.
+ * - POST /api/v1/task/:id/timer/start: Protected endpoint to start a timer on a task. Accepts an optional JSON payload with a note. A user can only run one timer at a time; starting a second one is refused with 409.
+ * - GET /api/v1/task/:id/time: Protected endpoint to get the time entries of a task, by every user, with their total in seconds.
+ * - POST /api/v1/task/:id/time: Protected endpoint to enter time spent on a task by hand. Expects a JSON payload with the RFC 3339 start and end and an optional note.
+ * 
+ * Time Routes:
+ * - GET /api/v1/time/running: Protected endpoint to get the running timer of the logged-in user.
+ * - POST /api/v1/time/stop: Protected endpoint to stop the running timer of the logged-in user.
+ * - DELETE /api/v1/time/entry/:id: Protected endpoint to delete a time entry of the logged-in user.
+ * - GET /api/v1/time/report: Protected endpoint to get the timesheet of the logged-in user with totals per task, category and day. Accepts the from and to query parameters (YYYY-MM-DD in the user's time zone, both included, the last seven days by default).
+ * - GET /api/v1/time/export: Protected endpoint to download the entries of the same timesheet as a CSV file.
+ * 
+ * Status Routes:
+ * - GET /api/v1/status/list: Protected endpoint to get the logged-in user's workflow: its statuses, the transitions they allow and its custom statuses.
+ * - POST /api/v1/status/add: Protected endpoint to add a custom status. Expects a JSON payload with the name and the statuses it can be reached from and lead to.
+ * - DELETE /api/v1/status/delete/:id: Protected endpoint to delete a custom status that no task uses anymore.
+ * 
+ * Tag Routes:
+ * - GET /api/v1/tag/list: Protected endpoint to get the tags of the logged-in user together with the tasks they are assigned to.
+ * - POST /api/v1/tag/add: Protected endpoint to add a tag. Expects a JSON payload with the name and an optional hex color; names are unique per user.
+ * - PUT /api/v1/tag/update/:id: Protected endpoint to rename or recolor a tag.
+ * - DELETE /api/v1/tag/delete/:id: Protected endpoint to delete a tag, removing it from every task.
  * 
  * Category Routes:
  * - POST /api/v1/category/add: Protected endpoint to add a new category. Expects a JSON payload with category details. Returns a JSON response with the added category's details.
  * - GET /api/v1/category/get/:id: Protected endpoint to get a category by its ID. Requires a valid authentication token. Returns a JSON response with the category details.
  * - PUT /api/v1/category/update/:id: Protected endpoint to update a category by its ID. Expects a JSON payload with updated category details. Returns a JSON response with the updated category's details.
- * - DELETE /api/v1/category/delete/:id: Protected endpoint to delete a category by its ID. Requires a valid authentication token. Returns a JSON response indicating the success of the operation.
- * - GET /api/v1/category/list: Protected endpoint to get the list of all categories. Requires a valid authentication token. Returns a JSON response with the list of categories.
+ * - DELETE /api/v1/category/delete/:id: Protected endpoint to move a category by its ID to the trash, keeping its tasks. Requires a valid authentication token. Returns a JSON response indicating the success of the operation.
+ * - GET /api/v1/category/list: Protected endpoint to get the list of all categories. Requires a valid authentication token. Returns a JSON response with the list of categories. ?user=<id>, ?project=<id> and ?name=<text> filter the categories, ?sort=name|created, prefixed with - for descending order, sorts them, and ?limit=<n> with ?cursor=<cursor> pages through them like the task list, the X-Next-Cursor response header carrying the cursor of the next page.
+ * - POST /api/v1/category/:id/watch: Protected endpoint to watch a category. Watchers are notified of the events of every task in it.
+ * - DELETE /api/v1/category/:id/watch: Protected endpoint to stop watching a category.
+ * - GET /api/v1/category/:id/dependencies: Protected endpoint to get the dependency graph of the tasks of a category, with their topological order and critical path.
+ * 
+ * Workspace Routes:
+ * - GET /api/v1/workspace/list: Protected endpoint to get the workspaces of the logged-in user with their role in each, the default workspace first. The selected one is marked as current.
+ * - POST /api/v1/workspace/add: Protected endpoint to create a workspace owned by the logged-in user. Expects a JSON payload with the name.
+ * - PUT /api/v1/workspace/select/:id: Protected endpoint to select the workspace to work in; 0 selects the default workspace.
+ * - GET /api/v1/workspace/invitations: Protected endpoint to get the pending invitations sent to the email address of the logged-in user.
+ * - POST /api/v1/workspace/invitations/:id/accept: Protected endpoint to accept an invitation, joining the workspace with the role it offers.
+ * - POST /api/v1/workspace/invitations/:id/decline: Protected endpoint to decline an invitation.
+ * - GET /api/v1/workspace/:id/members: Protected endpoint to get the members of a workspace with their roles.
+ * - POST /api/v1/workspace/:id/invitations: Protected endpoint for owners and admins to invite a user by email. Expects a JSON payload with the email and an optional role: admin, member (the default) or viewer.
+ * - PUT /api/v1/workspace/:id/members/:user: Protected endpoint for the owner to change the role of a member. Expects a JSON payload with the role.
+ * - DELETE /api/v1/workspace/:id/members/:user: Protected endpoint to remove a member. Owners remove anyone but themselves, admins remove members and viewers, and everyone can leave.
+ * 
+ * Project Routes:
+ * - GET /api/v1/project/list: Protected endpoint to get the projects of the selected workspace.
+ * - POST /api/v1/project/add: Protected endpoint to create a project. Expects a JSON payload with the name and an optional description.
+ * - GET /api/v1/project/get/:id: Protected endpoint to get a project by its ID.
+ * - PUT /api/v1/project/update/:id: Protected endpoint to rename a project or change its description.
+ * - DELETE /api/v1/project/delete/:id: Protected endpoint to delete a project with its milestones. Its categories and tasks are kept.
+ * - GET /api/v1/project/:id/overview: Protected endpoint to get the completion of a project, its categories and its milestones, earliest due first, with overdue milestones flagged.
+ * - PUT /api/v1/project/:id/categories/:category: Protected endpoint to group a category under a project.
+ * - DELETE /api/v1/project/:id/categories/:category: Protected endpoint to take a category out of a project.
+ * - POST /api/v1/project/:id/milestones: Protected endpoint to add a milestone. Expects a JSON payload with the title and an optional due date, YYYY-MM-DD or RFC 3339.
+ * - PUT /api/v1/project/:id/milestones/:milestone: Protected endpoint to change the title and due date of a milestone.
+ * - DELETE /api/v1/project/:id/milestones/:milestone: Protected endpoint to delete a milestone, detaching its tasks.
+ * - PUT /api/v1/project/:id/milestones/:milestone/tasks/:task: Protected endpoint to attach a task to a milestone.
+ * - DELETE /api/v1/project/:id/milestones/:milestone/tasks/:task: Protected endpoint to detach a task from a milestone.
+ * 
+ * Sprint Routes:
+ * - GET /api/v1/sprint/list: Protected endpoint to get the sprints of the selected workspace.
+ * - POST /api/v1/sprint/add: Protected endpoint to plan a sprint. Expects a JSON payload with the name, an optional goal, and the start and end dates as YYYY-MM-DD.
+ * - GET /api/v1/sprint/get/:id: Protected endpoint to get a sprint by its ID.
+ * - PUT /api/v1/sprint/update/:id: Protected endpoint to change the name, goal and dates of a sprint that is not closed.
+ * - DELETE /api/v1/sprint/delete/:id: Protected endpoint to delete a sprint that is not active, returning its tasks to the backlog.
+ * - PUT /api/v1/sprint/:id/tasks/:task: Protected endpoint to plan a task into a sprint that is not closed.
+ * - DELETE /api/v1/sprint/:id/tasks/:task: Protected endpoint to return a task of a sprint that is not closed to the backlog.
+ * - POST /api/v1/sprint/:id/start: Protected endpoint to start a planned sprint. Only one sprint of a workspace can be active.
+ * - POST /api/v1/sprint/:id/close: Protected endpoint to close the active sprint. Accepts a JSON payload with the next_sprint_id unfinished tasks are carried over to; without it they return to the backlog.
+ * - GET /api/v1/sprint/:id/burndown: Protected endpoint to get the tasks and story points remaining at the end of each day of a sprint, next to the ideal burndown.
+ * 
+ * Template Routes:
+ * - GET /api/v1/template/list: Protected endpoint to get the task templates of the selected workspace.
+ * - POST /api/v1/template/add: Protected endpoint to create a task template. Expects a JSON payload with the name, an optional description and the tasks, each with a title, priority, optional category_id of the workspace and optional deadline_offset in days.
+ * - GET /api/v1/template/get/:id: Protected endpoint to get a task template by its ID.
+ * - PUT /api/v1/template/update/:id: Protected endpoint to replace the name, description and tasks of a task template.
+ * - DELETE /api/v1/template/delete/:id: Protected endpoint to delete a task template. Tasks created from it are kept.
+ * - POST /api/v1/template/:id/instantiate: Protected endpoint to create every task of a template for the logged-in user in one go. Expects a JSON payload with the start_date the deadline offsets are counted from, as YYYY-MM-DD or RFC 3339. Returns the created tasks.
+ * 
+ * Custom Field Routes:
+ * - GET /api/v1/field/list: Protected endpoint to get the custom fields of the selected workspace.
+ * - POST /api/v1/field/add: Protected endpoint to define a custom field. Expects a JSON payload with the name, unique in the workspace, the type (text, number, date, select or checkbox) and, for select fields, the options. Task payloads carry the values of custom fields in fields, keyed by field ID, and are validated against their definitions.
+ * - GET /api/v1/field/get/:id: Protected endpoint to get a custom field by its ID.
+ * - PUT /api/v1/field/update/:id: Protected endpoint to rename a custom field or replace the options of a select field. The type cannot be changed.
+ * - DELETE /api/v1/field/delete/:id: Protected endpoint to delete a custom field together with its values on every task.
+ * 
+ * Trash Routes:
+ * Deleted tasks, with their subtasks, comments, time entries, attachments, tags, dependencies and assignees, and deleted categories
+ * are kept in the trash of their workspace for TRASH_RETENTION (a Go duration, 720h by default) and purged afterwards.
+ * - GET /api/v1/trash/list: Protected endpoint to get the trash of the selected workspace, most recently deleted first, with the time each item is purged.
+ * - GET /api/v1/trash/get/:id: Protected endpoint to get a trash item with its deleted task and subtasks or category.
+ * - POST /api/v1/trash/restore/:id: Protected endpoint to restore a trash item. A category whose ID has been taken is refused with 409. Restored tasks lose a parent, sprint or milestone that has been deleted since.
+ * - DELETE /api/v1/trash/purge/:id: Protected endpoint to remove a trash item for good.
+ * 
+ * Search Routes:
+ * Task titles and category names are kept in a full-text index, updated with every change to them. Words are lowercased and stemmed for English and Indonesian, so "running" finds "run" and "menulis" finds "tulis".
+ * - GET /api/v1/search: Protected endpoint to search the tasks and categories of the selected workspace for the words of ?q=. Records matching any word are returned, ranked by relevance with those matching more of the words first, each with its kind ("task" or "category"), ID, title or name, the title or name with the matching words wrapped in <mark> and their byte ranges, and its score. ?limit=<n>, at most 100 and 20 by default, caps the number of results. A query without words to search for returns 400.
+ * 
+ * Filter Routes:
+ * Smart lists are the built-in lists every user has, Today, Overdue and Upcoming (the open tasks due in the next 7 days), and the filters the user saved in the selected workspace. A filter combines statuses, open (not Completed) tasks only, a priority range, a deadline window ("overdue", "today", "tomorrow", "next N days" or "none"), a category and text in the title. Deadline windows are evaluated on the day it is in the time zone of the user when the list is read.
+ * - GET /api/v1/filter/list: Protected endpoint to get the built-in lists followed by the filters the logged-in user saved in the selected workspace. Built-in lists have a key instead of an ID.
+ * - POST /api/v1/filter/add: Protected endpoint to save a filter. Expects JSON with the name, unique per user and workspace regardless of case, and the statuses, open, min_priority, max_priority, deadline, category_id, title and sort criteria, all optional. Invalid criteria return 400 and a name in use 409.
+ * - PUT /api/v1/filter/update/:id: Protected endpoint to replace the name and criteria of a saved filter.
+ * - DELETE /api/v1/filter/delete/:id: Protected endpoint to delete a saved filter.
+ * - GET /api/v1/filter/tasks/:ref: Protected endpoint to get a list, by the key of a built-in list or the ID of a saved filter, with the tasks it contains now.
+ * 
+ * Notification Routes:
+ * Notifications are sent to the owner, the assignees and the watchers of a task, and the watchers of its category, except
+ * the user who caused them. Deadline reminders are sent once per deadline, DEADLINE_REMINDER (a Go duration, 24h by default) before it.
+ * - GET /api/v1/notification/list: Protected endpoint to get the notifications of the logged-in user, newest first. With ?unread=true only the unread ones are returned.
+ * - GET /api/v1/notification/unread: Protected endpoint to get the number of unread notifications of the logged-in user.
+ * - PUT /api/v1/notification/read/:id: Protected endpoint to mark a notification as read.
+ * - PUT /api/v1/notification/read: Protected endpoint to mark every notification of the logged-in user as read.
+ * - GET /api/v1/notification/watches: Protected endpoint to get the tasks and categories the logged-in user watches.
+ * 
+ * Admin Routes:
+ * - DELETE /api/v1/admin/user/delete/:id: Admin-only endpoint to schedule the deletion of a user's account.
+ * - POST /api/v1/admin/user/restore/:id: Admin-only endpoint to cancel the pending deletion of a user's account.
+ * - GET /api/v1/admin/audit/list: Admin-only endpoint to get the audit log, including account erasures.
+ * - POST /api/v1/admin/search/rebuild: Admin-only endpoint to rebuild the full-text search index from every task and category. Returns the number of records indexed.
  * 
  * Web Client Routes:
  * 
@@ -80,13 +279,44 @@
  * - POST /client/login/process: Route to process the login form. Expects form data with username and password. Redirects to the appropriate page based on the success of the login.
  * - GET /client/register: Route to display the registration page.
  * - POST /client/register/process: Route to process the registration form. Expects form data with user details such as username, password, and email. Redirects to the appropriate page based on the success of the registration.
+ * - GET /client/restore: Route to display the account restore page.
+ * - POST /client/restore/process: Route to process the account restore form. Expects form data with email and password.
  * - GET /client/logout: Protected route to log out the user. Redirects to the home page after logging out.
  * 
  * Main Routes:
+ * Every page shows the latest notifications of the logged-in user in a dropdown of its header, with the number of unread ones.
  * - GET /client/dashboard: Protected route to display the dashboard page.
- * - GET /client/task: Protected route to display the task page.
+ * - GET /client/task: Protected route to display the task page, with the tasks assigned to the logged-in user and a sidebar of smart lists. Accepts the text query parameter to find tasks and categories with the full-text search, showing the matching words highlighted, and the list query parameter to show the tasks of a smart list instead.
  * - POST /client/task/add/process: Protected route to process the task addition form. Expects form data with task details. Redirects to the task page based on the success of the task addition.
+ * - POST /client/task/transition/process: Protected route to move a task to another status. Expects form data with the task ID and the new status.
+ * - POST /client/task/checklist/add/process: Protected route to add a checklist item. Expects form data with the task ID and the item title.
+ * - POST /client/task/checklist/toggle/process: Protected route to check or uncheck a checklist item. Expects form data with the task ID and the item ID.
+ * - GET /client/task/detail/:id: Protected route to display a task with its assignees, dependencies, tracked time, attachments and discussion thread. Accepts the page query parameter to page through the comments.
+ * - POST /client/task/comment/add/process: Protected route to comment on a task. Expects form data with the task ID and the Markdown body.
+ * - POST /client/task/attachment/upload/process: Protected route to attach a file to a task. Expects multipart form data with the task ID and the file.
+ * - POST /client/task/blocker/add/process: Protected route to make a task wait for another one. Expects form data with the task ID and the ID of the blocking task.
+ * - POST /client/task/assign/process: Protected route to assign a user to a task. Expects form data with the task ID and the user's email.
+ * - POST /client/task/fields/process: Protected route to save the custom field values of a task. Expects form data with the task ID and a field_<id> value per custom field of the workspace; empty values clear the field.
+ * - POST /client/task/archive/process: Protected route to archive a completed task, or unarchive it. Expects form data with the task ID and archived set to true or false.
+ * - GET /client/board: Protected route to display the Kanban board of the selected workspace, where tasks are dragged between and within status columns.
+ * - POST /client/board/move/process: Protected route to move a task on the board. Expects form data with the task ID, the target status and the ID of the task it is placed after, empty for the top of the column.
+ * - POST /client/task/timer/process: Protected route to start a timer on a task or stop the running one. Expects form data with the task ID and the action, "start" or "stop".
  * - GET /client/category: Protected route to display the category page.
+ * - GET /client/project: Protected route to display the projects of the selected workspace. Accepts the id query parameter to show the overview of a project.
+ * - POST /client/project/add/process: Protected route to create a project. Expects form data with the name and description.
+ * - POST /client/project/category/add/process: Protected route to group a category under a project. Expects form data with the project ID and the category ID.
+ * - POST /client/project/milestone/add/process: Protected route to add a milestone to a project. Expects form data with the project ID, the title and the due date.
+ * - POST /client/workspace/select/process: Protected route to select the workspace to work in. Expects form data with the workspace ID and redirects back to the page it came from.
+ * - POST /client/workspace/add/process: Protected route to create a workspace. Expects form data with the name.
+ * - POST /client/workspace/invite/process: Protected route to invite a user to a workspace. Expects form data with the workspace ID, the email and the role.
+ * - POST /client/workspace/invitation/process: Protected route to accept or decline an invitation. Expects form data with the invitation ID and the action, "accept" or "decline".
+ * - GET /client/settings: Protected route to display the account settings page.
+ * - POST /client/settings/profile/process: Protected route to update the logged-in user's time zone.
+ * - POST /client/settings/delete/process: Protected route to schedule the deletion of the logged-in user's account.
+ * - POST /client/notification/read/process: Protected route to mark a notification as read and open its task. Expects form data with the notification ID and the task ID; without a notification ID every notification is marked as read.
+ * - POST /client/filter/add/process: Protected route to save a smart list. Expects form data with the name and the criteria: status, open, min and max priority, deadline window, category ID and title. Redirects to the new list.
+ * - POST /client/filter/delete/process: Protected route to delete a saved smart list. Expects form data with the filter ID.
+ * - POST /client/watch/process: Protected route to watch a task or category, or stop watching it. Expects form data with the target ("task" or "category"), its ID and watch set to true or false.
  * 
  * Modal Routes:
  * - GET /client/modal: Route to display a modal page.
@@ -96,6 +326,8 @@ package main
 
 import (
 	"a21hc3NpZ25tZW50/client"
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/db/blobstore"
 	"a21hc3NpZ25tZW50/db/filebased"
 	"a21hc3NpZ25tZW50/handler/api"
 	"a21hc3NpZ25tZW50/handler/web"
@@ -104,9 +336,12 @@ import (
 	"a21hc3NpZ25tZW50/service"
 	"embed"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
 	"sync"
 	"time"
+	_ "time/tzdata"
 
 	_ "embed"
 
@@ -115,24 +350,52 @@ import (
 )
 
 type APIHandler struct {
-	UserAPIHandler     api.UserAPI
-	CategoryAPIHandler api.CategoryAPI
-	TaskAPIHandler     api.TaskAPI
+	UserAPIHandler         api.UserAPI
+	CategoryAPIHandler     api.CategoryAPI
+	TaskAPIHandler         api.TaskAPI
+	StatusAPIHandler       api.StatusAPI
+	TagAPIHandler          api.TagAPI
+	CommentAPIHandler      api.CommentAPI
+	AttachmentAPIHandler   api.AttachmentAPI
+	DependencyAPIHandler   api.DependencyAPI
+	TimeAPIHandler         api.TimeAPI
+	EstimateAPIHandler     api.EstimateAPI
+	AssignmentAPIHandler   api.AssignmentAPI
+	WorkspaceAPIHandler    api.WorkspaceAPI
+	ProjectAPIHandler      api.ProjectAPI
+	SprintAPIHandler       api.SprintAPI
+	TemplateAPIHandler     api.TemplateAPI
+	FieldAPIHandler        api.FieldAPI
+	TrashAPIHandler        api.TrashAPI
+	NotificationAPIHandler api.NotificationAPI
+	SearchAPIHandler       api.SearchAPI
+	FilterAPIHandler       api.FilterAPI
 }
 
 type ClientHandler struct {
-	AuthWeb      web.AuthWeb
-	HomeWeb      web.HomeWeb
-	DashboardWeb web.DashboardWeb
-	TaskWeb      web.TaskWeb
-	CategoryWeb  web.CategoryWeb
-	ModalWeb     web.ModalWeb
+	AuthWeb         web.AuthWeb
+	HomeWeb         web.HomeWeb
+	DashboardWeb    web.DashboardWeb
+	TaskWeb         web.TaskWeb
+	CategoryWeb     web.CategoryWeb
+	ModalWeb        web.ModalWeb
+	SettingsWeb     web.SettingsWeb
+	WorkspaceWeb    web.WorkspaceWeb
+	ProjectWeb      web.ProjectWeb
+	BoardWeb        web.BoardWeb
+	NotificationWeb web.NotificationWeb
+	FilterWeb       web.FilterWeb
 }
 
 //go:embed views/*
 var Resources embed.FS
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "reindex" {
+		RunReindex()
+		return
+	}
+
 	gin.SetMode(gin.ReleaseMode) //release
 
 	wg := sync.WaitGroup{}
@@ -161,6 +424,8 @@ func main() {
 		router = RunServer(router, filebasedDb)
 		router = RunClient(router, Resources, filebasedDb)
 
+		go RunScheduler(filebasedDb)
+
 		fmt.Println("Server is running on port 8080")
 		err = router.Run(":8080")
 		if err != nil {
@@ -172,24 +437,106 @@ func main() {
 	wg.Wait()
 }
 
+func RunReindex() {
+	filebasedDb, err := filebased.InitDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	indexed, err := service.NewSearchService(repo.NewSearchRepo(filebasedDb)).Rebuild()
+	filebasedDb.CloseDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Search index rebuilt with %d tasks and categories\n", indexed)
+}
+
 func RunServer(gin *gin.Engine, filebasedDb *filebased.Data) *gin.Engine {
 	userRepo := repo.NewUserRepo(filebasedDb)
 	sessionRepo := repo.NewSessionsRepo(filebasedDb)
 	categoryRepo := repo.NewCategoryRepo(filebasedDb)
 	taskRepo := repo.NewTaskRepo(filebasedDb)
+	statusRepo := repo.NewStatusRepo(filebasedDb)
+	tagRepo := repo.NewTagRepo(filebasedDb)
+	commentRepo := repo.NewCommentRepo(filebasedDb)
+	attachmentRepo := repo.NewAttachmentRepo(filebasedDb)
+	dependencyRepo := repo.NewDependencyRepo(filebasedDb)
+	timeEntryRepo := repo.NewTimeEntryRepo(filebasedDb)
+	assignmentRepo := repo.NewAssignmentRepo(filebasedDb)
+	workspaceRepo := repo.NewWorkspaceRepo(filebasedDb)
+	projectRepo := repo.NewProjectRepo(filebasedDb)
+	sprintRepo := repo.NewSprintRepo(filebasedDb)
+	templateRepo := repo.NewTemplateRepo(filebasedDb)
+	fieldRepo := repo.NewFieldRepo(filebasedDb)
+	trashRepo := repo.NewTrashRepo(filebasedDb)
+	notificationRepo := repo.NewNotificationRepo(filebasedDb)
+	searchRepo := repo.NewSearchRepo(filebasedDb)
+	filterRepo := repo.NewFilterRepo(filebasedDb)
 
 	userService := service.NewUserService(userRepo, sessionRepo)
+	sessionService := service.NewSessionService(sessionRepo)
 	categoryService := service.NewCategoryService(categoryRepo)
-	taskService := service.NewTaskService(taskRepo)
+	taskService := service.NewTaskService(taskRepo, statusRepo, fieldRepo, userRepo, categoryRepo)
+	statusService := service.NewStatusService(statusRepo, taskRepo)
+	tagService := service.NewTagService(tagRepo, taskRepo)
+	commentService := service.NewCommentService(commentRepo, taskRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, NewBlobStore())
+	dependencyService := service.NewDependencyService(dependencyRepo, taskRepo)
+	timeService := service.NewTimeService(timeEntryRepo, taskRepo, userRepo)
+	estimateService := service.NewEstimateService(taskRepo, categoryRepo, userRepo)
+	assignmentService := service.NewAssignmentService(assignmentRepo, taskRepo, userRepo, workspaceRepo)
+	workspaceService := service.NewWorkspaceService(workspaceRepo, userRepo, taskRepo, categoryRepo)
+	projectService := service.NewProjectService(projectRepo, categoryService, taskService, userRepo)
+	sprintService := service.NewSprintService(sprintRepo, taskService, userRepo)
+	templateService := service.NewTemplateService(templateRepo, categoryService, taskService)
+	fieldService := service.NewFieldService(fieldRepo)
+	trashService := service.NewTrashService(trashRepo, categoryRepo)
+	notificationService := service.NewNotificationService(notificationRepo, taskRepo, assignmentRepo, userRepo)
+	searchService := service.NewSearchService(searchRepo)
+	filterService := service.NewFilterService(filterRepo, taskService, categoryRepo, userRepo)
 
 	userAPIHandler := api.NewUserAPI(userService)
 	categoryAPIHandler := api.NewCategoryAPI(categoryService)
-	taskAPIHandler := api.NewTaskAPI(taskService)
+	taskAPIHandler := api.NewTaskAPI(taskService, assignmentService, notificationService)
+	statusAPIHandler := api.NewStatusAPI(statusService)
+	tagAPIHandler := api.NewTagAPI(tagService)
+	commentAPIHandler := api.NewCommentAPI(commentService, notificationService)
+	attachmentAPIHandler := api.NewAttachmentAPI(attachmentService)
+	dependencyAPIHandler := api.NewDependencyAPI(dependencyService)
+	timeAPIHandler := api.NewTimeAPI(timeService)
+	estimateAPIHandler := api.NewEstimateAPI(estimateService)
+	assignmentAPIHandler := api.NewAssignmentAPI(assignmentService, notificationService)
+	workspaceAPIHandler := api.NewWorkspaceAPI(workspaceService)
+	projectAPIHandler := api.NewProjectAPI(projectService)
+	sprintAPIHandler := api.NewSprintAPI(sprintService)
+	templateAPIHandler := api.NewTemplateAPI(templateService)
+	fieldAPIHandler := api.NewFieldAPI(fieldService)
+	trashAPIHandler := api.NewTrashAPI(trashService)
+	notificationAPIHandler := api.NewNotificationAPI(notificationService)
+	searchAPIHandler := api.NewSearchAPI(searchService)
+	filterAPIHandler := api.NewFilterAPI(filterService)
 
 	apiHandler := APIHandler{
-		UserAPIHandler:     userAPIHandler,
-		CategoryAPIHandler: categoryAPIHandler,
-		TaskAPIHandler:     taskAPIHandler,
+		UserAPIHandler:         userAPIHandler,
+		CategoryAPIHandler:     categoryAPIHandler,
+		TaskAPIHandler:         taskAPIHandler,
+		StatusAPIHandler:       statusAPIHandler,
+		TagAPIHandler:          tagAPIHandler,
+		CommentAPIHandler:      commentAPIHandler,
+		AttachmentAPIHandler:   attachmentAPIHandler,
+		DependencyAPIHandler:   dependencyAPIHandler,
+		TimeAPIHandler:         timeAPIHandler,
+		EstimateAPIHandler:     estimateAPIHandler,
+		AssignmentAPIHandler:   assignmentAPIHandler,
+		WorkspaceAPIHandler:    workspaceAPIHandler,
+		ProjectAPIHandler:      projectAPIHandler,
+		SprintAPIHandler:       sprintAPIHandler,
+		TemplateAPIHandler:     templateAPIHandler,
+		FieldAPIHandler:        fieldAPIHandler,
+		TrashAPIHandler:        trashAPIHandler,
+		NotificationAPIHandler: notificationAPIHandler,
+		SearchAPIHandler:       searchAPIHandler,
+		FilterAPIHandler:       filterAPIHandler,
 	}
 
 	version := gin.Group("/api/v1")
@@ -198,53 +545,313 @@ func RunServer(gin *gin.Engine, filebasedDb *filebased.Data) *gin.Engine {
 		{
 			user.POST("/login", apiHandler.UserAPIHandler.Login)
 			user.POST("/register", apiHandler.UserAPIHandler.Register)
+			user.POST("/restore", apiHandler.UserAPIHandler.RestoreAccount)
 
-			user.Use(middleware.Auth())
-			user.GET("/tasks", apiHandler.UserAPIHandler.GetUserTaskCategory)
+			user.Use(middleware.Auth(), middleware.Session(sessionService, userService))
+			user.GET("/tasks", middleware.Workspace(workspaceService), apiHandler.UserAPIHandler.GetUserTaskCategory)
+			user.GET("/profile", apiHandler.UserAPIHandler.GetProfile)
+			user.PUT("/profile", apiHandler.UserAPIHandler.UpdateProfile)
+			user.DELETE("/delete", apiHandler.UserAPIHandler.DeleteAccount)
 		}
 
 		task := version.Group("/task")
 		{
-			task.Use(middleware.Auth())
+			task.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
 			task.POST("/add", apiHandler.TaskAPIHandler.AddTask)
-			task.GET("/get/:id", apiHandler.TaskAPIHandler.GetTaskByID)
-			task.PUT("/update/:id", apiHandler.TaskAPIHandler.UpdateTask)
-			task.DELETE("/delete/:id", apiHandler.TaskAPIHandler.DeleteTask)
 			task.GET("/list", apiHandler.TaskAPIHandler.GetTaskList)
-			task.GET("/category/:id", apiHandler.TaskAPIHandler.GetTaskListByCategory)
+			task.GET("/search", apiHandler.TaskAPIHandler.SearchTasks)
+			task.GET("/estimates", apiHandler.EstimateAPIHandler.GetEstimates)
+			task.GET("/assigned", apiHandler.AssignmentAPIHandler.GetAssignedTasks)
+			task.GET("/board", apiHandler.TaskAPIHandler.GetBoard)
+			task.GET("/category/:id", middleware.InWorkspace(workspaceService.CategoryWorkspace), apiHandler.TaskAPIHandler.GetTaskListByCategory)
+
+			byID := task.Group("", middleware.InWorkspace(workspaceService.TaskWorkspace))
+			byID.GET("/get/:id", apiHandler.TaskAPIHandler.GetTaskByID)
+			byID.PUT("/update/:id", apiHandler.TaskAPIHandler.UpdateTask)
+			byID.DELETE("/delete/:id", apiHandler.TaskAPIHandler.DeleteTask)
+			byID.POST("/transition/:id", apiHandler.TaskAPIHandler.TransitionTask)
+			byID.GET("/transitions/:id", apiHandler.TaskAPIHandler.GetTaskTransitions)
+			byID.PUT("/move/:id", apiHandler.TaskAPIHandler.MoveTask)
+			byID.PUT("/:id/fields", apiHandler.TaskAPIHandler.SetTaskFields)
+			byID.POST("/:id/archive", apiHandler.TaskAPIHandler.ArchiveTask)
+			byID.DELETE("/:id/archive", apiHandler.TaskAPIHandler.UnarchiveTask)
+			byID.POST("/:id/watch", apiHandler.NotificationAPIHandler.WatchTask)
+			byID.DELETE("/:id/watch", apiHandler.NotificationAPIHandler.UnwatchTask)
+			byID.GET("/:id/history", apiHandler.TaskAPIHandler.GetTaskHistory)
+			byID.GET("/:id/subtasks", apiHandler.TaskAPIHandler.GetSubtasks)
+			byID.POST("/:id/subtasks", apiHandler.TaskAPIHandler.AddSubtask)
+			byID.DELETE("/:id/parent", apiHandler.TaskAPIHandler.DetachSubtask)
+			byID.GET("/:id/progress", apiHandler.TaskAPIHandler.GetTaskProgress)
+			byID.POST("/:id/checklist", apiHandler.TaskAPIHandler.AddChecklistItem)
+			byID.PUT("/:id/checklist/order", apiHandler.TaskAPIHandler.ReorderChecklist)
+			byID.PUT("/:id/checklist/:item/toggle", apiHandler.TaskAPIHandler.ToggleChecklistItem)
+			byID.DELETE("/:id/checklist/:item", apiHandler.TaskAPIHandler.DeleteChecklistItem)
+			byID.PUT("/:id/occurrence", apiHandler.TaskAPIHandler.UpdateOccurrence)
+			byID.PUT("/:id/series", apiHandler.TaskAPIHandler.UpdateSeries)
+			byID.GET("/:id/series", apiHandler.TaskAPIHandler.GetSeries)
+			byID.GET("/:id/tags", apiHandler.TagAPIHandler.GetTaskTags)
+			byID.POST("/:id/tags/:tag", apiHandler.TagAPIHandler.AssignTag)
+			byID.DELETE("/:id/tags/:tag", apiHandler.TagAPIHandler.UnassignTag)
+			byID.GET("/:id/comments", apiHandler.CommentAPIHandler.GetComments)
+			byID.POST("/:id/comments", apiHandler.CommentAPIHandler.AddComment)
+			byID.PUT("/:id/comments/:comment", apiHandler.CommentAPIHandler.EditComment)
+			byID.DELETE("/:id/comments/:comment", apiHandler.CommentAPIHandler.DeleteComment)
+			byID.GET("/:id/attachments", apiHandler.AttachmentAPIHandler.GetAttachments)
+			byID.POST("/:id/attachments", apiHandler.AttachmentAPIHandler.UploadAttachment)
+			byID.GET("/:id/attachments/:attachment", apiHandler.AttachmentAPIHandler.DownloadAttachment)
+			byID.DELETE("/:id/attachments/:attachment", apiHandler.AttachmentAPIHandler.DeleteAttachment)
+			byID.GET("/:id/dependencies", apiHandler.DependencyAPIHandler.GetTaskDependencies)
+			byID.POST("/:id/blockers/:blocker", apiHandler.DependencyAPIHandler.AddBlocker)
+			byID.DELETE("/:id/blockers/:blocker", apiHandler.DependencyAPIHandler.RemoveBlocker)
+			byID.GET("/:id/assignees", apiHandler.AssignmentAPIHandler.GetAssignees)
+			byID.POST("/:id/assignees", apiHandler.AssignmentAPIHandler.AssignUser)
+			byID.DELETE("/:id/assignees/:user", apiHandler.AssignmentAPIHandler.UnassignUser)
+			byID.POST("/:id/timer/start", apiHandler.TimeAPIHandler.StartTimer)
+			byID.GET("/:id/time", apiHandler.TimeAPIHandler.GetTaskTime)
+			byID.POST("/:id/time", apiHandler.TimeAPIHandler.AddTimeEntry)
+		}
+
+		timeTracking := version.Group("/time")
+		{
+			timeTracking.Use(middleware.Auth(), middleware.Session(sessionService, userService))
+			timeTracking.GET("/running", apiHandler.TimeAPIHandler.GetRunningTimer)
+			timeTracking.POST("/stop", apiHandler.TimeAPIHandler.StopTimer)
+			timeTracking.DELETE("/entry/:id", apiHandler.TimeAPIHandler.DeleteTimeEntry)
+			timeTracking.GET("/report", apiHandler.TimeAPIHandler.GetTimesheet)
+			timeTracking.GET("/export", apiHandler.TimeAPIHandler.ExportTimesheet)
+		}
+
+		status := version.Group("/status")
+		{
+			status.Use(middleware.Auth(), middleware.Session(sessionService, userService))
+			status.GET("/list", apiHandler.StatusAPIHandler.GetStatusList)
+			status.POST("/add", apiHandler.StatusAPIHandler.AddStatus)
+			status.DELETE("/delete/:id", apiHandler.StatusAPIHandler.DeleteStatus)
+		}
+
+		tag := version.Group("/tag")
+		{
+			tag.Use(middleware.Auth(), middleware.Session(sessionService, userService))
+			tag.GET("/list", apiHandler.TagAPIHandler.GetTagList)
+			tag.POST("/add", apiHandler.TagAPIHandler.AddTag)
+			tag.PUT("/update/:id", apiHandler.TagAPIHandler.UpdateTag)
+			tag.DELETE("/delete/:id", apiHandler.TagAPIHandler.DeleteTag)
 		}
 
 		category := version.Group("/category")
 		{
-			category.Use(middleware.Auth())
+			category.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
 			category.POST("/add", apiHandler.CategoryAPIHandler.AddCategory)
-			category.GET("/get/:id", apiHandler.CategoryAPIHandler.GetCategoryByID)
-			category.PUT("/update/:id", apiHandler.CategoryAPIHandler.UpdateCategory)
-			category.DELETE("/delete/:id", apiHandler.CategoryAPIHandler.DeleteCategory)
 			category.GET("/list", apiHandler.CategoryAPIHandler.GetCategoryList)
+
+			byID := category.Group("", middleware.InWorkspace(workspaceService.CategoryWorkspace))
+			byID.GET("/get/:id", apiHandler.CategoryAPIHandler.GetCategoryByID)
+			byID.PUT("/update/:id", apiHandler.CategoryAPIHandler.UpdateCategory)
+			byID.DELETE("/delete/:id", apiHandler.CategoryAPIHandler.DeleteCategory)
+			byID.GET("/:id/dependencies", apiHandler.DependencyAPIHandler.GetDependencyGraph)
+			byID.POST("/:id/watch", apiHandler.NotificationAPIHandler.WatchCategory)
+			byID.DELETE("/:id/watch", apiHandler.NotificationAPIHandler.UnwatchCategory)
+		}
+
+		workspace := version.Group("/workspace")
+		{
+			workspace.Use(middleware.Auth(), middleware.Session(sessionService, userService))
+			workspace.GET("/list", apiHandler.WorkspaceAPIHandler.GetWorkspaces)
+			workspace.POST("/add", apiHandler.WorkspaceAPIHandler.AddWorkspace)
+			workspace.PUT("/select/:id", apiHandler.WorkspaceAPIHandler.SelectWorkspace)
+			workspace.GET("/invitations", apiHandler.WorkspaceAPIHandler.GetInvitations)
+			workspace.POST("/invitations/:id/accept", apiHandler.WorkspaceAPIHandler.AcceptInvitation)
+			workspace.POST("/invitations/:id/decline", apiHandler.WorkspaceAPIHandler.DeclineInvitation)
+			workspace.GET("/:id/members", apiHandler.WorkspaceAPIHandler.GetMembers)
+			workspace.POST("/:id/invitations", apiHandler.WorkspaceAPIHandler.Invite)
+			workspace.PUT("/:id/members/:user", apiHandler.WorkspaceAPIHandler.ChangeRole)
+			workspace.DELETE("/:id/members/:user", apiHandler.WorkspaceAPIHandler.RemoveMember)
+		}
+
+		project := version.Group("/project")
+		{
+			project.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
+			project.GET("/list", apiHandler.ProjectAPIHandler.GetProjects)
+			project.POST("/add", apiHandler.ProjectAPIHandler.AddProject)
+			project.GET("/get/:id", apiHandler.ProjectAPIHandler.GetProject)
+			project.PUT("/update/:id", apiHandler.ProjectAPIHandler.UpdateProject)
+			project.DELETE("/delete/:id", apiHandler.ProjectAPIHandler.DeleteProject)
+			project.GET("/:id/overview", apiHandler.ProjectAPIHandler.GetOverview)
+			project.PUT("/:id/categories/:category", apiHandler.ProjectAPIHandler.AddCategory)
+			project.DELETE("/:id/categories/:category", apiHandler.ProjectAPIHandler.RemoveCategory)
+			project.POST("/:id/milestones", apiHandler.ProjectAPIHandler.AddMilestone)
+			project.PUT("/:id/milestones/:milestone", apiHandler.ProjectAPIHandler.UpdateMilestone)
+			project.DELETE("/:id/milestones/:milestone", apiHandler.ProjectAPIHandler.DeleteMilestone)
+			project.PUT("/:id/milestones/:milestone/tasks/:task", apiHandler.ProjectAPIHandler.AttachTask)
+			project.DELETE("/:id/milestones/:milestone/tasks/:task", apiHandler.ProjectAPIHandler.DetachTask)
+		}
+
+		sprint := version.Group("/sprint")
+		{
+			sprint.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
+			sprint.GET("/list", apiHandler.SprintAPIHandler.GetSprints)
+			sprint.POST("/add", apiHandler.SprintAPIHandler.AddSprint)
+			sprint.GET("/get/:id", apiHandler.SprintAPIHandler.GetSprint)
+			sprint.PUT("/update/:id", apiHandler.SprintAPIHandler.UpdateSprint)
+			sprint.DELETE("/delete/:id", apiHandler.SprintAPIHandler.DeleteSprint)
+			sprint.PUT("/:id/tasks/:task", apiHandler.SprintAPIHandler.PlanTask)
+			sprint.DELETE("/:id/tasks/:task", apiHandler.SprintAPIHandler.UnplanTask)
+			sprint.POST("/:id/start", apiHandler.SprintAPIHandler.StartSprint)
+			sprint.POST("/:id/close", apiHandler.SprintAPIHandler.CloseSprint)
+			sprint.GET("/:id/burndown", apiHandler.SprintAPIHandler.GetBurndown)
+		}
+
+		template := version.Group("/template")
+		{
+			template.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
+			template.GET("/list", apiHandler.TemplateAPIHandler.GetTemplates)
+			template.POST("/add", apiHandler.TemplateAPIHandler.AddTemplate)
+			template.GET("/get/:id", apiHandler.TemplateAPIHandler.GetTemplate)
+			template.PUT("/update/:id", apiHandler.TemplateAPIHandler.UpdateTemplate)
+			template.DELETE("/delete/:id", apiHandler.TemplateAPIHandler.DeleteTemplate)
+			template.POST("/:id/instantiate", apiHandler.TemplateAPIHandler.InstantiateTemplate)
+		}
+
+		field := version.Group("/field")
+		{
+			field.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
+			field.GET("/list", apiHandler.FieldAPIHandler.GetFields)
+			field.POST("/add", apiHandler.FieldAPIHandler.AddField)
+			field.GET("/get/:id", apiHandler.FieldAPIHandler.GetField)
+			field.PUT("/update/:id", apiHandler.FieldAPIHandler.UpdateField)
+			field.DELETE("/delete/:id", apiHandler.FieldAPIHandler.DeleteField)
+		}
+
+		trash := version.Group("/trash")
+		{
+			trash.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
+			trash.GET("/list", apiHandler.TrashAPIHandler.GetTrash)
+			trash.GET("/get/:id", apiHandler.TrashAPIHandler.GetTrashItem)
+			trash.POST("/restore/:id", apiHandler.TrashAPIHandler.RestoreTrashItem)
+			trash.DELETE("/purge/:id", apiHandler.TrashAPIHandler.PurgeTrashItem)
+		}
+
+		notification := version.Group("/notification")
+		{
+			notification.Use(middleware.Auth(), middleware.Session(sessionService, userService))
+			notification.GET("/list", apiHandler.NotificationAPIHandler.GetNotifications)
+			notification.GET("/unread", apiHandler.NotificationAPIHandler.GetUnreadCount)
+			notification.PUT("/read/:id", apiHandler.NotificationAPIHandler.MarkRead)
+			notification.PUT("/read", apiHandler.NotificationAPIHandler.MarkAllRead)
+			notification.GET("/watches", apiHandler.NotificationAPIHandler.GetWatches)
+		}
+
+		search := version.Group("/search")
+		{
+			search.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
+			search.GET("", apiHandler.SearchAPIHandler.Search)
+		}
+
+		filter := version.Group("/filter")
+		{
+			filter.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Workspace(workspaceService))
+			filter.GET("/list", apiHandler.FilterAPIHandler.GetFilters)
+			filter.POST("/add", apiHandler.FilterAPIHandler.AddFilter)
+			filter.PUT("/update/:id", apiHandler.FilterAPIHandler.UpdateFilter)
+			filter.DELETE("/delete/:id", apiHandler.FilterAPIHandler.DeleteFilter)
+			filter.GET("/tasks/:ref", apiHandler.FilterAPIHandler.GetFilterTasks)
+		}
+
+		admin := version.Group("/admin")
+		{
+			admin.Use(middleware.Auth(), middleware.Session(sessionService, userService), middleware.Admin())
+			admin.DELETE("/user/delete/:id", apiHandler.UserAPIHandler.DeleteUser)
+			admin.POST("/user/restore/:id", apiHandler.UserAPIHandler.RestoreUser)
+			admin.GET("/audit/list", apiHandler.UserAPIHandler.GetAuditLog)
+			admin.POST("/search/rebuild", apiHandler.SearchAPIHandler.RebuildIndex)
 		}
 	}
 
 	return gin
 }
 
+func RunScheduler(filebasedDb *filebased.Data) {
+	userService := service.NewUserService(repo.NewUserRepo(filebasedDb), repo.NewSessionsRepo(filebasedDb))
+	attachmentService := service.NewAttachmentService(repo.NewAttachmentRepo(filebasedDb), repo.NewTaskRepo(filebasedDb), NewBlobStore())
+	trashService := service.NewTrashService(repo.NewTrashRepo(filebasedDb), repo.NewCategoryRepo(filebasedDb))
+	notificationService := service.NewNotificationService(repo.NewNotificationRepo(filebasedDb), repo.NewTaskRepo(filebasedDb), repo.NewAssignmentRepo(filebasedDb), repo.NewUserRepo(filebasedDb))
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for now := time.Now(); ; now = <-ticker.C {
+		erased, err := userService.PurgeDeletedAccounts(now)
+		if err != nil {
+			log.Println("Error purging deleted accounts:", err)
+		} else if erased > 0 {
+			log.Printf("Erased %d deleted accounts", erased)
+		}
+
+		purged, err := trashService.PurgeExpired(now)
+		if err != nil {
+			log.Println("Error purging the trash:", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d trash items", purged)
+		}
+
+		removed, err := attachmentService.PurgeOrphanedBlobs()
+		if err != nil {
+			log.Println("Error removing deleted attachments:", err)
+		} else if removed > 0 {
+			log.Printf("Removed %d deleted attachments", removed)
+		}
+
+		reminded, err := notificationService.NotifyDueSoon(now)
+		if err != nil {
+			log.Println("Error sending deadline reminders:", err)
+		} else if reminded > 0 {
+			log.Printf("Sent %d deadline reminders", reminded)
+		}
+	}
+}
+
+func NewBlobStore() blobstore.BlobStore {
+	if config.BlobStore == "s3" {
+		return blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  config.S3Endpoint,
+			Bucket:    config.S3Bucket,
+			Region:    config.GetS3Region(),
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+		}, nil)
+	}
+
+	return blobstore.NewLocalStore(config.GetBlobDir())
+}
+
 func RunClient(gin *gin.Engine, embed embed.FS, filebasedDb *filebased.Data) *gin.Engine {
 	sessionRepo := repo.NewSessionsRepo(filebasedDb)
 	sessionService := service.NewSessionService(sessionRepo)
+	userService := service.NewUserService(repo.NewUserRepo(filebasedDb), sessionRepo)
 
 	userClient := client.NewUserClient()
 	taskClient := client.NewTaskClient()
 	categoryClient := client.NewCategoryClient()
+	workspaceClient := client.NewWorkspaceClient()
+	projectClient := client.NewProjectClient()
+	notificationClient := client.NewNotificationClient()
+	filterClient := client.NewFilterClient()
 
 	authWeb := web.NewAuthWeb(userClient, sessionService, embed)
 	modalWeb := web.NewModalWeb(embed)
 	homeWeb := web.NewHomeWeb(embed)
-	dashboardWeb := web.NewDashboardWeb(userClient, sessionService, embed)
-	taskWeb := web.NewTaskWeb(taskClient, sessionService, embed)
-	categoryWeb := web.NewCategoryWeb(categoryClient, sessionService, embed)
+	dashboardWeb := web.NewDashboardWeb(userClient, taskClient, workspaceClient, notificationClient, sessionService, embed)
+	taskWeb := web.NewTaskWeb(taskClient, userClient, workspaceClient, notificationClient, filterClient, sessionService, embed)
+	categoryWeb := web.NewCategoryWeb(categoryClient, workspaceClient, notificationClient, sessionService, embed)
+	settingsWeb := web.NewSettingsWeb(userClient, workspaceClient, notificationClient, sessionService, embed)
+	workspaceWeb := web.NewWorkspaceWeb(workspaceClient, sessionService)
+	projectWeb := web.NewProjectWeb(projectClient, categoryClient, userClient, workspaceClient, notificationClient, sessionService, embed)
+	notificationWeb := web.NewNotificationWeb(notificationClient, sessionService)
+	boardWeb := web.NewBoardWeb(taskClient, userClient, workspaceClient, notificationClient, sessionService, embed)
+	filterWeb := web.NewFilterWeb(filterClient, sessionService)
 
 	client := ClientHandler{
-		authWeb, homeWeb, dashboardWeb, taskWeb, categoryWeb, modalWeb,
+		authWeb, homeWeb, dashboardWeb, taskWeb, categoryWeb, modalWeb, settingsWeb, workspaceWeb, projectWeb, boardWeb, notificationWeb, filterWeb,
 	}
 
 	gin.StaticFS("/static", http.Dir("frontend/public"))
@@ -257,18 +864,48 @@ func RunClient(gin *gin.Engine, embed embed.FS, filebasedDb *filebased.Data) *gi
 		user.POST("/login/process", client.AuthWeb.LoginProcess)
 		user.GET("/register", client.AuthWeb.Register)
 		user.POST("/register/process", client.AuthWeb.RegisterProcess)
+		user.GET("/restore", client.AuthWeb.Restore)
+		user.POST("/restore/process", client.AuthWeb.RestoreProcess)
 
-		user.Use(middleware.Auth())
+		user.Use(middleware.Auth(), middleware.Session(sessionService, userService))
 		user.GET("/logout", client.AuthWeb.Logout)
 	}
 
 	main := gin.Group("/client")
 	{
-		main.Use(middleware.Auth())
+		main.Use(middleware.Auth(), middleware.Session(sessionService, userService))
 		main.GET("/dashboard", client.DashboardWeb.Dashboard)
 		main.GET("/task", client.TaskWeb.TaskPage)
 		user.POST("/task/add/process", client.TaskWeb.TaskAddProcess)
+		main.POST("/task/transition/process", client.TaskWeb.TaskTransitionProcess)
+		main.POST("/task/checklist/add/process", client.TaskWeb.TaskChecklistAddProcess)
+		main.POST("/task/checklist/toggle/process", client.TaskWeb.TaskChecklistToggleProcess)
+		main.GET("/task/detail/:id", client.TaskWeb.TaskDetailPage)
+		main.POST("/task/comment/add/process", client.TaskWeb.TaskCommentAddProcess)
+		main.POST("/task/attachment/upload/process", client.TaskWeb.TaskAttachmentUploadProcess)
+		main.POST("/task/blocker/add/process", client.TaskWeb.TaskBlockerAddProcess)
+		main.POST("/task/timer/process", client.TaskWeb.TaskTimerProcess)
+		main.POST("/task/assign/process", client.TaskWeb.TaskAssignProcess)
+		main.POST("/task/fields/process", client.TaskWeb.TaskFieldsProcess)
+		main.POST("/task/archive/process", client.TaskWeb.TaskArchiveProcess)
+		main.GET("/board", client.BoardWeb.BoardPage)
+		main.POST("/board/move/process", client.BoardWeb.BoardMoveProcess)
 		main.GET("/category", client.CategoryWeb.Category)
+		main.GET("/project", client.ProjectWeb.ProjectPage)
+		main.POST("/project/add/process", client.ProjectWeb.ProjectAddProcess)
+		main.POST("/project/category/add/process", client.ProjectWeb.ProjectCategoryAddProcess)
+		main.POST("/project/milestone/add/process", client.ProjectWeb.ProjectMilestoneAddProcess)
+		main.POST("/workspace/select/process", client.WorkspaceWeb.SelectProcess)
+		main.POST("/workspace/add/process", client.WorkspaceWeb.AddProcess)
+		main.POST("/workspace/invite/process", client.WorkspaceWeb.InviteProcess)
+		main.POST("/workspace/invitation/process", client.WorkspaceWeb.InvitationProcess)
+		main.GET("/settings", client.SettingsWeb.Settings)
+		main.POST("/settings/profile/process", client.SettingsWeb.ProfileProcess)
+		main.POST("/settings/delete/process", client.SettingsWeb.DeleteAccountProcess)
+		main.POST("/notification/read/process", client.NotificationWeb.ReadProcess)
+		main.POST("/watch/process", client.NotificationWeb.WatchProcess)
+		main.POST("/filter/add/process", client.FilterWeb.AddProcess)
+		main.POST("/filter/delete/process", client.FilterWeb.DeleteProcess)
 	}
 
 	modal := gin.Group("/client")
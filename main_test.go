@@ -2,6 +2,8 @@ package main_test
 
 import (
 	main "a21hc3NpZ25tZW50"
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/db/blobstore"
 	"a21hc3NpZ25tZW50/db/filebased"
 	"a21hc3NpZ25tZW50/middleware"
 	"a21hc3NpZ25tZW50/model"
@@ -9,12 +11,16 @@ import (
 	"a21hc3NpZ25tZW50/service"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -24,6 +30,7 @@ import (
 	"github.com/golang-jwt/jwt"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.etcd.io/bbolt"
 )
 
 var html string
@@ -105,7 +112,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 		userService = service.NewUserService(userRepo, sessionRepo)
 		sessionService = service.NewSessionService(sessionRepo)
 		categoryService = service.NewCategoryService(categoryRepo)
-		taskService = service.NewTaskService(taskRepo)
+		taskService = service.NewTaskService(taskRepo, repo.NewStatusRepo(filebasedDb), repo.NewFieldRepo(filebasedDb), userRepo, categoryRepo)
 
 		Expect(err).ShouldNot(HaveOccurred())
 
@@ -153,7 +160,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 			{
 				ID:         1,
 				Title:      "Task 1",
-				Deadline:   "2023-05-30",
+				Deadline:   model.DateDeadline(2023, time.May, 30),
 				Priority:   2,
 				Status:     "In Progress",
 				CategoryID: 1,
@@ -162,7 +169,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 			{
 				ID:         2,
 				Title:      "Task 2",
-				Deadline:   "2023-06-01",
+				Deadline:   model.DateDeadline(2023, time.June, 1),
 				Priority:   1,
 				Status:     "Completed",
 				CategoryID: 2,
@@ -171,7 +178,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 			{
 				ID:         3,
 				Title:      "Task 3",
-				Deadline:   "2023-06-02",
+				Deadline:   model.DateDeadline(2023, time.June, 2),
 				Priority:   4,
 				Status:     "Completed",
 				CategoryID: 1,
@@ -180,7 +187,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 			{
 				ID:         4,
 				Title:      "Task 4",
-				Deadline:   "2023-06-02",
+				Deadline:   model.DateDeadline(2023, time.June, 2),
 				Priority:   3,
 				Status:     "Completed",
 				CategoryID: 1,
@@ -189,7 +196,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 			{
 				ID:         5,
 				Title:      "Task 5",
-				Deadline:   "2023-06-07",
+				Deadline:   model.DateDeadline(2023, time.June, 7),
 				Priority:   5,
 				Status:     "In Progress",
 				CategoryID: 3,
@@ -197,8 +204,8 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 			},
 		}
 
-		for _, v := range insertTasks {
-			err := taskRepo.Store(&v)
+		for i := range insertTasks {
+			err := taskRepo.Store(&insertTasks[i], "")
 			Expect(err).ShouldNot(HaveOccurred())
 		}
 
@@ -416,7 +423,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 
 			When("retrieving user task categories from user repository", func() {
 				It("should return the expected user task categories", func() {
-					resUserTask, err := userRepo.GetUserTaskCategory()
+					resUserTask, err := userRepo.GetUserTaskCategory(0)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(resUserTask).To(Equal(expectedUserTask))
 				})
@@ -454,7 +461,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 
 			When("retrieving the list of categories from the database", func() {
 				It("should return the list of categories without any errors and the list should contain the expected number of categories", func() {
-					results, err := categoryRepo.GetList()
+					results, err := categoryRepo.GetList(0)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(results).To(HaveLen(5))
 
@@ -470,12 +477,12 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 					newTask := model.Task{
 						ID:         1,
 						Title:      "Updated with Repository Task 1",
-						Deadline:   "2023-05-30",
+						Deadline:   model.DateDeadline(2023, time.May, 30),
 						Priority:   2,
 						CategoryID: 1,
 						Status:     "In Progress",
 					}
-					err = taskRepo.Update(newTask.ID, &newTask)
+					err = taskRepo.Update(newTask.ID, &newTask, "test@mail.com")
 					Expect(err).ShouldNot(HaveOccurred())
 
 					result, err := taskRepo.GetByID(1)
@@ -485,12 +492,17 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 					Expect(result.Priority).To(Equal(newTask.Priority))
 					Expect(result.CategoryID).To(Equal(newTask.CategoryID))
 					Expect(result.Status).To(Equal(newTask.Status))
+
+					events, err := taskRepo.GetHistory(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(events[len(events)-1].Action).To(Equal(model.EventUpdated))
+					Expect(events[len(events)-1].Actor).To(Equal("test@mail.com"))
 				})
 			})
 
 			When("deleting a task with a valid task ID from the database", func() {
 				It("should delete the task without any errors", func() {
-					err = taskRepo.Delete(2)
+					err = taskRepo.Delete(2, "test@mail.com")
 					Expect(err).ShouldNot(HaveOccurred())
 
 					result, err := taskRepo.GetByID(2)
@@ -501,7 +513,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 
 			When("retrieving the list of tasks from the database", func() {
 				It("should return the list of tasks without any errors", func() {
-					results, err := taskRepo.GetList()
+					results, err := taskRepo.GetList(0)
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(results).To(HaveLen(5))
 
@@ -511,7 +523,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 
 			When("retrieving the list of tasks for a specific category from the database", func() {
 				It("should return the list of tasks for the specified category without any errors", func() {
-					taskCategory, err := taskRepo.GetTaskCategory(1)
+					taskCategory, err := taskRepo.GetTaskCategory(0, 1)
 					Expect(err).ShouldNot(HaveOccurred())
 
 					Expect(taskCategory).To(Equal([]model.TaskCategory{
@@ -522,6 +534,85 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 				})
 			})
 
+			When("the database holds deadlines written as free text by an older version", func() {
+				It("should migrate readable ones and keep the others as legacy deadlines", func() {
+					err = filebasedDb.DB.Update(func(tx *bbolt.Tx) error {
+						tasks := tx.Bucket([]byte("Tasks"))
+						if err := tasks.Put([]byte("6"), []byte(`{"id":6,"title":"Dated","deadline":"2 January 2024"}`)); err != nil {
+							return err
+						}
+						if err := tasks.Put([]byte("7"), []byte(`{"id":7,"title":"Undated","deadline":"after lunch"}`)); err != nil {
+							return err
+						}
+						return tx.Bucket([]byte("Meta")).Put([]byte("migrations"), []byte("0"))
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(filebasedDb.DB.Close()).Should(Succeed())
+
+					filebasedDb, err = filebased.InitDB()
+					Expect(err).ShouldNot(HaveOccurred())
+					taskRepo = repo.NewTaskRepo(filebasedDb)
+
+					dated, err := taskRepo.GetByID(6)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(dated.Deadline).To(Equal(model.DateDeadline(2024, time.January, 2)))
+					Expect(dated.LegacyDeadline).To(BeEmpty())
+
+					undated, err := taskRepo.GetByID(7)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(undated.Deadline.IsZero()).To(BeTrue())
+					Expect(undated.LegacyDeadline).To(Equal("after lunch"))
+				})
+			})
+
+		})
+
+		Describe("Blob store", func() {
+			When("using an S3-compatible server", func() {
+				It("should store, read and delete signed objects", func() {
+					objects := map[string][]byte{}
+					server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") {
+							w.WriteHeader(http.StatusForbidden)
+							return
+						}
+						switch r.Method {
+						case http.MethodPut:
+							objects[r.URL.Path], _ = io.ReadAll(r.Body)
+						case http.MethodGet:
+							content, ok := objects[r.URL.Path]
+							if !ok {
+								w.WriteHeader(http.StatusNotFound)
+								return
+							}
+							w.Write(content)
+						case http.MethodDelete:
+							delete(objects, r.URL.Path)
+							w.WriteHeader(http.StatusNoContent)
+						}
+					}))
+					defer server.Close()
+
+					store := blobstore.NewS3Store(blobstore.S3Config{
+						Endpoint: server.URL, Bucket: "tasks", Region: "us-east-1", AccessKey: "minio", SecretKey: "minio123",
+					}, server.Client())
+
+					Expect(store.Put("tasks/1/report", strings.NewReader("quarterly report"), 16, "text/plain")).Should(Succeed())
+					Expect(objects).To(HaveKeyWithValue("/tasks/tasks/1/report", []byte("quarterly report")))
+
+					content, err := store.Get("tasks/1/report")
+					Expect(err).ShouldNot(HaveOccurred())
+					data, _ := io.ReadAll(content)
+					content.Close()
+					Expect(string(data)).To(Equal("quarterly report"))
+
+					Expect(store.Delete("tasks/1/report")).Should(Succeed())
+					_, err = store.Get("tasks/1/report")
+					Expect(err).To(MatchError(blobstore.ErrBlobNotFound))
+
+					Expect(store.Put("../escape", strings.NewReader("x"), 1, "")).ShouldNot(Succeed())
+				})
+			})
 		})
 	})
 
@@ -551,12 +642,198 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 			Describe("GetUserTaskCategory", func() {
 				When("retrieving user task categories from user repository", func() {
 					It("should return the expected user task categories", func() {
-						resUserTask, err := userService.GetUserTaskCategory()
+						resUserTask, err := userService.GetUserTaskCategory(0)
 						Expect(err).ShouldNot(HaveOccurred())
 						Expect(resUserTask).To(Equal(expectedUserTask))
 					})
 				})
 			})
+
+			Describe("RequestDeletion", func() {
+				When("the user requests the deletion of its account", func() {
+					It("should refuse logins until the account is restored", func() {
+						user, err := userService.RequestDeletion(1, "test@mail.com")
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(user.DeletionDueAt).NotTo(BeNil())
+
+						_, err = userService.Login(&model.User{Email: "test@mail.com", Password: "testing123"})
+						Expect(err).Should(HaveOccurred())
+
+						_, err = userService.RestoreAccount(&model.User{Email: "test@mail.com", Password: "testing123"})
+						Expect(err).ShouldNot(HaveOccurred())
+
+						_, err = userService.Login(&model.User{Email: "test@mail.com", Password: "testing123"})
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+				})
+
+				When("the deletion was already requested or the user does not exist", func() {
+					It("should return typed errors", func() {
+						_, err := userService.RequestDeletion(1, "test@mail.com")
+						Expect(err).ShouldNot(HaveOccurred())
+
+						_, err = userService.RequestDeletion(1, "test@mail.com")
+						Expect(err).Should(MatchError(model.ErrDeletionScheduled))
+
+						_, err = userService.RequestDeletion(99, "test@mail.com")
+						Expect(errors.Is(err, model.ErrUserNotFound)).To(BeTrue())
+					})
+				})
+			})
+
+			Describe("UpdateProfile", func() {
+				When("the time zone is a known IANA zone", func() {
+					It("should store it and use it to decide whether deadlines are overdue", func() {
+						profile, err := userService.UpdateProfile(1, model.UserProfile{TimeZone: "Asia/Jakarta"})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(profile.TimeZone).To(Equal("Asia/Jakarta"))
+
+						profile, err = userService.UpdateProfile(1, model.UserProfile{Fullname: "Test User"})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(profile.TimeZone).To(Equal("Asia/Jakarta"))
+
+						loc := model.LoadLocation(profile.TimeZone)
+						deadline := model.DateDeadline(2023, time.May, 30)
+						Expect(deadline.Overdue(time.Date(2023, time.May, 30, 16, 0, 0, 0, time.UTC), loc)).To(BeFalse())
+						Expect(deadline.Overdue(time.Date(2023, time.May, 30, 17, 0, 0, 0, time.UTC), loc)).To(BeTrue())
+					})
+				})
+
+				When("the time zone is unknown", func() {
+					It("should return an error", func() {
+						_, err := userService.UpdateProfile(1, model.UserProfile{TimeZone: "Mars/Olympus"})
+						Expect(err).Should(HaveOccurred())
+					})
+				})
+			})
+
+			Describe("PurgeDeletedAccounts", func() {
+				When("the grace period of a deleted account has ended", func() {
+					It("should erase the user with its tasks and record the erasure", func() {
+						_, err := userService.RequestDeletion(1, "test@mail.com")
+						Expect(err).ShouldNot(HaveOccurred())
+
+						erased, err := userService.PurgeDeletedAccounts(time.Now())
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(erased).To(Equal(0))
+
+						erased, err = userService.PurgeDeletedAccounts(time.Now().Add(config.GetDeletionGracePeriod() + time.Hour))
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(erased).To(Equal(1))
+
+						resUser, err := userRepo.GetUserByEmail("test@mail.com")
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(resUser.ID).To(Equal(0))
+
+						tasks, err := taskRepo.GetList(0)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(tasks).To(HaveLen(3))
+
+						records, err := userService.GetAuditLog()
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(records).To(HaveLen(2))
+						Expect(records[1].Action).To(Equal("user.erased"))
+						Expect(records[1].UserID).To(Equal(1))
+					})
+
+					It("should only erase the tasks the user owns, whatever user ID requests claim", func() {
+						send := func(method, url string, task model.Task) {
+							body, _ := json.Marshal(task)
+							r, _ := http.NewRequest(method, url, bytes.NewReader(body))
+							r.Header.Set("Content-Type", "application/json")
+							w := httptest.NewRecorder()
+							r.AddCookie(SetCookie(apiServer))
+							apiServer.ServeHTTP(w, r)
+							Expect(w.Code).To(Equal(http.StatusOK))
+						}
+						send("POST", "/api/v1/task/add", model.Task{Title: "Created by test", Priority: 1, UserID: 2})
+						Expect(repo.NewAssignmentRepo(filebasedDb).Assign(1, 1)).Should(Succeed())
+						send("PUT", "/api/v1/task/update/1", model.Task{Title: "Taken over", Priority: 2, Status: model.StatusInProgress, UserID: 1})
+
+						_, err := userService.RequestDeletion(1, "test@mail.com")
+						Expect(err).ShouldNot(HaveOccurred())
+						_, err = userService.PurgeDeletedAccounts(time.Now().Add(config.GetDeletionGracePeriod() + time.Hour))
+						Expect(err).ShouldNot(HaveOccurred())
+
+						tasks, err := taskRepo.GetList(0)
+						Expect(err).ShouldNot(HaveOccurred())
+						titles := []string{}
+						for _, task := range tasks {
+							titles = append(titles, task.Title)
+						}
+						Expect(titles).To(ConsistOf("Taken over", "Task 3", "Task 4"))
+					})
+
+					It("should hand shared workspaces over and keep what other members rely on", func() {
+						colleague, err := userService.Register(&model.User{Fullname: "Colleague", Email: "colleague@mail.com", Password: "testing123"})
+						Expect(err).ShouldNot(HaveOccurred())
+
+						workspaceRepo := repo.NewWorkspaceRepo(filebasedDb)
+						team, err := workspaceRepo.Store(model.Workspace{Name: "Team", OwnerID: 1, CreatedAt: time.Now()})
+						Expect(err).ShouldNot(HaveOccurred())
+						solo, err := workspaceRepo.Store(model.Workspace{Name: "Solo", OwnerID: 1, CreatedAt: time.Now()})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(workspaceRepo.PutMember(model.WorkspaceMember{WorkspaceID: team.ID, UserID: colleague.ID, Role: model.RoleMember, JoinedAt: time.Now()})).Should(Succeed())
+
+						project, err := repo.NewProjectRepo(filebasedDb).Store(model.Project{Name: "Launch", UserID: 1, WorkspaceID: team.ID})
+						Expect(err).ShouldNot(HaveOccurred())
+						sprint, err := repo.NewSprintRepo(filebasedDb).Store(model.Sprint{Name: "Sprint 1", UserID: 1, WorkspaceID: team.ID})
+						Expect(err).ShouldNot(HaveOccurred())
+						field, err := repo.NewFieldRepo(filebasedDb).Store(model.CustomField{Name: "Client", Type: model.FieldText, UserID: 1, WorkspaceID: team.ID})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(categoryRepo.Store(&model.Category{ID: 60, Name: "Team work", UserID: 1, WorkspaceID: team.ID})).Should(Succeed())
+
+						parent := model.Task{Title: "Team parent", Priority: 1, UserID: 1, WorkspaceID: team.ID, CategoryID: 60}
+						Expect(taskRepo.Store(&parent, "test@mail.com")).Should(Succeed())
+						shared := model.Task{Title: "Colleague task", Priority: 1, UserID: colleague.ID, WorkspaceID: team.ID, CategoryID: 60,
+							ParentID: parent.ID, SprintID: sprint.ID, Fields: map[int]interface{}{field.ID: "ACME"}}
+						Expect(taskRepo.Store(&shared, "colleague@mail.com")).Should(Succeed())
+						Expect(taskRepo.Store(&model.Task{Title: "Solo task", Priority: 1, UserID: 1, WorkspaceID: solo.ID}, "test@mail.com")).Should(Succeed())
+
+						purge := func() int {
+							_, err := userService.RequestDeletion(1, "test@mail.com")
+							Expect(err).ShouldNot(HaveOccurred())
+							erased, err := userService.PurgeDeletedAccounts(time.Now().Add(config.GetDeletionGracePeriod() + time.Hour))
+							Expect(err).ShouldNot(HaveOccurred())
+							return erased
+						}
+						Expect(purge()).To(Equal(0))
+
+						_, err = userService.RestoreAccountByID(1, "admin@mail.com")
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(workspaceRepo.PutMember(model.WorkspaceMember{WorkspaceID: team.ID, UserID: colleague.ID, Role: model.RoleAdmin, JoinedAt: time.Now()})).Should(Succeed())
+						Expect(purge()).To(Equal(1))
+
+						resTeam, err := workspaceRepo.GetByID(team.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(resTeam.OwnerID).To(Equal(colleague.ID))
+						members, err := workspaceRepo.GetMembers(team.ID, colleague.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(members).To(HaveLen(1))
+						Expect(members[0].Role).To(Equal(model.RoleOwner))
+
+						_, err = workspaceRepo.GetByID(solo.ID)
+						Expect(err).Should(HaveOccurred())
+						soloTasks, err := taskRepo.GetList(solo.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(soloTasks).To(BeEmpty())
+
+						_, err = repo.NewProjectRepo(filebasedDb).GetByID(project.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						_, err = repo.NewSprintRepo(filebasedDb).GetByID(sprint.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						_, err = repo.NewFieldRepo(filebasedDb).GetByID(field.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+
+						resTask, err := taskRepo.GetByID(shared.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(resTask.CategoryID).To(Equal(0))
+						Expect(resTask.ParentID).To(Equal(0))
+						Expect(resTask.SprintID).To(Equal(sprint.ID))
+						Expect(resTask.Fields).To(HaveKeyWithValue(field.ID, "ACME"))
+					})
+				})
+			})
 		})
 
 		Describe("Category Service", func() {
@@ -583,68 +860,1514 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 				})
 			})
 
-			Describe("GetList", func() {
-				When("retrieving the list of categories from the database", func() {
-					It("should return the list of categories without any errors", func() {
-						categories, err := categoryService.GetList()
-						Expect(err).ShouldNot(HaveOccurred())
-						Expect(categories).To(HaveLen(5))
+			Describe("GetList", func() {
+				When("retrieving the list of categories from the database", func() {
+					It("should return the list of categories without any errors", func() {
+						categories, err := categoryService.GetList(0)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(categories).To(HaveLen(5))
+
+						Expect(categories).To(Equal(insertCategories))
+					})
+				})
+			})
+		})
+
+		Describe("Task Service", func() {
+			Describe("Update", func() {
+				When("updating a task in the database", func() {
+					It("should update the task without any errors", func() {
+						task := &model.Task{
+							ID:         1,
+							Title:      "Updated with Service Task 1",
+							Deadline:   model.DateDeadline(2023, time.May, 30),
+							Priority:   5,
+							CategoryID: 1,
+							Status:     "In Progress",
+							UserID:     1,
+						}
+
+						err := taskService.Update(task.ID, task)
+						Expect(err).ShouldNot(HaveOccurred())
+
+						stored, err := taskService.GetByID(task.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(stored.UserID).To(Equal(insertTasks[0].UserID))
+					})
+				})
+			})
+
+			Describe("Transition", func() {
+				When("the workflow allows the new status", func() {
+					It("should move the task and record who changed it", func() {
+						task, err := taskService.As("test@mail.com").Transition(1, model.StatusReview)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(task.Status).To(Equal(model.StatusReview))
+
+						transitions, err := taskService.GetTransitions(1)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(transitions).To(HaveLen(1))
+						Expect(transitions[0].From).To(Equal(model.StatusInProgress))
+						Expect(transitions[0].To).To(Equal(model.StatusReview))
+						Expect(transitions[0].ChangedBy).To(Equal("test@mail.com"))
+					})
+
+					It("should record who changed it when the task is updated", func() {
+						task := insertTasks[0]
+						task.Status = model.StatusReview
+						Expect(taskService.As("test@mail.com").Update(task.ID, &task)).Should(Succeed())
+
+						transitions, err := taskService.GetTransitions(task.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(transitions).To(HaveLen(1))
+						Expect(transitions[0].ChangedBy).To(Equal("test@mail.com"))
+					})
+				})
+
+				When("the workflow does not allow the new status", func() {
+					It("should refuse the change in Transition and Update", func() {
+						_, err := taskService.As("test@mail.com").Transition(1, model.StatusCompleted)
+						Expect(errors.Is(err, model.ErrInvalidTransition)).To(BeTrue())
+
+						task := insertTasks[0]
+						task.Status = model.StatusCompleted
+						err = taskService.Update(task.ID, &task)
+						Expect(errors.Is(err, model.ErrInvalidTransition)).To(BeTrue())
+					})
+				})
+
+				When("the owner configured a custom status", func() {
+					It("should allow the transitions of the custom status", func() {
+						statusService := service.NewStatusService(repo.NewStatusRepo(filebasedDb), taskRepo)
+						err := statusService.Store(&model.CustomStatus{
+							UserID: 1,
+							Name:   "Blocked",
+							From:   []model.TaskStatus{model.StatusInProgress},
+							To:     []model.TaskStatus{model.StatusInProgress},
+						})
+						Expect(err).ShouldNot(HaveOccurred())
+
+						_, err = taskService.As("test@mail.com").Transition(5, "Blocked")
+						Expect(err).ShouldNot(HaveOccurred())
+
+						_, err = taskService.As("test@mail.com").Transition(5, model.StatusReview)
+						Expect(errors.Is(err, model.ErrInvalidTransition)).To(BeTrue())
+					})
+				})
+			})
+
+			Describe("Subtasks", func() {
+				var subtask model.Task
+
+				BeforeEach(func() {
+					subtask = model.Task{Title: "Subtask of Task 5", ParentID: 5, Status: model.StatusCompleted}
+					Expect(taskService.Store(&subtask)).Should(Succeed())
+				})
+
+				When("a subtask is added", func() {
+					It("should inherit the parent's owner and category and count towards its progress", func() {
+						Expect(subtask.ID).To(Equal(6))
+						Expect(subtask.UserID).To(Equal(1))
+						Expect(subtask.CategoryID).To(Equal(3))
+
+						_, err := taskService.AddChecklistItem(5, model.ChecklistItem{Title: "Write notes"})
+						Expect(err).ShouldNot(HaveOccurred())
+
+						progress, err := taskService.GetProgress(5)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(progress).To(Equal(model.Progress{Done: 1, Total: 2, Percent: 50}))
+
+						_, err = taskService.ToggleChecklistItem(5, 1)
+						Expect(err).ShouldNot(HaveOccurred())
+
+						progress, err = taskService.GetProgress(5)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(progress.Percent).To(Equal(100))
+					})
+				})
+
+				When("a subtask is updated without a parent", func() {
+					It("should keep its parent until it is detached", func() {
+						update := model.Task{Title: "Renamed subtask", Priority: 1, Status: model.StatusCompleted}
+						Expect(taskService.Update(subtask.ID, &update)).Should(Succeed())
+
+						task, err := taskService.GetByID(subtask.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(task.Title).To(Equal("Renamed subtask"))
+						Expect(task.ParentID).To(Equal(5))
+
+						r, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/task/%d/parent", subtask.ID), nil)
+						w := httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						task, err = taskService.GetByID(subtask.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(task.ParentID).To(Equal(0))
+					})
+				})
+
+				When("a task is moved under one of its own subtasks", func() {
+					It("should return an error", func() {
+						task := insertTasks[4]
+						task.ParentID = subtask.ID
+						err := taskService.Update(task.ID, &task)
+						Expect(errors.Is(err, model.ErrInvalidParent)).To(BeTrue())
+					})
+				})
+
+				When("the checklist is reordered", func() {
+					It("should require every item exactly once", func() {
+						_, err := taskService.AddChecklistItem(5, model.ChecklistItem{Title: "First"})
+						Expect(err).ShouldNot(HaveOccurred())
+						_, err = taskService.AddChecklistItem(5, model.ChecklistItem{Title: "Second"})
+						Expect(err).ShouldNot(HaveOccurred())
+
+						_, err = taskService.ReorderChecklist(5, []int{2, 2})
+						Expect(errors.Is(err, model.ErrInvalidChecklistOrder)).To(BeTrue())
+
+						task, err := taskService.ReorderChecklist(5, []int{2, 1})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(task.Checklist[0].Title).To(Equal("Second"))
+					})
+				})
+
+				When("the parent is deleted", func() {
+					It("should delete its subtasks too", func() {
+						Expect(taskService.Delete(5)).Should(Succeed())
+
+						_, err := taskService.GetByID(subtask.ID)
+						Expect(err).Should(HaveOccurred())
+					})
+
+					It("should keep its subtasks when asked to", func() {
+						Expect(taskService.DeleteKeepChildren(5)).Should(Succeed())
+
+						task, err := taskService.GetByID(subtask.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(task.ParentID).To(Equal(0))
+					})
+				})
+			})
+
+			Describe("Recurring tasks", func() {
+				var chore model.Task
+
+				BeforeEach(func() {
+					chore = model.Task{
+						Title:      "Water plants",
+						Deadline:   model.DateDeadline(2023, time.June, 30),
+						Priority:   1,
+						Status:     model.StatusReview,
+						CategoryID: 1,
+						UserID:     1,
+						Recurrence: "freq=weekly;byday=mo,fr",
+					}
+					Expect(taskService.Store(&chore)).Should(Succeed())
+				})
+
+				When("a recurring task is added", func() {
+					It("should store the rule in its canonical form and refuse unsupported rules", func() {
+						Expect(chore.Recurrence).To(Equal("FREQ=WEEKLY;BYDAY=MO,FR"))
+
+						yearly := model.Task{Title: "Renew license", UserID: 1, Recurrence: "FREQ=YEARLY"}
+						err := taskService.Store(&yearly)
+						Expect(errors.Is(err, model.ErrInvalidRecurrence)).To(BeTrue())
+					})
+				})
+
+				When("an occurrence is completed", func() {
+					It("should generate the next occurrence once, with the shifted deadline", func() {
+						done, err := taskService.As("test@mail.com").Transition(chore.ID, model.StatusCompleted)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(done.NextID).NotTo(BeZero())
+
+						next, err := taskService.GetByID(done.NextID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(next.Deadline).To(Equal(model.DateDeadline(2023, time.July, 3)))
+						Expect(next.Status).To(Equal(model.StatusTodo))
+						Expect(next.SeriesID).To(Equal(chore.ID))
+						Expect(next.Recurrence).To(Equal(chore.Recurrence))
+
+						_, err = taskService.As("test@mail.com").Transition(chore.ID, model.StatusInProgress)
+						Expect(err).ShouldNot(HaveOccurred())
+						_, err = taskService.As("test@mail.com").Transition(chore.ID, model.StatusReview)
+						Expect(err).ShouldNot(HaveOccurred())
+						_, err = taskService.As("test@mail.com").Transition(chore.ID, model.StatusCompleted)
+						Expect(err).ShouldNot(HaveOccurred())
+
+						series, err := taskService.GetSeries(chore.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(series).To(HaveLen(2))
+					})
+				})
+
+				When("the series is edited", func() {
+					It("should update the remaining occurrences and leave completed ones alone", func() {
+						done, err := taskService.As("test@mail.com").Transition(chore.ID, model.StatusCompleted)
+						Expect(err).ShouldNot(HaveOccurred())
+
+						updated, err := taskService.UpdateSeries(done.NextID, &model.Task{
+							Title:    "Water the plants",
+							Deadline: model.DateDeadline(2023, time.July, 4),
+						})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(updated).To(HaveLen(1))
+						Expect(updated[0].Deadline).To(Equal(model.DateDeadline(2023, time.July, 4)))
+
+						first, err := taskService.GetByID(chore.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(first.Title).To(Equal("Water plants"))
+					})
+				})
+
+				When("the next deadline is computed", func() {
+					It("should follow monthly rules", func() {
+						lastFriday, err := model.ParseRecurrence("RRULE:FREQ=MONTHLY;BYDAY=-1FR")
+						Expect(err).ShouldNot(HaveOccurred())
+						next, ok := lastFriday.Next(model.DateDeadline(2023, time.June, 30))
+						Expect(ok).To(BeTrue())
+						Expect(next).To(Equal(model.DateDeadline(2023, time.July, 28)))
+
+						monthly, err := model.ParseRecurrence("FREQ=MONTHLY;UNTIL=20230301")
+						Expect(err).ShouldNot(HaveOccurred())
+						next, ok = monthly.Next(model.DateDeadline(2023, time.January, 31))
+						Expect(ok).To(BeTrue())
+						Expect(next).To(Equal(model.DateDeadline(2023, time.February, 28)))
+
+						_, ok = monthly.Next(next)
+						Expect(ok).To(BeFalse())
+					})
+				})
+			})
+
+			Describe("Delete", func() {
+				When("deleting a task from the database", func() {
+					It("should delete the task without any errors", func() {
+						err := taskService.Delete(3)
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+				})
+			})
+
+			Describe("GetList", func() {
+				When("retrieving the list of tasks from the database", func() {
+					It("should return the list of tasks without any errors", func() {
+						tasks, err := taskService.GetList(0)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(tasks).To(Equal(insertTasks))
+					})
+				})
+			})
+
+			Describe("GetTaskCategory", func() {
+				When("retrieving the category of a task from the database", func() {
+					It("should return the task category without any errors", func() {
+						taskCategories, err := taskService.GetTaskCategory(0, 1)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(taskCategories).To(Equal([]model.TaskCategory{
+							{ID: 1, Title: "Task 1", Category: "Category 1"},
+							{ID: 3, Title: "Task 3", Category: "Category 1"},
+							{ID: 4, Title: "Task 4", Category: "Category 1"},
+						}))
+					})
+				})
+			})
+		})
+		Describe("Tag Service", func() {
+			var tagService service.TagService
+			var urgent, home model.Tag
+
+			BeforeEach(func() {
+				tagService = service.NewTagService(repo.NewTagRepo(filebasedDb), taskRepo)
+
+				urgent = model.Tag{Name: "Urgent", Color: "#ef4444", UserID: 1}
+				Expect(tagService.Store(&urgent)).Should(Succeed())
+				home = model.Tag{Name: "Home", UserID: 1}
+				Expect(tagService.Store(&home)).Should(Succeed())
+			})
+
+			When("adding tags", func() {
+				It("should default the color and refuse duplicate names and invalid colors", func() {
+					Expect(home.Color).To(Equal(model.DefaultTagColor))
+
+					duplicate := model.Tag{Name: "urgent", UserID: 1}
+					Expect(errors.Is(tagService.Store(&duplicate), model.ErrDuplicateTag)).To(BeTrue())
+
+					otherUser := model.Tag{Name: "urgent", UserID: 2}
+					Expect(tagService.Store(&otherUser)).Should(Succeed())
+
+					invalid := model.Tag{Name: "Later", Color: "blue", UserID: 1}
+					Expect(errors.Is(tagService.Store(&invalid), model.ErrInvalidTagColor)).To(BeTrue())
+				})
+			})
+
+			When("tags are assigned to tasks", func() {
+				It("should filter the task list by every given tag", func() {
+					Expect(tagService.Assign(1, urgent.ID, 1)).Should(Succeed())
+					Expect(tagService.Assign(2, urgent.ID, 1)).Should(Succeed())
+					Expect(tagService.Assign(2, home.ID, 1)).Should(Succeed())
+
+					tasks, err := taskService.GetListByTags(0, []int{urgent.ID})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(Equal(insertTasks[:2]))
+
+					tasks, err = taskService.GetListByTags(0, []int{urgent.ID, home.ID})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(Equal(insertTasks[1:2]))
+
+					list, err := tagService.GetList(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(list.ByTask()[2]).To(ConsistOf(urgent, home))
+				})
+
+				It("should refuse tags of other users", func() {
+					err := tagService.Assign(1, urgent.ID, 2)
+					Expect(errors.Is(err, model.ErrTagNotFound)).To(BeTrue())
+				})
+
+				It("should drop the assignments of deleted tags and tasks", func() {
+					Expect(tagService.Assign(1, urgent.ID, 1)).Should(Succeed())
+					Expect(tagService.Assign(2, home.ID, 1)).Should(Succeed())
+
+					Expect(tagService.Delete(urgent.ID, 1)).Should(Succeed())
+					Expect(taskService.Delete(2)).Should(Succeed())
+
+					list, err := tagService.GetList(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(list.Tags).To(Equal([]model.Tag{home}))
+					Expect(list.Assignments).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("Dependency Service", func() {
+			var dependencyService service.DependencyService
+			var design, build model.Task
+
+			BeforeEach(func() {
+				dependencyService = service.NewDependencyService(repo.NewDependencyRepo(filebasedDb), taskRepo)
+
+				design = model.Task{Title: "Design", Status: model.StatusTodo, CategoryID: 1, UserID: 2}
+				Expect(taskService.Store(&design)).Should(Succeed())
+				build = model.Task{Title: "Build", Status: model.StatusReview, CategoryID: 1, UserID: 2}
+				Expect(taskService.Store(&build)).Should(Succeed())
+
+				Expect(dependencyService.Block(1, design.ID)).Should(Succeed())
+				Expect(dependencyService.Block(design.ID, build.ID)).Should(Succeed())
+				Expect(dependencyService.Block(4, build.ID)).Should(Succeed())
+			})
+
+			When("adding dependencies", func() {
+				It("should refuse cycles and self dependencies", func() {
+					err := dependencyService.Block(build.ID, 1)
+					Expect(errors.Is(err, model.ErrDependencyCycle)).To(BeTrue())
+
+					err = dependencyService.Block(design.ID, design.ID)
+					Expect(errors.Is(err, model.ErrSelfDependency)).To(BeTrue())
+
+					err = dependencyService.Block(99, design.ID)
+					Expect(errors.Is(err, model.ErrDependencyTaskNotFound)).To(BeTrue())
+
+					dependencies, err := dependencyService.GetTaskDependencies(build.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(dependencies.BlockedBy).To(HaveLen(2))
+					Expect(dependencies.BlockedBy[0].ID).To(Equal(4))
+					Expect(dependencies.BlockedBy[1].ID).To(Equal(design.ID))
+					Expect(dependencies.Blocks).To(BeEmpty())
+				})
+			})
+
+			When("completing a blocked task", func() {
+				It("should refuse it until every blocker is completed", func() {
+					_, err := taskService.As("test@mail.com").Transition(build.ID, model.StatusCompleted)
+					Expect(errors.Is(err, model.ErrTaskBlocked)).To(BeTrue())
+
+					Expect(dependencyService.Unblock(design.ID, build.ID)).Should(Succeed())
+					_, err = taskService.As("test@mail.com").Transition(build.ID, model.StatusCompleted)
+					Expect(err).ShouldNot(HaveOccurred())
+				})
+			})
+
+			When("retrieving the graph of a category", func() {
+				It("should order the tasks and find the critical path", func() {
+					graph, err := dependencyService.GetGraph(0, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(graph.Tasks).To(HaveLen(5))
+					Expect(graph.Edges).To(HaveLen(3))
+					Expect(graph.Order).To(Equal([]int{1, 3, 4, design.ID, build.ID}))
+					Expect(graph.CriticalPath).To(Equal([]int{1, design.ID, build.ID}))
+				})
+
+				It("should drop the dependencies of deleted tasks", func() {
+					Expect(taskService.Delete(design.ID)).Should(Succeed())
+
+					graph, err := dependencyService.GetGraph(0, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(graph.Edges).To(Equal([]model.Dependency{{BlockerID: 4, BlockedID: build.ID}}))
+					Expect(graph.CriticalPath).To(Equal([]int{1}))
+				})
+			})
+		})
+
+		Describe("Estimate Service", func() {
+			var estimateService service.EstimateService
+
+			BeforeEach(func() {
+				estimateService = service.NewEstimateService(taskRepo, categoryRepo, userRepo)
+
+				for _, task := range []model.Task{
+					{Title: "Write spec", Status: model.StatusCompleted, CategoryID: 1, UserID: 2, EstimateHours: 4, StoryPoints: 3},
+					{Title: "Implement", Status: model.StatusTodo, CategoryID: 1, UserID: 2, EstimateHours: 2.5, StoryPoints: 5},
+				} {
+					Expect(taskService.Store(&task)).Should(Succeed())
+				}
+			})
+
+			When("storing a task", func() {
+				It("should refuse negative estimates", func() {
+					err := taskService.Store(&model.Task{Title: "Negative", CategoryID: 1, UserID: 2, StoryPoints: -1})
+					Expect(errors.Is(err, model.ErrInvalidEstimate)).To(BeTrue())
+				})
+			})
+
+			When("rolling up the estimates", func() {
+				It("should total the estimated and completed effort per category and per user", func() {
+					rollup, err := estimateService.GetRollup(0)
+					Expect(err).ShouldNot(HaveOccurred())
+
+					Expect(rollup.ByCategory[0]).To(Equal(model.EstimateTotal{
+						ID: 1, Name: "Category 1", Tasks: 5, CompletedTasks: 3,
+						EstimatedHours: 6.5, CompletedHours: 4, StoryPoints: 8, CompletedPoints: 3,
+					}))
+					Expect(rollup.ByUser).To(HaveLen(4))
+					Expect(rollup.ByUser[1].ID).To(Equal(2))
+					Expect(rollup.ByUser[1].Tasks).To(Equal(3))
+					Expect(rollup.ByUser[1].CompletedHours).To(Equal(4.0))
+					Expect(rollup.Total.Tasks).To(Equal(7))
+					Expect(rollup.Total.HoursPercent()).To(Equal(61))
+					Expect(rollup.Total.PointsPercent()).To(Equal(37))
+				})
+			})
+		})
+
+		Describe("Assignment Service", func() {
+			var assignmentService service.AssignmentService
+			var user model.User
+
+			BeforeEach(func() {
+				assignmentService = service.NewAssignmentService(repo.NewAssignmentRepo(filebasedDb), taskRepo, userRepo, repo.NewWorkspaceRepo(filebasedDb))
+
+				var err error
+				user, err = userRepo.GetUserByEmail("test@mail.com")
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			When("assigning a task by email", func() {
+				It("should share the task with the user", func() {
+					profile, err := assignmentService.Assign(1, 2, " test@mail.com ")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(profile.Fullname).To(Equal("test"))
+
+					assignees, err := assignmentService.GetAssignees(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(assignees).To(HaveLen(1))
+					Expect(assignees[0].ID).To(Equal(user.ID))
+
+					tasks, err := assignmentService.GetAssignedTasks(0, user.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(1))
+					Expect(tasks[0].ID).To(Equal(1))
+				})
+
+				It("should refuse unknown addresses", func() {
+					_, err := assignmentService.Assign(1, 2, "nobody@mail.com")
+					Expect(errors.Is(err, model.ErrAssigneeNotFound)).To(BeTrue())
+				})
+			})
+
+			When("an assignee acts on the task", func() {
+				It("should let them edit and transition it but not delete or reassign it", func() {
+					_, err := assignmentService.Assign(1, 2, "test@mail.com")
+					Expect(err).ShouldNot(HaveOccurred())
+
+					Expect(assignmentService.Authorize(1, user.ID, model.TaskActionEdit)).Should(Succeed())
+					Expect(assignmentService.Authorize(1, user.ID, model.TaskActionTransition)).Should(Succeed())
+					Expect(errors.Is(assignmentService.Authorize(1, user.ID, model.TaskActionDelete), model.ErrAssigneeForbidden)).To(BeTrue())
+					Expect(errors.Is(assignmentService.Authorize(1, user.ID, model.TaskActionAssign), model.ErrAssigneeForbidden)).To(BeTrue())
+					Expect(assignmentService.Authorize(1, 2, model.TaskActionDelete)).Should(Succeed())
+
+					Expect(errors.Is(assignmentService.Unassign(1, user.ID, user.ID), model.ErrAssigneeForbidden)).To(BeTrue())
+					Expect(assignmentService.Unassign(1, 2, user.ID)).Should(Succeed())
+					Expect(errors.Is(assignmentService.Authorize(1, user.ID, model.TaskActionEdit), model.ErrTaskForbidden)).To(BeTrue())
+				})
+			})
+
+			When("someone else acts on the task", func() {
+				It("should only let the admins of its workspace through", func() {
+					Expect(errors.Is(assignmentService.Authorize(1, user.ID, model.TaskActionDelete), model.ErrTaskForbidden)).To(BeTrue())
+					Expect(errors.Is(assignmentService.Authorize(1, user.ID, model.TaskActionTransition), model.ErrTaskForbidden)).To(BeTrue())
+					Expect(errors.Is(assignmentService.Authorize(99, 2, model.TaskActionEdit), model.ErrTaskNotFound)).To(BeTrue())
+
+					workspaceService := service.NewWorkspaceService(repo.NewWorkspaceRepo(filebasedDb), userRepo, taskRepo, categoryRepo)
+					team, err := workspaceService.Create("Team", user.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					guest, err := userRepo.CreateUser(model.User{Fullname: "guest", Email: "guest@mail.com", Password: "testing123"})
+					Expect(err).ShouldNot(HaveOccurred())
+					invitation, err := workspaceService.Invite(team.ID, user.ID, "guest@mail.com", model.RoleMember)
+					Expect(err).ShouldNot(HaveOccurred())
+					_, err = workspaceService.RespondInvitation(invitation.ID, guest.ID, true)
+					Expect(err).ShouldNot(HaveOccurred())
+
+					task := model.Task{Title: "Guest task", Priority: 1, UserID: guest.ID, WorkspaceID: team.ID}
+					Expect(taskService.Store(&task)).Should(Succeed())
+					Expect(assignmentService.Authorize(task.ID, user.ID, model.TaskActionDelete)).Should(Succeed())
+
+					mine := model.Task{Title: "Owner task", Priority: 1, UserID: user.ID, WorkspaceID: team.ID}
+					Expect(taskService.Store(&mine)).Should(Succeed())
+					Expect(errors.Is(assignmentService.Authorize(mine.ID, guest.ID, model.TaskActionEdit), model.ErrTaskForbidden)).To(BeTrue())
+				})
+			})
+
+			When("the task is deleted", func() {
+				It("should drop its assignments", func() {
+					_, err := assignmentService.Assign(1, 2, "test@mail.com")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(taskService.Delete(1)).Should(Succeed())
+
+					tasks, err := assignmentService.GetAssignedTasks(0, user.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("Workspace Service", func() {
+			var workspaceService service.WorkspaceService
+			var owner, guest model.User
+			var workspace model.Workspace
+
+			BeforeEach(func() {
+				workspaceService = service.NewWorkspaceService(repo.NewWorkspaceRepo(filebasedDb), userRepo, taskRepo, categoryRepo)
+
+				var err error
+				owner, err = userRepo.GetUserByEmail("test@mail.com")
+				Expect(err).ShouldNot(HaveOccurred())
+				guest, err = userRepo.CreateUser(model.User{Fullname: "guest", Email: "guest@mail.com", Password: "testing123"})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				workspace, err = workspaceService.Create(" Team ", owner.ID)
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			invite := func(role model.WorkspaceRole) {
+				invitation, err := workspaceService.Invite(workspace.ID, owner.ID, "GUEST@mail.com", role)
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = workspaceService.RespondInvitation(invitation.ID, guest.ID, true)
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+
+			When("a workspace is created", func() {
+				It("should list it after the default workspace with the creator as owner", func() {
+					list, err := workspaceService.GetList(owner.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(list).To(HaveLen(2))
+					Expect(list[0].Workspace.Name).To(Equal(model.DefaultWorkspaceName))
+					Expect(list[0].Current).To(BeTrue())
+					Expect(list[1].Workspace.Name).To(Equal("Team"))
+					Expect(list[1].Role).To(Equal(model.RoleOwner))
+				})
+
+				It("should refuse an empty name", func() {
+					_, err := workspaceService.Create("  ", owner.ID)
+					Expect(errors.Is(err, model.ErrInvalidWorkspace)).To(BeTrue())
+				})
+			})
+
+			When("a user is invited", func() {
+				It("should make them a member once they accept", func() {
+					invitation, err := workspaceService.Invite(workspace.ID, owner.ID, "guest@mail.com", "")
+					Expect(err).ShouldNot(HaveOccurred())
+
+					pending, err := workspaceService.GetInvitations(guest.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(pending).To(HaveLen(1))
+					Expect(pending[0].WorkspaceName).To(Equal("Team"))
+
+					_, err = workspaceService.RespondInvitation(invitation.ID, guest.ID, true)
+					Expect(err).ShouldNot(HaveOccurred())
+
+					members, err := workspaceService.GetMembers(workspace.ID, guest.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(members).To(HaveLen(2))
+
+					_, err = workspaceService.Invite(workspace.ID, owner.ID, "guest@mail.com", model.RoleMember)
+					Expect(errors.Is(err, model.ErrAlreadyMember)).To(BeTrue())
+				})
+
+				It("should not let them in when they decline", func() {
+					invitation, err := workspaceService.Invite(workspace.ID, owner.ID, "guest@mail.com", model.RoleMember)
+					Expect(err).ShouldNot(HaveOccurred())
+					_, err = workspaceService.RespondInvitation(invitation.ID, guest.ID, false)
+					Expect(err).ShouldNot(HaveOccurred())
+
+					Expect(errors.Is(workspaceService.Select(guest.ID, workspace.ID), model.ErrWorkspaceNotFound)).To(BeTrue())
+					_, err = workspaceService.RespondInvitation(invitation.ID, guest.ID, true)
+					Expect(errors.Is(err, model.ErrInvitationNotFound)).To(BeTrue())
+				})
+
+				It("should only let owners and admins invite", func() {
+					invite(model.RoleMember)
+
+					_, err := workspaceService.Invite(workspace.ID, guest.ID, "someone@mail.com", model.RoleMember)
+					Expect(errors.Is(err, model.ErrWorkspaceForbidden)).To(BeTrue())
+				})
+			})
+
+			When("roles change", func() {
+				It("should only let the owner change them and never the owner's own", func() {
+					invite(model.RoleViewer)
+
+					Expect(workspaceService.ChangeRole(workspace.ID, owner.ID, guest.ID, model.RoleAdmin)).Should(Succeed())
+					Expect(errors.Is(workspaceService.ChangeRole(workspace.ID, guest.ID, owner.ID, model.RoleMember), model.ErrWorkspaceForbidden)).To(BeTrue())
+					Expect(errors.Is(workspaceService.RemoveMember(workspace.ID, guest.ID, owner.ID), model.ErrWorkspaceOwner)).To(BeTrue())
+					Expect(errors.Is(workspaceService.ChangeRole(workspace.ID, owner.ID, guest.ID, model.RoleOwner), model.ErrInvalidWorkspaceRole)).To(BeTrue())
+
+					Expect(workspaceService.RemoveMember(workspace.ID, guest.ID, guest.ID)).Should(Succeed())
+					_, err := workspaceService.GetMembers(workspace.ID, guest.ID)
+					Expect(errors.Is(err, model.ErrWorkspaceNotFound)).To(BeTrue())
+				})
+			})
+
+			When("a workspace is selected", func() {
+				It("should scope the category and task lists to it", func() {
+					Expect(workspaceService.Select(owner.ID, workspace.ID)).Should(Succeed())
+					current, err := workspaceService.Current(owner.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(current.Workspace.ID).To(Equal(workspace.ID))
+
+					category := model.Category{Name: "Shared", WorkspaceID: workspace.ID}
+					Expect(categoryService.Store(&category)).Should(Succeed())
+					task := model.Task{Title: "Shared task", CategoryID: category.ID, UserID: owner.ID, WorkspaceID: workspace.ID}
+					Expect(taskService.Store(&task)).Should(Succeed())
+
+					categories, err := categoryService.GetList(workspace.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(categories).To(HaveLen(1))
+					tasks, err := taskService.GetList(workspace.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(1))
+
+					tasks, err = taskService.GetList(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(len(insertTasks)))
+
+					_, err = taskService.GetTaskCategory(0, category.ID)
+					Expect(err).Should(HaveOccurred())
+				})
+			})
+		})
+
+		Describe("Project Service", func() {
+			var projectService service.ProjectService
+			var project model.Project
+
+			BeforeEach(func() {
+				projectService = service.NewProjectService(repo.NewProjectRepo(filebasedDb), categoryService, taskService, userRepo)
+
+				var err error
+				project, err = projectService.Create(model.Project{Name: " Launch ", UserID: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			When("a project is created", func() {
+				It("should trim its name and keep it in its workspace", func() {
+					Expect(project.Name).To(Equal("Launch"))
+
+					projects, err := projectService.GetList(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(projects).To(HaveLen(1))
+
+					_, err = projectService.GetByID(project.ID, 7)
+					Expect(errors.Is(err, model.ErrProjectNotFound)).To(BeTrue())
+					_, err = projectService.Create(model.Project{Name: " "})
+					Expect(errors.Is(err, model.ErrInvalidProject)).To(BeTrue())
+				})
+			})
+
+			When("the overview is requested", func() {
+				It("should compute the progress of categories and milestones and flag overdue milestones", func() {
+					Expect(projectService.AddCategory(project.ID, 0, 1)).Should(Succeed())
+
+					late, err := projectService.AddMilestone(project.ID, 0, model.MilestoneRequest{Title: "Beta", DueDate: model.DateDeadline(2020, time.January, 1)})
+					Expect(err).ShouldNot(HaveOccurred())
+					undated, err := projectService.AddMilestone(project.ID, 0, model.MilestoneRequest{Title: "Someday"})
+					Expect(err).ShouldNot(HaveOccurred())
+					done, err := projectService.AddMilestone(project.ID, 0, model.MilestoneRequest{Title: "Alpha", DueDate: model.DateDeadline(2019, time.June, 1)})
+					Expect(err).ShouldNot(HaveOccurred())
+
+					Expect(projectService.AttachTask(project.ID, 0, late.ID, 1, "test@mail.com")).Should(Succeed())
+					Expect(projectService.AttachTask(project.ID, 0, done.ID, 2, "test@mail.com")).Should(Succeed())
+
+					overview, err := projectService.GetOverview(project.ID, 0, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(overview.Categories).To(HaveLen(1))
+					Expect(overview.Categories[0].Progress).To(Equal(model.Progress{Done: 2, Total: 3, Percent: 66}))
+					Expect(overview.Progress).To(Equal(model.Progress{Done: 3, Total: 4, Percent: 75}))
+
+					Expect(overview.Milestones).To(HaveLen(3))
+					Expect(overview.Milestones[0].Milestone.ID).To(Equal(done.ID))
+					Expect(overview.Milestones[0].Overdue).To(BeFalse())
+					Expect(overview.Milestones[1].Milestone.ID).To(Equal(late.ID))
+					Expect(overview.Milestones[1].Overdue).To(BeTrue())
+					Expect(overview.Milestones[2].Milestone.ID).To(Equal(undated.ID))
+					Expect(overview.Milestones[2].Overdue).To(BeFalse())
+					Expect(overview.OverdueMilestones).To(Equal(1))
+				})
+			})
+
+			When("a project is deleted", func() {
+				It("should keep its categories and tasks but detach them", func() {
+					Expect(projectService.AddCategory(project.ID, 0, 1)).Should(Succeed())
+					milestone, err := projectService.AddMilestone(project.ID, 0, model.MilestoneRequest{Title: "Beta"})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(projectService.AttachTask(project.ID, 0, milestone.ID, 1, "test@mail.com")).Should(Succeed())
+
+					Expect(errors.Is(projectService.Delete(project.ID, 7, "test@mail.com"), model.ErrProjectNotFound)).To(BeTrue())
+					Expect(projectService.Delete(project.ID, 0, "test@mail.com")).Should(Succeed())
+
+					category, err := categoryService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(category.ProjectID).To(BeZero())
+					task, err := taskService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(task.MilestoneID).To(BeZero())
+
+					history, err := taskService.GetHistory(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					for _, event := range history[1:] {
+						Expect(event.Actor).To(Equal("test@mail.com"))
+					}
+				})
+			})
+		})
+
+		Describe("Sprint Service", func() {
+			var sprintService service.SprintService
+			today := time.Now().UTC()
+			day := func(offset int) model.Deadline {
+				date := today.AddDate(0, 0, offset)
+				return model.DateDeadline(date.Year(), date.Month(), date.Day())
+			}
+
+			BeforeEach(func() {
+				sprintService = service.NewSprintService(repo.NewSprintRepo(filebasedDb), taskService, userRepo)
+			})
+
+			When("a sprint is closed", func() {
+				It("should carry its unfinished tasks over to the next sprint", func() {
+					current, err := sprintService.Create(model.Sprint{Name: "Sprint 1", StartDate: day(-7), EndDate: day(0)})
+					Expect(err).ShouldNot(HaveOccurred())
+					next, err := sprintService.Create(model.Sprint{Name: "Sprint 2", StartDate: day(1), EndDate: day(14)})
+					Expect(err).ShouldNot(HaveOccurred())
+					_, err = sprintService.Create(model.Sprint{Name: "Backwards", StartDate: day(1), EndDate: day(0)})
+					Expect(errors.Is(err, model.ErrInvalidSprint)).To(BeTrue())
+					_, err = sprintService.Create(model.Sprint{Name: "Endless", StartDate: day(1), EndDate: day(model.MaxSprintDays + 1)})
+					Expect(errors.Is(err, model.ErrInvalidSprint)).To(BeTrue())
+					_, err = sprintService.Update(next.ID, 0, model.SprintRequest{Name: "Sprint 2", StartDate: day(1), EndDate: day(3650)})
+					Expect(errors.Is(err, model.ErrInvalidSprint)).To(BeTrue())
+
+					Expect(sprintService.PlanTask(current.ID, 0, 1, "test@mail.com")).Should(Succeed())
+					Expect(sprintService.PlanTask(current.ID, 0, 2, "test@mail.com")).Should(Succeed())
+
+					_, err = sprintService.Start(current.ID, 0)
+					Expect(err).ShouldNot(HaveOccurred())
+					_, err = sprintService.Start(next.ID, 0)
+					Expect(errors.Is(err, model.ErrSprintActive)).To(BeTrue())
+
+					closed, err := sprintService.Close(current.ID, 0, next.ID, "test@mail.com")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(closed.Status).To(Equal(model.SprintClosed))
+					Expect(closed.CarriedOver).To(Equal([]int{1}))
+
+					unfinished, err := taskService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(unfinished.SprintID).To(Equal(next.ID))
+					history, err := taskService.GetHistory(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(history[len(history)-1].Actor).To(Equal("test@mail.com"))
+					completed, err := taskService.GetByID(2)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(completed.SprintID).To(Equal(current.ID))
+
+					_, err = sprintService.Close(current.ID, 0, 0, "test@mail.com")
+					Expect(errors.Is(err, model.ErrSprintState)).To(BeTrue())
+				})
+			})
+
+			When("the burndown is requested", func() {
+				It("should count the work remaining each day from the status history recorded by task updates", func() {
+					sprint, err := sprintService.Create(model.Sprint{Name: "Sprint 1", StartDate: day(-2), EndDate: day(2)})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sprintService.PlanTask(sprint.ID, 0, 1, "test@mail.com")).Should(Succeed())
+					Expect(sprintService.PlanTask(sprint.ID, 0, 5, "test@mail.com")).Should(Succeed())
+
+					for _, status := range []model.TaskStatus{model.StatusReview, model.StatusCompleted} {
+						task, err := taskService.GetByID(1)
+						Expect(err).ShouldNot(HaveOccurred())
+						task.Status = status
+						Expect(taskService.Update(1, task)).Should(Succeed())
+					}
+
+					transitions, err := taskService.GetTransitions(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(transitions).To(HaveLen(2))
+					Expect(transitions[0].From).To(Equal(model.StatusInProgress))
+					Expect(transitions[1].To).To(Equal(model.StatusCompleted))
+
+					burndown, err := sprintService.GetBurndown(sprint.ID, 0, 0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(burndown.TotalTasks).To(Equal(2))
+					Expect(burndown.Points).To(HaveLen(5))
+					Expect(*burndown.Points[0].RemainingTasks).To(Equal(2))
+					Expect(*burndown.Points[1].RemainingTasks).To(Equal(2))
+					Expect(*burndown.Points[2].RemainingTasks).To(Equal(1))
+					Expect(burndown.Points[3].RemainingTasks).To(BeNil())
+					Expect(burndown.Points[0].IdealTasks).To(Equal(2.0))
+					Expect(burndown.Points[4].IdealTasks).To(BeZero())
+				})
+			})
+		})
+
+		Describe("Template Service", func() {
+			var templateService service.TemplateService
+			offset := func(days int) *int { return &days }
+
+			BeforeEach(func() {
+				templateService = service.NewTemplateService(repo.NewTemplateRepo(filebasedDb), categoryService, taskService)
+			})
+
+			When("a template is instantiated", func() {
+				It("should create its tasks with deadlines offset from the start date", func() {
+					template, err := templateService.Create(model.Template{
+						Name: " Release checklist ",
+						Tasks: []model.TemplateTask{
+							{Title: "Freeze branch", Priority: 3, CategoryID: 1, DeadlineOffset: offset(0)},
+							{Title: "Publish notes", Priority: 1, DeadlineOffset: offset(3)},
+							{Title: "Retrospective"},
+						},
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(template.Name).To(Equal("Release checklist"))
+
+					_, err = templateService.Create(model.Template{Name: "Broken", Tasks: []model.TemplateTask{{Title: "Late", DeadlineOffset: offset(-1)}}})
+					Expect(errors.Is(err, model.ErrInvalidTemplate)).To(BeTrue())
+					_, err = templateService.Create(model.Template{Name: "Foreign", Tasks: []model.TemplateTask{{Title: "Elsewhere", CategoryID: 99}}})
+					Expect(errors.Is(err, model.ErrInvalidTemplate)).To(BeTrue())
+					_, err = templateService.GetByID(template.ID, 7)
+					Expect(errors.Is(err, model.ErrTemplateNotFound)).To(BeTrue())
+
+					tasks, err := templateService.Instantiate(template.ID, 0, 1, "test@mail.com", model.InstantiateRequest{StartDate: model.DateDeadline(2024, time.March, 1)})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(3))
+					Expect(tasks[0].Deadline).To(Equal(model.DateDeadline(2024, time.March, 1)))
+					Expect(tasks[0].CategoryID).To(Equal(1))
+					Expect(tasks[1].Deadline).To(Equal(model.DateDeadline(2024, time.March, 4)))
+					Expect(tasks[2].Deadline.IsZero()).To(BeTrue())
+
+					for _, task := range tasks {
+						stored, err := taskService.GetByID(task.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(stored.Status).To(Equal(model.StatusTodo))
+						Expect(stored.UserID).To(Equal(1))
+
+						events, err := taskService.GetHistory(task.ID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(events[0].Action).To(Equal(model.EventCreated))
+						Expect(events[0].Actor).To(Equal("test@mail.com"))
+					}
+					Expect(tasks[0].Rank < tasks[1].Rank && tasks[1].Rank < tasks[2].Rank).To(BeTrue())
+
+					_, err = templateService.Instantiate(template.ID, 0, 1, "test@mail.com", model.InstantiateRequest{})
+					Expect(errors.Is(err, model.ErrInvalidTemplate)).To(BeTrue())
+				})
+			})
+		})
+
+		Describe("Custom Fields", func() {
+			var fieldService service.FieldService
+
+			BeforeEach(func() {
+				fieldService = service.NewFieldService(repo.NewFieldRepo(filebasedDb))
+			})
+
+			When("tasks carry custom field values", func() {
+				It("should validate them, filter by them and drop them with their field", func() {
+					severity, err := fieldService.Create(model.CustomField{Name: " Severity ", Type: model.FieldSelect, Options: []string{"low", "high", " high"}})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(severity.Name).To(Equal("Severity"))
+					Expect(severity.Options).To(Equal([]string{"low", "high"}))
+
+					points, err := fieldService.Create(model.CustomField{Name: "Points", Type: model.FieldNumber})
+					Expect(err).ShouldNot(HaveOccurred())
+
+					_, err = fieldService.Create(model.CustomField{Name: "severity", Type: model.FieldText})
+					Expect(errors.Is(err, model.ErrDuplicateField)).To(BeTrue())
+					_, err = fieldService.Create(model.CustomField{Name: "Stage", Type: model.FieldSelect})
+					Expect(errors.Is(err, model.ErrInvalidField)).To(BeTrue())
+
+					task, err := taskService.SetFields(1, map[int]interface{}{severity.ID: "high", points.ID: "3"})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(task.Fields).To(Equal(map[int]interface{}{severity.ID: "high", points.ID: 3.0}))
+
+					_, err = taskService.SetFields(3, map[int]interface{}{severity.ID: "urgent"})
+					Expect(errors.Is(err, model.ErrInvalidFieldValue)).To(BeTrue())
+					err = taskService.Store(&model.Task{Title: "Unknown field", UserID: 1, Fields: map[int]interface{}{99: "x"}})
+					Expect(errors.Is(err, model.ErrInvalidFieldValue)).To(BeTrue())
+
+					stored, err := taskService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					stored.Fields = nil
+					Expect(taskService.Update(1, stored)).To(Succeed())
+
+					tasks, err := taskService.GetListByFields(0, nil, map[int]string{severity.ID: "high"})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(1))
+					Expect(tasks[0].ID).To(Equal(1))
+					Expect(tasks[0].Fields[points.ID]).To(Equal(3.0))
+
+					Expect(fieldService.Delete(severity.ID, 0, "test@mail.com")).To(Succeed())
+					stored, err = taskService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(stored.Fields).To(Equal(map[int]interface{}{points.ID: 3.0}))
+				})
+			})
+		})
+
+		Describe("Trash", func() {
+			var trashService service.TrashService
+
+			BeforeEach(func() {
+				trashService = service.NewTrashService(repo.NewTrashRepo(filebasedDb), categoryRepo)
+			})
+
+			When("a task is deleted", func() {
+				It("should keep it with its subtasks until it is restored", func() {
+					subtask := model.Task{Title: "Subtask", ParentID: 1}
+					Expect(taskService.Store(&subtask)).To(Succeed())
+
+					Expect(taskService.Delete(1)).To(Succeed())
+					_, err := taskService.GetByID(subtask.ID)
+					Expect(err).Should(HaveOccurred())
+
+					items, err := trashService.GetList(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(items).To(HaveLen(1))
+					Expect(items[0].Kind).To(Equal(model.TrashTask))
+					Expect(items[0].Tasks).To(HaveLen(2))
+					Expect(items[0].PurgeAt).To(Equal(items[0].DeletedAt.Add(config.GetTrashRetention())))
+
+					next := model.Task{Title: "Next", UserID: 1}
+					Expect(taskService.Store(&next)).To(Succeed())
+					Expect(next.ID).To(BeNumerically(">", subtask.ID))
+
+					_, err = trashService.Restore(items[0].ID, 1, "test@mail.com")
+					Expect(errors.Is(err, model.ErrTrashItemNotFound)).To(BeTrue())
+
+					_, err = trashService.Restore(items[0].ID, 0, "test@mail.com")
+					Expect(err).ShouldNot(HaveOccurred())
+					restored, err := taskService.GetByID(subtask.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(restored.ParentID).To(Equal(1))
+
+					items, err = trashService.GetList(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(items).To(BeEmpty())
+				})
+			})
+
+			When("a category is deleted", func() {
+				It("should refuse to restore it over a category that took its ID", func() {
+					Expect(categoryService.Delete(3)).To(Succeed())
+					items, err := trashService.GetList(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(items).To(HaveLen(1))
+					Expect(items[0].Title).To(Equal("Category 3"))
+
+					Expect(categoryService.Store(&model.Category{ID: 3, Name: "Replacement"})).To(Succeed())
+					_, err = trashService.Restore(items[0].ID, 0, "test@mail.com")
+					Expect(errors.Is(err, model.ErrRestoreConflict)).To(BeTrue())
+
+					purged, err := trashService.PurgeExpired(time.Now())
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(purged).To(Equal(0))
+					Expect(trashService.Purge(items[0].ID, 0)).To(Succeed())
+					_, err = trashService.GetByID(items[0].ID, 0)
+					Expect(errors.Is(err, model.ErrTrashItemNotFound)).To(BeTrue())
+				})
+			})
+
+			When("a completed task is archived", func() {
+				It("should hide it from the task list until it is unarchived", func() {
+					_, err := taskService.Archive(1)
+					Expect(errors.Is(err, model.ErrNotArchivable)).To(BeTrue())
+
+					task, err := taskService.Archive(2)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(task.ArchivedAt).NotTo(BeNil())
+
+					tasks, err := taskService.GetList(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(4))
+					archived, err := taskService.GetArchived(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(archived).To(HaveLen(1))
+					Expect(archived[0].ID).To(Equal(2))
+
+					_, err = taskService.Unarchive(2)
+					Expect(err).ShouldNot(HaveOccurred())
+					tasks, err = taskService.GetList(0)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(tasks).To(HaveLen(5))
+				})
+			})
+		})
+
+		Describe("History", func() {
+			When("a task is created, updated and deleted", func() {
+				It("should record each change with its actor and field diffs", func() {
+					task := model.Task{Title: "Draft", Status: "In Progress", UserID: 1}
+					Expect(taskService.As("test@mail.com").Store(&task)).To(Succeed())
+
+					task.Title = "Final"
+					Expect(taskService.As("other@mail.com").Update(task.ID, &task)).To(Succeed())
+					Expect(taskService.As("other@mail.com").Update(task.ID, &task)).To(Succeed())
+
+					events, err := taskService.GetHistory(task.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(events).To(HaveLen(2))
+					Expect(events[0].Action).To(Equal(model.EventCreated))
+					Expect(events[0].Actor).To(Equal("test@mail.com"))
+					Expect(events[1].Action).To(Equal(model.EventUpdated))
+					Expect(events[1].Actor).To(Equal("other@mail.com"))
+					Expect(events[1].Changes).To(ContainElement(model.FieldChange{Field: "title", From: "Draft", To: "Final"}))
+
+					Expect(taskService.Delete(task.ID)).To(Succeed())
+					events, err = taskRepo.GetHistory(task.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(events).To(HaveLen(3))
+					Expect(events[2].Action).To(Equal(model.EventDeleted))
+				})
+			})
+		})
+
+		Describe("Notifications", func() {
+			var notificationService service.NotificationService
+
+			BeforeEach(func() {
+				notificationService = service.NewNotificationService(repo.NewNotificationRepo(filebasedDb), taskRepo, repo.NewAssignmentRepo(filebasedDb), userRepo)
+			})
+
+			When("a watched task changes status", func() {
+				It("should notify its owner and watchers except the actor", func() {
+					_, err := notificationService.Watch(7, model.WatchCategory, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					_, err = notificationService.Watch(8, model.WatchTask, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					_, err = notificationService.Watch(8, model.WatchTask, 99)
+					Expect(err).Should(HaveOccurred())
+
+					task, err := taskService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					from := task.Status
+					task.Status = model.StatusReview
+					Expect(notificationService.StatusChanged(*task, from, 8, "watcher@mail.com")).To(Succeed())
+
+					for _, userID := range []int{2, 7} {
+						unread, err := notificationService.CountUnread(userID)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(unread).To(Equal(1))
+					}
+					unread, err := notificationService.CountUnread(8)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(unread).To(BeZero())
+
+					notifications, err := notificationService.GetList(7, true)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(notifications[0].Kind).To(Equal(model.NotifyStatusChanged))
+					Expect(notifications[0].TaskID).To(Equal(1))
+
+					err = notificationService.MarkRead(8, notifications[0].ID)
+					Expect(errors.Is(err, model.ErrNotificationNotFound)).To(BeTrue())
+					Expect(notificationService.MarkRead(7, notifications[0].ID)).To(Succeed())
+					unread, err = notificationService.CountUnread(7)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(unread).To(BeZero())
+				})
+			})
+		})
+
+		Describe("Board", func() {
+			columnIDs := func(board model.Board, status model.TaskStatus) []int {
+				var ids []int
+				for _, column := range board.Columns {
+					if column.Status == status {
+						for _, task := range column.Tasks {
+							ids = append(ids, task.ID)
+						}
+					}
+				}
+				return ids
+			}
+
+			When("ranks are computed", func() {
+				It("should always find a rank between two neighbours", func() {
+					Expect(model.RankBetween("", "")).To(Equal("i"))
+					Expect(model.RankBetween("i", "") > "i").To(BeTrue())
+					for _, pair := range [][2]string{{"", "1"}, {"a", "b"}, {"az", "b"}, {"a", "a1"}, {"", "01"}} {
+						rank := model.RankBetween(pair[0], pair[1])
+						Expect(rank > pair[0] && rank < pair[1]).To(BeTrue(), "%q between %q and %q", rank, pair[0], pair[1])
+					}
+					Expect(model.RankBetween("a0", "b") > "a0").To(BeTrue())
+					Expect(func() { model.RankBetween("", "0") }).To(Panic())
+					Expect(func() { model.RankBetween("a", "a0") }).To(Panic())
+					Expect(func() { model.RankBetween("a", "a00") }).To(Panic())
+				})
+
+				It("should keep ranks short however tasks are added and moved", func() {
+					last := ""
+					for i := 0; i < 1000; i++ {
+						next := model.RankBetween(last, "")
+						Expect(next > last).To(BeTrue())
+						last = next
+					}
+					Expect(len(last)).To(BeNumerically("<", 40))
+
+					ranks := model.SpreadRanks(50)
+					for i := 1; i < len(ranks); i++ {
+						Expect(ranks[i] > ranks[i-1]).To(BeTrue())
+					}
+
+					for i := 0; i < 300; i++ {
+						_, err := taskService.As("test@mail.com").Move(1+4*(i%2), model.MoveRequest{})
+						Expect(err).ShouldNot(HaveOccurred())
+					}
+					board, err := taskService.GetBoard(0, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(columnIDs(board, model.StatusInProgress)).To(Equal([]int{5, 1}))
+					for _, column := range board.Columns {
+						for _, task := range column.Tasks {
+							Expect(len(task.Rank)).To(BeNumerically("<=", model.MaxRankLength))
+						}
+					}
+				})
+			})
+
+			When("a task is moved", func() {
+				It("should reorder only the moved task and record status changes", func() {
+					board, err := taskService.GetBoard(0, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(board.Columns[0].Status).To(Equal(model.StatusTodo))
+					Expect(columnIDs(board, model.StatusInProgress)).To(Equal([]int{1, 5}))
+
+					before, err := taskService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+
+					moved, err := taskService.As("test@mail.com").Move(5, model.MoveRequest{})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(moved.Rank < before.Rank).To(BeTrue())
+
+					after, err := taskService.GetByID(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(after.Rank).To(Equal(before.Rank))
+
+					history, err := taskService.GetHistory(5)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(history[len(history)-1].Actor).To(Equal("test@mail.com"))
+
+					_, err = taskService.As("test@mail.com").Move(1, model.MoveRequest{Status: model.StatusReview})
+					Expect(err).ShouldNot(HaveOccurred())
+					_, err = taskService.As("test@mail.com").Move(5, model.MoveRequest{Status: model.StatusReview, AfterID: 1})
+					Expect(err).ShouldNot(HaveOccurred())
+
+					board, err = taskService.GetBoard(0, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(columnIDs(board, model.StatusInProgress)).To(BeEmpty())
+					Expect(columnIDs(board, model.StatusReview)).To(Equal([]int{1, 5}))
+
+					transitions, err := taskService.GetTransitions(5)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(transitions).To(HaveLen(1))
+					Expect(transitions[0].ChangedBy).To(Equal("test@mail.com"))
+
+					_, err = taskService.As("test@mail.com").Move(1, model.MoveRequest{Status: model.StatusReview, AfterID: 3})
+					Expect(errors.Is(err, model.ErrInvalidMove)).To(BeTrue())
+					_, err = taskService.As("test@mail.com").Move(1, model.MoveRequest{Status: model.StatusTodo})
+					Expect(errors.Is(err, model.ErrInvalidTransition)).To(BeTrue())
+				})
+			})
+		})
+
+		Describe("Time Service", func() {
+			var timeService service.TimeService
+			var user model.User
+			at := func(value string) time.Time {
+				t, err := time.Parse(time.RFC3339, value)
+				Expect(err).ShouldNot(HaveOccurred())
+				return t
+			}
+
+			BeforeEach(func() {
+				timeService = service.NewTimeService(repo.NewTimeEntryRepo(filebasedDb), taskRepo, userRepo)
+
+				var err error
+				user, err = userRepo.GetUserByEmail("test@mail.com")
+				Expect(err).ShouldNot(HaveOccurred())
+				user.TimeZone = "Asia/Jakarta"
+				Expect(userRepo.UpdateUser(user)).Should(Succeed())
+			})
+
+			When("running timers", func() {
+				It("should allow one running timer per user", func() {
+					entry, err := timeService.Start(1, user.ID, "review")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(entry.Running()).To(BeTrue())
+
+					_, err = timeService.Start(5, user.ID, "")
+					Expect(errors.Is(err, model.ErrTimerRunning)).To(BeTrue())
+
+					_, err = timeService.Start(5, user.ID+1, "")
+					Expect(err).ShouldNot(HaveOccurred())
+
+					running, err := timeService.GetRunning(user.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(running.TaskID).To(Equal(1))
+
+					stopped, err := timeService.Stop(user.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(stopped.ID).To(Equal(entry.ID))
+					Expect(stopped.End).ShouldNot(BeNil())
+
+					_, err = timeService.Stop(user.ID)
+					Expect(errors.Is(err, model.ErrNoRunningTimer)).To(BeTrue())
+				})
+			})
+
+			When("entering time by hand", func() {
+				It("should refuse entries that do not end after they start or end in the future", func() {
+					_, err := timeService.AddEntry(1, user.ID, model.TimeEntryRequest{Start: at("2024-03-02T10:00:00Z"), End: at("2024-03-02T09:00:00Z")})
+					Expect(errors.Is(err, model.ErrInvalidTimeEntry)).To(BeTrue())
+
+					_, err = timeService.AddEntry(1, user.ID, model.TimeEntryRequest{Start: time.Now(), End: time.Now().Add(time.Hour)})
+					Expect(errors.Is(err, model.ErrInvalidTimeEntry)).To(BeTrue())
+				})
+
+				It("should only let the user delete their own entries", func() {
+					entry, err := timeService.AddEntry(1, user.ID, model.TimeEntryRequest{Start: at("2024-03-02T09:00:00Z"), End: at("2024-03-02T10:00:00Z")})
+					Expect(err).ShouldNot(HaveOccurred())
+
+					err = timeService.DeleteEntry(entry.ID, user.ID+1)
+					Expect(errors.Is(err, model.ErrTimeEntryNotFound)).To(BeTrue())
+					Expect(timeService.DeleteEntry(entry.ID, user.ID)).Should(Succeed())
+
+					taskTime, err := timeService.GetTaskTime(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(taskTime.Entries).To(BeEmpty())
+				})
+			})
+
+			When("reporting a timesheet", func() {
+				BeforeEach(func() {
+					for _, entry := range []struct {
+						taskID     int
+						start, end string
+					}{
+						{1, "2024-03-05T00:00:00Z", "2024-03-05T00:15:00Z"},
+						{1, "2024-03-01T23:30:00Z", "2024-03-02T00:30:00Z"},
+						{5, "2024-03-02T02:00:00Z", "2024-03-02T02:30:00Z"},
+						{5, "2024-03-01T16:00:00Z", "2024-03-01T16:10:00Z"},
+					} {
+						_, err := timeService.AddEntry(entry.taskID, user.ID, model.TimeEntryRequest{Start: at(entry.start), End: at(entry.end)})
+						Expect(err).ShouldNot(HaveOccurred())
+					}
+				})
+
+				It("should total the entries per task, category and day in the user's time zone", func() {
+					sheet, err := timeService.Report(user.ID, "2024-03-02", "2024-03-03")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sheet.Entries).To(HaveLen(2))
+					Expect(sheet.Entries[0].TaskID).To(Equal(1))
+					Expect(sheet.ByTask).To(Equal([]model.TimeTotal{{TaskID: 1, Seconds: 3600}, {TaskID: 5, Seconds: 1800}}))
+					Expect(sheet.ByCategory).To(Equal([]model.TimeTotal{{CategoryID: 1, Seconds: 3600}, {CategoryID: 3, Seconds: 1800}}))
+					Expect(sheet.ByDay).To(Equal([]model.TimeTotal{{Date: "2024-03-02", Seconds: 5400}}))
+					Expect(sheet.TotalSeconds).To(Equal(int64(5400)))
+
+					_, err = timeService.Report(user.ID, "2024-03-03", "2024-03-02")
+					Expect(errors.Is(err, model.ErrInvalidTimeRange)).To(BeTrue())
+				})
+
+				It("should drop the entries of deleted tasks", func() {
+					Expect(taskService.Delete(5)).Should(Succeed())
+
+					sheet, err := timeService.Report(user.ID, "2024-03-01", "2024-03-05")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(sheet.ByTask).To(Equal([]model.TimeTotal{{TaskID: 1, Seconds: 4500}}))
+				})
+			})
+		})
+
+		Describe("Attachment Service", func() {
+			var attachmentService service.AttachmentService
+			var dir string
+			png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 24)...)
+
+			BeforeEach(func() {
+				dir, err = os.MkdirTemp("", "attachments")
+				Expect(err).ShouldNot(HaveOccurred())
+				DeferCleanup(os.RemoveAll, dir)
+
+				attachmentService = service.NewAttachmentService(repo.NewAttachmentRepo(filebasedDb), taskRepo, blobstore.NewLocalStore(dir))
+			})
+
+			When("uploading a file", func() {
+				It("should store the content and detect its type", func() {
+					attachment, err := attachmentService.Upload(1, 1, "C:\\shots\\screen.png", "application/octet-stream", int64(len(png)), bytes.NewReader(png))
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(attachment.FileName).To(Equal("screen.png"))
+					Expect(attachment.ContentType).To(Equal("image/png"))
+
+					stored, content, err := attachmentService.Open(1, attachment.ID)
+					Expect(err).ShouldNot(HaveOccurred())
+					data, _ := io.ReadAll(content)
+					content.Close()
+					Expect(data).To(Equal(png))
+					Expect(stored.Key).To(Equal(attachment.Key))
+
+					_, _, err = attachmentService.Open(2, attachment.ID)
+					Expect(errors.Is(err, model.ErrAttachmentNotFound)).To(BeTrue())
+				})
+
+				It("should refuse files over the size limit or of a type that is not allowed", func() {
+					page := []byte("<html><script>alert(1)</script></html>")
+					_, err := attachmentService.Upload(1, 1, "screen.png", "image/png", int64(len(page)), bytes.NewReader(page))
+					Expect(errors.Is(err, model.ErrAttachmentType)).To(BeTrue())
+
+					DeferCleanup(func(size string) { config.MaxAttachmentSize = size }, config.MaxAttachmentSize)
+					config.MaxAttachmentSize = "16"
+					_, err = attachmentService.Upload(1, 1, "screen.png", "image/png", int64(len(png)), bytes.NewReader(png))
+					Expect(errors.Is(err, model.ErrAttachmentTooLarge)).To(BeTrue())
+
+					attachments, err := attachmentService.GetList(1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(attachments).To(BeEmpty())
+				})
+			})
+
+			When("deleting attachments", func() {
+				It("should only let the uploader delete a file", func() {
+					attachment, err := attachmentService.Upload(1, 1, "screen.png", "image/png", int64(len(png)), bytes.NewReader(png))
+					Expect(err).ShouldNot(HaveOccurred())
+
+					err = attachmentService.Delete(1, attachment.ID, 2)
+					Expect(errors.Is(err, model.ErrNotAttachmentUploader)).To(BeTrue())
+
+					Expect(attachmentService.Delete(1, attachment.ID, 1)).Should(Succeed())
+					Expect(filepath.Join(dir, attachment.Key)).NotTo(BeAnExistingFile())
+				})
+
+				It("should remove the content of the files of deleted tasks once they are purged from the trash", func() {
+					attachment, err := attachmentService.Upload(2, 1, "screen.png", "image/png", int64(len(png)), bytes.NewReader(png))
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(filepath.Join(dir, attachment.Key)).To(BeAnExistingFile())
+
+					Expect(taskService.Delete(2)).Should(Succeed())
 
-						Expect(categories).To(Equal(insertCategories))
-					})
+					removed, err := attachmentService.PurgeOrphanedBlobs()
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(removed).To(Equal(0))
+					Expect(filepath.Join(dir, attachment.Key)).To(BeAnExistingFile())
+
+					trashService := service.NewTrashService(repo.NewTrashRepo(filebasedDb), categoryRepo)
+					purged, err := trashService.PurgeExpired(time.Now().Add(config.GetTrashRetention() + time.Minute))
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(purged).To(Equal(1))
+
+					removed, err = attachmentService.PurgeOrphanedBlobs()
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(removed).To(Equal(1))
+					Expect(filepath.Join(dir, attachment.Key)).NotTo(BeAnExistingFile())
 				})
 			})
 		})
 
-		Describe("Task Service", func() {
-			Describe("Update", func() {
-				When("updating a task in the database", func() {
-					It("should update the task without any errors", func() {
-						task := &model.Task{
-							ID:         1,
-							Title:      "Updated with Service Task 1",
-							Deadline:   "2023-05-30",
-							Priority:   5,
-							CategoryID: 1,
-							Status:     "In Progress",
-						}
+		Describe("Comment Service", func() {
+			var commentService service.CommentService
+			var first *model.Comment
 
-						err := taskService.Update(task.ID, task)
-						Expect(err).ShouldNot(HaveOccurred())
-					})
-				})
+			BeforeEach(func() {
+				commentService = service.NewCommentService(repo.NewCommentRepo(filebasedDb), taskRepo)
+
+				var err error
+				first, err = commentService.Add(1, 1, "aditira@gmail.com", "**Done** <script>alert(1)</script>")
+				Expect(err).ShouldNot(HaveOccurred())
 			})
 
-			Describe("Delete", func() {
-				When("deleting a task from the database", func() {
-					It("should delete the task without any errors", func() {
-						err := taskService.Delete(3)
-						Expect(err).ShouldNot(HaveOccurred())
-					})
+			When("adding a comment", func() {
+				It("should render the Markdown body without its raw HTML", func() {
+					Expect(first.HTML).To(Equal("<p><strong>Done</strong> &lt;script&gt;alert(1)&lt;/script&gt;</p>"))
+
+					_, err := commentService.Add(1, 1, "aditira@gmail.com", "   ")
+					Expect(errors.Is(err, model.ErrEmptyComment)).To(BeTrue())
+				})
+
+				It("should drop NUL characters instead of reading them as placeholders", func() {
+					comment, err := commentService.Add(1, 1, "aditira@gmail.com", "a `\x005\x00")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(comment.HTML).To(Equal("<p>a `5</p>"))
 				})
 			})
 
-			Describe("GetList", func() {
-				When("retrieving the list of tasks from the database", func() {
-					It("should return the list of tasks without any errors", func() {
-						tasks, err := taskService.GetList()
-						Expect(err).ShouldNot(HaveOccurred())
-						Expect(tasks).To(Equal(insertTasks))
-					})
+			When("editing a comment", func() {
+				It("should keep the previous body in the history", func() {
+					edited, err := commentService.Edit(1, first.ID, 1, "Done, see [notes](https://example.com)")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(edited.EditedAt).NotTo(BeNil())
+					Expect(edited.Edits).To(HaveLen(1))
+					Expect(edited.Edits[0].Body).To(Equal(first.Body))
+					Expect(edited.HTML).To(ContainSubstring(`<a href="https://example.com" rel="nofollow noopener">notes</a>`))
+				})
+
+				It("should refuse edits of other users", func() {
+					_, err := commentService.Edit(1, first.ID, 2, "Hijacked")
+					Expect(errors.Is(err, model.ErrNotCommentAuthor)).To(BeTrue())
 				})
 			})
 
-			Describe("GetTaskCategory", func() {
-				When("retrieving the category of a task from the database", func() {
-					It("should return the task category without any errors", func() {
-						taskCategories, err := taskService.GetTaskCategory(1)
-						Expect(err).ShouldNot(HaveOccurred())
-						Expect(taskCategories).To(Equal([]model.TaskCategory{
-							{ID: 1, Title: "Task 1", Category: "Category 1"},
-							{ID: 3, Title: "Task 3", Category: "Category 1"},
-							{ID: 4, Title: "Task 4", Category: "Category 1"},
-						}))
-					})
+			When("listing comments", func() {
+				It("should page through the thread and hide deleted comments", func() {
+					second, err := commentService.Add(1, 2, "dito@gmail.com", "Second")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(commentService.Delete(1, first.ID, 1)).Should(Succeed())
+
+					page, err := commentService.GetPage(1, 1, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(page.Total).To(Equal(2))
+					Expect(page.Comments).To(HaveLen(1))
+					Expect(page.Comments[0].DeletedAt).NotTo(BeNil())
+					Expect(page.Comments[0].Body).To(BeEmpty())
+
+					page, err = commentService.GetPage(1, 2, 1)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(page.Comments).To(HaveLen(1))
+					Expect(page.Comments[0].ID).To(Equal(second.ID))
+
+					page, err = commentService.GetPage(1, 1<<62, 4)
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(page.Comments).To(BeEmpty())
+
+					_, err = commentService.Edit(1, first.ID, 1, "Restored")
+					Expect(errors.Is(err, model.ErrCommentDeleted)).To(BeTrue())
 				})
 			})
 		})
@@ -719,6 +2442,87 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 						Expect(userTasks).To(Equal(expectedUserTask))
 					})
 				})
+
+				When("the user works in several workspaces", func() {
+					It("should only list the tasks of the selected workspace that are not archived", func() {
+						workspaceService := service.NewWorkspaceService(repo.NewWorkspaceRepo(filebasedDb), userRepo, taskRepo, categoryRepo)
+						team, err := workspaceService.Create("Team", 1)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(taskService.Store(&model.Task{Title: "Team task", Priority: 1, UserID: 1, WorkspaceID: team.ID})).Should(Succeed())
+						_, err = taskService.Archive(2)
+						Expect(err).ShouldNot(HaveOccurred())
+
+						list := func() []string {
+							r, _ := http.NewRequest("GET", "/api/v1/user/tasks", nil)
+							w := httptest.NewRecorder()
+							r.AddCookie(SetCookie(apiServer))
+							apiServer.ServeHTTP(w, r)
+							Expect(w.Code).To(Equal(http.StatusOK))
+
+							var userTasks []model.UserTaskCategory
+							Expect(json.Unmarshal(w.Body.Bytes(), &userTasks)).Should(Succeed())
+							titles := []string{}
+							for _, task := range userTasks {
+								titles = append(titles, task.Task)
+							}
+							return titles
+						}
+						Expect(list()).To(Equal([]string{"Task 5"}))
+
+						Expect(workspaceService.Select(1, team.ID)).Should(Succeed())
+						Expect(list()).To(Equal([]string{"Team task"}))
+					})
+				})
+			})
+
+			Describe("DeleteAccount", func() {
+				When("the account was deleted", func() {
+					It("should refuse the cookie of the deleted session", func() {
+						cookie := SetCookie(apiServer)
+
+						r, _ := http.NewRequest("DELETE", "/api/v1/user/delete", nil)
+						w := httptest.NewRecorder()
+						r.AddCookie(cookie)
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						r, _ = http.NewRequest("GET", "/api/v1/task/list", nil)
+						w = httptest.NewRecorder()
+						r.Header.Set("Content-Type", "application/json")
+						r.AddCookie(cookie)
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusUnauthorized))
+
+						_, err := userService.RestoreAccount(&model.User{Email: "test@mail.com", Password: "testing123"})
+						Expect(err).ShouldNot(HaveOccurred())
+
+						r, _ = http.NewRequest("GET", "/api/v1/task/list", nil)
+						w = httptest.NewRecorder()
+						r.Header.Set("Content-Type", "application/json")
+						r.AddCookie(cookie)
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				When("the account is restored", func() {
+					It("should refuse a wrong password and accounts without a pending deletion", func() {
+						restore := func(password string) int {
+							body, _ := json.Marshal(model.UserLogin{Email: "test@mail.com", Password: password})
+							r := httptest.NewRequest("POST", "/api/v1/user/restore", bytes.NewReader(body))
+							r.Header.Set("Content-Type", "application/json")
+							w := httptest.NewRecorder()
+							apiServer.ServeHTTP(w, r)
+							return w.Code
+						}
+						Expect(restore("testing123")).To(Equal(http.StatusConflict))
+
+						_, err := userService.RequestDeletion(1, "test@mail.com")
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(restore("wrong")).To(Equal(http.StatusUnauthorized))
+						Expect(restore("testing123")).To(Equal(http.StatusOK))
+					})
+				})
 			})
 		})
 
@@ -855,6 +2659,33 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 						Expect(response).To(Equal(insertCategories))
 					})
 				})
+
+				When("sorting and paging the categories", func() {
+					It("should return one page and the cursor of the next", func() {
+						r, _ := http.NewRequest("GET", "/api/v1/category/list?sort=-created&limit=1", nil)
+						w := httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						var response []model.Category
+						Expect(json.Unmarshal(w.Body.Bytes(), &response)).Should(Succeed())
+						Expect(response).To(Equal(insertCategories[len(insertCategories)-1:]))
+						Expect(w.Header().Get("X-Next-Cursor")).NotTo(BeEmpty())
+					})
+				})
+
+				When("a query parameter is not a number", func() {
+					It("should return status code 400", func() {
+						for _, params := range []string{"user=me", "project=x", "limit=ten"} {
+							r, _ := http.NewRequest("GET", "/api/v1/category/list?"+params, nil)
+							w := httptest.NewRecorder()
+							r.AddCookie(SetCookie(apiServer))
+							apiServer.ServeHTTP(w, r)
+							Expect(w.Code).To(Equal(http.StatusBadRequest))
+						}
+					})
+				})
 			})
 		})
 
@@ -865,7 +2696,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 						updatedTask := model.Task{
 							ID:         1,
 							Title:      "Updated with API Task 1",
-							Deadline:   "2023-05-30",
+							Deadline:   model.DateDeadline(2023, time.May, 30),
 							Priority:   5,
 							CategoryID: 1,
 							Status:     "In Progress",
@@ -885,12 +2716,14 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 						updatedTask := model.Task{
 							ID:         1,
 							Title:      "Updated with API Task 1",
-							Deadline:   "2023-05-30",
+							Deadline:   model.DateDeadline(2023, time.May, 30),
 							Priority:   5,
 							CategoryID: 1,
 							Status:     "In Progress",
 						}
 						reqBody, _ := json.Marshal(updatedTask)
+						// Task 1 is owned by another user, the test user works on it as an assignee.
+						Expect(repo.NewAssignmentRepo(filebasedDb).Assign(1, 1)).Should(Succeed())
 
 						r, _ := http.NewRequest("PUT", fmt.Sprintf("/api/v1/task/update/%d", 1), bytes.NewReader(reqBody))
 						w := httptest.NewRecorder()
@@ -936,7 +2769,7 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 
 				When("deleting existing task", func() {
 					It("should return status code 200", func() {
-						r, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/task/delete/%d", 1), nil)
+						r, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/task/delete/%d", 5), nil)
 						w := httptest.NewRecorder()
 
 						r.AddCookie(SetCookie(apiServer))
@@ -949,6 +2782,20 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 					})
 				})
 
+				When("deleting a task of another user", func() {
+					It("should return status code 403", func() {
+						r, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/task/delete/%d", 1), nil)
+						w := httptest.NewRecorder()
+
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusForbidden))
+
+						_, err := taskRepo.GetByID(1)
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+				})
+
 				When("deleting non-existing task", func() {
 					It("should return status code 400", func() {
 						taskID := "abc"
@@ -966,6 +2813,79 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 				})
 			})
 
+			Describe("AddSubtask", func() {
+				add := func(parentID int, subtask model.Task) *httptest.ResponseRecorder {
+					body, _ := json.Marshal(subtask)
+					r, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/task/%d/subtasks", parentID), bytes.NewReader(body))
+					w := httptest.NewRecorder()
+					r.Header.Set("Content-Type", "application/json")
+					r.AddCookie(SetCookie(apiServer))
+					apiServer.ServeHTTP(w, r)
+					return w
+				}
+
+				When("adding a subtask to an own task", func() {
+					It("should own it whatever user ID the body claims", func() {
+						w := add(5, model.Task{Title: "Step", Priority: 1, UserID: 4})
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						var subtask model.Task
+						Expect(json.Unmarshal(w.Body.Bytes(), &subtask)).Should(Succeed())
+						Expect(subtask.UserID).To(Equal(1))
+						Expect(subtask.ParentID).To(Equal(5))
+					})
+				})
+
+				When("the category belongs to another workspace", func() {
+					It("should return status code 400", func() {
+						foreign := model.Category{ID: 50, Name: "Elsewhere", WorkspaceID: 7}
+						Expect(categoryRepo.Store(&foreign)).Should(Succeed())
+
+						w := add(5, model.Task{Title: "Step", Priority: 1, CategoryID: foreign.ID})
+						Expect(w.Code).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				When("the parent belongs to another user", func() {
+					It("should return status code 403", func() {
+						w := add(1, model.Task{Title: "Step", Priority: 1})
+						Expect(w.Code).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
+			Describe("TransitionTask", func() {
+				When("moving a task along the workflow", func() {
+					It("should return status code 200 with the updated task", func() {
+						body, _ := json.Marshal(model.TransitionRequest{Status: model.StatusReview})
+						r, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/task/transition/%d", 5), bytes.NewReader(body))
+						w := httptest.NewRecorder()
+						r.Header.Set("Content-Type", "application/json")
+
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						var task model.Task
+						Expect(json.Unmarshal(w.Body.Bytes(), &task)).Should(Succeed())
+						Expect(task.Status).To(Equal(model.StatusReview))
+					})
+				})
+
+				When("skipping a step of the workflow", func() {
+					It("should return status code 400", func() {
+						body, _ := json.Marshal(model.TransitionRequest{Status: model.StatusCompleted})
+						r, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/task/transition/%d", 5), bytes.NewReader(body))
+						w := httptest.NewRecorder()
+						r.Header.Set("Content-Type", "application/json")
+
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Describe("GetTaskList", func() {
 				When("sending without cookie", func() {
 					It("should return status code 401", func() {
@@ -991,6 +2911,248 @@ var _ = Describe("Task Tracker Plus", Ordered, func() {
 						Expect(response).To(Equal(insertTasks))
 					})
 				})
+				When("filtering by tag", func() {
+					It("should return only the tasks carrying the tag", func() {
+						body, _ := json.Marshal(model.Tag{Name: "Urgent", Color: "#ef4444"})
+						r, _ := http.NewRequest("POST", "/api/v1/tag/add", bytes.NewReader(body))
+						w := httptest.NewRecorder()
+						r.Header.Set("Content-Type", "application/json")
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						var tag model.Tag
+						Expect(json.Unmarshal(w.Body.Bytes(), &tag)).Should(Succeed())
+
+						r, _ = http.NewRequest("POST", fmt.Sprintf("/api/v1/task/3/tags/%d", tag.ID), nil)
+						w = httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						r, _ = http.NewRequest("GET", fmt.Sprintf("/api/v1/task/list?tag=%d", tag.ID), nil)
+						w = httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						var response []model.Task
+						Expect(json.Unmarshal(w.Body.Bytes(), &response)).Should(Succeed())
+						Expect(response).To(Equal(insertTasks[2:3]))
+					})
+				})
+				When("searching with a query", func() {
+					It("should parse it and return the matching tasks or point at the error", func() {
+						parsed, err := model.ParseSearch(`status:open priority>=3 OR (due<2023-06-01 -tag:"home")`)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(parsed.Root.String()).To(Equal(`(OR (AND status:open priority>=3) (AND due<2023-06-01 (NOT tag:home)))`))
+
+						_, err = model.ParseSearch("status:open prio>3")
+						var parseErr *model.ParseError
+						Expect(errors.As(err, &parseErr)).To(BeTrue())
+						Expect(parseErr.Column).To(Equal(13))
+						Expect(parseErr.Token).To(Equal("prio>3"))
+
+						parsed, err = model.ParseSearch("re: meeting https://example.com/a status:open")
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(parsed.Root.String()).To(Equal(`(AND re: meeting https://example.com/a status:open)`))
+
+						_, err = model.ParseSearch("status:open priority:high")
+						Expect(errors.As(err, &parseErr)).To(BeTrue())
+						Expect(parseErr.Token).To(Equal("priority:high"))
+
+						tasks, err := taskService.Search(0, 1, `status:done priority>=3 task`)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(tasks).To(Equal([]model.Task{insertTasks[2], insertTasks[3]}))
+
+						_, err = userService.UpdateProfile(1, model.UserProfile{TimeZone: "Asia/Jakarta"})
+						Expect(err).ShouldNot(HaveOccurred())
+						late := model.Task{Title: "Late call", Deadline: model.Deadline{At: time.Date(2023, time.May, 31, 20, 0, 0, 0, time.UTC)}, Status: model.StatusTodo, UserID: 1}
+						Expect(taskRepo.Store(&late, "")).Should(Succeed())
+						tasks, err = taskService.Search(0, 1, `due:2023-06-01 late`)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(tasks).To(HaveLen(1))
+						Expect(tasks[0].ID).To(Equal(late.ID))
+						Expect(taskRepo.Delete(late.ID, "")).Should(Succeed())
+
+						r, _ := http.NewRequest("GET", "/api/v1/task/search?q="+url.QueryEscape(`status:open OR due<2023-06-01`), nil)
+						w := httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						var response []model.Task
+						Expect(json.Unmarshal(w.Body.Bytes(), &response)).Should(Succeed())
+						Expect(response).To(Equal([]model.Task{insertTasks[0], insertTasks[4]}))
+
+						r, _ = http.NewRequest("GET", "/api/v1/task/search?q="+url.QueryEscape(`(status:open`), nil)
+						w = httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusBadRequest))
+
+						var searchErr model.SearchErrorResponse
+						Expect(json.Unmarshal(w.Body.Bytes(), &searchErr)).Should(Succeed())
+						Expect(searchErr.Column).To(Equal(1))
+						Expect(searchErr.Token).To(Equal("("))
+					})
+				})
+				When("stemming Indonesian words", func() {
+					It("should strip the confix in order and return one root of the root list", func() {
+						Expect(model.StemIndonesian("kesehatan")).To(Equal("sehat"))
+						Expect(model.StemIndonesian("pekerjaan")).To(Equal("kerja"))
+						Expect(model.StemIndonesian("mempermainkan")).To(Equal("main"))
+						Expect(model.StemIndonesian("menulis")).To(Equal("tulis"))
+						Expect(model.StemIndonesian("memakan")).To(Equal("makan"))
+						Expect(model.StemIndonesian("meeting")).To(BeEmpty())
+					})
+				})
+				When("searching the full-text index", func() {
+					It("should rank stemmed matches, highlight them and follow changes", func() {
+						searchService := service.NewSearchService(repo.NewSearchRepo(filebasedDb))
+
+						report := model.Task{Title: "Menulis laporan <keuangan>", Status: model.StatusTodo, UserID: 1}
+						Expect(taskRepo.Store(&report, "")).Should(Succeed())
+						Expect(categoryRepo.Store(&model.Category{ID: 6, Name: "Laporan tahunan"})).Should(Succeed())
+
+						hits, err := searchService.Search(0, "tulis laporan", 0)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(hits).To(HaveLen(2))
+						Expect(hits[0].Kind).To(Equal(model.SearchKindTask))
+						Expect(hits[0].ID).To(Equal(report.ID))
+						Expect(hits[0].Highlighted).To(Equal("<mark>Menulis</mark> <mark>laporan</mark> &lt;keuangan&gt;"))
+						Expect(hits[0].Highlights).To(Equal([]model.TextRange{{Start: 0, End: 7}, {Start: 8, End: 15}}))
+						Expect(hits[1].Kind).To(Equal(model.SearchKindCategory))
+						Expect(hits[1].Highlighted).To(Equal("<mark>Laporan</mark> tahunan"))
+						Expect(hits[0].Score).To(BeNumerically(">", hits[1].Score))
+
+						hits, err = searchService.Search(0, "tasks", 2)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(hits).To(HaveLen(2))
+						Expect(hits[0].Highlighted).To(Equal("<mark>Task</mark> 1"))
+
+						report.Title = "Review budget"
+						Expect(taskRepo.Update(report.ID, &report, "")).Should(Succeed())
+						Expect(categoryRepo.Delete(6)).Should(Succeed())
+						hits, err = searchService.Search(0, "laporan", 0)
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(hits).To(BeEmpty())
+
+						_, err = searchService.Search(0, "the", 0)
+						Expect(errors.Is(err, model.ErrEmptyTextSearch)).To(BeTrue())
+
+						indexed, err := searchService.Rebuild()
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(indexed).To(Equal(len(insertTasks) + 1 + len(insertCategories)))
+
+						r, _ := http.NewRequest("GET", "/api/v1/search?q="+url.QueryEscape("budgeting"), nil)
+						w := httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusOK))
+
+						var response []model.SearchHit
+						Expect(json.Unmarshal(w.Body.Bytes(), &response)).Should(Succeed())
+						Expect(response).To(HaveLen(1))
+						Expect(response[0].Highlighted).To(Equal("Review <mark>budget</mark>"))
+
+						r, _ = http.NewRequest("GET", "/api/v1/search?q=the", nil)
+						w = httptest.NewRecorder()
+						r.AddCookie(SetCookie(apiServer))
+						apiServer.ServeHTTP(w, r)
+						Expect(w.Code).To(Equal(http.StatusBadRequest))
+					})
+				})
+				When("listing saved filters and smart lists", func() {
+					It("should list the tasks of built-in and saved filters", func() {
+						now := time.Now().UTC()
+						today := model.Task{Title: "Task today", Deadline: model.DateDeadline(now.Year(), now.Month(), now.Day()), Priority: 3, Status: model.StatusTodo, UserID: 1}
+						Expect(taskRepo.Store(&today, "")).Should(Succeed())
+
+						request := func(method, url string, body interface{}, out interface{}) int {
+							var payload io.Reader
+							if body != nil {
+								data, _ := json.Marshal(body)
+								payload = bytes.NewReader(data)
+							}
+							r, _ := http.NewRequest(method, url, payload)
+							r.Header.Set("Content-Type", "application/json")
+							w := httptest.NewRecorder()
+							r.AddCookie(SetCookie(apiServer))
+							apiServer.ServeHTTP(w, r)
+							if w.Code == http.StatusOK && out != nil {
+								Expect(json.Unmarshal(w.Body.Bytes(), out)).Should(Succeed())
+							}
+							return w.Code
+						}
+						taskIDs := func(ref string) []int {
+							var list model.SmartList
+							Expect(request("GET", "/api/v1/filter/tasks/"+ref, nil, &list)).To(Equal(http.StatusOK))
+							ids := []int{}
+							for _, task := range list.Tasks {
+								ids = append(ids, task.ID)
+							}
+							return ids
+						}
+
+						Expect(taskIDs("today")).To(Equal([]int{today.ID}))
+						Expect(taskIDs("overdue")).To(Equal([]int{1, 5}))
+						Expect(taskIDs("upcoming")).To(Equal([]int{today.ID}))
+
+						var filter model.SavedFilter
+						Expect(request("POST", "/api/v1/filter/add", model.FilterDefinition{Name: " Urgent ", Open: true, MinPriority: 3}, &filter)).To(Equal(http.StatusOK))
+						Expect(filter.Name).To(Equal("Urgent"))
+						Expect(taskIDs(filter.Ref())).To(Equal([]int{5, today.ID}))
+
+						Expect(request("POST", "/api/v1/filter/add", model.FilterDefinition{Name: "today"}, nil)).To(Equal(http.StatusConflict))
+						Expect(request("POST", "/api/v1/filter/add", model.FilterDefinition{Name: "Soon", Deadline: "next week"}, nil)).To(Equal(http.StatusBadRequest))
+
+						var filters []model.SavedFilter
+						Expect(request("GET", "/api/v1/filter/list", nil, &filters)).To(Equal(http.StatusOK))
+						Expect(filters).To(HaveLen(len(model.BuiltinFilters) + 1))
+						Expect(filters[len(filters)-1].ID).To(Equal(filter.ID))
+
+						Expect(request("PUT", "/api/v1/filter/update/"+filter.Ref(), model.FilterDefinition{Name: "Urgent", Deadline: "Next 2 Days"}, &filter)).To(Equal(http.StatusOK))
+						Expect(filter.Deadline).To(Equal(model.DeadlineWindow("next 2 days")))
+						Expect(taskIDs(filter.Ref())).To(Equal([]int{today.ID}))
+
+						Expect(request("DELETE", "/api/v1/filter/delete/"+filter.Ref(), nil, nil)).To(Equal(http.StatusOK))
+						Expect(request("GET", "/api/v1/filter/tasks/"+filter.Ref(), nil, nil)).To(Equal(http.StatusNotFound))
+					})
+				})
+				When("filtering, sorting and paging", func() {
+					It("should return the matching tasks a page at a time", func() {
+						list := func(url string) ([]model.Task, *httptest.ResponseRecorder) {
+							r, _ := http.NewRequest("GET", url, nil)
+							w := httptest.NewRecorder()
+							r.AddCookie(SetCookie(apiServer))
+							apiServer.ServeHTTP(w, r)
+
+							var response []model.Task
+							if w.Code == http.StatusOK {
+								Expect(json.Unmarshal(w.Body.Bytes(), &response)).Should(Succeed())
+							}
+							return response, w
+						}
+
+						response, w := list("/api/v1/task/list?status=Completed&sort=-priority&limit=2")
+						Expect(w.Code).To(Equal(http.StatusOK))
+						Expect(response).To(Equal([]model.Task{insertTasks[2], insertTasks[3]}))
+						cursor := w.Header().Get("X-Next-Cursor")
+						Expect(cursor).NotTo(BeEmpty())
+
+						response, w = list("/api/v1/task/list?status=Completed&sort=-priority&limit=2&cursor=" + cursor)
+						Expect(w.Code).To(Equal(http.StatusOK))
+						Expect(response).To(Equal([]model.Task{insertTasks[1]}))
+						Expect(w.Header().Get("X-Next-Cursor")).To(BeEmpty())
+
+						response, _ = list("/api/v1/task/list?deadline_before=2023-06-02&priority_max=2&sort=-deadline")
+						Expect(response).To(Equal([]model.Task{insertTasks[1], insertTasks[0]}))
+
+						_, w = list("/api/v1/task/list?sort=title")
+						Expect(w.Code).To(Equal(http.StatusBadRequest))
+					})
+				})
 			})
 
 			Describe("GetTaskListByCategory", func() {
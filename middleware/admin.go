@@ -0,0 +1,33 @@
+/** 
+ * Package middleware provides middleware functions for authentication and authorization in web applications.
+ * 
+ * Functions:
+ * 
+ * - Admin: Function to create an authorization middleware for admin-only routes.
+ *   Returns:
+ *   - gin.HandlerFunc: A Gin middleware handler function.
+ *   Description: This function returns a Gin middleware handler function that must run after Auth. 
+ *     It reads the user's email set by Auth and aborts with a forbidden response unless the email is listed in config.AdminEmails.
+ */
+
+package middleware
+
+import (
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/model"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Admin() gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		if !config.IsAdmin(ctx.GetString("email")) {
+			ctx.JSON(http.StatusForbidden, model.NewErrorResponse("Forbidden"))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	})
+}
@@ -9,7 +9,7 @@
  *   Description: This function returns a Gin middleware handler function that performs authentication. 
  *     It checks for the presence of a session token in the request cookie. 
  *     If the token is missing, it returns an unauthorized response or redirects the user to the login page based on the request content type. 
- *     If the token is present, it parses and validates the token using JWT and sets the user's email in the Gin context for further request processing.
+ *     If the token is present, it parses and validates the token using JWT and sets the user's email and ID in the Gin context for further request processing.
  */
 
 package middleware
@@ -59,6 +59,7 @@ func Auth() gin.HandlerFunc {
 		}
 
 		ctx.Set("email", claims.Email)
+		ctx.Set("user_id", claims.UserID)
 		ctx.Next()
 	})
 }
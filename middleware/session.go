@@ -0,0 +1,56 @@
+/** 
+ * Package middleware provides middleware functions for authentication and authorization in web applications.
+ * 
+ * Functions:
+ * 
+ * - Session: Function to create a middleware rejecting revoked sessions.
+ *   Parameters:
+ *   - sessionService: Instance of the SessionService interface used to look up the session of the token.
+ *   - userService: Instance of the UserService interface used to look up the signed in user.
+ *   Returns:
+ *   - gin.HandlerFunc: A Gin middleware handler function.
+ *   Description: This function returns a Gin middleware handler function that must run after Auth.
+ *     It aborts like Auth does for a missing token when the session of the token was removed, for example by signing out or deleting the account,
+ *     or when its user no longer exists or is scheduled for deletion.
+ */
+
+package middleware
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Session(sessionService service.SessionService, userService service.UserService) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		token, _ := ctx.Cookie("session_token")
+		email := ctx.GetString("email")
+
+		session, err := sessionService.GetSessionByToken(token)
+		if err != nil || session.Email != email {
+			revoked(ctx)
+			return
+		}
+
+		user, err := userService.GetByEmail(email)
+		if err != nil || user.ID != ctx.GetInt("user_id") || user.DeletionDueAt != nil {
+			revoked(ctx)
+			return
+		}
+
+		ctx.Next()
+	})
+}
+
+func revoked(ctx *gin.Context) {
+	if ctx.Request.Header.Get("Content-Type") == "application/json" {
+		ctx.JSON(http.StatusUnauthorized, model.NewErrorResponse("Unauthorized"))
+		ctx.Abort()
+		return
+	}
+	ctx.Redirect(http.StatusSeeOther, "/login")
+	ctx.Abort()
+}
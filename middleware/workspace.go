@@ -0,0 +1,79 @@
+/** 
+ * Package middleware provides middleware functions for authentication and authorization in web applications.
+ * 
+ * Functions:
+ * 
+ * - Workspace: Function to create a middleware scoping a request to the workspace the user selected.
+ *   Parameters:
+ *   - workspaceService: Instance of the WorkspaceService interface used to look up the selected workspace.
+ *   Returns:
+ *   - gin.HandlerFunc: A Gin middleware handler function.
+ *   Description: This function returns a Gin middleware handler function that must run after Auth.
+ *     It sets the ID of the selected workspace and the user's role in it in the Gin context
+ *     and aborts with a forbidden response when a viewer sends anything but a GET request.
+ * 
+ * - InWorkspace: Function to create a middleware hiding records of other workspaces.
+ *   Parameters:
+ *   - lookup: Function returning the workspace of the record with the ID in the path.
+ *   Returns:
+ *   - gin.HandlerFunc: A Gin middleware handler function.
+ *   Description: This function returns a Gin middleware handler function that must run after Workspace.
+ *     It aborts with a not found response when the record in the path belongs to another workspace than the selected one.
+ *     Invalid and unknown IDs are left to the handler.
+ */
+
+package middleware
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	"a21hc3NpZ25tZW50/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Workspace(workspaceService service.WorkspaceService) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		membership, err := workspaceService.Current(ctx.GetInt("user_id"))
+		if err != nil {
+			ctx.JSON(http.StatusUnauthorized, model.NewErrorResponse("Unauthorized"))
+			ctx.Abort()
+			return
+		}
+
+		if ctx.Request.Method != http.MethodGet && !membership.Role.CanWrite() {
+			ctx.JSON(http.StatusForbidden, model.NewErrorResponse(model.ErrWorkspaceForbidden.Error()))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set("workspace_id", membership.Workspace.ID)
+		ctx.Set("workspace_role", string(membership.Role))
+		ctx.Next()
+	})
+}
+
+func InWorkspace(lookup func(id int) (int, error)) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		workspaceID, err := lookup(id)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		if workspaceID != ctx.GetInt("workspace_id") {
+			ctx.JSON(http.StatusNotFound, model.NewErrorResponse("record not found"))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	})
+}
@@ -0,0 +1,65 @@
+/** 
+ * Package model provides the models of task assignments.
+ * 
+ * Structs:
+ * 
+ * - Assignment: Struct representing a user assigned to a task owned by someone else.
+ *   Fields:
+ *   - TaskID: ID of the task.
+ *     Type: int
+ *   - UserID: ID of the assigned user.
+ *     Type: int
+ *   - AssignedAt: Time the user was assigned.
+ *     Type: time.Time
+ * 
+ * - AssignRequest: Struct representing the body of a request assigning a user to a task.
+ *   Fields:
+ *   - Email: Email address of the user to assign.
+ *     Type: string
+ * 
+ * Types:
+ * 
+ * - TaskAction: Action a user performs on a task, checked against the user's relation to the task.
+ * 
+ * Errors:
+ * 
+ * - ErrAssigneeNotFound: Returned when no user has the email address to assign, or the user is not assigned to the task.
+ * - ErrAssignOwner: Returned when the owner of a task is assigned to it.
+ * - ErrAssigneeForbidden: Returned when an assignee attempts an action reserved to the owner of the task and the admins of its workspace.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type TaskAction string
+
+const (
+	// TaskActionEdit covers changing the fields of a task other than its status.
+	TaskActionEdit TaskAction = "edit"
+	// TaskActionTransition covers changing the status of a task.
+	TaskActionTransition TaskAction = "transition"
+	// TaskActionDelete covers deleting a task.
+	TaskActionDelete TaskAction = "delete"
+	// TaskActionAssign covers assigning users to a task and unassigning them.
+	TaskActionAssign TaskAction = "assign"
+)
+
+var (
+	ErrAssigneeNotFound  = errors.New("assignee not found")
+	ErrAssignOwner       = errors.New("the owner of a task cannot be assigned to it")
+	ErrAssigneeForbidden = errors.New("assignees cannot delete a task or change who it is assigned to")
+)
+
+type Assignment struct {
+	TaskID     int       `json:"task_id"`
+	UserID     int       `json:"user_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+type AssignRequest struct {
+	Email string `json:"email" binding:"required"`
+}
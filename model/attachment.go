@@ -0,0 +1,56 @@
+/** 
+ * Package model provides the models of task attachments.
+ * 
+ * Structs:
+ * 
+ * - Attachment: Struct representing the metadata of a file attached to a task. The content itself lives in a blob store.
+ *   Fields:
+ *   - ID: Unique identifier for the attachment.
+ *     Type: int
+ *   - TaskID: ID of the task the file is attached to.
+ *     Type: int
+ *   - UploaderID: ID of the user who uploaded the file.
+ *     Type: int
+ *   - FileName: Name of the file as uploaded, without any directory.
+ *     Type: string
+ *   - ContentType: MIME type of the file, detected from its content when possible.
+ *     Type: string
+ *   - Size: Size of the file in bytes.
+ *     Type: int64
+ *   - Key: Key of the content in the blob store.
+ *     Type: string
+ *   - CreatedAt: Time the file was uploaded.
+ *     Type: time.Time
+ * 
+ * Errors:
+ * 
+ * - ErrAttachmentNotFound: Returned when an attachment does not exist or belongs to another task.
+ * - ErrAttachmentTooLarge: Returned when a file is larger than the configured limit.
+ * - ErrAttachmentType: Returned when the type of a file is not in the list of allowed types.
+ * - ErrNotAttachmentUploader: Returned when a user deletes a file uploaded by someone else.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+	ErrAttachmentTooLarge    = errors.New("attachment is too large")
+	ErrAttachmentType        = errors.New("attachment type is not allowed")
+	ErrNotAttachmentUploader = errors.New("only the uploader can delete an attachment")
+)
+
+type Attachment struct {
+	ID          int       `json:"id"`
+	TaskID      int       `json:"task_id"`
+	UploaderID  int       `json:"uploader_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Key         string    `json:"key"`
+	CreatedAt   time.Time `json:"created_at"`
+}
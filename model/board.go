@@ -0,0 +1,177 @@
+/** 
+ * Package model provides the models of the Kanban board and the ranks that order tasks on it.
+ * 
+ * Structs:
+ * 
+ * - BoardColumn: Struct representing the tasks of one status on the board.
+ *   Fields:
+ *   - Status: Status of the column.
+ *     Type: TaskStatus
+ *   - Tasks: Tasks with this status, ordered by rank.
+ *     Type: []Task
+ * 
+ * - Board: Struct representing the Kanban board of a workspace.
+ *   Fields:
+ *   - Columns: One column per status of the workflow, in workflow order, followed by the statuses of tasks that are not
+ *     part of the workflow.
+ *     Type: []BoardColumn
+ * 
+ * - MoveRequest: Struct representing the body of a request moving a task on the board.
+ *   Fields:
+ *   - Status: Column the task is moved to, empty to reorder the task within its own column.
+ *     Type: TaskStatus
+ *   - AfterID: ID of the task the moved task is placed right after, 0 to place it at the top of the column.
+ *     Type: int
+ * 
+ * Errors:
+ * 
+ * - ErrInvalidMove: Returned when the task to place a task after is not in the target column.
+ * 
+ * Functions:
+ * 
+ * - RankBetween: Function to compute a rank sorting between two ranks. An empty before stands for the top of the column
+ *   and an empty after for its bottom. Ranks are base-36 fractions without trailing zeros, so there is
+ *   always room for another rank between two different ones and moving a task never renumbers its neighbours.
+ *   When after does not sort after before, it is ignored. Ranks at the bottom step by one digit, so appending
+ *   35 tasks lengthens the last rank by one character. Nothing sorts between a rank and the same rank followed by
+ *   zeros, e.g. a and a0, so RankBetween panics when given such bounds; the ranks it and SpreadRanks return never end
+ *   in 0.
+ * 
+ * - SpreadRanks: Function to compute n evenly spaced ranks of the same length, in ascending order. Tasks are given
+ *   them again in their current order once a rank grows longer than MaxRankLength.
+ * 
+ * - SortByRank: Function to order tasks by rank, tasks sharing a rank by ID.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// MaxRankLength is the length past which the ranks of all tasks are spread out again.
+const MaxRankLength = 24
+
+var ErrInvalidMove = errors.New("invalid board position")
+
+type BoardColumn struct {
+	Status TaskStatus `json:"status"`
+	Tasks  []Task     `json:"tasks"`
+}
+
+type Board struct {
+	Columns []BoardColumn `json:"columns"`
+}
+
+type MoveRequest struct {
+	Status  TaskStatus `json:"status"`
+	AfterID int        `json:"after_id"`
+}
+
+func RankBetween(before, after string) string {
+	if after != "" && before >= after {
+		after = ""
+	}
+	if after != "" && strings.HasPrefix(after, before) && strings.TrimRight(after[len(before):], "0") == "" {
+		panic(fmt.Sprintf("model: no rank sorts between %q and %q", before, after))
+	}
+	if after == "" && before != "" {
+		return rankAfter(before)
+	}
+	return rankMidpoint(before, after, after != "")
+}
+
+// rankAfter returns the shortest rank after a, raising its first digit below z by one.
+func rankAfter(a string) string {
+	for i := 0; i < len(a); i++ {
+		if a[i] != 'z' {
+			return a[:i] + string(rankDigits[strings.IndexByte(rankDigits, a[i])+1])
+		}
+	}
+	return a + "1"
+}
+
+func SpreadRanks(n int) []string {
+	// Leave 36 free ranks around every task so moves stay short after spreading.
+	width, space := 1, len(rankDigits)
+	for space < (n+1)*len(rankDigits) {
+		width++
+		space *= len(rankDigits)
+	}
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		value := (i + 1) * (space / (n + 1))
+		digits := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%len(rankDigits)]
+			value /= len(rankDigits)
+		}
+		ranks[i] = strings.TrimRight(string(digits), "0")
+	}
+	return ranks
+}
+
+// rankMidpoint finds the shortest rank between a and b, where a missing digit of a counts as 0 and an
+// unbounded b stands for 1.
+func rankMidpoint(a, b string, bounded bool) string {
+	if bounded {
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n == len(b) {
+			return rankMidpoint(a, "", false)
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + rankMidpoint(rest, b[n:], true)
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(rankDigits, a[0])
+	}
+	high := len(rankDigits)
+	if bounded {
+		high = strings.IndexByte(rankDigits, b[0])
+	}
+
+	if high-low > 1 {
+		return string(rankDigits[(low+high+1)/2])
+	}
+	if bounded && len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+	return string(rankDigits[low]) + rankMidpoint(rest, "", false)
+}
+
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return '0'
+}
+
+func SortByRank(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Rank != tasks[j].Rank {
+			return tasks[i].Rank < tasks[j].Rank
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
@@ -0,0 +1,106 @@
+/** 
+ * Package model provides the models of task comments.
+ * 
+ * Structs:
+ * 
+ * - Comment: Struct representing a comment in the discussion thread of a task.
+ *   Fields:
+ *   - ID: Unique identifier for the comment.
+ *     Type: int
+ *   - TaskID: ID of the task the comment belongs to.
+ *     Type: int
+ *   - AuthorID: ID of the user who wrote the comment.
+ *     Type: int
+ *   - Author: Email of the user who wrote the comment.
+ *     Type: string
+ *   - Body: Markdown source of the comment. Deleted comments keep their body in storage but are listed without it.
+ *     Type: string
+ *   - HTML: Body rendered to HTML by RenderMarkdown.
+ *     Type: string
+ *   - CreatedAt: Time the comment was written.
+ *     Type: time.Time
+ *   - EditedAt: Time of the last edit, nil when the comment was never edited.
+ *     Type: *time.Time
+ *   - DeletedAt: Time the comment was deleted, nil while it is visible.
+ *     Type: *time.Time
+ *   - Edits: Previous versions of the body, oldest first.
+ *     Type: []CommentEdit
+ * 
+ * - CommentEdit: Struct representing a previous version of a comment.
+ *   Fields:
+ *   - Body: Markdown source before the edit.
+ *     Type: string
+ *   - EditedAt: Time the version was replaced.
+ *     Type: time.Time
+ * 
+ * - CommentRequest: Struct representing the body of a request adding or editing a comment.
+ *   Fields:
+ *   - Body: Markdown source of the comment.
+ *     Type: string
+ * 
+ * - CommentPage: Struct representing one page of a task's comments, oldest first.
+ *   Fields:
+ *   - Comments: Comments on the page.
+ *     Type: []Comment
+ *   - Page: Number of the page, starting at 1.
+ *     Type: int
+ *   - PageSize: Maximum number of comments per page.
+ *     Type: int
+ *   - Total: Number of comments of the task, deleted ones included.
+ *     Type: int
+ * 
+ * Errors:
+ * 
+ * - ErrCommentNotFound: Returned when a comment does not exist or belongs to another task.
+ * - ErrNotCommentAuthor: Returned when a user edits or deletes a comment written by someone else.
+ * - ErrCommentDeleted: Returned when a deleted comment is edited.
+ * - ErrEmptyComment: Returned when the body of a comment is blank.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultCommentPageSize = 20
+	MaxCommentPageSize     = 100
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrNotCommentAuthor = errors.New("only the author can change a comment")
+	ErrCommentDeleted   = errors.New("comment has been deleted")
+	ErrEmptyComment     = errors.New("comment body is required")
+)
+
+type Comment struct {
+	ID        int           `json:"id"`
+	TaskID    int           `json:"task_id"`
+	AuthorID  int           `json:"author_id"`
+	Author    string        `json:"author"`
+	Body      string        `json:"body"`
+	HTML      string        `json:"html"`
+	CreatedAt time.Time     `json:"created_at"`
+	EditedAt  *time.Time    `json:"edited_at,omitempty"`
+	DeletedAt *time.Time    `json:"deleted_at,omitempty"`
+	Edits     []CommentEdit `json:"edits,omitempty"`
+}
+
+type CommentEdit struct {
+	Body     string    `json:"body"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+type CommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+	Total    int       `json:"total"`
+}
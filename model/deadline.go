@@ -0,0 +1,172 @@
+/**
+ * Package model provides the deadline type used by tasks.
+ *
+ * Structs:
+ *
+ * - Deadline: Struct representing a task deadline, either a calendar date or an exact instant.
+ *   Fields:
+ *   - At: Instant of the deadline. Date-only deadlines are stored at midnight UTC of that date.
+ *     Type: time.Time
+ *   - DateOnly: True when the deadline is a calendar date without a time of day.
+ *     Type: bool
+ *   Methods:
+ *   - IsZero: Reports whether no deadline is set.
+ *   - Due: Returns the instant the deadline expires in the given location. A date-only deadline expires at the end of that day.
+ *   - Overdue: Reports whether the deadline has passed at the given instant in the given location.
+ *   - Before: Reports whether the deadline expires before another one.
+ *   - String: Returns the canonical form, YYYY-MM-DD for dates and RFC 3339 in UTC otherwise.
+ *   - Format: Returns the deadline rendered for a reader in the given location.
+ *   - MarshalJSON / UnmarshalJSON: Encode the deadline as its canonical string, an empty string meaning no deadline.
+ *     Decoding only accepts values carrying their own zone, so API clients must send a date or an RFC 3339 timestamp.
+ *
+ * Functions:
+ *
+ * - ParseDeadline: Function to parse a deadline.
+ *   Parameters:
+ *   - value: YYYY-MM-DD, RFC 3339, or YYYY-MM-DDTHH:MM as sent by datetime-local inputs. An empty value is no deadline.
+ *     Type: string
+ *   - loc: Location used for date-times without an offset. When nil, such values are rejected.
+ *     Type: *time.Location
+ *   Returns:
+ *   - Deadline: The parsed deadline.
+ *   - error: ErrInvalidDeadline wrapped with the offending value when the value cannot be parsed.
+ *
+ * - DateDeadline: Function to create a date-only deadline.
+ *
+ * - LoadLocation: Function to load an IANA time zone, falling back to UTC when the name is empty or unknown.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DateLayout          = "2006-01-02"
+	dateTimeLocalLayout = "2006-01-02T15:04"
+)
+
+var ErrInvalidDeadline = errors.New("invalid deadline, use YYYY-MM-DD or RFC 3339")
+
+type Deadline struct {
+	At       time.Time
+	DateOnly bool
+}
+
+func ParseDeadline(value string, loc *time.Location) (Deadline, error) {
+	if value == "" {
+		return Deadline{}, nil
+	}
+
+	if t, err := time.Parse(DateLayout, value); err == nil {
+		return Deadline{At: t, DateOnly: true}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return Deadline{At: t.UTC()}, nil
+	}
+
+	if loc != nil {
+		if t, err := time.ParseInLocation(dateTimeLocalLayout, value, loc); err == nil {
+			return Deadline{At: t.UTC()}, nil
+		}
+	}
+
+	return Deadline{}, fmt.Errorf("%w: %q", ErrInvalidDeadline, value)
+}
+
+func DateDeadline(year int, month time.Month, day int) Deadline {
+	return Deadline{At: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), DateOnly: true}
+}
+
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+func (d Deadline) IsZero() bool {
+	return d.At.IsZero()
+}
+
+func (d Deadline) Due(loc *time.Location) time.Time {
+	if !d.DateOnly {
+		return d.At
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	year, month, day := d.At.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+}
+
+func (d Deadline) Overdue(now time.Time, loc *time.Location) bool {
+	return !d.IsZero() && !now.Before(d.Due(loc))
+}
+
+func (d Deadline) Before(other Deadline) bool {
+	return d.Due(time.UTC).Before(other.Due(time.UTC))
+}
+
+func (d Deadline) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	if d.DateOnly {
+		return d.At.Format(DateLayout)
+	}
+
+	return d.At.UTC().Format(time.RFC3339)
+}
+
+func (d Deadline) Format(loc *time.Location) string {
+	if d.IsZero() {
+		return ""
+	}
+
+	if d.DateOnly {
+		return d.At.Format("Mon, 02 Jan 2006")
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	return d.At.In(loc).Format("Mon, 02 Jan 2006 15:04 MST")
+}
+
+func (d Deadline) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Deadline) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Deadline{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDeadline, data)
+	}
+
+	deadline, err := ParseDeadline(value, nil)
+	if err != nil {
+		return err
+	}
+
+	*d = deadline
+	return nil
+}
@@ -0,0 +1,68 @@
+/** 
+ * Package model provides the models of dependencies between tasks.
+ * 
+ * Structs:
+ * 
+ * - Dependency: Struct representing a "blocks / blocked by" relation between two tasks.
+ *   Fields:
+ *   - BlockerID: ID of the task that has to be completed first.
+ *     Type: int
+ *   - BlockedID: ID of the task waiting for the blocker.
+ *     Type: int
+ * 
+ * - TaskDependencies: Struct representing the direct dependencies of a task.
+ *   Fields:
+ *   - BlockedBy: Tasks blocking the task.
+ *     Type: []Task
+ *   - Blocks: Tasks blocked by the task.
+ *     Type: []Task
+ * 
+ * - DependencyGraph: Struct representing the dependencies between the tasks of a category.
+ *   Fields:
+ *   - CategoryID: ID of the category.
+ *     Type: int
+ *   - Tasks: Tasks of the category.
+ *     Type: []Task
+ *   - Edges: Dependencies between these tasks. Dependencies on tasks of other categories are left out.
+ *     Type: []Dependency
+ *   - Order: IDs of the tasks in topological order: every blocker comes before the tasks it blocks.
+ *     Type: []int
+ *   - CriticalPath: IDs of the longest chain of open tasks blocking one another, first blocker first.
+ *     Type: []int
+ * 
+ * Errors:
+ * 
+ * - ErrSelfDependency: Returned when a task would block itself.
+ * - ErrDependencyCycle: Returned when a dependency would make a task wait, directly or not, for itself.
+ * - ErrDependencyTaskNotFound: Returned when a task of a dependency does not exist.
+ * - ErrTaskBlocked: Returned when a task with open blockers is moved to Completed.
+ */
+
+package model
+
+import "errors"
+
+var (
+	ErrSelfDependency         = errors.New("a task cannot block itself")
+	ErrDependencyCycle        = errors.New("dependency would create a cycle")
+	ErrDependencyTaskNotFound = errors.New("dependency task not found")
+	ErrTaskBlocked            = errors.New("task is blocked by open tasks")
+)
+
+type Dependency struct {
+	BlockerID int `json:"blocker_id"`
+	BlockedID int `json:"blocked_id"`
+}
+
+type TaskDependencies struct {
+	BlockedBy []Task `json:"blocked_by"`
+	Blocks    []Task `json:"blocks"`
+}
+
+type DependencyGraph struct {
+	CategoryID   int          `json:"category_id"`
+	Tasks        []Task       `json:"tasks"`
+	Edges        []Dependency `json:"edges"`
+	Order        []int        `json:"order"`
+	CriticalPath []int        `json:"critical_path"`
+}
@@ -0,0 +1,89 @@
+/** 
+ * Package model provides the models of task estimates.
+ * 
+ * Structs:
+ * 
+ * - EstimateTotal: Struct representing the estimated and completed effort of a group of tasks, a category or a user.
+ *   Fields:
+ *   - ID: ID of the category or user, 0 for the total of every task.
+ *     Type: int
+ *   - Name: Name of the category or full name of the user.
+ *     Type: string
+ *   - Tasks: Number of tasks in the group.
+ *     Type: int
+ *   - CompletedTasks: Number of completed tasks in the group.
+ *     Type: int
+ *   - EstimatedHours: Sum of the hour estimates of the tasks.
+ *     Type: float64
+ *   - CompletedHours: Sum of the hour estimates of the completed tasks.
+ *     Type: float64
+ *   - StoryPoints: Sum of the story points of the tasks.
+ *     Type: int
+ *   - CompletedPoints: Sum of the story points of the completed tasks.
+ *     Type: int
+ *   Methods:
+ *   - Add: Adds the estimates of a task to the total.
+ *   - HoursPercent: Returns the share of the estimated hours that is completed, from 0 to 100.
+ *   - PointsPercent: Returns the share of the story points that is completed, from 0 to 100.
+ * 
+ * - EstimateRollup: Struct representing the estimates of every task rolled up per category and per user.
+ *   Fields:
+ *   - ByCategory: Totals per category, in category ID order.
+ *     Type: []EstimateTotal
+ *   - ByUser: Totals per task owner, in user ID order.
+ *     Type: []EstimateTotal
+ *   - Total: Total of every task.
+ *     Type: EstimateTotal
+ * 
+ * Errors:
+ * 
+ * - ErrInvalidEstimate: Returned when a task is given a negative estimate.
+ */
+
+package model
+
+import "errors"
+
+var ErrInvalidEstimate = errors.New("estimates cannot be negative")
+
+type EstimateTotal struct {
+	ID              int     `json:"id,omitempty"`
+	Name            string  `json:"name,omitempty"`
+	Tasks           int     `json:"tasks"`
+	CompletedTasks  int     `json:"completed_tasks"`
+	EstimatedHours  float64 `json:"estimated_hours"`
+	CompletedHours  float64 `json:"completed_hours"`
+	StoryPoints     int     `json:"story_points"`
+	CompletedPoints int     `json:"completed_points"`
+}
+
+func (t *EstimateTotal) Add(task Task) {
+	t.Tasks++
+	t.EstimatedHours += task.EstimateHours
+	t.StoryPoints += task.StoryPoints
+	if task.Status == StatusCompleted {
+		t.CompletedTasks++
+		t.CompletedHours += task.EstimateHours
+		t.CompletedPoints += task.StoryPoints
+	}
+}
+
+func (t EstimateTotal) HoursPercent() int {
+	if t.EstimatedHours == 0 {
+		return 0
+	}
+	return int(t.CompletedHours * 100 / t.EstimatedHours)
+}
+
+func (t EstimateTotal) PointsPercent() int {
+	if t.StoryPoints == 0 {
+		return 0
+	}
+	return t.CompletedPoints * 100 / t.StoryPoints
+}
+
+type EstimateRollup struct {
+	ByCategory []EstimateTotal `json:"by_category"`
+	ByUser     []EstimateTotal `json:"by_user"`
+	Total      EstimateTotal   `json:"total"`
+}
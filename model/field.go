@@ -0,0 +1,158 @@
+/** 
+ * Package model provides the models of typed custom fields users define for the tasks of a workspace.
+ * 
+ * Types:
+ * 
+ * - FieldType: Type of the values of a custom field: text, number, date, select or checkbox.
+ * 
+ * Structs:
+ * 
+ * - CustomField: Struct representing a custom field. The values of the field are stored with each task in Task.Fields,
+ *   keyed by the ID of the field.
+ *   Fields:
+ *   - ID: Unique identifier for the field.
+ *     Type: int
+ *   - Name: Name of the field, unique per workspace regardless of case.
+ *     Type: string
+ *   - Type: Type of the values of the field.
+ *     Type: FieldType
+ *   - Options: Values a select field can take, in display order. Empty for other types.
+ *     Type: []string
+ *   - UserID: ID of the user who defined the field.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the field belongs to.
+ *     Type: int
+ *   Methods:
+ *   - Normalize: Returns the canonical form of a value of the field: a string for text, date and select fields,
+ *     a float64 for number fields and true for checked checkboxes. Besides values of these types, the string forms
+ *     sent by HTML forms are accepted. Empty values, and unchecked checkboxes, normalize to nil, meaning no value.
+ *   - Matches: Reports whether a stored value of the field equals the filter value, comparing text case-insensitively.
+ *     A checkbox without a value matches "false".
+ * 
+ * - FieldRequest: Struct representing the body of a request defining or updating a custom field.
+ *   The type of an existing field cannot be changed.
+ * 
+ * Errors:
+ * 
+ * - ErrFieldNotFound: Returned when the field does not exist or belongs to another workspace.
+ * - ErrInvalidField: Returned when a field is defined without a name, with an unknown type or a select field without options.
+ * - ErrDuplicateField: Returned when the workspace already has a field with the same name.
+ * - ErrInvalidFieldValue: Returned when a task carries a value for an unknown field or a value that does not fit the type of its field.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type FieldType string
+
+const (
+	FieldText     FieldType = "text"
+	FieldNumber   FieldType = "number"
+	FieldDate     FieldType = "date"
+	FieldSelect   FieldType = "select"
+	FieldCheckbox FieldType = "checkbox"
+)
+
+var (
+	ErrFieldNotFound     = errors.New("custom field not found")
+	ErrInvalidField      = errors.New("custom field needs a name, one of the types text, number, date, select or checkbox, and options for select fields")
+	ErrDuplicateField    = errors.New("custom field already exists")
+	ErrInvalidFieldValue = errors.New("invalid custom field value")
+)
+
+type CustomField struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Type        FieldType `json:"type"`
+	Options     []string  `json:"options,omitempty"`
+	UserID      int       `json:"user_id"`
+	WorkspaceID int       `json:"workspace_id,omitempty"`
+}
+
+type FieldRequest struct {
+	Name    string    `json:"name" binding:"required"`
+	Type    FieldType `json:"type"`
+	Options []string  `json:"options"`
+}
+
+func (f CustomField) Normalize(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	text, isText := value.(string)
+	if isText {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, nil
+		}
+	}
+
+	invalid := fmt.Errorf("%w: %v for %s field %q", ErrInvalidFieldValue, value, f.Type, f.Name)
+	switch f.Type {
+	case FieldText:
+		if !isText {
+			return nil, invalid
+		}
+		return text, nil
+	case FieldNumber:
+		if number, ok := value.(float64); ok {
+			return number, nil
+		}
+		if number, err := strconv.ParseFloat(text, 64); isText && err == nil {
+			return number, nil
+		}
+		return nil, invalid
+	case FieldDate:
+		if !isText {
+			return nil, invalid
+		}
+		date, err := ParseDeadline(text, nil)
+		if err != nil || !date.DateOnly {
+			return nil, invalid
+		}
+		return date.String(), nil
+	case FieldSelect:
+		for _, option := range f.Options {
+			if isText && option == text {
+				return text, nil
+			}
+		}
+		return nil, invalid
+	case FieldCheckbox:
+		checked, ok := value.(bool)
+		if isText {
+			var err error
+			checked, err = strconv.ParseBool(text)
+			ok = err == nil || text == "on"
+			checked = checked || text == "on"
+		}
+		if !ok {
+			return nil, invalid
+		}
+		if !checked {
+			return nil, nil
+		}
+		return true, nil
+	}
+	return nil, invalid
+}
+
+func (f CustomField) Matches(value interface{}, filter string) (bool, error) {
+	want, err := f.Normalize(filter)
+	if err != nil {
+		return false, err
+	}
+
+	if f.Type == FieldText {
+		text, ok := value.(string)
+		return ok && want != nil && strings.EqualFold(text, want.(string)), nil
+	}
+	return value == want, nil
+}
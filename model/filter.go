@@ -0,0 +1,228 @@
+/**
+ * Package model provides the models of saved filters, the smart lists of tasks users keep per workspace.
+ *
+ * Types:
+ *
+ * - DeadlineWindow: Range of deadlines relative to the current day in the time zone of the user: "overdue" for open
+ *   tasks whose deadline has passed, "today", "tomorrow", "next N days" for today and the N-1 days after it (N from 1
+ *   to MaxWindowDays), "none" for tasks without a deadline, or empty for any deadline.
+ *   Methods:
+ *   - Normalize: Returns the window lowercased with single spaces.
+ *   - Validate: Checks that the window is one of the forms above.
+ *   - Contains: Reports whether the deadline of a task falls in the window at the given instant in the given location.
+ *
+ * Structs:
+ *
+ * - FilterDefinition: Struct representing the name and criteria of a filter, the body of requests saving one. Zero
+ *   values do not filter.
+ *   Fields:
+ *   - Name: Name of the list, unique per user and workspace regardless of case, built-in lists included.
+ *     Type: string
+ *   - Statuses: Statuses a task may have.
+ *     Type: []TaskStatus
+ *   - Open: Whether only tasks that are not Completed are listed.
+ *     Type: bool
+ *   - MinPriority / MaxPriority: Inclusive range of the priority of a task.
+ *     Type: int
+ *   - Deadline: Window the deadline of a task falls in.
+ *     Type: DeadlineWindow
+ *   - CategoryID: ID of the category of a task.
+ *     Type: int
+ *   - Title: Text the title of a task contains, compared case-insensitively.
+ *     Type: string
+ *   - Sort: Sort key of the list, as for TaskQuery.
+ *     Type: string
+ *
+ * - SavedFilter: Struct representing a filter of a user, or one of the BuiltinFilters every user has.
+ *   Fields:
+ *   - ID: Unique identifier for the filter, 0 for built-in filters.
+ *     Type: int
+ *   - Key: Name of a built-in filter in URLs, empty for saved filters.
+ *     Type: string
+ *   - UserID: ID of the user who saved the filter.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the filter lists tasks of.
+ *     Type: int
+ *   - FilterDefinition: Name and criteria of the filter.
+ *   Methods:
+ *   - Ref: Returns how the list is referred to in URLs: the key of a built-in filter or the ID of a saved one.
+ *   - Validate: Checks the name, the deadline window, the priority range and the sort key of the filter.
+ *   - TaskQuery: Returns the TaskQuery of the criteria other than Open and Deadline, which depend on the current time.
+ *   - Matches: Reports whether a task passes Open and Deadline at the given instant in the given location.
+ *
+ * - SmartList: Struct representing a filter together with the tasks it currently lists.
+ *
+ * Variables:
+ *
+ * - BuiltinFilters: The lists every user has: Today, Overdue and Upcoming, the open tasks due in the next 7 days. All of
+ *   them are sorted by deadline.
+ *
+ * Errors:
+ *
+ * - ErrFilterNotFound: Returned when a filter does not exist or belongs to another user or workspace.
+ * - ErrInvalidFilter: Returned when a filter has no name or invalid criteria.
+ * - ErrDuplicateFilter: Returned when the user already has a list with the same name in the workspace.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DeadlineWindow string
+
+const (
+	WindowAny      DeadlineWindow = ""
+	WindowNone     DeadlineWindow = "none"
+	WindowOverdue  DeadlineWindow = "overdue"
+	WindowToday    DeadlineWindow = "today"
+	WindowTomorrow DeadlineWindow = "tomorrow"
+)
+
+// MaxWindowDays is the longest "next N days" window.
+const MaxWindowDays = 365
+
+var (
+	ErrFilterNotFound  = errors.New("filter not found")
+	ErrInvalidFilter   = errors.New("invalid filter")
+	ErrDuplicateFilter = errors.New("filter already exists")
+)
+
+var BuiltinFilters = []SavedFilter{
+	{Key: "today", FilterDefinition: FilterDefinition{Name: "Today", Open: true, Deadline: WindowToday, Sort: "deadline"}},
+	{Key: "overdue", FilterDefinition: FilterDefinition{Name: "Overdue", Deadline: WindowOverdue, Sort: "deadline"}},
+	{Key: "upcoming", FilterDefinition: FilterDefinition{Name: "Upcoming", Open: true, Deadline: "next 7 days", Sort: "deadline"}},
+}
+
+type FilterDefinition struct {
+	Name        string         `json:"name"`
+	Statuses    []TaskStatus   `json:"statuses,omitempty"`
+	Open        bool           `json:"open,omitempty"`
+	MinPriority int            `json:"min_priority,omitempty"`
+	MaxPriority int            `json:"max_priority,omitempty"`
+	Deadline    DeadlineWindow `json:"deadline,omitempty"`
+	CategoryID  int            `json:"category_id,omitempty"`
+	Title       string         `json:"title,omitempty"`
+	Sort        string         `json:"sort,omitempty"`
+}
+
+type SavedFilter struct {
+	ID          int    `json:"id,omitempty"`
+	Key         string `json:"key,omitempty"`
+	UserID      int    `json:"user_id,omitempty"`
+	WorkspaceID int    `json:"workspace_id,omitempty"`
+	FilterDefinition
+}
+
+type SmartList struct {
+	Filter SavedFilter `json:"filter"`
+	Tasks  []Task      `json:"tasks"`
+}
+
+func (w DeadlineWindow) Normalize() DeadlineWindow {
+	return DeadlineWindow(strings.Join(strings.Fields(strings.ToLower(string(w))), " "))
+}
+
+func (w DeadlineWindow) Validate() error {
+	switch w {
+	case WindowAny, WindowNone, WindowOverdue, WindowToday, WindowTomorrow:
+		return nil
+	}
+	if _, ok := w.days(); ok {
+		return nil
+	}
+	return fmt.Errorf("%w: unknown deadline window %q, use overdue, today, tomorrow, next N days or none", ErrInvalidFilter, string(w))
+}
+
+func (w DeadlineWindow) Contains(task Task, now time.Time, loc *time.Location) bool {
+	switch w {
+	case WindowAny:
+		return true
+	case WindowNone:
+		return task.Deadline.IsZero()
+	case WindowOverdue:
+		return task.Status != StatusCompleted && task.Deadline.Overdue(now, loc)
+	}
+	if task.Deadline.IsZero() {
+		return false
+	}
+
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	day := deadlineDay(task.Deadline, loc)
+	switch w {
+	case WindowToday:
+		return day.Equal(today)
+	case WindowTomorrow:
+		return day.Equal(today.AddDate(0, 0, 1))
+	}
+	days, _ := w.days()
+	return !day.Before(today) && day.Before(today.AddDate(0, 0, days))
+}
+
+// days returns N of a "next N days" window.
+func (w DeadlineWindow) days() (int, bool) {
+	parts := strings.Fields(string(w))
+	if len(parts) != 3 || parts[0] != "next" || (parts[2] != "days" && parts[2] != "day") {
+		return 0, false
+	}
+
+	days, err := strconv.Atoi(parts[1])
+	if err != nil || days < 1 || days > MaxWindowDays {
+		return 0, false
+	}
+	return days, true
+}
+
+// deadlineDay returns the start of the day the deadline falls on in the location. Date-only deadlines fall on their
+// date wherever the reader is.
+func deadlineDay(deadline Deadline, loc *time.Location) time.Time {
+	at := deadline.At
+	if !deadline.DateOnly {
+		at = at.In(loc)
+	}
+	return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, loc)
+}
+
+func (f SavedFilter) Ref() string {
+	if f.Key != "" {
+		return f.Key
+	}
+	return strconv.Itoa(f.ID)
+}
+
+func (f SavedFilter) Validate() error {
+	if f.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidFilter)
+	}
+	if err := f.Deadline.Validate(); err != nil {
+		return err
+	}
+	if err := f.TaskQuery().Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return nil
+}
+
+func (f SavedFilter) TaskQuery() TaskQuery {
+	return TaskQuery{
+		Statuses:    f.Statuses,
+		MinPriority: f.MinPriority,
+		MaxPriority: f.MaxPriority,
+		CategoryID:  f.CategoryID,
+		Title:       f.Title,
+		Sort:        f.Sort,
+	}
+}
+
+func (f SavedFilter) Matches(task Task, now time.Time, loc *time.Location) bool {
+	if f.Open && task.Status == StatusCompleted {
+		return false
+	}
+	return f.Deadline.Contains(task, now, loc)
+}
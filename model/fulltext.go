@@ -0,0 +1,598 @@
+/**
+ * Package model provides the text analysis behind the full-text search over task titles and category names.
+ *
+ * Text is split into words of letters and digits and lowercased. Every word is indexed under a set of terms: the word
+ * itself, its English stem and its Indonesian root, so "running" is found by "run" and "menulis" by "tulis". Common
+ * English and Indonesian stop words are not indexed.
+ *
+ * English words are stemmed with the Porter algorithm. Indonesian words are stemmed after Nazief and Adriani, with the
+ * confix stripping refinements of Arifin and Setiono: a particle (-lah, -kah, -tah, -pun), then a possessive pronoun
+ * (-ku, -mu, -nya), then a derivational suffix (-kan, -an, -i) and up to three prefixes (di-, ke-, se-, be(r)-, te(r)-,
+ * per- and me- and pe- with their nasal forms) are removed in this order, and the word is looked up in the root list
+ * indonesianRoots after every step. Where a nasal prefix may have replaced the first letter of the root, e.g. menulis
+ * from tulis or memakan from makan, the readings are tried in turn. When no reading leads to a root the removed suffixes
+ * are put back one by one and the prefixes tried again. Words without a known root have no Indonesian stem.
+ *
+ * Structs:
+ *
+ * - SearchHit: Struct representing a task or category found by a full-text search.
+ *   Fields:
+ *   - Kind: Kind of the record, SearchKindTask or SearchKindCategory.
+ *     Type: string
+ *   - ID: ID of the task or category.
+ *     Type: int
+ *   - Text: Title of the task or name of the category.
+ *     Type: string
+ *   - Highlighted: Text escaped for HTML with the matching words wrapped in <mark> elements.
+ *     Type: string
+ *   - Highlights: Byte ranges of the matching words in Text.
+ *     Type: []TextRange
+ *   - Score: Relevance of the record, higher is better.
+ *     Type: float64
+ *
+ * - TextRange: Struct representing the byte range [Start, End) of a word in a text.
+ *
+ * - Token: Struct representing a lowercased word of a text with its byte range in the text.
+ *
+ * - SearchRebuildResponse: Struct representing the response to a rebuild of the search index, with the number of tasks
+ *   and categories indexed.
+ *
+ * - TextQuery: Struct representing a parsed full-text query.
+ *   Fields:
+ *   - Words: Terms of every distinct word of the query. A record matches a word when it has any of its terms.
+ *     Type: [][]string
+ *   Methods:
+ *   - Terms: Returns the terms of all words of the query, as used by Highlight.
+ *
+ * Functions:
+ *
+ * - Tokenize: Function to split a text into its words.
+ * - Stems: Function to return the terms a lowercased word is indexed under, nil for stop words.
+ * - AnalyzeText: Function to return how often each term occurs in a text and the number of words indexed.
+ * - ParseTextSearch: Function to parse a full-text query, returning ErrEmptyTextSearch when it has no words to search for.
+ * - Highlight: Function to mark the words of a text having any of the given terms.
+ * - StemEnglish: Function to return the Porter stem of a lowercased word.
+ * - StemIndonesian: Function to return the Indonesian root of a lowercased word, "" when none of the root list is found.
+ *
+ * Errors:
+ *
+ * - ErrEmptyTextSearch: Returned when a full-text query has no words to search for.
+ */
+
+package model
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	SearchKindTask     = "task"
+	SearchKindCategory = "category"
+)
+
+var ErrEmptyTextSearch = errors.New("search text has no words to search for")
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true, "for": true,
+	"from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true, "the": true, "to": true,
+	"with": true,
+	"yang": true, "dan": true, "di": true, "ke": true, "dari": true, "untuk": true, "dengan": true, "pada": true,
+	"ini": true, "itu": true, "atau": true, "dalam": true, "adalah": true,
+}
+
+// indonesianInvalidConfix holds the prefix and suffix pairs that do not occur together around an Indonesian root.
+var indonesianInvalidConfix = map[string]bool{
+	"be-i": true, "di-an": true, "ke-i": true, "ke-kan": true, "me-an": true, "se-i": true, "se-kan": true, "te-an": true,
+}
+
+// indonesianRoots is the root list the Indonesian stemmer checks its candidates against, common roots of everyday and
+// office language.
+var indonesianRoots = map[string]bool{
+	"ada": true, "adil": true, "ajak": true, "ajar": true, "akhir": true, "aku": true, "alam": true, "alih": true,
+	"alir": true, "amal": true, "aman": true, "amat": true, "ambil": true, "ampun": true, "anak": true, "andal": true,
+	"angkat": true, "angkut": true, "anjur": true, "antar": true, "antri": true, "arah": true, "arsip": true,
+	"asah": true, "asuh": true, "atur": true, "awal": true, "awas": true, "ayun": true, "baca": true, "bagi": true,
+	"bagus": true, "bahas": true, "baik": true, "bakar": true, "balas": true, "balik": true, "bangun": true,
+	"bantu": true, "banyak": true, "baru": true, "batal": true, "batas": true, "bawa": true, "bayar": true, "beban": true,
+	"beda": true, "bekal": true, "beli": true, "benar": true, "bentuk": true, "berat": true, "beri": true, "bersih": true,
+	"besar": true, "betul": true, "biaya": true, "bicara": true, "bina": true, "bisa": true, "buat": true, "buka": true,
+	"bukti": true, "bulan": true, "buruk": true, "butuh": true, "cakap": true, "campur": true, "cantum": true,
+	"capai": true, "cari": true, "catat": true, "cegah": true, "cek": true, "cepat": true, "cerita": true, "cetak": true,
+	"cinta": true, "coba": true, "cocok": true, "cuci": true, "cukup": true, "curi": true, "daftar": true, "dapat": true,
+	"darat": true, "datang": true, "dekat": true, "dengar": true, "desain": true, "diam": true, "didik": true,
+	"dikit": true, "dorong": true, "duduk": true, "dukung": true, "dulu": true, "edar": true, "gabung": true,
+	"gagal": true, "gambar": true, "ganti": true, "gerak": true, "guna": true, "gunting": true, "hadap": true,
+	"hadir": true, "hapus": true, "harap": true, "harga": true, "hasil": true, "hati": true, "hemat": true, "henti": true,
+	"hidup": true, "hilang": true, "hitung": true, "hormat": true, "hubung": true, "hukum": true, "ikat": true,
+	"ikut": true, "ingat": true, "ingin": true, "isi": true, "izin": true, "jadi": true, "jadwal": true, "jaga": true,
+	"jahit": true, "jalan": true, "jamin": true, "janji": true, "jaring": true, "jatuh": true, "jawab": true,
+	"jelas": true, "jemput": true, "jual": true, "juang": true, "kabar": true, "kaji": true, "kalah": true, "kali": true,
+	"kandung": true, "kantor": true, "karang": true, "kasih": true, "kata": true, "kecil": true, "kejar": true,
+	"kelola": true, "kembali": true, "kembang": true, "kena": true, "kenal": true, "kendali": true, "kerja": true,
+	"kesan": true, "ketik": true, "khusus": true, "kirim": true, "kuat": true, "kumpul": true, "kunci": true,
+	"kurang": true, "lahir": true, "laku": true, "lalu": true, "lama": true, "lambat": true, "lampir": true,
+	"lanjut": true, "lapor": true, "latih": true, "lawan": true, "layan": true, "lebih": true, "lengkap": true,
+	"lepas": true, "lewat": true, "lihat": true, "lindung": true, "lintas": true, "luar": true, "luas": true,
+	"lulus": true, "lunas": true, "lupa": true, "main": true, "maju": true, "makan": true, "maksud": true, "malu": true,
+	"mampu": true, "mandi": true, "mangsa": true, "masak": true, "masuk": true, "mati": true, "minta": true,
+	"minum": true, "mirip": true, "mohon": true, "muat": true, "mudah": true, "mula": true, "mulai": true, "nama": true,
+	"nanti": true, "nikah": true, "nilai": true, "nyata": true, "olah": true, "omong": true, "paham": true, "pajak": true,
+	"pakai": true, "panggil": true, "pantau": true, "pasang": true, "pasar": true, "pasti": true, "pegang": true,
+	"pelihara": true, "penuh": true, "pergi": true, "periksa": true, "perlu": true, "pesan": true, "pikir": true,
+	"pilih": true, "pimpin": true, "pindah": true, "pinjam": true, "pisah": true, "pukul": true, "pulang": true,
+	"pulih": true, "pungut": true, "pusat": true, "putar": true, "putus": true, "rakit": true, "ramai": true,
+	"rancang": true, "rapat": true, "rasa": true, "rawat": true, "rekam": true, "rekap": true, "rencana": true,
+	"rinci": true, "ringkas": true, "rugi": true, "rumah": true, "rusak": true, "sadar": true, "sakit": true,
+	"salah": true, "salin": true, "sama": true, "sambung": true, "sampai": true, "samping": true, "sapa": true,
+	"saring": true, "satu": true, "sebut": true, "sedia": true, "sedih": true, "segar": true, "sehat": true,
+	"selesai": true, "sewa": true, "siap": true, "siar": true, "simpan": true, "singkat": true, "sisa": true,
+	"sulit": true, "sumbang": true, "susun": true, "tabung": true, "tagih": true, "tahan": true, "tahu": true,
+	"tambah": true, "tampil": true, "tanam": true, "tanda": true, "tangan": true, "tanggap": true, "tanggung": true,
+	"tanya": true, "tarik": true, "taruh": true, "tawar": true, "tebak": true, "tekan": true, "telepon": true,
+	"teliti": true, "tembak": true, "tempat": true, "temu": true, "tentu": true, "terang": true, "terima": true,
+	"terus": true, "tetap": true, "tidur": true, "timbang": true, "tinggal": true, "tingkat": true, "tolak": true,
+	"tolong": true, "tonton": true, "tugas": true, "tuju": true, "tukar": true, "tulis": true, "tumbuh": true,
+	"tunda": true, "tunggu": true, "tunjuk": true, "turun": true, "tutup": true, "uang": true, "ubah": true, "uji": true,
+	"ukur": true, "ulang": true, "umum": true, "untung": true, "urus": true, "usaha": true, "usul": true, "utama": true,
+	"wajib": true, "waktu": true, "warga": true, "warna": true, "wujud": true,
+}
+
+type SearchHit struct {
+	Kind        string      `json:"kind"`
+	ID          int         `json:"id"`
+	Text        string      `json:"text"`
+	Highlighted string      `json:"highlighted"`
+	Highlights  []TextRange `json:"highlights"`
+	Score       float64     `json:"score"`
+}
+
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type Token struct {
+	Word  string
+	Start int
+	End   int
+}
+
+type SearchRebuildResponse struct {
+	Message string `json:"message"`
+	Indexed int    `json:"indexed"`
+}
+
+type TextQuery struct {
+	Words [][]string
+}
+
+func Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, Token{Word: strings.ToLower(text[start:i]), Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Word: strings.ToLower(text[start:]), Start: start, End: len(text)})
+	}
+	return tokens
+}
+
+func Stems(word string) []string {
+	if word == "" || stopWords[word] {
+		return nil
+	}
+
+	stems := []string{word}
+	add := func(stem string) {
+		for _, known := range stems {
+			if known == stem {
+				return
+			}
+		}
+		stems = append(stems, stem)
+	}
+	add(StemEnglish(word))
+	if root := StemIndonesian(word); root != "" {
+		add(root)
+	}
+	return stems
+}
+
+func AnalyzeText(text string) (map[string]int, int) {
+	terms := map[string]int{}
+	length := 0
+	for _, token := range Tokenize(text) {
+		stems := Stems(token.Word)
+		if len(stems) == 0 {
+			continue
+		}
+		length++
+		for _, stem := range stems {
+			terms[stem]++
+		}
+	}
+	return terms, length
+}
+
+func ParseTextSearch(query string) (TextQuery, error) {
+	var parsed TextQuery
+	seen := map[string]bool{}
+	for _, token := range Tokenize(query) {
+		stems := Stems(token.Word)
+		if len(stems) == 0 || seen[token.Word] {
+			continue
+		}
+		seen[token.Word] = true
+		parsed.Words = append(parsed.Words, stems)
+	}
+	if len(parsed.Words) == 0 {
+		return TextQuery{}, ErrEmptyTextSearch
+	}
+	return parsed, nil
+}
+
+func (q TextQuery) Terms() map[string]bool {
+	terms := map[string]bool{}
+	for _, word := range q.Words {
+		for _, term := range word {
+			terms[term] = true
+		}
+	}
+	return terms
+}
+
+func Highlight(text string, terms map[string]bool) (string, []TextRange) {
+	var highlighted strings.Builder
+	ranges := []TextRange{}
+	last := 0
+	for _, token := range Tokenize(text) {
+		match := false
+		for _, stem := range Stems(token.Word) {
+			if terms[stem] {
+				match = true
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+
+		highlighted.WriteString(html.EscapeString(text[last:token.Start]))
+		highlighted.WriteString("<mark>" + html.EscapeString(text[token.Start:token.End]) + "</mark>")
+		ranges = append(ranges, TextRange{Start: token.Start, End: token.End})
+		last = token.End
+	}
+	highlighted.WriteString(html.EscapeString(text[last:]))
+	return highlighted.String(), ranges
+}
+
+// StemEnglish implements the original Porter stemming algorithm. Words that are not plain ASCII letters, or are two
+// letters or shorter, are returned unchanged.
+func StemEnglish(word string) string {
+	if len(word) <= 2 || !isLowerASCII(word) {
+		return word
+	}
+
+	w := porterStep1(word)
+	w = porterRules(w, porterStep2Rules, 0)
+	w = porterRules(w, porterStep3Rules, 0)
+	w = porterStep4(w)
+	return porterStep5(w)
+}
+
+var porterStep2Rules = map[string]string{
+	"ational": "ate", "tional": "tion", "enci": "ence", "anci": "ance", "izer": "ize", "abli": "able", "alli": "al",
+	"entli": "ent", "eli": "e", "ousli": "ous", "ization": "ize", "ation": "ate", "ator": "ate", "alism": "al",
+	"iveness": "ive", "fulness": "ful", "ousness": "ous", "aliti": "al", "iviti": "ive", "biliti": "ble",
+}
+
+var porterStep3Rules = map[string]string{
+	"icate": "ic", "ative": "", "alize": "al", "iciti": "ic", "ical": "ic", "ful": "", "ness": "",
+}
+
+var porterStep4Suffixes = map[string]string{
+	"al": "", "ance": "", "ence": "", "er": "", "ic": "", "able": "", "ible": "", "ant": "", "ement": "", "ment": "",
+	"ent": "", "ion": "", "ou": "", "ism": "", "ate": "", "iti": "", "ous": "", "ive": "", "ize": "",
+}
+
+func porterStep1(w string) string {
+	switch {
+	case strings.HasSuffix(w, "sses"), strings.HasSuffix(w, "ies"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "ss"):
+	case strings.HasSuffix(w, "s"):
+		w = w[:len(w)-1]
+	}
+
+	if strings.HasSuffix(w, "eed") {
+		if porterMeasure(w[:len(w)-3]) > 0 {
+			w = w[:len(w)-1]
+		}
+	} else {
+		for _, suffix := range []string{"ed", "ing"} {
+			stem := strings.TrimSuffix(w, suffix)
+			if stem == w || !porterHasVowel(stem) {
+				continue
+			}
+
+			w = stem
+			switch {
+			case strings.HasSuffix(w, "at"), strings.HasSuffix(w, "bl"), strings.HasSuffix(w, "iz"):
+				w += "e"
+			case porterEndsDouble(w) && !strings.ContainsAny(w[len(w)-1:], "lsz"):
+				w = w[:len(w)-1]
+			case porterMeasure(w) == 1 && porterEndsCVC(w):
+				w += "e"
+			}
+			break
+		}
+	}
+
+	if strings.HasSuffix(w, "y") && porterHasVowel(w[:len(w)-1]) {
+		w = w[:len(w)-1] + "i"
+	}
+	return w
+}
+
+// porterRules replaces the longest suffix of the rules the word ends with, when the measure of the rest of the word
+// is above min. Shorter suffixes are not tried when the longest one does not qualify.
+func porterRules(w string, rules map[string]string, min int) string {
+	longest := ""
+	for suffix := range rules {
+		if len(suffix) > len(longest) && strings.HasSuffix(w, suffix) {
+			longest = suffix
+		}
+	}
+	if longest == "" {
+		return w
+	}
+
+	stem := w[:len(w)-len(longest)]
+	if porterMeasure(stem) <= min {
+		return w
+	}
+	return stem + rules[longest]
+}
+
+func porterStep4(w string) string {
+	if strings.HasSuffix(w, "ion") {
+		stem := w[:len(w)-3]
+		if !strings.HasSuffix(stem, "s") && !strings.HasSuffix(stem, "t") {
+			return w
+		}
+	}
+	return porterRules(w, porterStep4Suffixes, 1)
+}
+
+func porterStep5(w string) string {
+	if strings.HasSuffix(w, "e") {
+		stem := w[:len(w)-1]
+		if m := porterMeasure(stem); m > 1 || (m == 1 && !porterEndsCVC(stem)) {
+			w = stem
+		}
+	}
+	if porterMeasure(w) > 1 && porterEndsDouble(w) && strings.HasSuffix(w, "l") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+// porterConsonant reports whether the letter at i is a consonant: y is one at the start of a word and after a vowel.
+func porterConsonant(w string, i int) bool {
+	switch w[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !porterConsonant(w, i-1)
+	}
+	return true
+}
+
+// porterMeasure returns m in the form [C](VC)^m[V] of the word, the number of vowel-consonant sequences in it.
+func porterMeasure(w string) int {
+	m, i := 0, 0
+	for i < len(w) && porterConsonant(w, i) {
+		i++
+	}
+	for i < len(w) {
+		for i < len(w) && !porterConsonant(w, i) {
+			i++
+		}
+		if i >= len(w) {
+			break
+		}
+		for i < len(w) && porterConsonant(w, i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+func porterHasVowel(w string) bool {
+	for i := range w {
+		if !porterConsonant(w, i) {
+			return true
+		}
+	}
+	return false
+}
+
+func porterEndsDouble(w string) bool {
+	n := len(w)
+	return n >= 2 && w[n-1] == w[n-2] && porterConsonant(w, n-1)
+}
+
+// porterEndsCVC reports whether the word ends with consonant, vowel, consonant, the last one not w, x or y.
+func porterEndsCVC(w string) bool {
+	n := len(w)
+	return n >= 3 && porterConsonant(w, n-3) && !porterConsonant(w, n-2) && porterConsonant(w, n-1) &&
+		!strings.ContainsAny(w[n-1:], "wxy")
+}
+
+func StemIndonesian(word string) string {
+	if !isLowerASCII(word) {
+		return ""
+	}
+	if indonesianRoots[word] {
+		return word
+	}
+
+	// Inflectional suffixes come off first, a particle before a possessive pronoun.
+	inflected := []string{word}
+	for _, suffixes := range [][]string{{"lah", "kah", "tah", "pun"}, {"nya", "ku", "mu"}} {
+		last := inflected[len(inflected)-1]
+		for _, suffix := range suffixes {
+			if base := strings.TrimSuffix(last, suffix); base != last {
+				if indonesianRoots[base] {
+					return base
+				}
+				inflected = append(inflected, base)
+				break
+			}
+		}
+	}
+
+	// Then one derivational suffix, -kan before -an.
+	base := inflected[len(inflected)-1]
+	suffix := ""
+	for _, candidate := range []string{"kan", "an", "i"} {
+		if trimmed := strings.TrimSuffix(base, candidate); trimmed != base {
+			if indonesianRoots[trimmed] {
+				return trimmed
+			}
+			base, suffix = trimmed, candidate
+			break
+		}
+	}
+
+	if root := indonesianRootWithoutPrefix(base, suffix, "", 0); root != "" {
+		return root
+	}
+
+	// The suffix may have been part of the root: retry with -k of -kan restored, then with every suffix restored,
+	// the derivational one first.
+	if suffix == "kan" {
+		if root := indonesianRootWithoutPrefix(base+"k", "an", "", 0); root != "" {
+			return root
+		}
+	}
+	for i := len(inflected) - 1; i >= 0; i-- {
+		if inflected[i] == base {
+			continue
+		}
+		if root := indonesianRootWithoutPrefix(inflected[i], "", "", 0); root != "" {
+			return root
+		}
+	}
+	return ""
+}
+
+// indonesianRootWithoutPrefix removes up to three prefixes from the word, trying each reading in turn, and returns the
+// first root of the root list it reaches. The same prefix is not removed twice in a row, and the first prefix must not
+// form one of the confixes Indonesian does not have with the suffix removed before, e.g. di-...-i is fine but di-...-an is not.
+func indonesianRootWithoutPrefix(w, suffix, previous string, depth int) string {
+	if depth == 3 {
+		return ""
+	}
+	for _, reading := range indonesianPrefixReadings(w) {
+		if reading.prefix == previous || depth == 0 && indonesianInvalidConfix[reading.prefix+"-"+suffix] {
+			continue
+		}
+		if indonesianRoots[reading.stem] {
+			return reading.stem
+		}
+		if root := indonesianRootWithoutPrefix(reading.stem, suffix, reading.prefix, depth+1); root != "" {
+			return root
+		}
+	}
+	return ""
+}
+
+// indonesianReading is a way to read a word as a prefix followed by a stem.
+type indonesianReading struct {
+	prefix string
+	stem   string
+}
+
+// indonesianPrefixReadings returns every reading of the word without its first prefix, the most common one first.
+// A nasal prefix replaces the first letter of roots starting with k, p, s and t, so menulis is read as both nulis
+// and tulis.
+func indonesianPrefixReadings(w string) []indonesianReading {
+	var readings []indonesianReading
+	add := func(prefix, stem string) {
+		if len(stem) >= 3 {
+			readings = append(readings, indonesianReading{prefix, stem})
+		}
+	}
+	at := func(i int, letters string) bool {
+		return i < len(w) && strings.IndexByte(letters, w[i]) >= 0
+	}
+	const vowels = "aeiou"
+
+	switch {
+	case strings.HasPrefix(w, "di"), strings.HasPrefix(w, "ke"), strings.HasPrefix(w, "se"):
+		add(w[:2], w[2:])
+	case strings.HasPrefix(w, "ber"), strings.HasPrefix(w, "ter"):
+		add(w[:2], w[3:])
+		add(w[:2], w[2:])
+	case strings.HasPrefix(w, "belajar"):
+		add("be", w[3:])
+	case strings.HasPrefix(w, "be"), strings.HasPrefix(w, "te"):
+		// bekerja is ber- before a root whose first syllable ends in -er.
+		add(w[:2], w[2:])
+	case strings.HasPrefix(w, "me"), strings.HasPrefix(w, "pe"):
+		prefix := w[:2]
+		switch {
+		case strings.HasPrefix(w[2:], "mper"):
+			add(prefix, w[3:])
+		case strings.HasPrefix(w[2:], "ng") && at(4, vowels):
+			add(prefix, w[4:])
+			add(prefix, "k"+w[4:])
+		case strings.HasPrefix(w[2:], "ng"):
+			add(prefix, w[4:])
+		case strings.HasPrefix(w[2:], "ny") && at(4, vowels):
+			add(prefix, "s"+w[4:])
+		case at(2, "m") && at(3, "bfvp"):
+			add(prefix, w[3:])
+		case at(2, "m") && at(3, vowels):
+			add(prefix, "p"+w[3:])
+			add(prefix, w[2:])
+		case at(2, "n") && at(3, "cdjz"):
+			add(prefix, w[3:])
+		case at(2, "n") && at(3, vowels):
+			add(prefix, "t"+w[3:])
+			add(prefix, w[2:])
+		case prefix == "pe" && at(2, "r"):
+			add("per", w[3:])
+			add(prefix, w[2:])
+		case prefix == "pe" && strings.HasPrefix(w[2:], "lajar"):
+			add(prefix, w[3:])
+		default:
+			add(prefix, w[2:])
+		}
+	}
+	return readings
+}
+
+func isLowerASCII(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+/** 
+ * Package model provides the models of the activity history of tasks.
+ * 
+ * Types:
+ * 
+ * - EventAction: Kind of change recorded in the history of a task: created, updated, deleted or restored.
+ * 
+ * Structs:
+ * 
+ * - FieldChange: Struct representing the change of one field of a task.
+ *   Fields:
+ *   - Field: JSON name of the changed field, such as status or deadline.
+ *     Type: string
+ *   - From: Value before the change, nil when the field was not set.
+ *     Type: interface{}
+ *   - To: Value after the change, nil when the field was cleared.
+ *     Type: interface{}
+ * 
+ * - TaskEvent: Struct representing an entry of the history of a task. Events are never changed once recorded.
+ *   Fields:
+ *   - ID: Unique identifier for the event, increasing in the order the events were recorded.
+ *     Type: int
+ *   - TaskID: ID of the changed task.
+ *     Type: int
+ *   - Action: Kind of change.
+ *     Type: EventAction
+ *   - Actor: Email of the user who made the change, empty for changes made by the system, such as closing a sprint.
+ *     Type: string
+ *   - Changes: Fields changed by the event. A created event lists the fields the task was created with.
+ *     Type: []FieldChange
+ *   - CreatedAt: Time the change was made.
+ *     Type: time.Time
+ * 
+ * Functions:
+ * 
+ * - DiffTasks: Function to list the fields that differ between two versions of a task, in alphabetical order.
+ *   Fields are compared in their JSON form, so a changed checklist or set of custom field values is reported as a whole.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+)
+
+type EventAction string
+
+const (
+	EventCreated  EventAction = "created"
+	EventUpdated  EventAction = "updated"
+	EventDeleted  EventAction = "deleted"
+	EventRestored EventAction = "restored"
+)
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type TaskEvent struct {
+	ID        int           `json:"id"`
+	TaskID    int           `json:"task_id"`
+	Action    EventAction    `json:"action"`
+	Actor     string        `json:"actor,omitempty"`
+	Changes   []FieldChange `json:"changes,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+func DiffTasks(before, after Task) ([]FieldChange, error) {
+	from, err := taskFields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := taskFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for name := range from {
+		names[name] = true
+	}
+	for name := range to {
+		names[name] = true
+	}
+	delete(names, "id")
+
+	var changes []FieldChange
+	for name := range names {
+		if !reflect.DeepEqual(from[name], to[name]) {
+			changes = append(changes, FieldChange{Field: name, From: from[name], To: to[name]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes, nil
+}
+
+func taskFields(task Task) (map[string]interface{}, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
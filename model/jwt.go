@@ -14,6 +14,9 @@
  *   - Email: Email address of the user.
  *     Type: string
  *     Description: This field holds the email address of the user included in the JWT claims.
+ *   - UserID: ID of the user.
+ *     Type: int
+ *     Description: This field holds the ID of the user included in the JWT claims.
  *   - StandardClaims: Embedded struct containing standard JWT claims.
  *     Type: jwt.StandardClaims
  *     Description: This embedded struct contains standard JWT claims such as expiration time, issuer, and subject.
@@ -26,6 +29,7 @@ import "github.com/golang-jwt/jwt"
 var JwtKey = []byte("secret-key")
 
 type Claims struct {
-	Email string `json:"email"`
+	Email  string `json:"email"`
+	UserID int    `json:"user_id"`
 	jwt.StandardClaims
 }
@@ -0,0 +1,176 @@
+/** 
+ * Package model provides a small Markdown renderer for user-written text such as comments.
+ * 
+ * Supported syntax: paragraphs, ATX headings (# to ######), bullet lists (-, * or +), ordered lists (1.),
+ * block quotes (>), fenced code blocks (```), inline code, **bold**, *italic*, ~~strikethrough~~ and [links](url).
+ * 
+ * Functions:
+ * 
+ * - RenderMarkdown: Function to render Markdown to HTML. All text is HTML-escaped before any markup is added,
+ *   so raw HTML in the source is shown as text, and links are only kept for http, https and mailto URLs
+ *   or paths on this site. NUL characters are dropped, as they delimit placeholders while rendering.
+ */
+
+package model
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	markdownBullet      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	markdownOrdered     = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	markdownQuote       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	markdownLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownBold        = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	markdownItalic      = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*|\b_(\S(?:.*?\S)?)_\b`)
+	markdownStrike      = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	markdownPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+func RenderMarkdown(source string) string {
+	source = strings.ReplaceAll(source, "\x00", "")
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	var out strings.Builder
+	var paragraph []string
+	var list []string
+	listTag := ""
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + renderInline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	flushList := func() {
+		if len(list) > 0 {
+			out.WriteString("<" + listTag + ">\n")
+			for _, item := range list {
+				out.WriteString("<li>" + renderInline(item) + "</li>\n")
+			}
+			out.WriteString("</" + listTag + ">\n")
+			list = nil
+		}
+	}
+	addListItem := func(tag, item string) {
+		flushParagraph()
+		if listTag != tag {
+			flushList()
+			listTag = tag
+		}
+		list = append(list, item)
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flushParagraph()
+			flushList()
+
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case trimmed == "":
+			flushParagraph()
+			flushList()
+
+		case markdownHeading.MatchString(trimmed):
+			flushParagraph()
+			flushList()
+
+			match := markdownHeading.FindStringSubmatch(trimmed)
+			level := len(match[1])
+			out.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n", level, renderInline(match[2]), level))
+
+		case markdownBullet.MatchString(line):
+			addListItem("ul", markdownBullet.FindStringSubmatch(line)[1])
+
+		case markdownOrdered.MatchString(line):
+			addListItem("ol", markdownOrdered.FindStringSubmatch(line)[1])
+
+		case markdownQuote.MatchString(line):
+			flushParagraph()
+			flushList()
+
+			var quote []string
+			for ; i < len(lines) && markdownQuote.MatchString(lines[i]); i++ {
+				quote = append(quote, markdownQuote.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			out.WriteString("<blockquote>" + RenderMarkdown(strings.Join(quote, "\n")) + "</blockquote>\n")
+
+		default:
+			flushList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	flushList()
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// renderInline renders the inline markup of a block. Code spans and links are replaced by
+// placeholders while emphasis is applied, so that their content is never reinterpreted.
+func renderInline(text string) string {
+	var tokens []string
+	hold := func(rendered string) string {
+		tokens = append(tokens, rendered)
+		return fmt.Sprintf("\x00%d\x00", len(tokens)-1)
+	}
+
+	var b strings.Builder
+	for parts := strings.Split(text, "`"); len(parts) > 0; parts = parts[2:] {
+		b.WriteString(html.EscapeString(parts[0]))
+		if len(parts) < 3 {
+			if len(parts) == 2 {
+				b.WriteString("`" + html.EscapeString(parts[1])) // An unclosed backtick is plain text
+			}
+			break
+		}
+		b.WriteString(hold("<code>" + html.EscapeString(parts[1]) + "</code>"))
+	}
+
+	escaped := markdownLink.ReplaceAllStringFunc(b.String(), func(link string) string {
+		match := markdownLink.FindStringSubmatch(link)
+		if !safeLinkTarget(html.UnescapeString(match[2])) {
+			return match[1]
+		}
+		return hold(`<a href="` + match[2] + `" rel="nofollow noopener">`) + match[1] + hold("</a>")
+	})
+
+	escaped = markdownBold.ReplaceAllString(escaped, "<strong>$1$2</strong>")
+	escaped = markdownItalic.ReplaceAllString(escaped, "<em>$1$2</em>")
+	escaped = markdownStrike.ReplaceAllString(escaped, "<del>$1</del>")
+	escaped = strings.ReplaceAll(escaped, "\n", "<br>\n")
+
+	return markdownPlaceholder.ReplaceAllStringFunc(escaped, func(placeholder string) string {
+		index, err := strconv.Atoi(strings.Trim(placeholder, "\x00"))
+		if err != nil || index >= len(tokens) {
+			return ""
+		}
+		return tokens[index]
+	})
+}
+
+func safeLinkTarget(target string) bool {
+	lower := strings.ToLower(target)
+	for _, prefix := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//")
+}
@@ -9,6 +9,12 @@
  *     Type: int
  *   - Name: Name of the category.
  *     Type: string
+ *   - UserID: ID of the user who owns the category.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the category belongs to, 0 for the default workspace.
+ *     Type: int
+ *   - ProjectID: ID of the project the category is grouped under, 0 when it is not part of a project.
+ *     Type: int
  * 
  * - User: Struct representing a user.
  *   Fields:
@@ -24,6 +30,23 @@
  *     Type: time.Time
  *   - UpdatedAt: Timestamp indicating the last update time of the user record.
  *     Type: time.Time
+ *   - TimeZone: IANA name of the user's time zone, used to render deadlines. Empty means UTC.
+ *     Type: string
+ *   - DeletionDueAt: Timestamp after which a pending account deletion is carried out, nil when no deletion was requested.
+ *     Type: *time.Time
+ *   - WorkspaceID: ID of the workspace the user selected to work in, 0 for the default workspace.
+ *     Type: int
+ * 
+ * - UserProfile: Struct representing the public part of a user, without its password.
+ *   Fields:
+ *   - ID: Unique identifier for the user.
+ *     Type: int
+ *   - Fullname: Full name of the user.
+ *     Type: string
+ *   - Email: Email address of the user.
+ *     Type: string
+ *   - TimeZone: IANA name of the user's time zone.
+ *     Type: string
  * 
  * - UserLogin: Struct representing user login credentials.
  *   Fields:
@@ -47,16 +70,45 @@
  *     Type: int
  *   - Title: Title of the task.
  *     Type: string
- *   - Deadline: Deadline of the task.
- *     Type: string
+ *   - Deadline: Deadline of the task, a calendar date or an exact instant.
+ *     Type: Deadline
  *   - Priority: Priority level of the task.
  *     Type: int
- *   - Status: Status of the task.
- *     Type: string
+ *   - Status: Status of the task, one of the statuses of the owner's workflow.
+ *     Type: TaskStatus
  *   - CategoryID: ID of the category to which the task belongs.
  *     Type: int
  *   - UserID: ID of the user who owns the task.
  *     Type: int
+ *   - ParentID: ID of the parent task, 0 for a top-level task.
+ *     Type: int
+ *   - LegacyDeadline: Free-form deadline stored by an older version that the migration could not read, kept until a deadline is set.
+ *     Type: string
+ *   - Checklist: Checklist items of the task, in display order.
+ *     Type: []ChecklistItem
+ *   - Recurrence: Recurrence rule of a repeating task, empty for a one-off task.
+ *     Type: string
+ *   - SeriesID: ID of the first occurrence of the series the task belongs to, 0 for the first occurrence itself.
+ *     Type: int
+ *   - NextID: ID of the occurrence generated when the task was completed, 0 while none was generated.
+ *     Type: int
+ *   - EstimateHours: Estimated effort in hours, 0 when not estimated.
+ *     Type: float64
+ *   - StoryPoints: Estimated effort in story points, 0 when not estimated.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the task belongs to, 0 for the default workspace.
+ *     Type: int
+ *   - MilestoneID: ID of the project milestone the task counts towards, 0 when it is not attached to one.
+ *     Type: int
+ *   - SprintID: ID of the sprint the task is planned into, 0 while it is in the backlog.
+ *     Type: int
+ *   - Rank: Position of the task within its status column on the board. Ranks compare as plain strings.
+ *     Type: string
+ *   - Fields: Values of the custom fields of the workspace set on the task, keyed by field ID, in the form
+ *     CustomField.Normalize returns them.
+ *     Type: map[int]interface{}
+ *   - ArchivedAt: Time the completed task was archived, nil while it is not. Archived tasks are hidden from the default task lists.
+ *     Type: *time.Time
  * 
  * - Session: Struct representing a user session.
  *   Fields:
@@ -96,6 +148,23 @@
  *     Type: string
  *   - Category: Name of the category to which the task belongs.
  *     Type: string
+ *   - Tags: Tags assigned to the task.
+ *     Type: []Tag
+ * 
+ * - AuditRecord: Struct representing an entry in the audit log.
+ *   Fields:
+ *   - ID: Unique identifier for the audit record.
+ *     Type: int
+ *   - Action: Name of the audited action, e.g. "user.erased".
+ *     Type: string
+ *   - Actor: Email address of the user who performed the action.
+ *     Type: string
+ *   - UserID: ID of the user the action was performed on.
+ *     Type: int
+ *   - Detail: Human readable description of the action.
+ *     Type: string
+ *   - CreatedAt: Timestamp indicating when the action happened.
+ *     Type: time.Time
  * 
  * - Credential: Struct representing database connection credentials.
  *   Fields:
@@ -111,15 +180,43 @@
  *     Type: int
  *   - Schema: Database schema name.
  *     Type: string
+ * 
+ * Errors:
+ * 
+ * - ErrTaskNotFound: Returned when the task does not exist.
+ * - ErrTaskForbidden: Returned when a user who is neither the owner, an admin of the task's workspace nor an assignee acts on a task.
+ * - ErrInvalidCategory: Returned when a task is put in a category that does not exist or belongs to another workspace.
+ * - ErrUserNotFound: Returned when the user does not exist.
+ * - ErrWrongCredentials: Returned when the email or password of a user does not match.
+ * - ErrDeletionScheduled: Returned when the deletion of an account that is already scheduled for deletion is requested.
+ * - ErrDeletionNotScheduled: Returned when an account that is not scheduled for deletion is restored.
  */
 
 package model
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTaskNotFound    = errors.New("task not found")
+	ErrTaskForbidden   = errors.New("only the owner, workspace admins and assignees can change this task")
+	ErrInvalidCategory = errors.New("invalid category")
+
+	ErrUserNotFound         = errors.New("user not found")
+	ErrWrongCredentials     = errors.New("wrong email or password")
+	ErrDeletionScheduled    = errors.New("account is already scheduled for deletion")
+	ErrDeletionNotScheduled = errors.New("account is not scheduled for deletion")
+)
 
 type Category struct {
-	ID   int    `gorm:"primaryKey" json:"id"`
-	Name string `json:"name"`
+	ID     int    `gorm:"primaryKey" json:"id"`
+	Name   string `json:"name"`
+	UserID int    `json:"user_id"`
+
+	WorkspaceID int `json:"workspace_id,omitempty"`
+	ProjectID   int `json:"project_id,omitempty"`
 }
 
 type User struct {
@@ -129,6 +226,18 @@ type User struct {
 	Password  string    `json:"password" gorm:"type:varchar(255);not null"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	TimeZone  string    `json:"time_zone"`
+
+	DeletionDueAt *time.Time `json:"deletion_due_at,omitempty"`
+
+	WorkspaceID int `json:"workspace_id,omitempty"`
+}
+
+type UserProfile struct {
+	ID       int    `json:"id"`
+	Fullname string `json:"fullname"`
+	Email    string `json:"email"`
+	TimeZone string `json:"time_zone"`
 }
 
 type UserLogin struct {
@@ -143,13 +252,35 @@ type UserRegister struct {
 }
 
 type Task struct {
-	ID         int    `gorm:"primaryKey" json:"id"`
-	Title      string `json:"title"`
-	Deadline   string `json:"deadline"`
-	Priority   int    `json:"priority"`
-	Status     string `json:"status"`
-	CategoryID int    `json:"category_id"`
-	UserID     int    `json:"user_id"`
+	ID         int        `gorm:"primaryKey" json:"id"`
+	Title      string     `json:"title"`
+	Deadline   Deadline   `json:"deadline"`
+	Priority   int        `json:"priority"`
+	Status     TaskStatus `json:"status"`
+	CategoryID int        `json:"category_id"`
+	UserID     int        `json:"user_id"`
+	ParentID   int        `json:"parent_id"`
+
+	LegacyDeadline string `json:"legacy_deadline,omitempty"`
+
+	Checklist []ChecklistItem `json:"checklist,omitempty"`
+
+	Recurrence string `json:"recurrence,omitempty"`
+	SeriesID   int    `json:"series_id,omitempty"`
+	NextID     int    `json:"next_id,omitempty"`
+
+	EstimateHours float64 `json:"estimate_hours,omitempty"`
+	StoryPoints   int     `json:"story_points,omitempty"`
+
+	WorkspaceID int `json:"workspace_id,omitempty"`
+	MilestoneID int `json:"milestone_id,omitempty"`
+	SprintID    int `json:"sprint_id,omitempty"`
+
+	Rank string `json:"rank,omitempty"`
+
+	Fields map[int]interface{} `json:"fields,omitempty"`
+
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
 }
 
 type Session struct {
@@ -163,6 +294,7 @@ type TaskCategory struct {
 	ID       int    `json:"id"`
 	Title    string `json:"title"`
 	Category string `json:"category"`
+	Tags     []Tag  `json:"tags,omitempty"`
 }
 
 type UserTaskCategory struct {
@@ -174,6 +306,16 @@ type UserTaskCategory struct {
 	Priority int    `json:"priority"`
 	Status   string `json:"status"`
 	Category string `json:"category"`
+	Tags     []Tag  `json:"tags,omitempty"`
+}
+
+type AuditRecord struct {
+	ID        int       `json:"id"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	UserID    int       `json:"user_id"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Credential struct {
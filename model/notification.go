@@ -0,0 +1,103 @@
+/**
+ * Package model provides the models of watchers and in-app notifications.
+ *
+ * Types:
+ *
+ * - WatchTarget: Kind of record a user can watch: a task or a category.
+ * - NotificationKind: Kind of event a notification is about: a status change, a comment, an assignment or an approaching deadline.
+ *
+ * Structs:
+ *
+ * - Watch: Struct representing a user watching a task or a category.
+ *   Fields:
+ *   - ID: Unique identifier for the watch.
+ *     Type: int
+ *   - UserID: ID of the watching user.
+ *     Type: int
+ *   - Target: Kind of the watched record.
+ *     Type: WatchTarget
+ *   - TargetID: ID of the watched task or category.
+ *     Type: int
+ *   - CreatedAt: Time the user started watching.
+ *     Type: time.Time
+ *
+ * - Notification: Struct representing a notification sent to a user.
+ *   Fields:
+ *   - ID: Unique identifier for the notification.
+ *     Type: int
+ *   - UserID: ID of the notified user.
+ *     Type: int
+ *   - Kind: Kind of event the notification is about.
+ *     Type: NotificationKind
+ *   - TaskID: ID of the task the event happened on.
+ *     Type: int
+ *   - Actor: Email of the user who caused the event, empty for deadline reminders.
+ *     Type: string
+ *   - Message: Text shown to the user.
+ *     Type: string
+ *   - CreatedAt: Time the notification was sent.
+ *     Type: time.Time
+ *   - ReadAt: Time the user read the notification, nil while it is unread.
+ *     Type: *time.Time
+ *
+ * - UnreadCount: Struct representing the number of unread notifications of a user.
+ *   Fields:
+ *   - Unread: Number of unread notifications.
+ *     Type: int
+ *
+ * Errors:
+ *
+ * - ErrNotificationNotFound: Returned when a notification does not exist or was sent to another user.
+ * - ErrInvalidWatchTarget: Returned when the kind of a watched record is neither task nor category.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type WatchTarget string
+
+const (
+	WatchTask     WatchTarget = "task"
+	WatchCategory WatchTarget = "category"
+)
+
+type NotificationKind string
+
+const (
+	NotifyStatusChanged NotificationKind = "status_changed"
+	NotifyCommented     NotificationKind = "commented"
+	NotifyAssigned      NotificationKind = "assigned"
+	NotifyDeadline      NotificationKind = "deadline"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidWatchTarget   = errors.New("only tasks and categories can be watched")
+)
+
+type Watch struct {
+	ID        int         `json:"id"`
+	UserID    int         `json:"user_id"`
+	Target    WatchTarget `json:"target"`
+	TargetID  int         `json:"target_id"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+type Notification struct {
+	ID        int              `json:"id"`
+	UserID    int              `json:"user_id"`
+	Kind      NotificationKind `json:"kind"`
+	TaskID    int              `json:"task_id"`
+	Actor     string           `json:"actor,omitempty"`
+	Message   string           `json:"message"`
+	CreatedAt time.Time        `json:"created_at"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+}
+
+type UnreadCount struct {
+	Unread int `json:"unread"`
+}
@@ -0,0 +1,156 @@
+/** 
+ * Package model provides the models of projects, which group categories and tasks, and their milestones.
+ * 
+ * Structs:
+ * 
+ * - Project: Struct representing a project.
+ *   Fields:
+ *   - ID: Unique identifier for the project.
+ *     Type: int
+ *   - Name: Name of the project.
+ *     Type: string
+ *   - Description: Optional description of the project.
+ *     Type: string
+ *   - UserID: ID of the user who created the project.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the project belongs to.
+ *     Type: int
+ *   - CreatedAt: Time the project was created.
+ *     Type: time.Time
+ * 
+ * - Milestone: Struct representing a milestone of a project. Tasks are attached to milestones through Task.MilestoneID.
+ *   Fields:
+ *   - ID: Unique identifier for the milestone.
+ *     Type: int
+ *   - ProjectID: ID of the project.
+ *     Type: int
+ *   - Title: Title of the milestone.
+ *     Type: string
+ *   - DueDate: Date or instant the milestone is due, optional.
+ *     Type: Deadline
+ *   - CreatedAt: Time the milestone was created.
+ *     Type: time.Time
+ * 
+ * - MilestoneProgress: Struct representing a milestone with the progress of its tasks.
+ *   Fields:
+ *   - Milestone: The milestone.
+ *     Type: Milestone
+ *   - Progress: Completed tasks out of the tasks attached to the milestone.
+ *     Type: Progress
+ *   - Overdue: Whether the due date has passed while some of the tasks, or none at all, are completed.
+ *     Type: bool
+ * 
+ * - CategoryProgress: Struct representing a category of a project with the progress of its tasks.
+ *   Fields:
+ *   - Category: The category.
+ *     Type: Category
+ *   - Progress: Completed tasks out of the tasks of the category.
+ *     Type: Progress
+ * 
+ * - ProjectOverview: Struct representing a project with the progress of its categories and milestones.
+ *   Fields:
+ *   - Project: The project.
+ *     Type: Project
+ *   - Categories: Categories of the project, in ID order.
+ *     Type: []CategoryProgress
+ *   - Milestones: Milestones of the project, the earliest due first and those without a due date last.
+ *     Type: []MilestoneProgress
+ *   - Progress: Completed tasks out of every task of the project's categories and milestones.
+ *     Type: Progress
+ *   - OverdueMilestones: Number of overdue milestones.
+ *     Type: int
+ * 
+ * - ProjectRequest: Struct representing the body of a request creating or updating a project.
+ * - MilestoneRequest: Struct representing the body of a request creating or updating a milestone.
+ * 
+ * Functions:
+ * 
+ * - TaskProgress: Function to count the completed tasks out of the given ones.
+ *   Parameters:
+ *   - tasks: The tasks.
+ *     Type: []Task
+ *   Returns:
+ *   - Progress: The completed and total number of tasks and the completed percentage.
+ * 
+ * Errors:
+ * 
+ * - ErrProjectNotFound: Returned when the project does not exist or belongs to another workspace.
+ * - ErrInvalidProject: Returned when a project is saved without a name.
+ * - ErrMilestoneNotFound: Returned when the milestone does not exist or belongs to another project.
+ * - ErrInvalidMilestone: Returned when a milestone is saved without a title.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrProjectNotFound   = errors.New("project not found")
+	ErrInvalidProject    = errors.New("project name is required")
+	ErrMilestoneNotFound = errors.New("milestone not found")
+	ErrInvalidMilestone  = errors.New("milestone title is required")
+)
+
+type Project struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	UserID      int       `json:"user_id"`
+	WorkspaceID int       `json:"workspace_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Milestone struct {
+	ID        int       `json:"id"`
+	ProjectID int       `json:"project_id"`
+	Title     string    `json:"title"`
+	DueDate   Deadline  `json:"due_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MilestoneProgress struct {
+	Milestone Milestone `json:"milestone"`
+	Progress  Progress  `json:"progress"`
+	Overdue   bool      `json:"overdue"`
+}
+
+type CategoryProgress struct {
+	Category Category `json:"category"`
+	Progress Progress `json:"progress"`
+}
+
+type ProjectOverview struct {
+	Project           Project             `json:"project"`
+	Categories        []CategoryProgress  `json:"categories"`
+	Milestones        []MilestoneProgress `json:"milestones"`
+	Progress          Progress            `json:"progress"`
+	OverdueMilestones int                 `json:"overdue_milestones"`
+}
+
+type ProjectRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type MilestoneRequest struct {
+	Title   string   `json:"title" binding:"required"`
+	DueDate Deadline `json:"due_date"`
+}
+
+func TaskProgress(tasks []Task) Progress {
+	var progress Progress
+	for _, task := range tasks {
+		progress.Total++
+		if task.Status == StatusCompleted {
+			progress.Done++
+		}
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = progress.Done * 100 / progress.Total
+	}
+	return progress
+}
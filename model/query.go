@@ -0,0 +1,325 @@
+/**
+ * Package model provides the models of filtered, sorted and paginated task and category lists.
+ *
+ * Structs:
+ *
+ * - TaskQuery: Struct representing the filters, sort order and page of a task list. Zero values do not filter.
+ *   Fields:
+ *   - Statuses: Statuses a task may have.
+ *     Type: []TaskStatus
+ *   - MinPriority / MaxPriority: Inclusive range of the priority of a task.
+ *     Type: int
+ *   - CategoryID: ID of the category of a task.
+ *     Type: int
+ *   - UserID: ID of the owner of a task.
+ *     Type: int
+ *   - DeadlineAfter / DeadlineBefore: Exclusive bounds of the deadline of a task. Tasks without a deadline are left out
+ *     when either is set.
+ *     Type: Deadline
+ *   - Title: Text the title of a task contains, compared case-insensitively.
+ *     Type: string
+ *   - TagIDs: IDs of tags a task must all carry.
+ *     Type: []int
+ *   - Fields: Values of custom fields a task must carry, keyed by field ID, compared as by CustomField.Matches.
+ *     Type: map[int]string
+ *   - Archived: Whether archived tasks are listed instead of the other ones.
+ *     Type: bool
+ *   - Sort: Sort key, one of TaskSortKeys, prefixed with "-" for descending order. Empty sorts by creation.
+ *     Type: string
+ *   - Cursor: Cursor returned with the previous page, empty for the first page.
+ *     Type: string
+ *   - Limit: Maximum number of tasks on the page, at most MaxPageSize. Zero lists every task.
+ *     Type: int
+ *   Methods:
+ *   - Validate: Checks the sort key, the priority range, the limit and the cursor of the query.
+ *   - Matches: Reports whether a task passes the filters of the query other than tags and custom fields, which are
+ *     checked where they are stored.
+ *   - Less: Reports whether a task comes before another in the sort order of the query. Ties are broken by ID, and tasks
+ *     without a deadline always come last when sorting by deadline.
+ *   - NextCursor: Returns the cursor of the page following the given task. The cursor holds the values of every sort
+ *     key, so it stays valid if the task changes or is deleted before the next page is requested.
+ *   - After: Returns the last task of the previous page, decoded from the cursor.
+ *
+ * - CategoryQuery: Struct representing the filters, sort order and page of a category list. Zero values do not filter.
+ *   Fields:
+ *   - UserID: ID of the owner of a category.
+ *     Type: int
+ *   - ProjectID: ID of the project of a category.
+ *     Type: int
+ *   - Name: Text the name of a category contains, compared case-insensitively.
+ *     Type: string
+ *   - Sort: Sort key, one of CategorySortKeys, prefixed with "-" for descending order. Empty sorts by creation.
+ *     Type: string
+ *   - Cursor: Cursor returned with the previous page, empty for the first page.
+ *     Type: string
+ *   - Limit: Maximum number of categories on the page, at most MaxPageSize. Zero lists every category.
+ *     Type: int
+ *   Methods:
+ *   - Validate / Matches / Less / NextCursor / After: Same as for TaskQuery. Names are compared case-insensitively.
+ *
+ * - TaskPage / CategoryPage: Structs representing one page of a list and the cursor of the next page, empty on the
+ *   last page.
+ *
+ * Errors:
+ *
+ * - ErrInvalidQuery: Returned when a list is requested with an unknown sort key, an invalid filter, limit or cursor.
+ */
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const MaxPageSize = 100
+
+var (
+	TaskSortKeys     = []string{"created", "deadline", "priority", "archived"}
+	CategorySortKeys = []string{"created", "name"}
+)
+
+var ErrInvalidQuery = errors.New("invalid list query")
+
+type TaskQuery struct {
+	Statuses       []TaskStatus
+	MinPriority    int
+	MaxPriority    int
+	CategoryID     int
+	UserID         int
+	DeadlineAfter  Deadline
+	DeadlineBefore Deadline
+	Title          string
+	TagIDs         []int
+	Fields         map[int]string
+	Archived       bool
+	Sort           string
+	Cursor         string
+	Limit          int
+}
+
+type TaskPage struct {
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type CategoryQuery struct {
+	UserID    int
+	ProjectID int
+	Name      string
+	Sort      string
+	Cursor    string
+	Limit     int
+}
+
+type CategoryPage struct {
+	Categories []Category `json:"categories"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+func (q TaskQuery) Validate() error {
+	if err := validateSort(q.Sort, TaskSortKeys); err != nil {
+		return err
+	}
+	if q.MaxPriority != 0 && q.MinPriority > q.MaxPriority {
+		return fmt.Errorf("%w: priority range %d to %d is empty", ErrInvalidQuery, q.MinPriority, q.MaxPriority)
+	}
+	if err := validateLimit(q.Limit); err != nil {
+		return err
+	}
+	_, _, err := q.After()
+	return err
+}
+
+func (q TaskQuery) Matches(task Task) bool {
+	if len(q.Statuses) > 0 {
+		found := false
+		for _, status := range q.Statuses {
+			if strings.EqualFold(string(status), string(task.Status)) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.MinPriority != 0 && task.Priority < q.MinPriority {
+		return false
+	}
+	if q.MaxPriority != 0 && task.Priority > q.MaxPriority {
+		return false
+	}
+	if q.CategoryID != 0 && task.CategoryID != q.CategoryID {
+		return false
+	}
+	if q.UserID != 0 && task.UserID != q.UserID {
+		return false
+	}
+	if !q.DeadlineAfter.IsZero() || !q.DeadlineBefore.IsZero() {
+		if task.Deadline.IsZero() {
+			return false
+		}
+		if !q.DeadlineAfter.IsZero() && !q.DeadlineAfter.Before(task.Deadline) {
+			return false
+		}
+		if !q.DeadlineBefore.IsZero() && !task.Deadline.Before(q.DeadlineBefore) {
+			return false
+		}
+	}
+	if q.Title != "" && !strings.Contains(strings.ToLower(task.Title), strings.ToLower(q.Title)) {
+		return false
+	}
+	return (task.ArchivedAt != nil) == q.Archived
+}
+
+func (q TaskQuery) Less(a, b Task) bool {
+	key, descending := sortKey(q.Sort)
+
+	cmp := 0
+	switch key {
+	case "deadline":
+		if a.Deadline.IsZero() != b.Deadline.IsZero() {
+			return b.Deadline.IsZero()
+		}
+		cmp = compareTimes(a.Deadline.Due(time.UTC), b.Deadline.Due(time.UTC))
+	case "priority":
+		cmp = a.Priority - b.Priority
+	case "archived":
+		if (a.ArchivedAt == nil) != (b.ArchivedAt == nil) {
+			return b.ArchivedAt == nil
+		}
+		if a.ArchivedAt != nil {
+			cmp = compareTimes(*a.ArchivedAt, *b.ArchivedAt)
+		}
+	}
+	return less(cmp, a.ID, b.ID, descending)
+}
+
+// NextCursor returns the cursor of the page following the given task, holding only the values tasks are sorted by.
+func (q TaskQuery) NextCursor(last Task) string {
+	return encodeCursor(Task{ID: last.ID, Deadline: last.Deadline, Priority: last.Priority, ArchivedAt: last.ArchivedAt})
+}
+
+func (q TaskQuery) After() (Task, bool, error) {
+	var task Task
+	ok, err := decodeCursor(q.Cursor, &task)
+	return task, ok, err
+}
+
+func (q CategoryQuery) Validate() error {
+	if err := validateSort(q.Sort, CategorySortKeys); err != nil {
+		return err
+	}
+	if err := validateLimit(q.Limit); err != nil {
+		return err
+	}
+	_, _, err := q.After()
+	return err
+}
+
+func (q CategoryQuery) Matches(category Category) bool {
+	if q.UserID != 0 && category.UserID != q.UserID {
+		return false
+	}
+	if q.ProjectID != 0 && category.ProjectID != q.ProjectID {
+		return false
+	}
+	return q.Name == "" || strings.Contains(strings.ToLower(category.Name), strings.ToLower(q.Name))
+}
+
+func (q CategoryQuery) Less(a, b Category) bool {
+	key, descending := sortKey(q.Sort)
+
+	cmp := 0
+	if key == "name" {
+		cmp = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	return less(cmp, a.ID, b.ID, descending)
+}
+
+func (q CategoryQuery) NextCursor(last Category) string {
+	return encodeCursor(Category{ID: last.ID, Name: last.Name})
+}
+
+func (q CategoryQuery) After() (Category, bool, error) {
+	var category Category
+	ok, err := decodeCursor(q.Cursor, &category)
+	return category, ok, err
+}
+
+func encodeCursor(last interface{}) string {
+	data, err := json.Marshal(last)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string, last interface{}) (bool, error) {
+	if cursor == "" {
+		return false, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || json.Unmarshal(data, last) != nil {
+		return false, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	return true, nil
+}
+
+func sortKey(sort string) (string, bool) {
+	if strings.HasPrefix(sort, "-") {
+		return sort[1:], true
+	}
+	return sort, false
+}
+
+func validateSort(sort string, keys []string) error {
+	if sort == "" {
+		return nil
+	}
+
+	key, _ := sortKey(sort)
+	for _, known := range keys {
+		if key == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unknown sort %q, use one of %s", ErrInvalidQuery, sort, strings.Join(keys, ", "))
+}
+
+func validateLimit(limit int) error {
+	if limit < 0 || limit > MaxPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxPageSize)
+	}
+	return nil
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// less orders two records by the comparison of their sort values, reversed for descending order, and then by ID so
+// every record has a fixed place a cursor can point to.
+func less(cmp, aID, bID int, descending bool) bool {
+	if descending {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp < 0
+	}
+	if descending && (aID != bID) {
+		return aID > bID
+	}
+	return aID < bID
+}
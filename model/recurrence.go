@@ -0,0 +1,302 @@
+/** 
+ * Package model provides the recurrence rules of repeating tasks.
+ * 
+ * Structs:
+ * 
+ * - Recurrence: Struct representing a parsed recurrence rule, a subset of the RFC 5545 RRULE.
+ *   Supported parts are FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY, BYMONTHDAY and UNTIL, e.g.
+ *   "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH" or "FREQ=MONTHLY;BYDAY=-1FR".
+ *   Fields:
+ *   - Freq: Frequency of the rule.
+ *     Type: string
+ *   - Interval: Number of days, weeks or months between occurrences, at least 1.
+ *     Type: int
+ *   - ByDay: Weekdays the task occurs on. With FREQ=MONTHLY a weekday may carry an ordinal, 1 for the first and -1 for the last of the month.
+ *     Type: []RecurrenceDay
+ *   - ByMonthDay: Days of the month the task occurs on, negative values counting from the end of the month. Only used with FREQ=MONTHLY.
+ *     Type: []int
+ *   - Until: Last date an occurrence may fall on, zero when the series never ends.
+ *     Type: time.Time
+ *   Methods:
+ *   - String: Returns the rule in its canonical RRULE form.
+ *   - Next: Returns the deadline of the occurrence following the given one, keeping its time of day,
+ *     and false when the series has ended. Dates are computed in UTC.
+ * 
+ * - RecurrenceDay: Struct representing a BYDAY entry.
+ *   Fields:
+ *   - Ordinal: Position of the weekday within the month, 0 for every such weekday.
+ *     Type: int
+ *   - Weekday: The weekday.
+ *     Type: time.Weekday
+ * 
+ * Functions:
+ * 
+ * - ParseRecurrence: Function to parse a recurrence rule. An optional "RRULE:" prefix is accepted.
+ *   Returns ErrInvalidRecurrence wrapped with the offending part when the rule is not supported.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// maxRecurrenceSearch bounds the day-by-day search for the next occurrence, so that a rule
+// without any matching day, e.g. BYMONTHDAY=31 every 12 months starting in April, cannot loop forever.
+const maxRecurrenceSearch = 20 * 366
+
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+type Recurrence struct {
+	Freq       string
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Until      time.Time
+}
+
+type RecurrenceDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+func ParseRecurrence(rule string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Recurrence{}, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+
+		var err error
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(value)
+			if r.Freq != FreqDaily && r.Freq != FreqWeekly && r.Freq != FreqMonthly {
+				err = fmt.Errorf("unsupported frequency")
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = fmt.Errorf("interval must be positive")
+			}
+		case "BYDAY":
+			r.ByDay, err = parseRecurrenceDays(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseMonthDays(value)
+		case "UNTIL":
+			if len(value) > 8 {
+				value = value[:8] // A date-time UNTIL only limits the date
+			}
+			r.Until, err = time.Parse("20060102", value)
+		default:
+			err = fmt.Errorf("unsupported part")
+		}
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("%w: %q: %v", ErrInvalidRecurrence, part, err)
+		}
+	}
+
+	if r.Freq == "" {
+		return Recurrence{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	}
+	if r.Freq == FreqDaily && (len(r.ByDay) > 0 || len(r.ByMonthDay) > 0) {
+		return Recurrence{}, fmt.Errorf("%w: BYDAY and BYMONTHDAY are not supported with FREQ=DAILY", ErrInvalidRecurrence)
+	}
+	if r.Freq == FreqWeekly {
+		if len(r.ByMonthDay) > 0 {
+			return Recurrence{}, fmt.Errorf("%w: BYMONTHDAY is not supported with FREQ=WEEKLY", ErrInvalidRecurrence)
+		}
+		for _, day := range r.ByDay {
+			if day.Ordinal != 0 {
+				return Recurrence{}, fmt.Errorf("%w: ordinal weekdays need FREQ=MONTHLY", ErrInvalidRecurrence)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func parseRecurrenceDays(value string) ([]RecurrenceDay, error) {
+	var days []RecurrenceDay
+	for _, entry := range strings.Split(strings.ToUpper(value), ",") {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("unknown weekday %q", entry)
+		}
+
+		weekday, ok := weekdayCodes[entry[len(entry)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", entry)
+		}
+
+		day := RecurrenceDay{Weekday: weekday}
+		if prefix := entry[:len(entry)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+				return nil, fmt.Errorf("invalid ordinal %q", prefix)
+			}
+			day.Ordinal = ordinal
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseMonthDays(value string) ([]int, error) {
+	var days []int
+	for _, entry := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(entry)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, fmt.Errorf("invalid day of month %q", entry)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		var days []string
+		for _, day := range r.ByDay {
+			code := strings.ToUpper(day.Weekday.String()[:2])
+			if day.Ordinal != 0 {
+				code = strconv.Itoa(day.Ordinal) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		var days []string
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r Recurrence) Next(current Deadline) (Deadline, bool) {
+	base := current.At.UTC()
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	for offset := 1; offset <= maxRecurrenceSearch; offset++ {
+		candidate := base.AddDate(0, 0, offset)
+		if !r.Until.IsZero() && !candidate.Before(r.Until.AddDate(0, 0, 1)) {
+			return Deadline{}, false
+		}
+
+		if r.matches(base, candidate, interval) {
+			return Deadline{At: candidate, DateOnly: current.DateOnly}, true
+		}
+	}
+	return Deadline{}, false
+}
+
+func (r Recurrence) matches(base, candidate time.Time, interval int) bool {
+	switch r.Freq {
+	case FreqDaily:
+		return daysBetween(base, candidate)%interval == 0
+
+	case FreqWeekly:
+		if len(r.ByDay) == 0 {
+			return daysBetween(base, candidate)%(7*interval) == 0
+		}
+		weeks := daysBetween(startOfWeek(base), startOfWeek(candidate)) / 7
+		return weeks%interval == 0 && r.onWeekday(candidate)
+
+	case FreqMonthly:
+		months := (candidate.Year()-base.Year())*12 + int(candidate.Month()) - int(base.Month())
+		if months%interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			day := base.Day()
+			if last := daysInMonth(candidate); day > last {
+				day = last
+			}
+			return candidate.Day() == day
+		}
+		return r.onMonthDay(candidate) || r.onWeekday(candidate)
+	}
+	return false
+}
+
+func (r Recurrence) onWeekday(t time.Time) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday != t.Weekday() {
+			continue
+		}
+		if day.Ordinal == 0 {
+			return true
+		}
+
+		if day.Ordinal > 0 && (t.Day()-1)/7+1 == day.Ordinal {
+			return true
+		}
+		if day.Ordinal < 0 && (daysInMonth(t)-t.Day())/7+1 == -day.Ordinal {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Recurrence) onMonthDay(t time.Time) bool {
+	for _, day := range r.ByMonthDay {
+		if day > 0 && t.Day() == day {
+			return true
+		}
+		if day < 0 && t.Day() == daysInMonth(t)+day+1 {
+			return true
+		}
+	}
+	return false
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // Weeks start on Monday as in RFC 5545
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,561 @@
+/**
+ * Package model provides the task search query language, e.g. status:open priority>=3 due<2026-11-01 category:"Work" report.
+ *
+ * A query is a list of terms that must all match. Terms are joined with OR to match either, negated with a leading - or
+ * NOT, and grouped with parentheses. A term is either a field, an operator and a value, or text the title of the task
+ * must contain. Values with spaces are quoted with double quotes.
+ *
+ * Fields:
+ *
+ * - status: Status of the task, compared case-insensitively. open matches every status but Completed, done and closed
+ *   match Completed.
+ * - priority: Priority of the task, a number.
+ * - due (or deadline): Day of the deadline of the task in the time zone of the user, YYYY-MM-DD, or none for tasks
+ *   without one. Tasks without a deadline never match a date.
+ * - category: Name of the category of the task, or none for tasks without one.
+ * - tag: Name of a tag of the task.
+ * - title: Text the title of the task contains.
+ * - is: archived for archived tasks, overdue for open tasks whose deadline has passed. Archived tasks are only found by
+ *   queries mentioning is:archived.
+ *
+ * Operators are : and = for equality, != for inequality, and <, <=, > and >= for priority and due. Text is always
+ * compared case-insensitively. A word with : or = after something that is not a field, e.g. re: or a URL, is text; any
+ * other operator after an unknown field is an error.
+ *
+ * Types:
+ *
+ * - SearchNode: Interface implemented by the nodes of a parsed query.
+ *   Methods:
+ *   - Eval: Reports whether a task matches the node.
+ *   - String: Returns the node in a fully parenthesized form, e.g. (AND status:open (OR tag:home tag:work)).
+ * - SearchAnd / SearchOr: Nodes matching when all, or any, of their children match.
+ * - SearchNot: Node matching when its child does not.
+ * - SearchTerm: Leaf node comparing one field of the task with a value. Text terms have an empty Field.
+ *
+ * Interfaces:
+ *
+ * - SearchEnv: Interface giving the evaluation of a query what is not stored in the task itself.
+ *   Methods:
+ *   - CategoryName: Returns the name of a category, empty when it does not exist.
+ *   - TagNames: Returns the names of the tags of a task.
+ *   - Now: Returns the current time, used by is:overdue.
+ *   - Location: Returns the time zone of the user, used by due and is:overdue.
+ *
+ * Structs:
+ *
+ * - SearchQuery: Struct representing a parsed query.
+ *   Fields:
+ *   - Root: Root node of the query, nil for an empty query matching every task.
+ *   - Archived: Whether the query mentions is:archived, so archived tasks are searched too.
+ *   Methods:
+ *   - Matches: Reports whether a task matches the query.
+ *
+ * - ParseError: Struct representing a syntax error in a query.
+ *   Fields:
+ *   - Message: Description of the error.
+ *   - Column: Column of the offending token, starting at 1.
+ *   - Token: The offending token, empty at the end of the query.
+ *
+ * - SearchErrorResponse: Struct representing the response to a query that cannot be parsed, with the column and token of
+ *   the ParseError next to its message.
+ *
+ * Functions:
+ *
+ * - ParseSearch: Function to parse a query into a SearchQuery, returning a *ParseError wrapping ErrInvalidSearch when it
+ *   is not valid.
+ *
+ * Errors:
+ *
+ * - ErrInvalidSearch: Returned when a search query cannot be parsed.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrInvalidSearch = errors.New("invalid search query")
+
+var searchFields = []string{"status", "priority", "due", "category", "tag", "title", "is"}
+
+type SearchEnv interface {
+	CategoryName(id int) string
+	TagNames(taskID int) []string
+	Now() time.Time
+	Location() *time.Location
+}
+
+type SearchNode interface {
+	Eval(task Task, env SearchEnv) bool
+	String() string
+}
+
+type SearchAnd []SearchNode
+
+type SearchOr []SearchNode
+
+type SearchNot struct {
+	Node SearchNode
+}
+
+type SearchTerm struct {
+	Field string
+	Op    string
+	Value string
+
+	number int
+}
+
+type SearchQuery struct {
+	Root     SearchNode
+	Archived bool
+}
+
+type ParseError struct {
+	Message string
+	Column  int
+	Token   string
+}
+
+type SearchErrorResponse struct {
+	Error  string `json:"error"`
+	Column int    `json:"column"`
+	Token  string `json:"token"`
+}
+
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("end of query: %s", e.Message)
+	}
+	return fmt.Sprintf("column %d (%q): %s", e.Column, e.Token, e.Message)
+}
+
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidSearch
+}
+
+func (q SearchQuery) Matches(task Task, env SearchEnv) bool {
+	if task.ArchivedAt != nil && !q.Archived {
+		return false
+	}
+	return q.Root == nil || q.Root.Eval(task, env)
+}
+
+func (n SearchAnd) Eval(task Task, env SearchEnv) bool {
+	for _, child := range n {
+		if !child.Eval(task, env) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n SearchAnd) String() string {
+	return joinNodes("AND", n)
+}
+
+func (n SearchOr) Eval(task Task, env SearchEnv) bool {
+	for _, child := range n {
+		if child.Eval(task, env) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n SearchOr) String() string {
+	return joinNodes("OR", n)
+}
+
+func (n SearchNot) Eval(task Task, env SearchEnv) bool {
+	return !n.Node.Eval(task, env)
+}
+
+func (n SearchNot) String() string {
+	return "(NOT " + n.Node.String() + ")"
+}
+
+func (t SearchTerm) Eval(task Task, env SearchEnv) bool {
+	if t.Op == "!=" {
+		equal := t
+		equal.Op = "="
+		return !equal.Eval(task, env)
+	}
+
+	switch t.Field {
+	case "", "title":
+		return strings.Contains(strings.ToLower(task.Title), strings.ToLower(t.Value))
+	case "status":
+		switch strings.ToLower(t.Value) {
+		case "open":
+			return task.Status != StatusCompleted
+		case "done", "closed":
+			return task.Status == StatusCompleted
+		}
+		return strings.EqualFold(string(task.Status), t.Value)
+	case "priority":
+		return compareOp(task.Priority-t.number, t.Op)
+	case "due":
+		if t.Value == "none" || task.Deadline.IsZero() {
+			return t.Value == "none" && task.Deadline.IsZero()
+		}
+		return compareOp(strings.Compare(deadlineDay(task.Deadline, env.Location()).Format("2006-01-02"), t.Value), t.Op)
+	case "category":
+		if t.Value == "none" {
+			return task.CategoryID == 0
+		}
+		return task.CategoryID != 0 && strings.EqualFold(env.CategoryName(task.CategoryID), t.Value)
+	case "tag":
+		for _, name := range env.TagNames(task.ID) {
+			if strings.EqualFold(name, t.Value) {
+				return true
+			}
+		}
+		return false
+	case "is":
+		if t.Value == "archived" {
+			return task.ArchivedAt != nil
+		}
+		return task.Status != StatusCompleted && task.Deadline.Overdue(env.Now(), env.Location())
+	}
+	return false
+}
+
+func (t SearchTerm) String() string {
+	value := t.Value
+	if value == "" || strings.ContainsAny(value, " ()\"") {
+		value = strconv.Quote(value)
+	}
+	if t.Field == "" {
+		return value
+	}
+	return t.Field + t.Op + value
+}
+
+func ParseSearch(query string) (*SearchQuery, error) {
+	p := &searchParser{input: query}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+
+	parsed := &SearchQuery{}
+	if len(p.tokens) > 1 {
+		root, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token := p.peek(); token.kind != tokenEnd {
+			return nil, token.errorf("unexpected %q", token.text)
+		}
+		parsed.Root = root
+	}
+	for _, token := range p.tokens {
+		if token.term.Field == "is" && token.term.Value == "archived" {
+			parsed.Archived = true
+		}
+	}
+	return parsed, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenTerm
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+type searchToken struct {
+	kind   tokenKind
+	text   string
+	column int
+	term   SearchTerm
+}
+
+func (t searchToken) errorf(format string, args ...interface{}) *ParseError {
+	return &ParseError{Message: fmt.Sprintf(format, args...), Column: t.column, Token: t.text}
+}
+
+type searchParser struct {
+	input  string
+	tokens []searchToken
+	pos    int
+}
+
+func (p *searchParser) peek() searchToken {
+	return p.tokens[p.pos]
+}
+
+func (p *searchParser) next() searchToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+// parseOr parses terms joined with OR, which binds looser than the implicit AND between terms.
+func (p *searchParser) parseOr() (SearchNode, error) {
+	var children SearchOr
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+
+		if p.peek().kind != tokenOr {
+			break
+		}
+		p.next()
+	}
+
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return children, nil
+}
+
+func (p *searchParser) parseAnd() (SearchNode, error) {
+	var children SearchAnd
+	for {
+		switch token := p.peek(); token.kind {
+		case tokenEnd, tokenClose, tokenOr:
+			if len(children) == 0 {
+				if token.kind == tokenEnd {
+					return nil, token.errorf("missing term")
+				}
+				return nil, token.errorf("missing term before %q", token.text)
+			}
+			if len(children) == 1 {
+				return children[0], nil
+			}
+			return children, nil
+		}
+
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+}
+
+func (p *searchParser) parseUnary() (SearchNode, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenNot:
+		if kind := p.peek().kind; kind != tokenTerm && kind != tokenOpen && kind != tokenNot {
+			return nil, token.errorf("%q must be followed by a term", token.text)
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return SearchNot{node}, nil
+	case tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenClose {
+			return nil, token.errorf("unclosed %q", token.text)
+		}
+		p.next()
+		return node, nil
+	}
+	return token.term, nil
+}
+
+// lex splits the query into tokens, checking the field, operator and value of every term so errors point at the
+// token causing them.
+func (p *searchParser) lex() error {
+	runes := []rune(p.input)
+	i := 0
+	for {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i == len(runes) {
+			p.tokens = append(p.tokens, searchToken{kind: tokenEnd, column: i + 1})
+			return nil
+		}
+
+		start := i
+		switch {
+		case runes[i] == '(' || runes[i] == ')':
+			kind := tokenOpen
+			if runes[i] == ')' {
+				kind = tokenClose
+			}
+			p.tokens = append(p.tokens, searchToken{kind: kind, text: string(runes[i]), column: i + 1})
+			i++
+			continue
+		case runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			p.tokens = append(p.tokens, searchToken{kind: tokenNot, text: "-", column: i + 1})
+			i++
+			continue
+		case runes[i] == '"':
+			value, end, err := quoted(runes, i)
+			if err != nil {
+				return err
+			}
+			p.tokens = append(p.tokens, searchToken{kind: tokenTerm, text: string(runes[start:end]), column: start + 1, term: SearchTerm{Value: value}})
+			i = end
+			continue
+		}
+
+		for i < len(runes) && (unicode.IsLetter(runes[i]) || runes[i] == '_') {
+			i++
+		}
+		field := string(runes[start:i])
+		op := ""
+		for _, candidate := range []string{">=", "<=", "!=", ":", "=", "<", ">"} {
+			if field != "" && strings.HasPrefix(string(runes[i:]), candidate) {
+				op = candidate
+				break
+			}
+		}
+		// Words like re: or https://example.com are text; only comparing an unknown field is taken for a typo.
+		if (op == ":" || op == "=") && !isSearchField(strings.ToLower(field)) {
+			op = ""
+		}
+
+		if op == "" {
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				i++
+			}
+			word := string(runes[start:i])
+			token := searchToken{kind: tokenTerm, text: word, column: start + 1, term: SearchTerm{Value: word}}
+			switch word {
+			case "OR":
+				token.kind = tokenOr
+			case "NOT":
+				token.kind = tokenNot
+			case "AND":
+				continue
+			}
+			p.tokens = append(p.tokens, token)
+			continue
+		}
+
+		i += len([]rune(op))
+		value := ""
+		if i < len(runes) && runes[i] == '"' {
+			var end int
+			var err error
+			if value, end, err = quoted(runes, i); err != nil {
+				return err
+			}
+			i = end
+		} else {
+			valueStart := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				i++
+			}
+			value = string(runes[valueStart:i])
+		}
+
+		token := searchToken{kind: tokenTerm, text: string(runes[start:i]), column: start + 1}
+		term, err := newSearchTerm(token, strings.ToLower(field), op, value)
+		if err != nil {
+			return err
+		}
+		token.term = term
+		p.tokens = append(p.tokens, token)
+	}
+}
+
+// isSearchField reports whether the lowercased field is one a query can compare.
+func isSearchField(field string) bool {
+	if field == "deadline" {
+		return true
+	}
+	for _, name := range searchFields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+func newSearchTerm(token searchToken, field, op, value string) (SearchTerm, error) {
+	if field == "deadline" {
+		field = "due"
+	}
+	term := SearchTerm{Field: field, Op: op, Value: value}
+
+	if !isSearchField(field) {
+		return SearchTerm{}, token.errorf("unknown field %q, use one of %s", field, strings.Join(searchFields, ", "))
+	}
+	if value == "" {
+		return SearchTerm{}, token.errorf("missing value after %s%s", field, op)
+	}
+	if field != "priority" && field != "due" && op != ":" && op != "=" && op != "!=" {
+		return SearchTerm{}, token.errorf("%s cannot be compared with %s", field, op)
+	}
+
+	switch field {
+	case "priority":
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return SearchTerm{}, token.errorf("priority must be a number")
+		}
+		term.number = number
+	case "due":
+		if value == "none" && (op == ":" || op == "=" || op == "!=") {
+			break
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return SearchTerm{}, token.errorf("due must be a date like 2006-01-02 or none")
+		}
+	case "is":
+		term.Value = strings.ToLower(value)
+		if term.Value != "archived" && term.Value != "overdue" {
+			return SearchTerm{}, token.errorf("is must be archived or overdue")
+		}
+	}
+	return term, nil
+}
+
+// quoted reads the double-quoted string starting at runes[start], returning its content and the index after the
+// closing quote.
+func quoted(runes []rune, start int) (string, int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '"' {
+			return string(runes[start+1 : i]), i + 1, nil
+		}
+	}
+	return "", 0, &ParseError{Message: "unterminated quote", Column: start + 1, Token: string(runes[start:])}
+}
+
+func compareOp(cmp int, op string) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return cmp == 0
+}
+
+func joinNodes(operator string, nodes []SearchNode) string {
+	parts := []string{operator}
+	for _, node := range nodes {
+		parts = append(parts, node.String())
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
@@ -0,0 +1,141 @@
+/** 
+ * Package model provides the models of sprints, the time-boxed iterations tasks are planned into, and their burndown.
+ * 
+ * Types:
+ * 
+ * - SprintStatus: Type representing the state of a sprint: planned, active or closed.
+ * 
+ * Constants:
+ * 
+ * - MaxSprintDays: Maximum number of days a sprint may span, its start and end day included.
+ * 
+ * Structs:
+ * 
+ * - Sprint: Struct representing a sprint. Tasks are planned into sprints through Task.SprintID.
+ *   Fields:
+ *   - ID: Unique identifier for the sprint.
+ *     Type: int
+ *   - Name: Name of the sprint.
+ *     Type: string
+ *   - Goal: Optional goal of the sprint.
+ *     Type: string
+ *   - UserID: ID of the user who created the sprint.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the sprint belongs to.
+ *     Type: int
+ *   - StartDate: First day of the sprint.
+ *     Type: Deadline
+ *   - EndDate: Last day of the sprint.
+ *     Type: Deadline
+ *   - Status: State of the sprint.
+ *     Type: SprintStatus
+ *   - StartedAt: Time the sprint was started, nil while it is planned.
+ *     Type: *time.Time
+ *   - ClosedAt: Time the sprint was closed, nil until then.
+ *     Type: *time.Time
+ *   - CarriedOver: IDs of the unfinished tasks moved out of the sprint when it was closed.
+ *     They still count as remaining work in its burndown.
+ *     Type: []int
+ *   - CreatedAt: Time the sprint was created.
+ *     Type: time.Time
+ * 
+ * - BurndownPoint: Struct representing the remaining work of a sprint at the end of one of its days.
+ *   Fields:
+ *   - Date: Day, as YYYY-MM-DD.
+ *     Type: string
+ *   - RemainingTasks: Tasks not completed at the end of the day, or now for the current day. Nil for days that have not started yet.
+ *     Type: *int
+ *   - RemainingPoints: Story points of these tasks. Nil for days that have not started yet.
+ *     Type: *int
+ *   - IdealTasks: Tasks that would remain if the work were burned down evenly over the sprint.
+ *     Type: float64
+ *   - IdealPoints: Story points that would remain if the work were burned down evenly over the sprint.
+ *     Type: float64
+ * 
+ * - Burndown: Struct representing the burndown chart data of a sprint.
+ *   Fields:
+ *   - Sprint: The sprint.
+ *     Type: Sprint
+ *   - TotalTasks: Number of tasks in the sprint, including the carried over ones.
+ *     Type: int
+ *   - TotalPoints: Story points of these tasks.
+ *     Type: int
+ *   - Points: One point per day of the sprint, in order.
+ *     Type: []BurndownPoint
+ * 
+ * - SprintRequest: Struct representing the body of a request creating or updating a sprint.
+ * - CloseSprintRequest: Struct representing the body of a request closing a sprint.
+ *   NextSprintID names the sprint unfinished tasks are carried over to, 0 returning them to the backlog.
+ * 
+ * Errors:
+ * 
+ * - ErrSprintNotFound: Returned when the sprint does not exist or belongs to another workspace.
+ * - ErrInvalidSprint: Returned when a sprint is saved without a name, or without dates, ending before it starts or spanning more than MaxSprintDays days.
+ * - ErrSprintState: Returned when an operation does not fit the state of the sprint, such as starting a closed sprint.
+ * - ErrSprintActive: Returned when a sprint is started while another sprint of the workspace is active.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type SprintStatus string
+
+const (
+	SprintPlanned SprintStatus = "planned"
+	SprintActive  SprintStatus = "active"
+	SprintClosed  SprintStatus = "closed"
+)
+
+const MaxSprintDays = 90
+
+var (
+	ErrSprintNotFound = errors.New("sprint not found")
+	ErrInvalidSprint  = errors.New("sprint needs a name and an end date on or after its start date")
+	ErrSprintState    = errors.New("operation not allowed in the current sprint state")
+	ErrSprintActive   = errors.New("another sprint of the workspace is active")
+)
+
+type Sprint struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Goal        string       `json:"goal,omitempty"`
+	UserID      int          `json:"user_id"`
+	WorkspaceID int          `json:"workspace_id,omitempty"`
+	StartDate   Deadline     `json:"start_date"`
+	EndDate     Deadline     `json:"end_date"`
+	Status      SprintStatus `json:"status"`
+	StartedAt   *time.Time   `json:"started_at,omitempty"`
+	ClosedAt    *time.Time   `json:"closed_at,omitempty"`
+	CarriedOver []int        `json:"carried_over,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type BurndownPoint struct {
+	Date            string  `json:"date"`
+	RemainingTasks  *int    `json:"remaining_tasks"`
+	RemainingPoints *int    `json:"remaining_points"`
+	IdealTasks      float64 `json:"ideal_tasks"`
+	IdealPoints     float64 `json:"ideal_points"`
+}
+
+type Burndown struct {
+	Sprint      Sprint          `json:"sprint"`
+	TotalTasks  int             `json:"total_tasks"`
+	TotalPoints int             `json:"total_points"`
+	Points      []BurndownPoint `json:"points"`
+}
+
+type SprintRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Goal      string   `json:"goal"`
+	StartDate Deadline `json:"start_date"`
+	EndDate   Deadline `json:"end_date"`
+}
+
+type CloseSprintRequest struct {
+	NextSprintID int `json:"next_sprint_id"`
+}
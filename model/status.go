@@ -0,0 +1,207 @@
+/** 
+ * Package model provides the task status workflow.
+ * 
+ * Types:
+ * 
+ * - TaskStatus: Name of a task status. The built-in statuses are StatusTodo, StatusInProgress, StatusReview and StatusCompleted.
+ * 
+ * - Workflow: Map of every status to the statuses a task may move to from it.
+ *   Methods:
+ *   - Has: Reports whether the status is part of the workflow.
+ *   - Statuses: Returns the statuses of the workflow, built-in statuses first and custom statuses by name.
+ *   - CanTransition: Reports whether a task may move from one status to another. Staying on the same status is always allowed,
+ *     and a task whose status is not part of the workflow, e.g. one stored before the workflow existed, may move to any status.
+ *   - Validate: Returns ErrUnknownStatus or ErrInvalidTransition wrapped with the statuses involved when a move is not allowed.
+ * 
+ * Structs:
+ * 
+ * - CustomStatus: Struct representing a status configured by a user on top of the built-in workflow.
+ *   Fields:
+ *   - ID: Unique identifier for the custom status.
+ *     Type: int
+ *   - UserID: ID of the user who owns the custom status.
+ *     Type: int
+ *   - Name: Name of the status.
+ *     Type: TaskStatus
+ *   - From: Statuses a task may move to this status from.
+ *     Type: []TaskStatus
+ *   - To: Statuses a task may move to from this status.
+ *     Type: []TaskStatus
+ * 
+ * - StatusTransition: Struct representing a recorded status change of a task.
+ *   Fields:
+ *   - ID: Unique identifier for the transition.
+ *     Type: int
+ *   - TaskID: ID of the task whose status changed.
+ *     Type: int
+ *   - From: Status before the change.
+ *     Type: TaskStatus
+ *   - To: Status after the change.
+ *     Type: TaskStatus
+ *   - ChangedBy: Email address of the user who changed the status.
+ *     Type: string
+ *   - ChangedAt: Timestamp indicating when the status changed.
+ *     Type: time.Time
+ * 
+ * - StatusList: Struct representing the workflow of a user as returned by the API.
+ *   Fields:
+ *   - Statuses: Every status of the workflow, built-in statuses first.
+ *     Type: []TaskStatus
+ *   - Transitions: Statuses a task may move to from each status.
+ *     Type: Workflow
+ *   - Custom: Custom statuses configured by the user.
+ *     Type: []CustomStatus
+ * 
+ * - TransitionRequest: Struct representing the body of a status transition request.
+ *   Fields:
+ *   - Status: Status the task should move to.
+ *     Type: TaskStatus
+ * 
+ * Functions:
+ * 
+ * - DefaultWorkflow: Function to create the built-in workflow, Todo → In Progress → Review → Completed,
+ *   where work can be sent back a step and completed tasks can be reopened.
+ * 
+ * - NewWorkflow: Function to create the built-in workflow extended with a user's custom statuses.
+ *   Transitions to or from statuses that are not part of the workflow are ignored.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "Todo"
+	StatusInProgress TaskStatus = "In Progress"
+	StatusReview     TaskStatus = "Review"
+	StatusCompleted  TaskStatus = "Completed"
+)
+
+var (
+	ErrUnknownStatus     = errors.New("unknown status")
+	ErrInvalidTransition = errors.New("status transition not allowed")
+)
+
+var builtinStatuses = []TaskStatus{StatusTodo, StatusInProgress, StatusReview, StatusCompleted}
+
+type Workflow map[TaskStatus][]TaskStatus
+
+type CustomStatus struct {
+	ID     int          `json:"id"`
+	UserID int          `json:"user_id"`
+	Name   TaskStatus   `json:"name" binding:"required"`
+	From   []TaskStatus `json:"from"`
+	To     []TaskStatus `json:"to"`
+}
+
+type StatusTransition struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"task_id"`
+	From      TaskStatus `json:"from"`
+	To        TaskStatus `json:"to"`
+	ChangedBy string     `json:"changed_by"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+type StatusList struct {
+	Statuses    []TaskStatus   `json:"statuses"`
+	Transitions Workflow       `json:"transitions"`
+	Custom      []CustomStatus `json:"custom"`
+}
+
+type TransitionRequest struct {
+	Status TaskStatus `json:"status" binding:"required"`
+}
+
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		StatusTodo:       {StatusInProgress},
+		StatusInProgress: {StatusTodo, StatusReview},
+		StatusReview:     {StatusInProgress, StatusCompleted},
+		StatusCompleted:  {StatusTodo, StatusInProgress},
+	}
+}
+
+func (w Workflow) Has(status TaskStatus) bool {
+	_, ok := w[status]
+	return ok
+}
+
+func (w Workflow) Statuses() []TaskStatus {
+	statuses := append([]TaskStatus(nil), builtinStatuses...)
+
+	var custom []TaskStatus
+	for status := range w {
+		if !isBuiltin(status) {
+			custom = append(custom, status)
+		}
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i] < custom[j] })
+
+	return append(statuses, custom...)
+}
+
+func (w Workflow) CanTransition(from, to TaskStatus) bool {
+	if from == to {
+		return true
+	}
+	if !w.Has(from) {
+		return w.Has(to)
+	}
+
+	for _, next := range w[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Workflow) Validate(from, to TaskStatus) error {
+	if !w.Has(to) && from != to {
+		return fmt.Errorf("%w: %q", ErrUnknownStatus, to)
+	}
+	if !w.CanTransition(from, to) {
+		return fmt.Errorf("%w: %q to %q", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+func NewWorkflow(custom ...CustomStatus) Workflow {
+	w := DefaultWorkflow()
+	for _, status := range custom {
+		if !w.Has(status.Name) {
+			w[status.Name] = nil
+		}
+	}
+
+	for _, status := range custom {
+		for _, next := range status.To {
+			if w.Has(next) {
+				w[status.Name] = append(w[status.Name], next)
+			}
+		}
+		for _, from := range status.From {
+			if w.Has(from) {
+				w[from] = append(w[from], status.Name)
+			}
+		}
+	}
+	return w
+}
+
+func isBuiltin(status TaskStatus) bool {
+	for _, builtin := range builtinStatuses {
+		if status == builtin {
+			return true
+		}
+	}
+	return false
+}
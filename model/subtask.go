@@ -0,0 +1,129 @@
+/** 
+ * Package model provides the models used to organise tasks into hierarchies with checklists.
+ * 
+ * Structs:
+ * 
+ * - ChecklistItem: Struct representing a lightweight item of a task's checklist.
+ *   Fields:
+ *   - ID: Identifier of the item, unique within its task.
+ *     Type: int
+ *   - Title: Title of the item.
+ *     Type: string
+ *   - Done: True when the item is checked.
+ *     Type: bool
+ * 
+ * - ChecklistOrder: Struct representing the body of a checklist reorder request.
+ *   Fields:
+ *   - IDs: IDs of every item of the checklist in their new order.
+ *     Type: []int
+ * 
+ * - Progress: Struct representing how much of a task is done, counting its direct subtasks and its checklist items.
+ *   Fields:
+ *   - Done: Number of completed subtasks and checked items.
+ *     Type: int
+ *   - Total: Number of subtasks and items.
+ *     Type: int
+ *   - Percent: Done as a percentage of Total, 0 when the task has neither subtasks nor items.
+ *     Type: int
+ * 
+ * - TaskNode: Struct representing a task together with its subtasks.
+ *   Fields:
+ *   - Task: The task.
+ *     Type: *Task
+ *   - Children: Subtasks of the task.
+ *     Type: []*TaskNode
+ *   - Progress: Progress of the task.
+ *     Type: Progress
+ * 
+ * Errors:
+ * 
+ * - ErrInvalidParent: Returned when a parent task does not exist or would make a task its own ancestor.
+ * - ErrChecklistItemNotFound: Returned when a checklist item does not exist.
+ * - ErrInvalidChecklistOrder: Returned when a reorder request does not list every item exactly once.
+ * 
+ * Functions:
+ * 
+ * - ComputeProgress: Function to compute the progress of a task from its direct subtasks and its checklist.
+ * 
+ * - BuildTaskTree: Function to arrange tasks into trees. Tasks whose parent is not part of the list are returned as roots.
+ */
+
+package model
+
+import "errors"
+
+var (
+	ErrInvalidParent         = errors.New("invalid parent task")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrInvalidChecklistOrder = errors.New("checklist order must list every item exactly once")
+)
+
+type ChecklistItem struct {
+	ID    int    `json:"id"`
+	Title string `json:"title" binding:"required"`
+	Done  bool   `json:"done"`
+}
+
+type ChecklistOrder struct {
+	IDs []int `json:"ids" binding:"required"`
+}
+
+type Progress struct {
+	Done    int `json:"done"`
+	Total   int `json:"total"`
+	Percent int `json:"percent"`
+}
+
+type TaskNode struct {
+	Task     *Task       `json:"task"`
+	Children []*TaskNode `json:"children"`
+	Progress Progress    `json:"progress"`
+}
+
+func ComputeProgress(task Task, children []Task) Progress {
+	var progress Progress
+	for _, child := range children {
+		progress.Total++
+		if child.Status == StatusCompleted {
+			progress.Done++
+		}
+	}
+	for _, item := range task.Checklist {
+		progress.Total++
+		if item.Done {
+			progress.Done++
+		}
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = progress.Done * 100 / progress.Total
+	}
+	return progress
+}
+
+func BuildTaskTree(tasks []*Task) []*TaskNode {
+	nodes := make(map[int]*TaskNode, len(tasks))
+	for _, task := range tasks {
+		nodes[task.ID] = &TaskNode{Task: task}
+	}
+
+	var roots []*TaskNode
+	for _, task := range tasks {
+		node := nodes[task.ID]
+		parent, ok := nodes[task.ParentID]
+		if task.ParentID == 0 || !ok || parent == node {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	for _, node := range nodes {
+		children := make([]Task, 0, len(node.Children))
+		for _, child := range node.Children {
+			children = append(children, *child.Task)
+		}
+		node.Progress = ComputeProgress(*node.Task, children)
+	}
+	return roots
+}
@@ -0,0 +1,96 @@
+/** 
+ * Package model provides the models of user-defined task tags.
+ * 
+ * Structs:
+ * 
+ * - Tag: Struct representing a tag a user can put on any number of tasks.
+ *   Fields:
+ *   - ID: Unique identifier for the tag.
+ *     Type: int
+ *   - UserID: ID of the user who owns the tag.
+ *     Type: int
+ *   - Name: Name of the tag, unique per user regardless of case.
+ *     Type: string
+ *   - Color: Color of the tag as a hex code, e.g. "#22c55e".
+ *     Type: string
+ * 
+ * - TaskTag: Struct representing the assignment of a tag to a task.
+ *   Fields:
+ *   - TaskID: ID of the tagged task.
+ *     Type: int
+ *   - TagID: ID of the tag.
+ *     Type: int
+ * 
+ * - TagList: Struct representing the tags of a user together with their assignments.
+ *   Fields:
+ *   - Tags: Tags of the user.
+ *     Type: []Tag
+ *   - Assignments: Assignments of these tags to tasks.
+ *     Type: []TaskTag
+ *   Methods:
+ *   - ByTask: Returns the tags of every tagged task, keyed by task ID.
+ * 
+ * Errors:
+ * 
+ * - ErrTagNotFound: Returned when a tag does not exist or belongs to another user.
+ * - ErrDuplicateTag: Returned when the user already has a tag with the same name.
+ * - ErrInvalidTagColor: Returned when a color is not a hex code.
+ * 
+ * Functions:
+ * 
+ * - ValidTagColor: Function to check whether a color is a "#rrggbb" hex code.
+ */
+
+package model
+
+import (
+	"errors"
+	"regexp"
+)
+
+// DefaultTagColor is used for tags created without a color.
+const DefaultTagColor = "#6b7280"
+
+var (
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrDuplicateTag    = errors.New("tag already exists")
+	ErrInvalidTagColor = errors.New("tag color must be a hex code like #22c55e")
+)
+
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type Tag struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name" binding:"required"`
+	Color  string `json:"color"`
+}
+
+type TaskTag struct {
+	TaskID int `json:"task_id"`
+	TagID  int `json:"tag_id"`
+}
+
+type TagList struct {
+	Tags        []Tag     `json:"tags"`
+	Assignments []TaskTag `json:"assignments"`
+}
+
+func (l TagList) ByTask() map[int][]Tag {
+	tags := make(map[int]Tag, len(l.Tags))
+	for _, tag := range l.Tags {
+		tags[tag.ID] = tag
+	}
+
+	byTask := map[int][]Tag{}
+	for _, assignment := range l.Assignments {
+		if tag, ok := tags[assignment.TagID]; ok {
+			byTask[assignment.TaskID] = append(byTask[assignment.TaskID], tag)
+		}
+	}
+	return byTask
+}
+
+func ValidTagColor(color string) bool {
+	return tagColorPattern.MatchString(color)
+}
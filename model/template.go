@@ -0,0 +1,82 @@
+/** 
+ * Package model provides the models of task templates, reusable bundles of tasks created together.
+ * 
+ * Structs:
+ * 
+ * - TemplateTask: Struct representing a task of a template.
+ *   Fields:
+ *   - Title: Title of the task.
+ *     Type: string
+ *   - Priority: Priority level of the task.
+ *     Type: int
+ *   - CategoryID: ID of the category the task is created in, 0 for none.
+ *     Type: int
+ *   - DeadlineOffset: Number of days after the start date the task is due, nil for a task without a deadline.
+ *     Type: *int
+ * 
+ * - Template: Struct representing a task template.
+ *   Fields:
+ *   - ID: Unique identifier for the template.
+ *     Type: int
+ *   - Name: Name of the template.
+ *     Type: string
+ *   - Description: Optional description of the template.
+ *     Type: string
+ *   - UserID: ID of the user who created the template.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the template belongs to.
+ *     Type: int
+ *   - Tasks: Tasks created when the template is instantiated, in order.
+ *     Type: []TemplateTask
+ *   - CreatedAt: Time the template was created.
+ *     Type: time.Time
+ * 
+ * - TemplateRequest: Struct representing the body of a request creating or updating a template.
+ * - InstantiateRequest: Struct representing the body of a request instantiating a template.
+ *   StartDate is the date, or instant, the deadline offsets of the tasks are counted from.
+ * 
+ * Errors:
+ * 
+ * - ErrTemplateNotFound: Returned when the template does not exist or belongs to another workspace.
+ * - ErrInvalidTemplate: Returned when a template is saved without a name or tasks, with a task without a title, a negative
+ *   deadline offset or a category of another workspace, or instantiated without a start date.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrInvalidTemplate  = errors.New("template needs a name and tasks with a title, a category of the workspace and a deadline offset of 0 days or more")
+)
+
+type TemplateTask struct {
+	Title          string `json:"title"`
+	Priority       int    `json:"priority"`
+	CategoryID     int    `json:"category_id,omitempty"`
+	DeadlineOffset *int   `json:"deadline_offset,omitempty"`
+}
+
+type Template struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	UserID      int            `json:"user_id"`
+	WorkspaceID int            `json:"workspace_id,omitempty"`
+	Tasks       []TemplateTask `json:"tasks"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type TemplateRequest struct {
+	Name        string         `json:"name" binding:"required"`
+	Description string         `json:"description"`
+	Tasks       []TemplateTask `json:"tasks"`
+}
+
+type InstantiateRequest struct {
+	StartDate Deadline `json:"start_date"`
+}
@@ -0,0 +1,151 @@
+/** 
+ * Package model provides the models of time tracking.
+ * 
+ * Structs:
+ * 
+ * - TimeEntry: Struct representing time a user spent on a task, recorded with a timer or entered by hand.
+ *   Fields:
+ *   - ID: Unique identifier for the entry.
+ *     Type: int
+ *   - TaskID: ID of the task the time was spent on.
+ *     Type: int
+ *   - UserID: ID of the user who spent the time.
+ *     Type: int
+ *   - Start: Time the work started.
+ *     Type: time.Time
+ *   - End: Time the work stopped, nil while the timer is running.
+ *     Type: *time.Time
+ *   - Note: Optional description of the work.
+ *     Type: string
+ *   - Manual: Whether the entry was entered by hand rather than recorded with a timer.
+ *     Type: bool
+ *   Methods:
+ *   - Running: Reports whether the timer of the entry is still running.
+ *   - Duration: Returns the length of the entry, counting a running timer up to the given time.
+ * 
+ * - TimeEntryRequest: Struct representing the body of a request adding a manual time entry.
+ *   Fields:
+ *   - Start: Time the work started.
+ *     Type: time.Time
+ *   - End: Time the work stopped.
+ *     Type: time.Time
+ *   - Note: Optional description of the work.
+ *     Type: string
+ * 
+ * - TaskTime: Struct representing the time spent on a task by every user.
+ *   Fields:
+ *   - TaskID: ID of the task.
+ *     Type: int
+ *   - Entries: Time entries of the task, oldest first.
+ *     Type: []TimeEntry
+ *   - TotalSeconds: Sum of the entries, running timers counted up to now.
+ *     Type: int64
+ * 
+ * - TimeTotal: Struct representing the time spent on one task, one category or one day of a timesheet.
+ *   Only the field identifying the group is set.
+ *   Fields:
+ *   - TaskID: ID of the task.
+ *     Type: int
+ *   - CategoryID: ID of the category.
+ *     Type: int
+ *   - Date: Day in the user's time zone, as YYYY-MM-DD.
+ *     Type: string
+ *   - Seconds: Time spent.
+ *     Type: int64
+ * 
+ * - Timesheet: Struct representing the time a user spent between two days, both included.
+ *   Fields:
+ *   - UserID: ID of the user.
+ *     Type: int
+ *   - From: First day of the report, as YYYY-MM-DD.
+ *     Type: string
+ *   - To: Last day of the report, as YYYY-MM-DD.
+ *     Type: string
+ *   - TimeZone: IANA time zone of the user the days are counted in.
+ *     Type: string
+ *   - Entries: Entries started during these days, oldest first.
+ *     Type: []TimeEntry
+ *   - ByTask: Totals per task, in task ID order.
+ *     Type: []TimeTotal
+ *   - ByCategory: Totals per category, in category ID order.
+ *     Type: []TimeTotal
+ *   - ByDay: Totals per day an entry started on, in date order.
+ *     Type: []TimeTotal
+ *   - TotalSeconds: Sum of the entries.
+ *     Type: int64
+ * 
+ * Errors:
+ * 
+ * - ErrTimerRunning: Returned when a user starts a timer while another one is running.
+ * - ErrNoRunningTimer: Returned when a user stops a timer while none is running.
+ * - ErrInvalidTimeEntry: Returned when a manual entry does not end after it starts or ends in the future.
+ * - ErrTimeEntryNotFound: Returned when a time entry does not exist or belongs to another user.
+ * - ErrInvalidTimeRange: Returned when the days of a timesheet are not YYYY-MM-DD dates or are in the wrong order.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTimerRunning      = errors.New("a timer is already running")
+	ErrNoRunningTimer    = errors.New("no timer is running")
+	ErrInvalidTimeEntry  = errors.New("a time entry must end after it starts and not in the future")
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+	ErrInvalidTimeRange  = errors.New("time range must be two YYYY-MM-DD dates, the first not after the second")
+)
+
+type TimeEntry struct {
+	ID     int        `json:"id"`
+	TaskID int        `json:"task_id"`
+	UserID int        `json:"user_id"`
+	Start  time.Time  `json:"start"`
+	End    *time.Time `json:"end,omitempty"`
+	Note   string     `json:"note,omitempty"`
+	Manual bool       `json:"manual"`
+}
+
+func (e TimeEntry) Running() bool {
+	return e.End == nil
+}
+
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	if e.End != nil {
+		return e.End.Sub(e.Start)
+	}
+	return now.Sub(e.Start)
+}
+
+type TimeEntryRequest struct {
+	Start time.Time `json:"start" binding:"required"`
+	End   time.Time `json:"end" binding:"required"`
+	Note  string    `json:"note"`
+}
+
+type TaskTime struct {
+	TaskID       int         `json:"task_id"`
+	Entries      []TimeEntry `json:"entries"`
+	TotalSeconds int64       `json:"total_seconds"`
+}
+
+type TimeTotal struct {
+	TaskID     int    `json:"task_id,omitempty"`
+	CategoryID int    `json:"category_id,omitempty"`
+	Date       string `json:"date,omitempty"`
+	Seconds    int64  `json:"seconds"`
+}
+
+type Timesheet struct {
+	UserID       int         `json:"user_id"`
+	From         string      `json:"from"`
+	To           string      `json:"to"`
+	TimeZone     string      `json:"time_zone"`
+	Entries      []TimeEntry `json:"entries"`
+	ByTask       []TimeTotal `json:"by_task"`
+	ByCategory   []TimeTotal `json:"by_category"`
+	ByDay        []TimeTotal `json:"by_day"`
+	TotalSeconds int64       `json:"total_seconds"`
+}
@@ -0,0 +1,68 @@
+/** 
+ * Package model provides the models of the trash, where deleted tasks and categories are kept until they are restored or purged.
+ * 
+ * Types:
+ * 
+ * - TrashKind: Kind of record kept in the trash: a task, with its subtasks, or a category.
+ * 
+ * Structs:
+ * 
+ * - TrashItem: Struct representing a deleted task or category.
+ *   Fields:
+ *   - ID: Unique identifier for the trash item.
+ *     Type: int
+ *   - Kind: Kind of the deleted record.
+ *     Type: TrashKind
+ *   - Title: Title of the deleted task or name of the deleted category.
+ *     Type: string
+ *   - UserID: ID of the user who owns the deleted task or category.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace the deleted record belonged to.
+ *     Type: int
+ *   - DeletedAt: Time the record was moved to the trash.
+ *     Type: time.Time
+ *   - PurgeAt: Time after which the record is purged for good, set when the item is listed.
+ *     Type: time.Time
+ *   - Tasks: The deleted task followed by the subtasks deleted with it. Empty for categories.
+ *     Type: []Task
+ *   - Category: The deleted category, nil for tasks.
+ *     Type: *Category
+ * 
+ * Errors:
+ * 
+ * - ErrTrashItemNotFound: Returned when the trash item does not exist or belongs to another workspace.
+ * - ErrRestoreConflict: Returned when a category cannot be restored because its ID has been taken by another category.
+ * - ErrNotArchivable: Returned when a task that is not completed is archived.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type TrashKind string
+
+const (
+	TrashTask     TrashKind = "task"
+	TrashCategory TrashKind = "category"
+)
+
+var (
+	ErrTrashItemNotFound = errors.New("trash item not found")
+	ErrRestoreConflict   = errors.New("a record with the same ID already exists")
+	ErrNotArchivable     = errors.New("only completed tasks can be archived")
+)
+
+type TrashItem struct {
+	ID          int       `json:"id"`
+	Kind        TrashKind `json:"kind"`
+	Title       string    `json:"title"`
+	UserID      int       `json:"user_id"`
+	WorkspaceID int       `json:"workspace_id,omitempty"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	PurgeAt     time.Time `json:"purge_at"`
+	Tasks       []Task    `json:"tasks,omitempty"`
+	Category    *Category `json:"category,omitempty"`
+}
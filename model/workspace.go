@@ -0,0 +1,199 @@
+/** 
+ * Package model provides the models of workspaces, shared boards owning categories and tasks.
+ * 
+ * Structs:
+ * 
+ * - Workspace: Struct representing a workspace.
+ *   Fields:
+ *   - ID: Unique identifier for the workspace. The default workspace, which every user can see, has ID 0 and is not stored.
+ *     Type: int
+ *   - Name: Name of the workspace.
+ *     Type: string
+ *   - OwnerID: ID of the user who created the workspace.
+ *     Type: int
+ *   - CreatedAt: Time the workspace was created.
+ *     Type: time.Time
+ * 
+ * - WorkspaceMember: Struct representing the membership of a user in a workspace.
+ *   Fields:
+ *   - WorkspaceID: ID of the workspace.
+ *     Type: int
+ *   - UserID: ID of the member.
+ *     Type: int
+ *   - Role: Role of the member within the workspace.
+ *     Type: WorkspaceRole
+ *   - JoinedAt: Time the user joined the workspace.
+ *     Type: time.Time
+ * 
+ * - Membership: Struct representing a workspace as seen by one of its members.
+ *   Fields:
+ *   - Workspace: The workspace.
+ *     Type: Workspace
+ *   - Role: Role of the member within the workspace.
+ *     Type: WorkspaceRole
+ *   - Current: Whether the member selected the workspace to work in.
+ *     Type: bool
+ * 
+ * - MemberProfile: Struct representing a member of a workspace with the public part of the user.
+ *   Fields:
+ *   - UserProfile: Profile of the member.
+ *     Type: UserProfile
+ *   - Role: Role of the member within the workspace.
+ *     Type: WorkspaceRole
+ *   - JoinedAt: Time the user joined the workspace.
+ *     Type: time.Time
+ * 
+ * - Invitation: Struct representing an invitation to join a workspace, sent to an email address.
+ *   Fields:
+ *   - ID: Unique identifier for the invitation.
+ *     Type: int
+ *   - WorkspaceID: ID of the workspace.
+ *     Type: int
+ *   - WorkspaceName: Name of the workspace, filled in when invitations are listed.
+ *     Type: string
+ *   - Email: Email address of the invited user.
+ *     Type: string
+ *   - Role: Role the user gets on accepting.
+ *     Type: WorkspaceRole
+ *   - InvitedBy: ID of the member who sent the invitation.
+ *     Type: int
+ *   - Status: Whether the invitation is pending, accepted or declined.
+ *     Type: InvitationStatus
+ *   - CreatedAt: Time the invitation was sent.
+ *     Type: time.Time
+ *   - RespondedAt: Time the invitation was accepted or declined, nil while pending.
+ *     Type: *time.Time
+ * 
+ * - WorkspaceRequest: Struct representing the body of a request creating a workspace.
+ * - InvitationRequest: Struct representing the body of a request inviting a user by email, with an optional role (member by default).
+ * - RoleRequest: Struct representing the body of a request changing the role of a member.
+ * 
+ * Types:
+ * 
+ * - WorkspaceRole: Role of a member within a workspace. The owner manages roles, admins invite and remove members,
+ *   members work on the categories and tasks and viewers can only read them.
+ *   Methods:
+ *   - Valid: Method reporting whether the role is one of the known roles.
+ *   - CanManageMembers: Method reporting whether the role may invite and remove members.
+ *   - CanWrite: Method reporting whether the role may change the categories and tasks of the workspace.
+ * 
+ * - InvitationStatus: State of an invitation.
+ * 
+ * Errors:
+ * 
+ * - ErrWorkspaceNotFound: Returned when the workspace does not exist or the user is not a member of it.
+ * - ErrWorkspaceForbidden: Returned when the role of the user within the workspace does not allow the action.
+ * - ErrInvalidWorkspace: Returned when a workspace is created without a name.
+ * - ErrInvalidWorkspaceRole: Returned when a role other than admin, member or viewer is given to a member.
+ * - ErrInvitationNotFound: Returned when the invitation does not exist, is not addressed to the user or was already answered.
+ * - ErrAlreadyMember: Returned when the invited user is already a member of the workspace.
+ * - ErrMemberNotFound: Returned when the user is not a member of the workspace.
+ * - ErrWorkspaceOwner: Returned when the owner of a workspace would be removed or given another role.
+ * - ErrNoWorkspaceSuccessor: Returned when a user who owns a workspace with other members but no other admin is erased.
+ */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type WorkspaceRole string
+
+const (
+	RoleOwner  WorkspaceRole = "owner"
+	RoleAdmin  WorkspaceRole = "admin"
+	RoleMember WorkspaceRole = "member"
+	RoleViewer WorkspaceRole = "viewer"
+)
+
+func (r WorkspaceRole) Valid() bool {
+	switch r {
+	case RoleOwner, RoleAdmin, RoleMember, RoleViewer:
+		return true
+	}
+	return false
+}
+
+func (r WorkspaceRole) CanManageMembers() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+func (r WorkspaceRole) CanWrite() bool {
+	return r != RoleViewer
+}
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+)
+
+// DefaultWorkspaceName is the name the default workspace, ID 0, is listed under.
+const DefaultWorkspaceName = "Default"
+
+var (
+	ErrWorkspaceNotFound    = errors.New("workspace not found")
+	ErrWorkspaceForbidden   = errors.New("your role in the workspace does not allow this")
+	ErrInvalidWorkspace     = errors.New("workspace name is required")
+	ErrInvalidWorkspaceRole = errors.New("workspace role must be admin, member or viewer")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrAlreadyMember        = errors.New("user is already a member of the workspace")
+	ErrMemberNotFound       = errors.New("workspace member not found")
+	ErrWorkspaceOwner       = errors.New("the owner of a workspace cannot be removed or given another role")
+	ErrNoWorkspaceSuccessor = errors.New("the workspace has no other admin to take over ownership")
+)
+
+type Workspace struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   int       `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int           `json:"workspace_id"`
+	UserID      int           `json:"user_id"`
+	Role        WorkspaceRole `json:"role"`
+	JoinedAt    time.Time     `json:"joined_at"`
+}
+
+type Membership struct {
+	Workspace Workspace     `json:"workspace"`
+	Role      WorkspaceRole `json:"role"`
+	Current   bool          `json:"current"`
+}
+
+type MemberProfile struct {
+	UserProfile
+	Role     WorkspaceRole `json:"role"`
+	JoinedAt time.Time     `json:"joined_at"`
+}
+
+type Invitation struct {
+	ID            int              `json:"id"`
+	WorkspaceID   int              `json:"workspace_id"`
+	WorkspaceName string           `json:"workspace_name,omitempty"`
+	Email         string           `json:"email"`
+	Role          WorkspaceRole    `json:"role"`
+	InvitedBy     int              `json:"invited_by"`
+	Status        InvitationStatus `json:"status"`
+	CreatedAt     time.Time        `json:"created_at"`
+	RespondedAt   *time.Time       `json:"responded_at,omitempty"`
+}
+
+type WorkspaceRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type InvitationRequest struct {
+	Email string        `json:"email" binding:"required"`
+	Role  WorkspaceRole `json:"role"`
+}
+
+type RoleRequest struct {
+	Role WorkspaceRole `json:"role" binding:"required"`
+}
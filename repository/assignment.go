@@ -0,0 +1,65 @@
+/** 
+ * Package repository provides interfaces and implementations for managing the users assigned to tasks.
+ * 
+ * Interfaces:
+ * 
+ * - AssignmentRepository: Interface defining methods for assignment data manipulation.
+ *   Methods:
+ *   - Assign: Method to assign a user to a task.
+ *   - Unassign: Method to remove a user from a task.
+ *   - GetList: Method to retrieve the assignments of a task, a user or both.
+ *   - GetAssignedTasks: Method to retrieve the tasks of a workspace a user is assigned to.
+ * 
+ * Structs:
+ * 
+ * - assignmentRepository: Struct implementing the AssignmentRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewAssignmentRepo: Function to create a new instance of assignmentRepository.
+ *   - Assign: Method to assign an existing user to an existing task using file-based database operations.
+ *   - Unassign: Method to remove a user from a task using file-based database operations.
+ *   - GetList: Method to retrieve assignments, a zero ID matching everything, using file-based database operations.
+ *   - GetAssignedTasks: Method to retrieve the tasks of a workspace a user is assigned to, in ID order, using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+	"time"
+)
+
+type AssignmentRepository interface {
+	Assign(taskID, userID int) error
+	Unassign(taskID, userID int) error
+	GetList(taskID, userID int) ([]model.Assignment, error)
+	GetAssignedTasks(workspaceID, userID int) ([]model.Task, error)
+}
+
+type assignmentRepository struct {
+	filebased *filebased.Data
+}
+
+func NewAssignmentRepo(filebasedDb *filebased.Data) *assignmentRepository {
+	return &assignmentRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (a *assignmentRepository) Assign(taskID, userID int) error {
+	return a.filebased.AssignTask(model.Assignment{TaskID: taskID, UserID: userID, AssignedAt: time.Now()})
+}
+
+func (a *assignmentRepository) Unassign(taskID, userID int) error {
+	return a.filebased.UnassignTask(taskID, userID)
+}
+
+func (a *assignmentRepository) GetList(taskID, userID int) ([]model.Assignment, error) {
+	return a.filebased.GetAssignments(taskID, userID)
+}
+
+func (a *assignmentRepository) GetAssignedTasks(workspaceID, userID int) ([]model.Task, error) {
+	return a.filebased.GetAssignedTasks(workspaceID, userID)
+}
@@ -0,0 +1,85 @@
+/** 
+ * Package repository provides interfaces and implementations for managing the metadata of task attachments.
+ * 
+ * Interfaces:
+ * 
+ * - AttachmentRepository: Interface defining methods for attachment data manipulation.
+ *   Methods:
+ *   - Store: Method to store the metadata of a new attachment.
+ *   - GetByID: Method to retrieve an attachment by its ID.
+ *   - GetList: Method to retrieve the attachments of a task.
+ *   - Delete: Method to delete an attachment.
+ *   - GetOrphanedBlobs: Method to retrieve the blob keys of deleted attachments.
+ *   - ForgetOrphanedBlob: Method to drop a blob key once its content is removed.
+ * 
+ * Structs:
+ * 
+ * - attachmentRepository: Struct implementing the AttachmentRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewAttachmentRepo: Function to create a new instance of attachmentRepository.
+ *   - Store: Method to store the metadata of a new attachment using file-based database operations.
+ *   - GetByID: Method to retrieve an attachment by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the attachments of a task, oldest first, using file-based database operations.
+ *   - Delete: Method to delete an attachment, queueing its blob key as orphaned, using file-based database operations.
+ *   - GetOrphanedBlobs: Method to retrieve the blob keys of deleted attachments and tasks using file-based database operations.
+ *   - ForgetOrphanedBlob: Method to drop an orphaned blob key using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type AttachmentRepository interface {
+	Store(attachment *model.Attachment) error
+	GetByID(id int) (*model.Attachment, error)
+	GetList(taskID int) ([]model.Attachment, error)
+	Delete(id int) error
+	GetOrphanedBlobs() ([]string, error)
+	ForgetOrphanedBlob(key string) error
+}
+
+type attachmentRepository struct {
+	filebased *filebased.Data
+}
+
+func NewAttachmentRepo(filebasedDb *filebased.Data) *attachmentRepository {
+	return &attachmentRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (a *attachmentRepository) Store(attachment *model.Attachment) error {
+	attachment.ID = 0
+	stored, err := a.filebased.StoreAttachment(*attachment)
+	if err != nil {
+		return err
+	}
+
+	*attachment = stored
+	return nil
+}
+
+func (a *attachmentRepository) GetByID(id int) (*model.Attachment, error) {
+	return a.filebased.GetAttachmentByID(id)
+}
+
+func (a *attachmentRepository) GetList(taskID int) ([]model.Attachment, error) {
+	return a.filebased.GetAttachments(taskID)
+}
+
+func (a *attachmentRepository) Delete(id int) error {
+	return a.filebased.DeleteAttachment(id)
+}
+
+func (a *attachmentRepository) GetOrphanedBlobs() ([]string, error) {
+	return a.filebased.GetOrphanedBlobs()
+}
+
+func (a *attachmentRepository) ForgetOrphanedBlob(key string) error {
+	return a.filebased.ForgetOrphanedBlob(key)
+}
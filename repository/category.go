@@ -8,8 +8,10 @@
  *   - Store: Method to store a new category.
  *   - Update: Method to update an existing category.
  *   - Delete: Method to delete a category.
+ *   - Trash: Method to move a category to the trash.
  *   - GetByID: Method to retrieve a category by its ID.
- *   - GetList: Method to retrieve a list of all categories.
+ *   - GetList: Method to retrieve the categories of a workspace.
+ *   - Query: Method to retrieve a filtered, sorted page of the categories of a workspace.
  * 
  * Structs:
  * 
@@ -21,8 +23,10 @@
  *   - Store: Method to store a new category using file-based database operations.
  *   - Update: Method to update an existing category using file-based database operations.
  *   - Delete: Method to delete a category using file-based database operations.
+ *   - Trash: Method to move a category to the trash using file-based database operations.
  *   - GetByID: Method to retrieve a category by its ID using file-based database operations.
- *   - GetList: Method to retrieve a list of all categories using file-based database operations.
+ *   - GetList: Method to retrieve the categories of a workspace using file-based database operations.
+ *   - Query: Method to retrieve a filtered, sorted page of the categories of a workspace using file-based database operations.
  */
 
 package repository
@@ -30,14 +34,17 @@ package repository
 import (
 	"a21hc3NpZ25tZW50/db/filebased"
 	"a21hc3NpZ25tZW50/model"
+	"time"
 )
 
 type CategoryRepository interface {
 	Store(Category *model.Category) error
 	Update(id int, category model.Category) error
 	Delete(id int) error
+	Trash(id int, deletedAt time.Time) (model.TrashItem, error)
 	GetByID(id int) (*model.Category, error)
-	GetList() ([]model.Category, error)
+	GetList(workspaceID int) ([]model.Category, error)
+	Query(workspaceID int, query model.CategoryQuery) (model.CategoryPage, error)
 }
 
 type categoryRepository struct {
@@ -60,10 +67,18 @@ func (c *categoryRepository) Delete(id int) error {
 	return c.filebasedDb.DeleteCategory(id)
 }
 
+func (c *categoryRepository) Trash(id int, deletedAt time.Time) (model.TrashItem, error) {
+	return c.filebasedDb.TrashCategory(id, deletedAt)
+}
+
 func (c *categoryRepository) GetByID(id int) (*model.Category, error) {
 	return c.filebasedDb.GetCategoryByID(id)
 }
 
-func (c *categoryRepository) GetList() ([]model.Category, error) {
-	return c.filebasedDb.GetCategories()
+func (c *categoryRepository) GetList(workspaceID int) ([]model.Category, error) {
+	return c.filebasedDb.GetCategories(workspaceID)
+}
+
+func (c *categoryRepository) Query(workspaceID int, query model.CategoryQuery) (model.CategoryPage, error) {
+	return c.filebasedDb.QueryCategories(workspaceID, query)
 }
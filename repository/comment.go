@@ -0,0 +1,72 @@
+/** 
+ * Package repository provides interfaces and implementations for managing task comments.
+ * 
+ * Interfaces:
+ * 
+ * - CommentRepository: Interface defining methods for comment data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new comment.
+ *   - Update: Method to update an existing comment.
+ *   - GetByID: Method to retrieve a comment by its ID.
+ *   - GetList: Method to retrieve the comments of a task.
+ * 
+ * Structs:
+ * 
+ * - commentRepository: Struct implementing the CommentRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewCommentRepo: Function to create a new instance of commentRepository.
+ *   - Store: Method to store a new comment using file-based database operations.
+ *   - Update: Method to update an existing comment using file-based database operations.
+ *   - GetByID: Method to retrieve a comment by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the comments of a task, oldest first, using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type CommentRepository interface {
+	Store(comment *model.Comment) error
+	Update(comment *model.Comment) error
+	GetByID(id int) (*model.Comment, error)
+	GetList(taskID int) ([]model.Comment, error)
+}
+
+type commentRepository struct {
+	filebased *filebased.Data
+}
+
+func NewCommentRepo(filebasedDb *filebased.Data) *commentRepository {
+	return &commentRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (c *commentRepository) Store(comment *model.Comment) error {
+	comment.ID = 0
+	stored, err := c.filebased.StoreComment(*comment)
+	if err != nil {
+		return err
+	}
+
+	*comment = stored
+	return nil
+}
+
+func (c *commentRepository) Update(comment *model.Comment) error {
+	_, err := c.filebased.StoreComment(*comment)
+	return err
+}
+
+func (c *commentRepository) GetByID(id int) (*model.Comment, error) {
+	return c.filebased.GetCommentByID(id)
+}
+
+func (c *commentRepository) GetList(taskID int) ([]model.Comment, error) {
+	return c.filebased.GetComments(taskID)
+}
@@ -0,0 +1,57 @@
+/** 
+ * Package repository provides interfaces and implementations for managing dependencies between tasks.
+ * 
+ * Interfaces:
+ * 
+ * - DependencyRepository: Interface defining methods for dependency data manipulation.
+ *   Methods:
+ *   - Add: Method to make a task block another one.
+ *   - Remove: Method to remove a dependency.
+ *   - GetList: Method to retrieve every dependency.
+ * 
+ * Structs:
+ * 
+ * - dependencyRepository: Struct implementing the DependencyRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewDependencyRepo: Function to create a new instance of dependencyRepository.
+ *   - Add: Method to store a dependency between two existing tasks using file-based database operations.
+ *   - Remove: Method to remove a dependency using file-based database operations.
+ *   - GetList: Method to retrieve every dependency using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type DependencyRepository interface {
+	Add(blockerID, blockedID int) error
+	Remove(blockerID, blockedID int) error
+	GetList() ([]model.Dependency, error)
+}
+
+type dependencyRepository struct {
+	filebased *filebased.Data
+}
+
+func NewDependencyRepo(filebasedDb *filebased.Data) *dependencyRepository {
+	return &dependencyRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (d *dependencyRepository) Add(blockerID, blockedID int) error {
+	return d.filebased.AddDependency(model.Dependency{BlockerID: blockerID, BlockedID: blockedID})
+}
+
+func (d *dependencyRepository) Remove(blockerID, blockedID int) error {
+	return d.filebased.RemoveDependency(model.Dependency{BlockerID: blockerID, BlockedID: blockedID})
+}
+
+func (d *dependencyRepository) GetList() ([]model.Dependency, error) {
+	return d.filebased.GetDependencies()
+}
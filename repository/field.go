@@ -0,0 +1,64 @@
+/** 
+ * Package repository provides interfaces and implementations for managing custom fields.
+ * 
+ * Interfaces:
+ * 
+ * - FieldRepository: Interface defining methods for custom field data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new or updated custom field.
+ *   - GetByID: Method to retrieve a custom field by its ID.
+ *   - GetList: Method to retrieve the custom fields of a workspace.
+ *   - Delete: Method to delete a custom field.
+ * 
+ * Structs:
+ * 
+ * - fieldRepository: Struct implementing the FieldRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewFieldRepo: Function to create a new instance of fieldRepository.
+ *   - Store: Method to store a custom field using file-based database operations.
+ *   - GetByID: Method to retrieve a custom field by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the custom fields of a workspace using file-based database operations.
+ *   - Delete: Method to delete a custom field using file-based database operations, recording the actor in the history of the tasks that had a value for it.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type FieldRepository interface {
+	Store(field model.CustomField) (model.CustomField, error)
+	GetByID(id int) (*model.CustomField, error)
+	GetList(workspaceID int) ([]model.CustomField, error)
+	Delete(id int, actor string) error
+}
+
+type fieldRepository struct {
+	filebased *filebased.Data
+}
+
+func NewFieldRepo(filebasedDb *filebased.Data) *fieldRepository {
+	return &fieldRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (t *fieldRepository) Store(field model.CustomField) (model.CustomField, error) {
+	return t.filebased.StoreField(field)
+}
+
+func (t *fieldRepository) GetByID(id int) (*model.CustomField, error) {
+	return t.filebased.GetFieldByID(id)
+}
+
+func (t *fieldRepository) GetList(workspaceID int) ([]model.CustomField, error) {
+	return t.filebased.GetFields(workspaceID)
+}
+
+func (t *fieldRepository) Delete(id int, actor string) error {
+	return t.filebased.DeleteField(id, actor)
+}
@@ -0,0 +1,64 @@
+/**
+ * Package repository provides interfaces and implementations for managing saved filters.
+ *
+ * Interfaces:
+ *
+ * - FilterRepository: Interface defining methods for saved filter data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new or updated filter.
+ *   - GetByID: Method to retrieve a filter by its ID.
+ *   - GetList: Method to retrieve the filters a user saved in a workspace.
+ *   - Delete: Method to delete a filter.
+ *
+ * Structs:
+ *
+ * - filterRepository: Struct implementing the FilterRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewFilterRepo: Function to create a new instance of filterRepository.
+ *   - Store: Method to store a filter using file-based database operations.
+ *   - GetByID: Method to retrieve a filter by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the filters of a user in a workspace using file-based database operations.
+ *   - Delete: Method to delete a filter using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type FilterRepository interface {
+	Store(filter model.SavedFilter) (model.SavedFilter, error)
+	GetByID(id int) (*model.SavedFilter, error)
+	GetList(userID, workspaceID int) ([]model.SavedFilter, error)
+	Delete(id int) error
+}
+
+type filterRepository struct {
+	filebased *filebased.Data
+}
+
+func NewFilterRepo(filebasedDb *filebased.Data) *filterRepository {
+	return &filterRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (f *filterRepository) Store(filter model.SavedFilter) (model.SavedFilter, error) {
+	return f.filebased.StoreFilter(filter)
+}
+
+func (f *filterRepository) GetByID(id int) (*model.SavedFilter, error) {
+	return f.filebased.GetFilterByID(id)
+}
+
+func (f *filterRepository) GetList(userID, workspaceID int) ([]model.SavedFilter, error) {
+	return f.filebased.GetFilters(userID, workspaceID)
+}
+
+func (f *filterRepository) Delete(id int) error {
+	return f.filebased.DeleteFilter(id)
+}
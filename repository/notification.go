@@ -0,0 +1,86 @@
+/**
+ * Package repository provides interfaces and implementations for managing watchers and notifications.
+ *
+ * Interfaces:
+ *
+ * - NotificationRepository: Interface defining methods for watch and notification data manipulation.
+ *   Methods:
+ *   - Watch: Method to store a watch of a user on a task or category.
+ *   - Unwatch: Method to remove the watch of a user on a task or category.
+ *   - GetWatches: Method to retrieve the watches of a user.
+ *   - GetWatchers: Method to retrieve the watches on a task or category.
+ *   - Store: Method to store new notifications.
+ *   - GetList: Method to retrieve the notifications of a user.
+ *   - MarkRead: Method to mark one or every notification of a user as read.
+ *
+ * Structs:
+ *
+ * - notificationRepository: Struct implementing the NotificationRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewNotificationRepo: Function to create a new instance of notificationRepository.
+ *   - Watch: Method to store a watch on an existing task or category, keeping an existing watch, using file-based database operations.
+ *   - Unwatch: Method to remove a watch using file-based database operations.
+ *   - GetWatches: Method to retrieve the watches of a user, in ID order, using file-based database operations.
+ *   - GetWatchers: Method to retrieve the watches on a task or category, in ID order, using file-based database operations.
+ *   - Store: Method to store notifications in one transaction using file-based database operations.
+ *   - GetList: Method to retrieve the notifications of a user, in ID order, using file-based database operations.
+ *   - MarkRead: Method to mark the notification with the ID, or every notification when it is 0, as read using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+	"time"
+)
+
+type NotificationRepository interface {
+	Watch(watch *model.Watch) error
+	Unwatch(userID int, target model.WatchTarget, targetID int) error
+	GetWatches(userID int) ([]model.Watch, error)
+	GetWatchers(target model.WatchTarget, targetID int) ([]model.Watch, error)
+	Store(notifications []model.Notification) error
+	GetList(userID int) ([]model.Notification, error)
+	MarkRead(userID, id int, readAt time.Time) error
+}
+
+type notificationRepository struct {
+	filebased *filebased.Data
+}
+
+func NewNotificationRepo(filebasedDb *filebased.Data) *notificationRepository {
+	return &notificationRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (n *notificationRepository) Watch(watch *model.Watch) error {
+	return n.filebased.StoreWatch(watch)
+}
+
+func (n *notificationRepository) Unwatch(userID int, target model.WatchTarget, targetID int) error {
+	return n.filebased.DeleteWatch(userID, target, targetID)
+}
+
+func (n *notificationRepository) GetWatches(userID int) ([]model.Watch, error) {
+	return n.filebased.GetWatches(userID, "", 0)
+}
+
+func (n *notificationRepository) GetWatchers(target model.WatchTarget, targetID int) ([]model.Watch, error) {
+	return n.filebased.GetWatches(0, target, targetID)
+}
+
+func (n *notificationRepository) Store(notifications []model.Notification) error {
+	return n.filebased.StoreNotifications(notifications)
+}
+
+func (n *notificationRepository) GetList(userID int) ([]model.Notification, error) {
+	return n.filebased.GetNotifications(userID)
+}
+
+func (n *notificationRepository) MarkRead(userID, id int, readAt time.Time) error {
+	return n.filebased.MarkNotificationsRead(userID, id, readAt)
+}
@@ -0,0 +1,93 @@
+/** 
+ * Package repository provides interfaces and implementations for managing projects and their milestones.
+ * 
+ * Interfaces:
+ * 
+ * - ProjectRepository: Interface defining methods for project data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new or updated project.
+ *   - GetByID: Method to retrieve a project by its ID.
+ *   - GetList: Method to retrieve the projects of a workspace.
+ *   - Delete: Method to delete a project with its milestones.
+ *   - StoreMilestone: Method to store a new or updated milestone.
+ *   - GetMilestoneByID: Method to retrieve a milestone by its ID.
+ *   - GetMilestones: Method to retrieve the milestones of a project.
+ *   - DeleteMilestone: Method to delete a milestone.
+ * 
+ * Structs:
+ * 
+ * - projectRepository: Struct implementing the ProjectRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewProjectRepo: Function to create a new instance of projectRepository.
+ *   - Store: Method to store a project using file-based database operations.
+ *   - GetByID: Method to retrieve a project by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the projects of a workspace using file-based database operations.
+ *   - Delete: Method to delete a project and its milestones, detaching its categories and tasks, in one file-based database transaction,
+ *     recording the actor in the history of the detached tasks.
+ *   - StoreMilestone: Method to store a milestone using file-based database operations.
+ *   - GetMilestoneByID: Method to retrieve a milestone by its ID using file-based database operations.
+ *   - GetMilestones: Method to retrieve the milestones of a project using file-based database operations.
+ *   - DeleteMilestone: Method to delete a milestone and detach its tasks in one file-based database transaction, recording the actor in their history.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type ProjectRepository interface {
+	Store(project model.Project) (model.Project, error)
+	GetByID(id int) (*model.Project, error)
+	GetList(workspaceID int) ([]model.Project, error)
+	Delete(id int, actor string) error
+	StoreMilestone(milestone model.Milestone) (model.Milestone, error)
+	GetMilestoneByID(id int) (*model.Milestone, error)
+	GetMilestones(projectID int) ([]model.Milestone, error)
+	DeleteMilestone(id int, actor string) error
+}
+
+type projectRepository struct {
+	filebased *filebased.Data
+}
+
+func NewProjectRepo(filebasedDb *filebased.Data) *projectRepository {
+	return &projectRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (p *projectRepository) Store(project model.Project) (model.Project, error) {
+	return p.filebased.StoreProject(project)
+}
+
+func (p *projectRepository) GetByID(id int) (*model.Project, error) {
+	return p.filebased.GetProjectByID(id)
+}
+
+func (p *projectRepository) GetList(workspaceID int) ([]model.Project, error) {
+	return p.filebased.GetProjects(workspaceID)
+}
+
+func (p *projectRepository) Delete(id int, actor string) error {
+	return p.filebased.DeleteProject(id, actor)
+}
+
+func (p *projectRepository) StoreMilestone(milestone model.Milestone) (model.Milestone, error) {
+	return p.filebased.StoreMilestone(milestone)
+}
+
+func (p *projectRepository) GetMilestoneByID(id int) (*model.Milestone, error) {
+	return p.filebased.GetMilestoneByID(id)
+}
+
+func (p *projectRepository) GetMilestones(projectID int) ([]model.Milestone, error) {
+	return p.filebased.GetMilestones(projectID)
+}
+
+func (p *projectRepository) DeleteMilestone(id int, actor string) error {
+	return p.filebased.DeleteMilestone(id, actor)
+}
@@ -0,0 +1,50 @@
+/**
+ * Package repository provides interfaces and implementations for the full-text search index.
+ *
+ * Interfaces:
+ *
+ * - SearchRepository: Interface defining methods for the full-text search index.
+ *   Methods:
+ *   - Search: Method to rank the tasks and categories of a workspace matching a full-text query.
+ *   - Rebuild: Method to index every task and category again.
+ *
+ * Structs:
+ *
+ * - searchRepository: Struct implementing the SearchRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewSearchRepo: Function to create a new instance of searchRepository.
+ *   - Search: Method to rank the matching tasks and categories using the index kept in the file-based database.
+ *   - Rebuild: Method to rebuild the index in one file-based database transaction, returning how many records were indexed.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type SearchRepository interface {
+	Search(workspaceID int, query model.TextQuery, limit int) ([]model.SearchHit, error)
+	Rebuild() (int, error)
+}
+
+type searchRepository struct {
+	filebased *filebased.Data
+}
+
+func NewSearchRepo(filebasedDb *filebased.Data) *searchRepository {
+	return &searchRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (s *searchRepository) Search(workspaceID int, query model.TextQuery, limit int) ([]model.SearchHit, error) {
+	return s.filebased.SearchText(workspaceID, query, limit)
+}
+
+func (s *searchRepository) Rebuild() (int, error) {
+	return s.filebased.RebuildSearchIndex()
+}
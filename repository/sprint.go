@@ -0,0 +1,72 @@
+/** 
+ * Package repository provides interfaces and implementations for managing sprints.
+ * 
+ * Interfaces:
+ * 
+ * - SprintRepository: Interface defining methods for sprint data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new or updated sprint.
+ *   - GetByID: Method to retrieve a sprint by its ID.
+ *   - GetList: Method to retrieve the sprints of a workspace.
+ *   - Delete: Method to delete a sprint.
+ *   - Close: Method to store a closed sprint and carry over its unfinished tasks.
+ * 
+ * Structs:
+ * 
+ * - sprintRepository: Struct implementing the SprintRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewSprintRepo: Function to create a new instance of sprintRepository.
+ *   - Store: Method to store a sprint using file-based database operations.
+ *   - GetByID: Method to retrieve a sprint by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the sprints of a workspace using file-based database operations.
+ *   - Delete: Method to delete a sprint and return its tasks to the backlog in one file-based database transaction, recording the actor in their history.
+ *   - Close: Method to store a closed sprint and move its unfinished tasks to the next sprint, or the backlog when nextSprintID is 0,
+ *     in one file-based database transaction, recording the actor in the history of the moved tasks.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type SprintRepository interface {
+	Store(sprint model.Sprint) (model.Sprint, error)
+	GetByID(id int) (*model.Sprint, error)
+	GetList(workspaceID int) ([]model.Sprint, error)
+	Delete(id int, actor string) error
+	Close(sprint model.Sprint, nextSprintID int, actor string) (model.Sprint, error)
+}
+
+type sprintRepository struct {
+	filebased *filebased.Data
+}
+
+func NewSprintRepo(filebasedDb *filebased.Data) *sprintRepository {
+	return &sprintRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (s *sprintRepository) Store(sprint model.Sprint) (model.Sprint, error) {
+	return s.filebased.StoreSprint(sprint)
+}
+
+func (s *sprintRepository) GetByID(id int) (*model.Sprint, error) {
+	return s.filebased.GetSprintByID(id)
+}
+
+func (s *sprintRepository) GetList(workspaceID int) ([]model.Sprint, error) {
+	return s.filebased.GetSprints(workspaceID)
+}
+
+func (s *sprintRepository) Delete(id int, actor string) error {
+	return s.filebased.DeleteSprint(id, actor)
+}
+
+func (s *sprintRepository) Close(sprint model.Sprint, nextSprintID int, actor string) (model.Sprint, error) {
+	return s.filebased.CloseSprint(sprint, nextSprintID, actor)
+}
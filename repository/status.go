@@ -0,0 +1,63 @@
+/** 
+ * Package repository provides interfaces and implementations for managing custom task statuses.
+ * 
+ * Interfaces:
+ * 
+ * - StatusRepository: Interface defining methods for custom status data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new custom status.
+ *   - GetList: Method to retrieve the custom statuses of a user.
+ *   - Delete: Method to delete a custom status of a user.
+ * 
+ * Structs:
+ * 
+ * - statusRepository: Struct implementing the StatusRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewStatusRepo: Function to create a new instance of statusRepository.
+ *   - Store: Method to store a new custom status using file-based database operations.
+ *   - GetList: Method to retrieve the custom statuses of a user using file-based database operations.
+ *   - Delete: Method to delete a custom status of a user using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type StatusRepository interface {
+	Store(status *model.CustomStatus) error
+	GetList(userID int) ([]model.CustomStatus, error)
+	Delete(id, userID int) error
+}
+
+type statusRepository struct {
+	filebased *filebased.Data
+}
+
+func NewStatusRepo(filebasedDb *filebased.Data) *statusRepository {
+	return &statusRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (s *statusRepository) Store(status *model.CustomStatus) error {
+	stored, err := s.filebased.StoreCustomStatus(*status)
+	if err != nil {
+		return err
+	}
+
+	*status = stored
+	return nil
+}
+
+func (s *statusRepository) GetList(userID int) ([]model.CustomStatus, error) {
+	return s.filebased.GetCustomStatuses(userID)
+}
+
+func (s *statusRepository) Delete(id, userID int) error {
+	return s.filebased.DeleteCustomStatus(id, userID)
+}
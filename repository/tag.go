@@ -0,0 +1,107 @@
+/** 
+ * Package repository provides interfaces and implementations for managing task tags.
+ * 
+ * Interfaces:
+ * 
+ * - TagRepository: Interface defining methods for tag data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new tag.
+ *   - Update: Method to update an existing tag.
+ *   - Delete: Method to delete a tag of a user.
+ *   - GetByID: Method to retrieve a tag by its ID.
+ *   - GetList: Method to retrieve the tags of a user.
+ *   - Assign: Method to put a tag on a task.
+ *   - Unassign: Method to remove a tag from a task.
+ *   - GetAssignments: Method to retrieve every assignment of a tag to a task.
+ *   - GetTaskTags: Method to retrieve the tags of a task.
+ * 
+ * Structs:
+ * 
+ * - tagRepository: Struct implementing the TagRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewTagRepo: Function to create a new instance of tagRepository.
+ *   - Store: Method to store a new tag using file-based database operations.
+ *   - Update: Method to update an existing tag using file-based database operations.
+ *   - Delete: Method to delete a tag of a user and its assignments in one file-based database transaction.
+ *   - GetByID: Method to retrieve a tag by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the tags of a user using file-based database operations.
+ *   - Assign: Method to put a tag on a task using file-based database operations.
+ *   - Unassign: Method to remove a tag from a task using file-based database operations.
+ *   - GetAssignments: Method to retrieve every assignment of a tag to a task using file-based database operations.
+ *   - GetTaskTags: Method to retrieve the tags of a task using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type TagRepository interface {
+	Store(tag *model.Tag) error
+	Update(tag *model.Tag) error
+	Delete(id, userID int) error
+	GetByID(id int) (*model.Tag, error)
+	GetList(userID int) ([]model.Tag, error)
+	Assign(taskID, tagID int) error
+	Unassign(taskID, tagID int) error
+	GetAssignments() ([]model.TaskTag, error)
+	GetTaskTags(taskID int) ([]model.Tag, error)
+}
+
+type tagRepository struct {
+	filebased *filebased.Data
+}
+
+func NewTagRepo(filebasedDb *filebased.Data) *tagRepository {
+	return &tagRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (t *tagRepository) Store(tag *model.Tag) error {
+	tag.ID = 0
+	stored, err := t.filebased.StoreTag(*tag)
+	if err != nil {
+		return err
+	}
+
+	*tag = stored
+	return nil
+}
+
+func (t *tagRepository) Update(tag *model.Tag) error {
+	_, err := t.filebased.StoreTag(*tag)
+	return err
+}
+
+func (t *tagRepository) Delete(id, userID int) error {
+	return t.filebased.DeleteTag(id, userID)
+}
+
+func (t *tagRepository) GetByID(id int) (*model.Tag, error) {
+	return t.filebased.GetTagByID(id)
+}
+
+func (t *tagRepository) GetList(userID int) ([]model.Tag, error) {
+	return t.filebased.GetTags(userID)
+}
+
+func (t *tagRepository) Assign(taskID, tagID int) error {
+	return t.filebased.AssignTag(model.TaskTag{TaskID: taskID, TagID: tagID})
+}
+
+func (t *tagRepository) Unassign(taskID, tagID int) error {
+	return t.filebased.UnassignTag(model.TaskTag{TaskID: taskID, TagID: tagID})
+}
+
+func (t *tagRepository) GetAssignments() ([]model.TaskTag, error) {
+	return t.filebased.GetTagAssignments()
+}
+
+func (t *tagRepository) GetTaskTags(taskID int) ([]model.Tag, error) {
+	return t.filebased.GetTaskTags(taskID)
+}
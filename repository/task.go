@@ -6,11 +6,23 @@
  * - TaskRepository: Interface defining methods for task data manipulation.
  *   Methods:
  *   - Store: Method to store a new task.
+ *   - StoreAll: Method to store several new tasks at once.
  *   - Update: Method to update an existing task.
- *   - Delete: Method to delete a task by ID.
+ *   - Delete: Method to delete a task by ID together with its subtasks.
+ *   - DeleteKeepChildren: Method to delete a task by ID, moving its subtasks up to its parent.
+ *   - Trash: Method to move a task, with or without its subtasks, to the trash.
  *   - GetByID: Method to retrieve a task by its ID.
- *   - GetList: Method to retrieve a list of all tasks.
- *   - GetTaskCategory: Method to retrieve a list of tasks by category.
+ *   - GetList: Method to retrieve the tasks of a workspace.
+ *   - Query: Method to retrieve a filtered, sorted page of the tasks of a workspace.
+ *   - Search: Method to retrieve the tasks of a workspace matching a parsed search query.
+ *   - GetListByUser: Method to retrieve the tasks a user owns in every workspace.
+ *   - GetTaskCategory: Method to retrieve the tasks of a workspace by category.
+ *   - Transition: Method to store a task's new status together with the recorded transition.
+ *   - GetTransitions: Method to retrieve the status transitions of a task.
+ *   - GetHistory: Method to retrieve the activity history of a task.
+ *   - GetSubtasks: Method to retrieve the direct subtasks of a task.
+ *   - GetListByTags: Method to retrieve the tasks of a workspace carrying every one of the given tags.
+ *   - GetBlockers: Method to retrieve the tasks blocking a task.
  * 
  * Structs:
  * 
@@ -19,12 +31,25 @@
  *   - filebased: Instance of filebased.Data for file-based database operations.
  *   Methods:
  *   - NewTaskRepo: Function to create a new instance of taskRepository.
- *   - Store: Method to store a new task using file-based database operations.
- *   - Update: Method to update an existing task using file-based database operations.
- *   - Delete: Method to delete a task by ID using file-based database operations.
+ *   - Store: Method to store a new task using file-based database operations, recording the actor in its history.
+ *   - StoreAll: Method to store several new tasks in one file-based database transaction, recording the actor in their history.
+ *   - Update: Method to update an existing task using file-based database operations, recording the actor in its history.
+ *   - Delete: Method to delete a task by ID and its subtasks using file-based database operations, recording the actor in their history.
+ *   - DeleteKeepChildren: Method to delete a task by ID and reparent its subtasks in one file-based database transaction, recording the actor in their history.
+ *   - Trash: Method to move a task and its related records to the trash in one file-based database transaction, recording the actor in its history.
  *   - GetByID: Method to retrieve a task by its ID using file-based database operations.
- *   - GetList: Method to retrieve a list of all tasks using file-based database operations.
- *   - GetTaskCategory: Method to retrieve a list of tasks by category using file-based database operations.
+ *   - GetList: Method to retrieve the tasks of a workspace using file-based database operations.
+ *   - Query: Method to retrieve a filtered, sorted page of the tasks of a workspace in one file-based database transaction.
+ *   - Search: Method to evaluate a parsed search query against the tasks of a workspace in one file-based database transaction.
+ *   - GetListByUser: Method to retrieve the tasks a user owns in every workspace using file-based database operations.
+ *   - GetTaskCategory: Method to retrieve the tasks of a workspace by category using file-based database operations.
+ *   - Transition: Method to store a task's new status and its transition in one file-based database transaction,
+ *     recording the user who changed the status in the task's history.
+ *   - GetTransitions: Method to retrieve the status transitions of a task using file-based database operations.
+ *   - GetHistory: Method to retrieve the activity history of a task using file-based database operations.
+ *   - GetSubtasks: Method to retrieve the direct subtasks of a task using file-based database operations.
+ *   - GetListByTags: Method to retrieve the tasks of a workspace carrying every one of the given tags using file-based database operations.
+ *   - GetBlockers: Method to retrieve the tasks blocking a task using file-based database operations.
  */
 
 package repository
@@ -32,15 +57,28 @@ package repository
 import (
 	"a21hc3NpZ25tZW50/db/filebased"
 	"a21hc3NpZ25tZW50/model"
+	"time"
 )
 
 type TaskRepository interface {
-	Store(task *model.Task) error
-	Update(taskID int, task *model.Task) error
-	Delete(id int) error
+	Store(task *model.Task, actor string) error
+	StoreAll(tasks []*model.Task, actor string) error
+	Update(taskID int, task *model.Task, actor string) error
+	Delete(id int, actor string) error
+	DeleteKeepChildren(id int, actor string) error
+	Trash(id int, keepChildren bool, actor string, deletedAt time.Time) (model.TrashItem, error)
 	GetByID(id int) (*model.Task, error)
-	GetList() ([]model.Task, error)
-	GetTaskCategory(id int) ([]model.TaskCategory, error)
+	GetList(workspaceID int) ([]model.Task, error)
+	Query(workspaceID int, query model.TaskQuery) (model.TaskPage, error)
+	Search(workspaceID int, query model.SearchQuery, now time.Time, loc *time.Location) ([]model.Task, error)
+	GetListByUser(userID int) ([]model.Task, error)
+	GetTaskCategory(workspaceID, id int) ([]model.TaskCategory, error)
+	Transition(task *model.Task, transition model.StatusTransition) error
+	GetTransitions(taskID int) ([]model.StatusTransition, error)
+	GetHistory(taskID int) ([]model.TaskEvent, error)
+	GetSubtasks(parentID int) ([]model.Task, error)
+	GetListByTags(workspaceID int, tagIDs []int) ([]model.Task, error)
+	GetBlockers(taskID int) ([]model.Task, error)
 }
 
 type taskRepository struct {
@@ -53,26 +91,74 @@ func NewTaskRepo(filebasedDb *filebased.Data) *taskRepository {
 	}
 }
 
-func (t *taskRepository) Store(task *model.Task) error {
-	return t.filebased.StoreTask(*task)
+func (t *taskRepository) Store(task *model.Task, actor string) error {
+	return t.filebased.StoreTask(task, actor)
 }
 
-func (t *taskRepository) Update(taskID int, task *model.Task) error {
-	return t.filebased.UpdateTask(taskID, *task)
+func (t *taskRepository) StoreAll(tasks []*model.Task, actor string) error {
+	return t.filebased.StoreTasks(tasks, actor)
 }
 
-func (t *taskRepository) Delete(id int) error {
-	return t.filebased.DeleteTask(id)
+func (t *taskRepository) Update(taskID int, task *model.Task, actor string) error {
+	return t.filebased.UpdateTask(taskID, *task, actor)
+}
+
+func (t *taskRepository) Delete(id int, actor string) error {
+	return t.filebased.DeleteTask(id, actor)
+}
+
+func (t *taskRepository) DeleteKeepChildren(id int, actor string) error {
+	return t.filebased.DeleteTaskKeepChildren(id, actor)
+}
+
+func (t *taskRepository) Trash(id int, keepChildren bool, actor string, deletedAt time.Time) (model.TrashItem, error) {
+	return t.filebased.TrashTask(id, keepChildren, actor, deletedAt)
 }
 
 func (t *taskRepository) GetByID(id int) (*model.Task, error) {
 	return t.filebased.GetTaskByID(id)
 }
 
-func (t *taskRepository) GetList() ([]model.Task, error) {
-	return t.filebased.GetTasks()
+func (t *taskRepository) GetList(workspaceID int) ([]model.Task, error) {
+	return t.filebased.GetTasks(workspaceID)
+}
+
+func (t *taskRepository) Query(workspaceID int, query model.TaskQuery) (model.TaskPage, error) {
+	return t.filebased.QueryTasks(workspaceID, query)
+}
+
+func (t *taskRepository) Search(workspaceID int, query model.SearchQuery, now time.Time, loc *time.Location) ([]model.Task, error) {
+	return t.filebased.SearchTasks(workspaceID, query, now, loc)
+}
+
+func (t *taskRepository) GetListByUser(userID int) ([]model.Task, error) {
+	return t.filebased.GetTasksByUser(userID)
+}
+
+func (t *taskRepository) GetTaskCategory(workspaceID, id int) ([]model.TaskCategory, error) {
+	return t.filebased.GetTaskListByCategory(workspaceID, id)
+}
+
+func (t *taskRepository) Transition(task *model.Task, transition model.StatusTransition) error {
+	return t.filebased.TransitionTask(*task, transition)
+}
+
+func (t *taskRepository) GetTransitions(taskID int) ([]model.StatusTransition, error) {
+	return t.filebased.GetStatusTransitions(taskID)
+}
+
+func (t *taskRepository) GetHistory(taskID int) ([]model.TaskEvent, error) {
+	return t.filebased.GetTaskHistory(taskID)
+}
+
+func (t *taskRepository) GetSubtasks(parentID int) ([]model.Task, error) {
+	return t.filebased.GetSubtasks(parentID)
+}
+
+func (t *taskRepository) GetListByTags(workspaceID int, tagIDs []int) ([]model.Task, error) {
+	return t.filebased.GetTasksByTags(workspaceID, tagIDs)
 }
 
-func (t *taskRepository) GetTaskCategory(id int) ([]model.TaskCategory, error) {
-	return t.filebased.GetTaskListByCategory(id)
+func (t *taskRepository) GetBlockers(taskID int) ([]model.Task, error) {
+	return t.filebased.GetBlockers(taskID)
 }
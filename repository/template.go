@@ -0,0 +1,64 @@
+/** 
+ * Package repository provides interfaces and implementations for managing task templates.
+ * 
+ * Interfaces:
+ * 
+ * - TemplateRepository: Interface defining methods for template data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new or updated template.
+ *   - GetByID: Method to retrieve a template by its ID.
+ *   - GetList: Method to retrieve the templates of a workspace.
+ *   - Delete: Method to delete a template.
+ * 
+ * Structs:
+ * 
+ * - templateRepository: Struct implementing the TemplateRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewTemplateRepo: Function to create a new instance of templateRepository.
+ *   - Store: Method to store a template using file-based database operations.
+ *   - GetByID: Method to retrieve a template by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the templates of a workspace using file-based database operations.
+ *   - Delete: Method to delete a template using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type TemplateRepository interface {
+	Store(template model.Template) (model.Template, error)
+	GetByID(id int) (*model.Template, error)
+	GetList(workspaceID int) ([]model.Template, error)
+	Delete(id int) error
+}
+
+type templateRepository struct {
+	filebased *filebased.Data
+}
+
+func NewTemplateRepo(filebasedDb *filebased.Data) *templateRepository {
+	return &templateRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (t *templateRepository) Store(template model.Template) (model.Template, error) {
+	return t.filebased.StoreTemplate(template)
+}
+
+func (t *templateRepository) GetByID(id int) (*model.Template, error) {
+	return t.filebased.GetTemplateByID(id)
+}
+
+func (t *templateRepository) GetList(workspaceID int) ([]model.Template, error) {
+	return t.filebased.GetTemplates(workspaceID)
+}
+
+func (t *templateRepository) Delete(id int) error {
+	return t.filebased.DeleteTemplate(id)
+}
@@ -0,0 +1,93 @@
+/** 
+ * Package repository provides interfaces and implementations for managing time entries.
+ * 
+ * Interfaces:
+ * 
+ * - TimeEntryRepository: Interface defining methods for time entry data manipulation.
+ *   Methods:
+ *   - Start: Method to start a timer for a user.
+ *   - Stop: Method to stop the running timer of a user.
+ *   - Store: Method to store a time entry entered by hand.
+ *   - GetByID: Method to retrieve a time entry by its ID.
+ *   - GetList: Method to retrieve the time entries of a task, a user or both.
+ *   - Delete: Method to delete a time entry.
+ * 
+ * Structs:
+ * 
+ * - timeEntryRepository: Struct implementing the TimeEntryRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewTimeEntryRepo: Function to create a new instance of timeEntryRepository.
+ *   - Start: Method to store a running time entry unless the user already has one, returned instead, using file-based database operations.
+ *   - Stop: Method to end the running time entry of a user, nil when none is running, using file-based database operations.
+ *   - Store: Method to store a new time entry using file-based database operations.
+ *   - GetByID: Method to retrieve a time entry by its ID using file-based database operations.
+ *   - GetList: Method to retrieve time entries, oldest first, a zero ID matching everything, using file-based database operations.
+ *   - Delete: Method to delete a time entry using file-based database operations.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+	"time"
+)
+
+type TimeEntryRepository interface {
+	Start(entry *model.TimeEntry) (*model.TimeEntry, error)
+	Stop(userID int, end time.Time) (*model.TimeEntry, error)
+	Store(entry *model.TimeEntry) error
+	GetByID(id int) (*model.TimeEntry, error)
+	GetList(taskID, userID int) ([]model.TimeEntry, error)
+	Delete(id int) error
+}
+
+type timeEntryRepository struct {
+	filebased *filebased.Data
+}
+
+func NewTimeEntryRepo(filebasedDb *filebased.Data) *timeEntryRepository {
+	return &timeEntryRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (t *timeEntryRepository) Start(entry *model.TimeEntry) (*model.TimeEntry, error) {
+	entry.ID = 0
+	stored, running, err := t.filebased.StartTimer(*entry)
+	if err != nil || running != nil {
+		return running, err
+	}
+
+	*entry = stored
+	return nil, nil
+}
+
+func (t *timeEntryRepository) Stop(userID int, end time.Time) (*model.TimeEntry, error) {
+	return t.filebased.StopTimer(userID, end)
+}
+
+func (t *timeEntryRepository) Store(entry *model.TimeEntry) error {
+	entry.ID = 0
+	stored, err := t.filebased.StoreTimeEntry(*entry)
+	if err != nil {
+		return err
+	}
+
+	*entry = stored
+	return nil
+}
+
+func (t *timeEntryRepository) GetByID(id int) (*model.TimeEntry, error) {
+	return t.filebased.GetTimeEntryByID(id)
+}
+
+func (t *timeEntryRepository) GetList(taskID, userID int) ([]model.TimeEntry, error) {
+	return t.filebased.GetTimeEntries(taskID, userID)
+}
+
+func (t *timeEntryRepository) Delete(id int) error {
+	return t.filebased.DeleteTimeEntry(id)
+}
@@ -0,0 +1,72 @@
+/** 
+ * Package repository provides interfaces and implementations for managing the trash.
+ * 
+ * Interfaces:
+ * 
+ * - TrashRepository: Interface defining methods for trash data manipulation.
+ *   Methods:
+ *   - GetByID: Method to retrieve a trash item by its ID.
+ *   - GetList: Method to retrieve the trash items of a workspace.
+ *   - Restore: Method to move a trash item back.
+ *   - Purge: Method to remove a trash item for good.
+ *   - PurgeBefore: Method to remove every trash item deleted before a given time for good.
+ * 
+ * Structs:
+ * 
+ * - trashRepository: Struct implementing the TrashRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewTrashRepo: Function to create a new instance of trashRepository.
+ *   - GetByID: Method to retrieve a trash item by its ID using file-based database operations.
+ *   - GetList: Method to retrieve the trash items of a workspace using file-based database operations.
+ *   - Restore: Method to move a trash item and its related records back in one file-based database transaction.
+ *   - Purge: Method to remove a trash item using file-based database operations.
+ *   - PurgeBefore: Method to remove the trash items deleted before a given time in one file-based database transaction.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+	"time"
+)
+
+type TrashRepository interface {
+	GetByID(id int) (*model.TrashItem, error)
+	GetList(workspaceID int) ([]model.TrashItem, error)
+	Restore(id int, actor string) (model.TrashItem, error)
+	Purge(id int) error
+	PurgeBefore(cutoff time.Time) (int, error)
+}
+
+type trashRepository struct {
+	filebased *filebased.Data
+}
+
+func NewTrashRepo(filebasedDb *filebased.Data) *trashRepository {
+	return &trashRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (t *trashRepository) GetByID(id int) (*model.TrashItem, error) {
+	return t.filebased.GetTrashItemByID(id)
+}
+
+func (t *trashRepository) GetList(workspaceID int) ([]model.TrashItem, error) {
+	return t.filebased.GetTrashItems(workspaceID)
+}
+
+func (t *trashRepository) Restore(id int, actor string) (model.TrashItem, error) {
+	return t.filebased.RestoreTrashItem(id, actor)
+}
+
+func (t *trashRepository) Purge(id int) error {
+	return t.filebased.PurgeTrashItem(id)
+}
+
+func (t *trashRepository) PurgeBefore(cutoff time.Time) (int, error) {
+	return t.filebased.PurgeTrashBefore(cutoff)
+}
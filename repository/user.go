@@ -7,7 +7,14 @@
  *   Methods:
  *   - GetUserByEmail: Method to retrieve a user by email.
  *   - CreateUser: Method to create a new user.
- *   - GetUserTaskCategory: Method to retrieve user task categories.
+ *   - GetUserTaskCategory: Method to retrieve the user task categories of a workspace.
+ *   - GetUserByID: Method to retrieve a user by ID.
+ *   - GetUserList: Method to retrieve a list of all users.
+ *   - UpdateUser: Method to update an existing user.
+ *   - ScheduleDeletion: Method to store a pending account deletion and sign the user out.
+ *   - RestoreUser: Method to store a user whose pending deletion was cancelled.
+ *   - EraseUser: Method to remove a user together with its sessions, tasks and categories.
+ *   - GetAuditLog: Method to retrieve the audit log.
  * 
  * Structs:
  * 
@@ -18,7 +25,14 @@
  *   - NewUserRepo: Function to create a new instance of userRepository.
  *   - GetUserByEmail: Method to retrieve a user by email using file-based database operations.
  *   - CreateUser: Method to create a new user using file-based database operations.
- *   - GetUserTaskCategory: Method to retrieve user task categories using file-based database operations.
+ *   - GetUserTaskCategory: Method to retrieve the user task categories of a workspace, archived tasks left out, using file-based database operations.
+ *   - GetUserByID: Method to retrieve a user by ID using file-based database operations.
+ *   - GetUserList: Method to retrieve a list of all users using file-based database operations.
+ *   - UpdateUser: Method to update an existing user using file-based database operations.
+ *   - ScheduleDeletion: Method to store a pending account deletion and its audit record in one file-based database transaction.
+ *   - RestoreUser: Method to store a restored user and its audit record in one file-based database transaction.
+ *   - EraseUser: Method to erase a user's data and record the erasure in one file-based database transaction.
+ *   - GetAuditLog: Method to retrieve the audit log using file-based database operations.
  */
 
 package repository
@@ -31,7 +45,14 @@ import (
 type UserRepository interface {
 	GetUserByEmail(email string) (model.User, error)
 	CreateUser(user model.User) (model.User, error)
-	GetUserTaskCategory() ([]model.UserTaskCategory, error)
+	GetUserTaskCategory(workspaceID int) ([]model.UserTaskCategory, error)
+	GetUserByID(id int) (model.User, error)
+	GetUserList() ([]model.User, error)
+	UpdateUser(user model.User) error
+	ScheduleDeletion(user model.User, record model.AuditRecord) error
+	RestoreUser(user model.User, record model.AuditRecord) error
+	EraseUser(id int, record model.AuditRecord) error
+	GetAuditLog() ([]model.AuditRecord, error)
 }
 
 type userRepository struct {
@@ -50,6 +71,34 @@ func (r *userRepository) CreateUser(user model.User) (model.User, error) {
 	return r.filebasedDb.CreateUser(user)
 }
 
-func (r *userRepository) GetUserTaskCategory() ([]model.UserTaskCategory, error) {
-	return r.filebasedDb.GetUserTaskCategory()
+func (r *userRepository) GetUserTaskCategory(workspaceID int) ([]model.UserTaskCategory, error) {
+	return r.filebasedDb.GetUserTaskCategory(workspaceID)
+}
+
+func (r *userRepository) GetUserByID(id int) (model.User, error) {
+	return r.filebasedDb.GetUserByID(id)
+}
+
+func (r *userRepository) GetUserList() ([]model.User, error) {
+	return r.filebasedDb.GetUsers()
+}
+
+func (r *userRepository) UpdateUser(user model.User) error {
+	return r.filebasedDb.UpdateUser(user)
+}
+
+func (r *userRepository) ScheduleDeletion(user model.User, record model.AuditRecord) error {
+	return r.filebasedDb.ScheduleUserDeletion(user, record)
+}
+
+func (r *userRepository) RestoreUser(user model.User, record model.AuditRecord) error {
+	return r.filebasedDb.RestoreUser(user, record)
+}
+
+func (r *userRepository) EraseUser(id int, record model.AuditRecord) error {
+	return r.filebasedDb.EraseUser(id, record)
+}
+
+func (r *userRepository) GetAuditLog() ([]model.AuditRecord, error) {
+	return r.filebasedDb.GetAuditRecords()
 }
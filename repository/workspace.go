@@ -0,0 +1,99 @@
+/** 
+ * Package repository provides interfaces and implementations for managing workspaces, their members and invitations.
+ * 
+ * Interfaces:
+ * 
+ * - WorkspaceRepository: Interface defining methods for workspace data manipulation.
+ *   Methods:
+ *   - Store: Method to store a new workspace together with the membership of its owner.
+ *   - GetByID: Method to retrieve a workspace by its ID.
+ *   - PutMember: Method to add a member to a workspace or change the role of a member.
+ *   - RemoveMember: Method to remove a member from a workspace.
+ *   - GetMembers: Method to retrieve the memberships of a workspace, a user or both.
+ *   - StoreInvitation: Method to store a new or answered invitation.
+ *   - GetInvitationByID: Method to retrieve an invitation by its ID.
+ *   - GetInvitations: Method to retrieve the invitations to a workspace, to an email address or both.
+ *   - AcceptInvitation: Method to store an accepted invitation together with the membership it grants.
+ * 
+ * Structs:
+ * 
+ * - workspaceRepository: Struct implementing the WorkspaceRepository interface.
+ *   Fields:
+ *   - filebased: Instance of filebased.Data for file-based database operations.
+ *   Methods:
+ *   - NewWorkspaceRepo: Function to create a new instance of workspaceRepository.
+ *   - Store: Method to store a new workspace and its owner's membership in one file-based database transaction.
+ *   - GetByID: Method to retrieve a workspace by its ID using file-based database operations.
+ *   - PutMember: Method to store a membership using file-based database operations.
+ *   - RemoveMember: Method to remove a membership using file-based database operations.
+ *   - GetMembers: Method to retrieve memberships, a zero ID matching everything, using file-based database operations.
+ *   - StoreInvitation: Method to store an invitation using file-based database operations.
+ *   - GetInvitationByID: Method to retrieve an invitation by its ID using file-based database operations.
+ *   - GetInvitations: Method to retrieve invitations, a zero ID or empty address matching everything, using file-based database operations.
+ *   - AcceptInvitation: Method to store an accepted invitation and its membership in one file-based database transaction.
+ */
+
+package repository
+
+import (
+	"a21hc3NpZ25tZW50/db/filebased"
+	"a21hc3NpZ25tZW50/model"
+)
+
+type WorkspaceRepository interface {
+	Store(workspace model.Workspace) (model.Workspace, error)
+	GetByID(id int) (*model.Workspace, error)
+	PutMember(member model.WorkspaceMember) error
+	RemoveMember(workspaceID, userID int) error
+	GetMembers(workspaceID, userID int) ([]model.WorkspaceMember, error)
+	StoreInvitation(invitation model.Invitation) (model.Invitation, error)
+	GetInvitationByID(id int) (*model.Invitation, error)
+	GetInvitations(workspaceID int, email string) ([]model.Invitation, error)
+	AcceptInvitation(invitation model.Invitation, member model.WorkspaceMember) error
+}
+
+type workspaceRepository struct {
+	filebased *filebased.Data
+}
+
+func NewWorkspaceRepo(filebasedDb *filebased.Data) *workspaceRepository {
+	return &workspaceRepository{
+		filebased: filebasedDb,
+	}
+}
+
+func (w *workspaceRepository) Store(workspace model.Workspace) (model.Workspace, error) {
+	return w.filebased.StoreWorkspace(workspace)
+}
+
+func (w *workspaceRepository) GetByID(id int) (*model.Workspace, error) {
+	return w.filebased.GetWorkspaceByID(id)
+}
+
+func (w *workspaceRepository) PutMember(member model.WorkspaceMember) error {
+	return w.filebased.PutWorkspaceMember(member)
+}
+
+func (w *workspaceRepository) RemoveMember(workspaceID, userID int) error {
+	return w.filebased.DeleteWorkspaceMember(workspaceID, userID)
+}
+
+func (w *workspaceRepository) GetMembers(workspaceID, userID int) ([]model.WorkspaceMember, error) {
+	return w.filebased.GetWorkspaceMembers(workspaceID, userID)
+}
+
+func (w *workspaceRepository) StoreInvitation(invitation model.Invitation) (model.Invitation, error) {
+	return w.filebased.StoreInvitation(invitation)
+}
+
+func (w *workspaceRepository) GetInvitationByID(id int) (*model.Invitation, error) {
+	return w.filebased.GetInvitationByID(id)
+}
+
+func (w *workspaceRepository) GetInvitations(workspaceID int, email string) ([]model.Invitation, error) {
+	return w.filebased.GetInvitations(workspaceID, email)
+}
+
+func (w *workspaceRepository) AcceptInvitation(invitation model.Invitation, member model.WorkspaceMember) error {
+	return w.filebased.AcceptInvitation(invitation, member)
+}
@@ -0,0 +1,181 @@
+/** 
+ * Package service provides interfaces and implementations for sharing tasks with other users.
+ * 
+ * Interfaces:
+ * 
+ * - AssignmentService: Interface defining methods for task assignment.
+ *   Methods:
+ *   - Assign: Method to assign a user to a task by email address.
+ *   - Unassign: Method to remove a user from a task.
+ *   - GetAssignees: Method to retrieve the users assigned to a task.
+ *   - GetAssignedTasks: Method to retrieve the tasks of a workspace a user is assigned to.
+ *   - Authorize: Method to check whether a user may perform an action on a task.
+ * 
+ * Structs:
+ * 
+ * - assignmentService: Struct implementing the AssignmentService interface.
+ *   Fields:
+ *   - assignmentRepository: Instance of repo.AssignmentRepository for assignment repository operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to find the owner of a task.
+ *   - userRepository: Instance of repo.UserRepository used to look users up by email address.
+ *   - workspaceRepository: Instance of repo.WorkspaceRepository used to check that assignees are members of the task's workspace.
+ *   Methods:
+ *   - NewAssignmentService: Function to create a new instance of assignmentService.
+ *   - Assign: Method to assign the user with the email address to the task. Unknown addresses are reported as ErrAssigneeNotFound
+ *     and the owner of the task cannot be assigned to it. Assignees cannot assign other users. The tasks of a workspace other
+ *     than the default one can only be assigned to its members.
+ *   - Unassign: Method to remove a user from the task. Assignees cannot remove anyone, themselves included, as that would
+ *     lift the restrictions Authorize puts on them.
+ *   - GetAssignees: Method to retrieve the profiles of the users assigned to the task, in user ID order.
+ *   - GetAssignedTasks: Method to retrieve the tasks of the workspace the user is assigned to, in ID order.
+ *   - Authorize: Method to check an action on a task against the user's relation to it. The owner of the task and the
+ *     owner and admins of its workspace may do anything. Assignees may edit the task and move it through the workflow, but
+ *     deleting or reassigning it is refused with ErrAssigneeForbidden. Everyone else is refused with ErrTaskForbidden,
+ *     and missing tasks are reported as ErrTaskNotFound.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type AssignmentService interface {
+	Assign(taskID, actorID int, email string) (model.UserProfile, error)
+	Unassign(taskID, actorID, userID int) error
+	GetAssignees(taskID int) ([]model.UserProfile, error)
+	GetAssignedTasks(workspaceID, userID int) ([]model.Task, error)
+	Authorize(taskID, userID int, action model.TaskAction) error
+}
+
+type assignmentService struct {
+	assignmentRepository repo.AssignmentRepository
+	taskRepository       repo.TaskRepository
+	userRepository       repo.UserRepository
+	workspaceRepository  repo.WorkspaceRepository
+}
+
+func NewAssignmentService(assignmentRepository repo.AssignmentRepository, taskRepository repo.TaskRepository, userRepository repo.UserRepository, workspaceRepository repo.WorkspaceRepository) AssignmentService {
+	return &assignmentService{assignmentRepository, taskRepository, userRepository, workspaceRepository}
+}
+
+func (s *assignmentService) Assign(taskID, actorID int, email string) (model.UserProfile, error) {
+	task, err := s.taskRepository.GetByID(taskID)
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+
+	if err := s.Authorize(taskID, actorID, model.TaskActionAssign); err != nil {
+		return model.UserProfile{}, err
+	}
+
+	email = strings.TrimSpace(email)
+	user, err := s.userRepository.GetUserByEmail(email)
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+	if user.ID == 0 {
+		return model.UserProfile{}, fmt.Errorf("%w: %s", model.ErrAssigneeNotFound, email)
+	}
+	if user.ID == task.UserID {
+		return model.UserProfile{}, model.ErrAssignOwner
+	}
+	if task.WorkspaceID != 0 {
+		members, err := s.workspaceRepository.GetMembers(task.WorkspaceID, user.ID)
+		if err != nil {
+			return model.UserProfile{}, err
+		}
+		if len(members) == 0 {
+			return model.UserProfile{}, fmt.Errorf("%w: %s is not a member of the workspace", model.ErrAssigneeNotFound, email)
+		}
+	}
+
+	if err := s.assignmentRepository.Assign(taskID, user.ID); err != nil {
+		return model.UserProfile{}, err
+	}
+	return toProfile(user), nil
+}
+
+func (s *assignmentService) Unassign(taskID, actorID, userID int) error {
+	if err := s.Authorize(taskID, actorID, model.TaskActionAssign); err != nil {
+		return err
+	}
+
+	assignments, err := s.assignmentRepository.GetList(taskID, userID)
+	if err != nil {
+		return err
+	}
+	if len(assignments) == 0 {
+		return fmt.Errorf("%w: %d", model.ErrAssigneeNotFound, userID)
+	}
+	return s.assignmentRepository.Unassign(taskID, userID)
+}
+
+func (s *assignmentService) GetAssignees(taskID int) ([]model.UserProfile, error) {
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepository.GetList(taskID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []model.UserProfile{}
+	for _, assignment := range assignments {
+		user, err := s.userRepository.GetUserByID(assignment.UserID)
+		if err != nil {
+			continue
+		}
+		profiles = append(profiles, toProfile(user))
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].ID < profiles[j].ID })
+	return profiles, nil
+}
+
+func (s *assignmentService) GetAssignedTasks(workspaceID, userID int) ([]model.Task, error) {
+	tasks, err := s.assignmentRepository.GetAssignedTasks(workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tasks == nil {
+		tasks = []model.Task{}
+	}
+	return tasks, nil
+}
+
+func (s *assignmentService) Authorize(taskID, userID int, action model.TaskAction) error {
+	task, err := s.taskRepository.GetByID(taskID)
+	if err != nil {
+		return fmt.Errorf("%w: %d", model.ErrTaskNotFound, taskID)
+	}
+	if task.UserID == userID {
+		return nil
+	}
+
+	if task.WorkspaceID != 0 {
+		members, err := s.workspaceRepository.GetMembers(task.WorkspaceID, userID)
+		if err != nil {
+			return err
+		}
+		if len(members) > 0 && members[0].Role.CanManageMembers() {
+			return nil
+		}
+	}
+
+	assignments, err := s.assignmentRepository.GetList(taskID, userID)
+	if err != nil {
+		return err
+	}
+	if len(assignments) == 0 {
+		return fmt.Errorf("%w: cannot %s task %d", model.ErrTaskForbidden, action, taskID)
+	}
+	if action != model.TaskActionEdit && action != model.TaskActionTransition {
+		return fmt.Errorf("%w: cannot %s task %d", model.ErrAssigneeForbidden, action, taskID)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+/** 
+ * Package service provides interfaces and implementations for managing task attachments.
+ * 
+ * Interfaces:
+ * 
+ * - AttachmentService: Interface defining methods for attachment management.
+ *   Methods:
+ *   - Upload: Method to attach a file to a task.
+ *   - GetList: Method to retrieve the attachments of a task.
+ *   - Open: Method to open the content of an attachment.
+ *   - Delete: Method to delete an attachment.
+ *   - PurgeOrphanedBlobs: Method to remove the content of deleted attachments from the blob store.
+ * 
+ * Structs:
+ * 
+ * - attachmentService: Struct implementing the AttachmentService interface.
+ *   Fields:
+ *   - attachmentRepository: Instance of repo.AttachmentRepository for attachment metadata operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to check that the task exists.
+ *   - blobStore: Instance of blobstore.BlobStore keeping the content of the files.
+ *   Methods:
+ *   - NewAttachmentService: Function to create a new instance of attachmentService.
+ *   - Upload: Method to store a file in the blob store and its metadata in the database. Files larger than
+ *     config.GetMaxAttachmentSize are refused with ErrAttachmentTooLarge, and files whose type is not in
+ *     config.GetAttachmentTypes with ErrAttachmentType. The type is detected from the first bytes of the content;
+ *     the declared type, or the one of the file extension, is only used for content that cannot be told apart
+ *     by sniffing, such as Office documents and CSV files.
+ *   - GetList: Method to retrieve the attachments of a task, oldest first.
+ *   - Open: Method to retrieve an attachment of a task with a reader of its content. The caller closes the reader.
+ *   - Delete: Method to delete an attachment. Only the uploader can delete an attachment.
+ *   - PurgeOrphanedBlobs: Method to remove the content of attachments deleted on their own or with their task,
+ *     returning how many blobs were removed. Blobs that cannot be removed are kept for the next run.
+ *   - removeBlob: Method to remove an orphaned blob and forget its key.
+ * 
+ * Functions:
+ * 
+ * - detectContentType: Function to pick the MIME type of an upload from its first bytes and its declared type.
+ * - cleanFileName: Function to strip the directories and control characters from an uploaded file name.
+ * - newBlobKey: Function to generate a random blob key for a file of a task.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/db/blobstore"
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type AttachmentService interface {
+	Upload(taskID, uploaderID int, fileName, contentType string, size int64, content io.Reader) (*model.Attachment, error)
+	GetList(taskID int) ([]model.Attachment, error)
+	Open(taskID, id int) (*model.Attachment, io.ReadCloser, error)
+	Delete(taskID, id, userID int) error
+	PurgeOrphanedBlobs() (int, error)
+}
+
+type attachmentService struct {
+	attachmentRepository repo.AttachmentRepository
+	taskRepository       repo.TaskRepository
+	blobStore            blobstore.BlobStore
+}
+
+func NewAttachmentService(attachmentRepository repo.AttachmentRepository, taskRepository repo.TaskRepository, blobStore blobstore.BlobStore) AttachmentService {
+	return &attachmentService{attachmentRepository, taskRepository, blobStore}
+}
+
+func (s *attachmentService) Upload(taskID, uploaderID int, fileName, contentType string, size int64, content io.Reader) (*model.Attachment, error) {
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	if limit := config.GetMaxAttachmentSize(); size > limit {
+		return nil, fmt.Errorf("%w: %d bytes, the limit is %d", model.ErrAttachmentTooLarge, size, limit)
+	}
+
+	fileName = cleanFileName(fileName)
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType = detectContentType(head, contentType, fileName)
+	allowed := false
+	for _, t := range config.GetAttachmentTypes() {
+		if t == contentType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: %s", model.ErrAttachmentType, contentType)
+	}
+
+	key, err := newBlobKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.blobStore.Put(key, io.MultiReader(bytes.NewReader(head), content), size, contentType); err != nil {
+		return nil, err
+	}
+
+	attachment := model.Attachment{
+		TaskID:      taskID,
+		UploaderID:  uploaderID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		Key:         key,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.attachmentRepository.Store(&attachment); err != nil {
+		s.blobStore.Delete(key)
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (s *attachmentService) GetList(taskID int) ([]model.Attachment, error) {
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepository.GetList(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if attachments == nil {
+		attachments = []model.Attachment{}
+	}
+	return attachments, nil
+}
+
+func (s *attachmentService) Open(taskID, id int) (*model.Attachment, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepository.GetByID(id)
+	if err != nil || attachment.TaskID != taskID {
+		return nil, nil, fmt.Errorf("%w: %d", model.ErrAttachmentNotFound, id)
+	}
+
+	content, err := s.blobStore.Get(attachment.Key)
+	if errors.Is(err, blobstore.ErrBlobNotFound) {
+		return nil, nil, fmt.Errorf("%w: %d", model.ErrAttachmentNotFound, id)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (s *attachmentService) Delete(taskID, id, userID int) error {
+	attachment, err := s.attachmentRepository.GetByID(id)
+	if err != nil || attachment.TaskID != taskID {
+		return fmt.Errorf("%w: %d", model.ErrAttachmentNotFound, id)
+	}
+
+	if attachment.UploaderID != userID {
+		return fmt.Errorf("%w: %d", model.ErrNotAttachmentUploader, id)
+	}
+
+	if err := s.attachmentRepository.Delete(id); err != nil {
+		return err
+	}
+
+	// The key is queued as orphaned, so a blob that cannot be removed now is retried by PurgeOrphanedBlobs.
+	s.removeBlob(attachment.Key)
+	return nil
+}
+
+func (s *attachmentService) PurgeOrphanedBlobs() (int, error) {
+	keys, err := s.attachmentRepository.GetOrphanedBlobs()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	var lastErr error
+	for _, key := range keys {
+		if err := s.removeBlob(key); err != nil {
+			lastErr = err
+			continue
+		}
+		removed++
+	}
+	return removed, lastErr
+}
+
+func (s *attachmentService) removeBlob(key string) error {
+	if err := s.blobStore.Delete(key); err != nil {
+		return err
+	}
+	return s.attachmentRepository.ForgetOrphanedBlob(key)
+}
+
+func detectContentType(head []byte, declared, fileName string) string {
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+
+	if declared == "" || declared == "application/octet-stream" {
+		declared = mime.TypeByExtension(path.Ext(fileName))
+	}
+	declared, _, _ = mime.ParseMediaType(declared)
+	declared = strings.ToLower(declared)
+
+	switch {
+	case declared == "":
+		return sniffed
+	case sniffed == "application/octet-stream", sniffed == "application/zip":
+		// Office documents are zip archives or OLE files, which sniffing cannot tell apart
+		if !strings.HasPrefix(declared, "image/") && !strings.HasPrefix(declared, "text/") {
+			return declared
+		}
+	case sniffed == "text/plain" && strings.HasPrefix(declared, "text/") && declared != "text/html":
+		return declared
+	}
+	return sniffed
+}
+
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	return name
+}
+
+func newBlobKey(taskID int) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(random)), nil
+}
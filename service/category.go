@@ -9,7 +9,9 @@
  *   - Update: Method to update a category.
  *   - Delete: Method to delete a category.
  *   - GetByID: Method to retrieve a category by ID.
- *   - GetList: Method to retrieve a list of categories.
+ *   - GetList: Method to retrieve the categories of a workspace.
+ *   - Query: Method to retrieve a filtered, sorted page of the categories of a workspace.
+ *   - SetProject: Method to group a category under a project or take it out.
  * 
  * Structs:
  * 
@@ -19,10 +21,12 @@
  *   Methods:
  *   - NewCategoryService: Function to create a new instance of categoryService.
  *   - Store: Method to store a category using the category repository.
- *   - Update: Method to update a category using the category repository.
- *   - Delete: Method to delete a category using the category repository.
+ *   - Update: Method to update a category using the category repository, keeping its owner when none is given and always its workspace and project.
+ *   - Delete: Method to move a category to the trash using the category repository. Its tasks are kept.
  *   - GetByID: Method to retrieve a category by ID using the category repository.
- *   - GetList: Method to retrieve a list of categories using the category repository.
+ *   - GetList: Method to retrieve the categories of a workspace using the category repository.
+ *   - Query: Method to validate a category query and retrieve the page it selects using the category repository.
+ *   - SetProject: Method to set the project of a category, 0 taking it out of its project, using the category repository.
  */
 
 package service
@@ -30,6 +34,7 @@ package service
 import (
 	"a21hc3NpZ25tZW50/model"
 	repo "a21hc3NpZ25tZW50/repository"
+	"time"
 )
 
 type CategoryService interface {
@@ -37,7 +42,9 @@ type CategoryService interface {
 	Update(id int, category model.Category) error
 	Delete(id int) error
 	GetByID(id int) (*model.Category, error)
-	GetList() ([]model.Category, error)
+	GetList(workspaceID int) ([]model.Category, error)
+	Query(workspaceID int, query model.CategoryQuery) (model.CategoryPage, error)
+	SetProject(id, projectID int) error
 }
 
 type categoryService struct {
@@ -53,17 +60,43 @@ func (c *categoryService) Store(category *model.Category) error {
 }
 
 func (c *categoryService) Update(id int, category model.Category) error {
+	if existing, err := c.categoryRepository.GetByID(id); err == nil {
+		if category.UserID == 0 {
+			category.UserID = existing.UserID
+		}
+		category.WorkspaceID = existing.WorkspaceID
+		category.ProjectID = existing.ProjectID
+	}
+
 	return c.categoryRepository.Update(id, category)
 }
 
 func (c *categoryService) Delete(id int) error {
-	return c.categoryRepository.Delete(id)
+	_, err := c.categoryRepository.Trash(id, time.Now())
+	return err
 }
 
 func (c *categoryService) GetByID(id int) (*model.Category, error) {
 	return c.categoryRepository.GetByID(id)
 }
 
-func (c *categoryService) GetList() ([]model.Category, error) {
-	return c.categoryRepository.GetList()
+func (c *categoryService) GetList(workspaceID int) ([]model.Category, error) {
+	return c.categoryRepository.GetList(workspaceID)
+}
+
+func (c *categoryService) Query(workspaceID int, query model.CategoryQuery) (model.CategoryPage, error) {
+	if err := query.Validate(); err != nil {
+		return model.CategoryPage{}, err
+	}
+	return c.categoryRepository.Query(workspaceID, query)
+}
+
+func (c *categoryService) SetProject(id, projectID int) error {
+	category, err := c.categoryRepository.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	category.ProjectID = projectID
+	return c.categoryRepository.Update(id, *category)
 }
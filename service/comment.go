@@ -0,0 +1,186 @@
+/** 
+ * Package service provides interfaces and implementations for managing task comments.
+ * 
+ * Interfaces:
+ * 
+ * - CommentService: Interface defining methods for comment management.
+ *   Methods:
+ *   - Add: Method to comment on a task.
+ *   - Edit: Method to change the body of a comment.
+ *   - Delete: Method to delete a comment.
+ *   - GetPage: Method to retrieve one page of a task's comments.
+ * 
+ * Structs:
+ * 
+ * - commentService: Struct implementing the CommentService interface.
+ *   Fields:
+ *   - commentRepository: Instance of repo.CommentRepository for comment repository operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to check that the commented task exists.
+ *   Methods:
+ *   - NewCommentService: Function to create a new instance of commentService.
+ *   - Add: Method to store a comment of the given author on an existing task, rendering its Markdown body to HTML.
+ *   - Edit: Method to replace the body of a comment, keeping the previous body in its edit history.
+ *     Only the author can edit a comment, and deleted comments cannot be edited.
+ *   - Delete: Method to soft delete a comment. Only the author can delete a comment; deleting it twice changes nothing.
+ *   - GetPage: Method to retrieve one page of a task's comments, oldest first. Pages start at 1, the page size defaults
+ *     to DefaultCommentPageSize and is capped at MaxCommentPageSize. Deleted comments stay in the thread without their body.
+ *   - authoredComment: Method to retrieve a comment of a task, checking that the user wrote it.
+ * 
+ * Functions:
+ * 
+ * - redactComment: Function to hide the body and history of a deleted comment.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type CommentService interface {
+	Add(taskID, authorID int, author, body string) (*model.Comment, error)
+	Edit(taskID, id, userID int, body string) (*model.Comment, error)
+	Delete(taskID, id, userID int) error
+	GetPage(taskID, page, pageSize int) (model.CommentPage, error)
+}
+
+type commentService struct {
+	commentRepository repo.CommentRepository
+	taskRepository    repo.TaskRepository
+}
+
+func NewCommentService(commentRepository repo.CommentRepository, taskRepository repo.TaskRepository) CommentService {
+	return &commentService{commentRepository, taskRepository}
+}
+
+func (s *commentService) Add(taskID, authorID int, author, body string) (*model.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, model.ErrEmptyComment
+	}
+
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	comment := model.Comment{
+		TaskID:    taskID,
+		AuthorID:  authorID,
+		Author:    author,
+		Body:      body,
+		HTML:      model.RenderMarkdown(body),
+		CreatedAt: time.Now(),
+	}
+	if err := s.commentRepository.Store(&comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (s *commentService) Edit(taskID, id, userID int, body string) (*model.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, model.ErrEmptyComment
+	}
+
+	comment, err := s.authoredComment(taskID, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: %d", model.ErrCommentDeleted, id)
+	}
+
+	if body == comment.Body {
+		return comment, nil
+	}
+
+	now := time.Now()
+	comment.Edits = append(comment.Edits, model.CommentEdit{Body: comment.Body, EditedAt: now})
+	comment.Body = body
+	comment.HTML = model.RenderMarkdown(body)
+	comment.EditedAt = &now
+
+	if err := s.commentRepository.Update(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *commentService) Delete(taskID, id, userID int) error {
+	comment, err := s.authoredComment(taskID, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if comment.DeletedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	comment.DeletedAt = &now
+	return s.commentRepository.Update(comment)
+}
+
+func (s *commentService) GetPage(taskID, page, pageSize int) (model.CommentPage, error) {
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return model.CommentPage{}, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = model.DefaultCommentPageSize
+	}
+	if pageSize > model.MaxCommentPageSize {
+		pageSize = model.MaxCommentPageSize
+	}
+
+	comments, err := s.commentRepository.GetList(taskID)
+	if err != nil {
+		return model.CommentPage{}, err
+	}
+
+	// Pages past the end are checked before multiplying, so huge page numbers cannot overflow the offset.
+	start := len(comments)
+	if page-1 <= len(comments)/pageSize {
+		start = (page - 1) * pageSize
+	}
+	end := start + pageSize
+	if end > len(comments) {
+		end = len(comments)
+	}
+
+	result := model.CommentPage{Comments: []model.Comment{}, Page: page, PageSize: pageSize, Total: len(comments)}
+	for _, comment := range comments[start:end] {
+		result.Comments = append(result.Comments, redactComment(comment))
+	}
+	return result, nil
+}
+
+func (s *commentService) authoredComment(taskID, id, userID int) (*model.Comment, error) {
+	comment, err := s.commentRepository.GetByID(id)
+	if err != nil || comment.TaskID != taskID {
+		return nil, fmt.Errorf("%w: %d", model.ErrCommentNotFound, id)
+	}
+
+	if comment.AuthorID != userID {
+		return nil, fmt.Errorf("%w: %d", model.ErrNotCommentAuthor, id)
+	}
+	return comment, nil
+}
+
+func redactComment(comment model.Comment) model.Comment {
+	if comment.DeletedAt != nil {
+		comment.Body = ""
+		comment.HTML = ""
+		comment.Edits = nil
+	}
+	return comment
+}
@@ -0,0 +1,257 @@
+/** 
+ * Package service provides interfaces and implementations for managing dependencies between tasks.
+ * 
+ * Interfaces:
+ * 
+ * - DependencyService: Interface defining methods for dependency management.
+ *   Methods:
+ *   - Block: Method to make a task block another one.
+ *   - Unblock: Method to remove a dependency.
+ *   - GetTaskDependencies: Method to retrieve the direct dependencies of a task.
+ *   - GetGraph: Method to retrieve the dependency graph of a category of a workspace.
+ * 
+ * Structs:
+ * 
+ * - dependencyService: Struct implementing the DependencyService interface.
+ *   Fields:
+ *   - dependencyRepository: Instance of repo.DependencyRepository for dependency repository operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to look up the tasks of a dependency.
+ *   Methods:
+ *   - NewDependencyService: Function to create a new instance of dependencyService.
+ *   - Block: Method to make the blocker block the blocked task. Both tasks must exist in the same workspace, a task cannot block itself
+ *     and a dependency that would let a task wait, directly or not, for itself is refused with ErrDependencyCycle.
+ *   - Unblock: Method to remove a dependency. Removing a missing dependency changes nothing.
+ *   - GetTaskDependencies: Method to retrieve the tasks blocking a task and the tasks it blocks, in ID order.
+ *   - GetGraph: Method to retrieve the tasks of a category of the workspace with the dependencies between them, their topological order
+ *     and the critical path: the longest chain of open tasks, each counting for one step.
+ * 
+ * Functions:
+ * 
+ * - dependsOn: Function to check whether a task waits, directly or not, for another one.
+ * - topologicalOrder: Function to order tasks so that every blocker comes before the tasks it blocks, lowest ID first among ready tasks.
+ * - criticalPath: Function to find the longest chain of open tasks in a topological order.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+)
+
+type DependencyService interface {
+	Block(blockerID, blockedID int) error
+	Unblock(blockerID, blockedID int) error
+	GetTaskDependencies(taskID int) (model.TaskDependencies, error)
+	GetGraph(workspaceID, categoryID int) (model.DependencyGraph, error)
+}
+
+type dependencyService struct {
+	dependencyRepository repo.DependencyRepository
+	taskRepository       repo.TaskRepository
+}
+
+func NewDependencyService(dependencyRepository repo.DependencyRepository, taskRepository repo.TaskRepository) DependencyService {
+	return &dependencyService{dependencyRepository, taskRepository}
+}
+
+func (s *dependencyService) Block(blockerID, blockedID int) error {
+	if blockerID == blockedID {
+		return fmt.Errorf("%w: %d", model.ErrSelfDependency, blockerID)
+	}
+
+	workspaces := map[int]bool{}
+	for _, id := range []int{blockerID, blockedID} {
+		task, err := s.taskRepository.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("%w: %d", model.ErrDependencyTaskNotFound, id)
+		}
+		workspaces[task.WorkspaceID] = true
+	}
+	if len(workspaces) > 1 {
+		return fmt.Errorf("%w: %d is in another workspace", model.ErrDependencyTaskNotFound, blockerID)
+	}
+
+	dependencies, err := s.dependencyRepository.GetList()
+	if err != nil {
+		return err
+	}
+
+	if dependsOn(dependencies, blockerID, blockedID) {
+		return fmt.Errorf("%w: task %d already waits for task %d", model.ErrDependencyCycle, blockerID, blockedID)
+	}
+
+	return s.dependencyRepository.Add(blockerID, blockedID)
+}
+
+func (s *dependencyService) Unblock(blockerID, blockedID int) error {
+	return s.dependencyRepository.Remove(blockerID, blockedID)
+}
+
+func (s *dependencyService) GetTaskDependencies(taskID int) (model.TaskDependencies, error) {
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return model.TaskDependencies{}, fmt.Errorf("%w: %d", model.ErrDependencyTaskNotFound, taskID)
+	}
+
+	dependencies, err := s.dependencyRepository.GetList()
+	if err != nil {
+		return model.TaskDependencies{}, err
+	}
+
+	result := model.TaskDependencies{BlockedBy: []model.Task{}, Blocks: []model.Task{}}
+	for _, dependency := range dependencies {
+		var list *[]model.Task
+		var otherID int
+		switch taskID {
+		case dependency.BlockedID:
+			list, otherID = &result.BlockedBy, dependency.BlockerID
+		case dependency.BlockerID:
+			list, otherID = &result.Blocks, dependency.BlockedID
+		default:
+			continue
+		}
+
+		task, err := s.taskRepository.GetByID(otherID)
+		if err != nil {
+			continue
+		}
+		*list = append(*list, *task)
+	}
+
+	sort.Slice(result.BlockedBy, func(i, j int) bool { return result.BlockedBy[i].ID < result.BlockedBy[j].ID })
+	sort.Slice(result.Blocks, func(i, j int) bool { return result.Blocks[i].ID < result.Blocks[j].ID })
+	return result, nil
+}
+
+func (s *dependencyService) GetGraph(workspaceID, categoryID int) (model.DependencyGraph, error) {
+	tasks, err := s.taskRepository.GetList(workspaceID)
+	if err != nil {
+		return model.DependencyGraph{}, err
+	}
+
+	dependencies, err := s.dependencyRepository.GetList()
+	if err != nil {
+		return model.DependencyGraph{}, err
+	}
+
+	graph := model.DependencyGraph{
+		CategoryID:   categoryID,
+		Tasks:        []model.Task{},
+		Edges:        []model.Dependency{},
+		CriticalPath: []int{},
+	}
+	inCategory := map[int]model.Task{}
+	for _, task := range tasks {
+		if task.CategoryID == categoryID {
+			graph.Tasks = append(graph.Tasks, task)
+			inCategory[task.ID] = task
+		}
+	}
+	sort.Slice(graph.Tasks, func(i, j int) bool { return graph.Tasks[i].ID < graph.Tasks[j].ID })
+
+	for _, dependency := range dependencies {
+		_, blocker := inCategory[dependency.BlockerID]
+		_, blocked := inCategory[dependency.BlockedID]
+		if blocker && blocked {
+			graph.Edges = append(graph.Edges, dependency)
+		}
+	}
+
+	graph.Order = topologicalOrder(graph.Tasks, graph.Edges)
+	graph.CriticalPath = criticalPath(inCategory, graph.Order, graph.Edges)
+	return graph, nil
+}
+
+// dependsOn walks the dependencies from taskID towards its blockers and reports whether
+// blockerID is reached.
+func dependsOn(dependencies []model.Dependency, taskID, blockerID int) bool {
+	blockers := map[int][]int{}
+	for _, dependency := range dependencies {
+		blockers[dependency.BlockedID] = append(blockers[dependency.BlockedID], dependency.BlockerID)
+	}
+
+	seen := map[int]bool{}
+	pending := []int{taskID}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if current == blockerID {
+			return true
+		}
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		pending = append(pending, blockers[current]...)
+	}
+	return false
+}
+
+func topologicalOrder(tasks []model.Task, edges []model.Dependency) []int {
+	waiting := map[int]int{}
+	blocks := map[int][]int{}
+	for _, edge := range edges {
+		waiting[edge.BlockedID]++
+		blocks[edge.BlockerID] = append(blocks[edge.BlockerID], edge.BlockedID)
+	}
+
+	var ready []int
+	for _, task := range tasks {
+		if waiting[task.ID] == 0 {
+			ready = append(ready, task.ID)
+		}
+	}
+
+	order := []int{}
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		current := ready[0]
+		ready = ready[1:]
+		order = append(order, current)
+
+		for _, blocked := range blocks[current] {
+			if waiting[blocked]--; waiting[blocked] == 0 {
+				ready = append(ready, blocked)
+			}
+		}
+	}
+	return order
+}
+
+func criticalPath(tasks map[int]model.Task, order []int, edges []model.Dependency) []int {
+	blockers := map[int][]int{}
+	for _, edge := range edges {
+		blockers[edge.BlockedID] = append(blockers[edge.BlockedID], edge.BlockerID)
+	}
+
+	length := map[int]int{}
+	previous := map[int]int{}
+	end := 0
+	for _, id := range order {
+		if tasks[id].Status == model.StatusCompleted {
+			continue
+		}
+
+		length[id] = 1
+		for _, blocker := range blockers[id] {
+			if length[blocker] == 0 {
+				continue
+			}
+			if length[blocker]+1 > length[id] || length[blocker]+1 == length[id] && blocker < previous[id] {
+				length[id] = length[blocker] + 1
+				previous[id] = blocker
+			}
+		}
+		if end == 0 || length[id] > length[end] {
+			end = id
+		}
+	}
+
+	path := []int{}
+	for id := end; id != 0; id = previous[id] {
+		path = append([]int{id}, path...)
+	}
+	return path
+}
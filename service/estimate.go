@@ -0,0 +1,103 @@
+/** 
+ * Package service provides interfaces and implementations for rolling up task estimates.
+ * 
+ * Interfaces:
+ * 
+ * - EstimateService: Interface defining methods for estimate reporting.
+ *   Methods:
+ *   - GetRollup: Method to roll up the estimates of every task of a workspace per category and per user.
+ * 
+ * Structs:
+ * 
+ * - estimateService: Struct implementing the EstimateService interface.
+ *   Fields:
+ *   - taskRepository: Instance of repo.TaskRepository used to list the tasks.
+ *   - categoryRepository: Instance of repo.CategoryRepository used to name the categories.
+ *   - userRepository: Instance of repo.UserRepository used to name the task owners.
+ *   Methods:
+ *   - NewEstimateService: Function to create a new instance of estimateService.
+ *   - GetRollup: Method to sum the hour and story point estimates of the tasks of the workspace, and of the completed ones, per category
+ *     and per owner. Every task counts on its own, so a parent and its subtasks each add their own estimate.
+ * 
+ * Functions:
+ * 
+ * - estimateTotals: Function to turn totals per ID into totals ordered by ID, named from the given names.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"sort"
+)
+
+type EstimateService interface {
+	GetRollup(workspaceID int) (model.EstimateRollup, error)
+}
+
+type estimateService struct {
+	taskRepository     repo.TaskRepository
+	categoryRepository repo.CategoryRepository
+	userRepository     repo.UserRepository
+}
+
+func NewEstimateService(taskRepository repo.TaskRepository, categoryRepository repo.CategoryRepository, userRepository repo.UserRepository) EstimateService {
+	return &estimateService{taskRepository, categoryRepository, userRepository}
+}
+
+func (s *estimateService) GetRollup(workspaceID int) (model.EstimateRollup, error) {
+	tasks, err := s.taskRepository.GetList(workspaceID)
+	if err != nil {
+		return model.EstimateRollup{}, err
+	}
+
+	categories, err := s.categoryRepository.GetList(workspaceID)
+	if err != nil {
+		return model.EstimateRollup{}, err
+	}
+	categoryNames := map[int]string{}
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	users, err := s.userRepository.GetUserList()
+	if err != nil {
+		return model.EstimateRollup{}, err
+	}
+	userNames := map[int]string{}
+	for _, user := range users {
+		userNames[user.ID] = user.Fullname
+	}
+
+	var rollup model.EstimateRollup
+	byCategory, byUser := map[int]*model.EstimateTotal{}, map[int]*model.EstimateTotal{}
+	for _, task := range tasks {
+		for _, group := range []struct {
+			totals map[int]*model.EstimateTotal
+			id     int
+		}{{byCategory, task.CategoryID}, {byUser, task.UserID}} {
+			total, ok := group.totals[group.id]
+			if !ok {
+				total = &model.EstimateTotal{ID: group.id}
+				group.totals[group.id] = total
+			}
+			total.Add(task)
+		}
+		rollup.Total.Add(task)
+	}
+
+	rollup.ByCategory = estimateTotals(byCategory, categoryNames)
+	rollup.ByUser = estimateTotals(byUser, userNames)
+	return rollup, nil
+}
+
+func estimateTotals(totals map[int]*model.EstimateTotal, names map[int]string) []model.EstimateTotal {
+	result := []model.EstimateTotal{}
+	for id, total := range totals {
+		total.Name = names[id]
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
@@ -0,0 +1,141 @@
+/** 
+ * Package service provides interfaces and implementations for managing the custom fields of a workspace.
+ * 
+ * Interfaces:
+ * 
+ * - FieldService: Interface defining methods for custom field management.
+ *   Methods:
+ *   - Create: Method to define a custom field.
+ *   - Update: Method to rename a custom field or change the options of a select field.
+ *   - Delete: Method to delete a custom field.
+ *   - GetByID: Method to retrieve a custom field by ID.
+ *   - GetList: Method to retrieve the custom fields of a workspace.
+ * 
+ * Structs:
+ * 
+ * - fieldService: Struct implementing the FieldService interface.
+ *   Fields:
+ *   - fieldRepository: Instance of repo.FieldRepository for custom field repository operations.
+ *   Methods:
+ *   - NewFieldService: Function to create a new instance of fieldService.
+ *   - Create: Method to store a custom field with a trimmed name unique in its workspace, a known type and, for select
+ *     fields, at least one option.
+ *   - Update: Method to change the name and options of a custom field of the workspace. The type is kept, so the values
+ *     stored on tasks stay valid; tasks keep values of removed select options until they are changed.
+ *   - Delete: Method to delete a custom field of the workspace together with its values on every task, on behalf of the actor.
+ *   - GetByID: Method to retrieve a custom field, reporting fields of other workspaces as not found.
+ *   - GetList: Method to retrieve the custom fields of a workspace using the custom field repository.
+ *   - field: Method to retrieve a custom field of the workspace or ErrFieldNotFound.
+ *   - validateField: Method to trim the name and options of a custom field and check them and its type.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"strings"
+)
+
+type FieldService interface {
+	Create(field model.CustomField) (model.CustomField, error)
+	Update(id, workspaceID int, request model.FieldRequest) (model.CustomField, error)
+	Delete(id, workspaceID int, actor string) error
+	GetByID(id, workspaceID int) (*model.CustomField, error)
+	GetList(workspaceID int) ([]model.CustomField, error)
+}
+
+type fieldService struct {
+	fieldRepository repo.FieldRepository
+}
+
+func NewFieldService(fieldRepository repo.FieldRepository) FieldService {
+	return &fieldService{fieldRepository}
+}
+
+func (s *fieldService) Create(field model.CustomField) (model.CustomField, error) {
+	field.ID = 0
+	if err := s.validateField(&field); err != nil {
+		return model.CustomField{}, err
+	}
+	return s.fieldRepository.Store(field)
+}
+
+func (s *fieldService) Update(id, workspaceID int, request model.FieldRequest) (model.CustomField, error) {
+	field, err := s.field(id, workspaceID)
+	if err != nil {
+		return model.CustomField{}, err
+	}
+
+	field.Name = request.Name
+	if field.Type == model.FieldSelect {
+		field.Options = request.Options
+	}
+	if err := s.validateField(field); err != nil {
+		return model.CustomField{}, err
+	}
+	return s.fieldRepository.Store(*field)
+}
+
+func (s *fieldService) Delete(id, workspaceID int, actor string) error {
+	if _, err := s.field(id, workspaceID); err != nil {
+		return err
+	}
+	return s.fieldRepository.Delete(id, actor)
+}
+
+func (s *fieldService) GetByID(id, workspaceID int) (*model.CustomField, error) {
+	return s.field(id, workspaceID)
+}
+
+func (s *fieldService) GetList(workspaceID int) ([]model.CustomField, error) {
+	return s.fieldRepository.GetList(workspaceID)
+}
+
+func (s *fieldService) field(id, workspaceID int) (*model.CustomField, error) {
+	field, err := s.fieldRepository.GetByID(id)
+	if err != nil || field.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("%w: %d", model.ErrFieldNotFound, id)
+	}
+	return field, nil
+}
+
+func (s *fieldService) validateField(field *model.CustomField) error {
+	field.Name = strings.TrimSpace(field.Name)
+	if field.Name == "" {
+		return model.ErrInvalidField
+	}
+
+	switch field.Type {
+	case model.FieldText, model.FieldNumber, model.FieldDate, model.FieldCheckbox:
+		field.Options = nil
+	case model.FieldSelect:
+		var options []string
+		seen := map[string]bool{}
+		for _, option := range field.Options {
+			option = strings.TrimSpace(option)
+			if option != "" && !seen[option] {
+				seen[option] = true
+				options = append(options, option)
+			}
+		}
+		if len(options) == 0 {
+			return fmt.Errorf("%w: select field %q has no options", model.ErrInvalidField, field.Name)
+		}
+		field.Options = options
+	default:
+		return fmt.Errorf("%w: unknown type %q", model.ErrInvalidField, field.Type)
+	}
+
+	fields, err := s.fieldRepository.GetList(field.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	for _, other := range fields {
+		if other.ID != field.ID && strings.EqualFold(other.Name, field.Name) {
+			return fmt.Errorf("%w: %q", model.ErrDuplicateField, field.Name)
+		}
+	}
+	return nil
+}
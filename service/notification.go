@@ -0,0 +1,340 @@
+/**
+ * Package service provides interfaces and implementations for watching tasks and categories and notifying users.
+ *
+ * Interfaces:
+ *
+ * - NotificationService: Interface defining methods for watchers and notifications.
+ *   Methods:
+ *   - Watch: Method to start watching a task or category.
+ *   - Unwatch: Method to stop watching a task or category.
+ *   - GetWatches: Method to retrieve what a user watches.
+ *   - StatusChanged: Method to notify the followers of a task that its status changed.
+ *   - Commented: Method to notify the followers of a task of a new comment.
+ *   - Assigned: Method to notify a user assigned to a task and the followers of the task.
+ *   - NotifyDueSoon: Method to remind the followers of tasks whose deadline is approaching.
+ *   - GetList: Method to retrieve the notifications of a user.
+ *   - CountUnread: Method to count the unread notifications of a user.
+ *   - MarkRead: Method to mark a notification as read.
+ *   - MarkAllRead: Method to mark every notification of a user as read.
+ *
+ * Structs:
+ *
+ * - notificationService: Struct implementing the NotificationService interface.
+ *   Fields:
+ *   - notificationRepository: Instance of repo.NotificationRepository for watch and notification repository operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to find the tasks events happen on.
+ *   - assignmentRepository: Instance of repo.AssignmentRepository used to find the assignees of a task.
+ *   - userRepository: Instance of repo.UserRepository used to find the tasks with a deadline and the time zone of their owner.
+ *   Methods:
+ *   - NewNotificationService: Function to create a new instance of notificationService.
+ *   - Watch: Method to watch an existing task or category. Watching it again keeps the original watch.
+ *   - Unwatch: Method to stop watching a task or category. Unwatching a record that is not watched changes nothing.
+ *   - GetWatches: Method to retrieve the watches of a user, in ID order.
+ *   - StatusChanged: Method to notify the followers of the task that it moved from the given status to its current one.
+ *   - Commented: Method to notify the followers of the commented task.
+ *   - Assigned: Method to notify the assignee that they were assigned to the task, and the other followers who was.
+ *   - NotifyDueSoon: Method to remind the followers of every open task whose deadline is due within DEADLINE_REMINDER,
+ *     once per deadline, and return how many notifications were sent. Date-only deadlines are due at the end of the day
+ *     in the time zone of the task's owner.
+ *   - GetList: Method to retrieve the notifications of a user, newest first, optionally only the unread ones.
+ *   - CountUnread: Method to count the unread notifications of a user.
+ *   - MarkRead: Method to mark a notification of the user as read, reporting notifications of other users as not found.
+ *   - MarkAllRead: Method to mark every unread notification of the user as read.
+ *   - notify: Method to send a notification about a task to its followers other than the actor.
+ *   - followers: Method to find the IDs of the owner, the assignees and the watchers of a task and of its category.
+ *   - remindedSince: Method to check whether a user was already reminded of the deadline of a task.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type NotificationService interface {
+	Watch(userID int, target model.WatchTarget, targetID int) (model.Watch, error)
+	Unwatch(userID int, target model.WatchTarget, targetID int) error
+	GetWatches(userID int) ([]model.Watch, error)
+	StatusChanged(task model.Task, from model.TaskStatus, actorID int, actor string) error
+	Commented(comment model.Comment) error
+	Assigned(taskID int, assignee model.UserProfile, actorID int, actor string) error
+	NotifyDueSoon(now time.Time) (int, error)
+	GetList(userID int, unreadOnly bool) ([]model.Notification, error)
+	CountUnread(userID int) (int, error)
+	MarkRead(userID, id int) error
+	MarkAllRead(userID int) error
+}
+
+type notificationService struct {
+	notificationRepository repo.NotificationRepository
+	taskRepository         repo.TaskRepository
+	assignmentRepository   repo.AssignmentRepository
+	userRepository         repo.UserRepository
+}
+
+func NewNotificationService(notificationRepository repo.NotificationRepository, taskRepository repo.TaskRepository, assignmentRepository repo.AssignmentRepository, userRepository repo.UserRepository) NotificationService {
+	return &notificationService{notificationRepository, taskRepository, assignmentRepository, userRepository}
+}
+
+func (s *notificationService) Watch(userID int, target model.WatchTarget, targetID int) (model.Watch, error) {
+	if target != model.WatchTask && target != model.WatchCategory {
+		return model.Watch{}, fmt.Errorf("%w: %q", model.ErrInvalidWatchTarget, target)
+	}
+
+	watch := model.Watch{UserID: userID, Target: target, TargetID: targetID, CreatedAt: time.Now()}
+	if err := s.notificationRepository.Watch(&watch); err != nil {
+		return model.Watch{}, err
+	}
+	return watch, nil
+}
+
+func (s *notificationService) Unwatch(userID int, target model.WatchTarget, targetID int) error {
+	if target != model.WatchTask && target != model.WatchCategory {
+		return fmt.Errorf("%w: %q", model.ErrInvalidWatchTarget, target)
+	}
+
+	return s.notificationRepository.Unwatch(userID, target, targetID)
+}
+
+func (s *notificationService) GetWatches(userID int) ([]model.Watch, error) {
+	watches, err := s.notificationRepository.GetWatches(userID)
+	if err != nil {
+		return nil, err
+	}
+	if watches == nil {
+		watches = []model.Watch{}
+	}
+	return watches, nil
+}
+
+func (s *notificationService) StatusChanged(task model.Task, from model.TaskStatus, actorID int, actor string) error {
+	if task.Status == from {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s moved %q from %s to %s", actor, task.Title, from, task.Status)
+	return s.notify(task, model.NotifyStatusChanged, actorID, actor, message)
+}
+
+func (s *notificationService) Commented(comment model.Comment) error {
+	task, err := s.taskRepository.GetByID(comment.TaskID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("%s commented on %q", comment.Author, task.Title)
+	return s.notify(*task, model.NotifyCommented, comment.AuthorID, comment.Author, message)
+}
+
+func (s *notificationService) Assigned(taskID int, assignee model.UserProfile, actorID int, actor string) error {
+	task, err := s.taskRepository.GetByID(taskID)
+	if err != nil {
+		return err
+	}
+
+	followers, err := s.followers(*task)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var notifications []model.Notification
+	for _, userID := range followers {
+		if userID == actorID {
+			continue
+		}
+
+		message := fmt.Sprintf("%s assigned %s to %q", actor, assignee.Email, task.Title)
+		if userID == assignee.ID {
+			message = fmt.Sprintf("%s assigned you to %q", actor, task.Title)
+		}
+		notifications = append(notifications, model.Notification{
+			UserID:    userID,
+			Kind:      model.NotifyAssigned,
+			TaskID:    task.ID,
+			Actor:     actor,
+			Message:   message,
+			CreatedAt: now,
+		})
+	}
+	return s.notificationRepository.Store(notifications)
+}
+
+func (s *notificationService) NotifyDueSoon(now time.Time) (int, error) {
+	users, err := s.userRepository.GetUserList()
+	if err != nil {
+		return 0, err
+	}
+
+	reminder := config.GetDeadlineReminder()
+	var notifications []model.Notification
+	for _, user := range users {
+		tasks, err := s.taskRepository.GetListByUser(user.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		loc := model.LoadLocation(user.TimeZone)
+		for _, task := range tasks {
+			if task.Deadline.IsZero() || task.Status == model.StatusCompleted || task.ArchivedAt != nil {
+				continue
+			}
+			due := task.Deadline.Due(loc)
+			if !due.After(now) || due.After(now.Add(reminder)) {
+				continue
+			}
+
+			followers, err := s.followers(task)
+			if err != nil {
+				return 0, err
+			}
+			for _, userID := range followers {
+				reminded, err := s.remindedSince(userID, task.ID, due.Add(-reminder))
+				if err != nil {
+					return 0, err
+				}
+				if reminded {
+					continue
+				}
+
+				notifications = append(notifications, model.Notification{
+					UserID:    userID,
+					Kind:      model.NotifyDeadline,
+					TaskID:    task.ID,
+					Message:   fmt.Sprintf("%q is due %s", task.Title, task.Deadline.Format(loc)),
+					CreatedAt: now,
+				})
+			}
+		}
+	}
+
+	if err := s.notificationRepository.Store(notifications); err != nil {
+		return 0, err
+	}
+	return len(notifications), nil
+}
+
+func (s *notificationService) GetList(userID int, unreadOnly bool) ([]model.Notification, error) {
+	notifications, err := s.notificationRepository.GetList(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []model.Notification{}
+	for i := len(notifications) - 1; i >= 0; i-- {
+		if !unreadOnly || notifications[i].ReadAt == nil {
+			list = append(list, notifications[i])
+		}
+	}
+	return list, nil
+}
+
+func (s *notificationService) CountUnread(userID int) (int, error) {
+	unread, err := s.GetList(userID, true)
+	if err != nil {
+		return 0, err
+	}
+	return len(unread), nil
+}
+
+func (s *notificationService) MarkRead(userID, id int) error {
+	notifications, err := s.notificationRepository.GetList(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		if notification.ID == id {
+			return s.notificationRepository.MarkRead(userID, id, time.Now())
+		}
+	}
+	return fmt.Errorf("%w: %d", model.ErrNotificationNotFound, id)
+}
+
+func (s *notificationService) MarkAllRead(userID int) error {
+	return s.notificationRepository.MarkRead(userID, 0, time.Now())
+}
+
+func (s *notificationService) notify(task model.Task, kind model.NotificationKind, actorID int, actor, message string) error {
+	followers, err := s.followers(task)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var notifications []model.Notification
+	for _, userID := range followers {
+		if userID == actorID {
+			continue
+		}
+		notifications = append(notifications, model.Notification{
+			UserID:    userID,
+			Kind:      kind,
+			TaskID:    task.ID,
+			Actor:     actor,
+			Message:   message,
+			CreatedAt: now,
+		})
+	}
+	return s.notificationRepository.Store(notifications)
+}
+
+// followers returns the IDs of the users following the task, in ID order: its owner, its assignees, the users
+// watching it and the users watching its category.
+func (s *notificationService) followers(task model.Task) ([]int, error) {
+	following := map[int]bool{}
+	if task.UserID != 0 {
+		following[task.UserID] = true
+	}
+
+	assignments, err := s.assignmentRepository.GetList(task.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		following[assignment.UserID] = true
+	}
+
+	watches, err := s.notificationRepository.GetWatchers(model.WatchTask, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	if task.CategoryID != 0 {
+		categoryWatches, err := s.notificationRepository.GetWatchers(model.WatchCategory, task.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		watches = append(watches, categoryWatches...)
+	}
+	for _, watch := range watches {
+		following[watch.UserID] = true
+	}
+
+	ids := make([]int, 0, len(following))
+	for id := range following {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// remindedSince reports whether the user got a deadline reminder for the task at or after the given time, so a
+// deadline is only reminded once while a deadline moved later is reminded again.
+func (s *notificationService) remindedSince(userID, taskID int, since time.Time) (bool, error) {
+	notifications, err := s.notificationRepository.GetList(userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, notification := range notifications {
+		if notification.Kind == model.NotifyDeadline && notification.TaskID == taskID && !notification.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,339 @@
+/** 
+ * Package service provides interfaces and implementations for managing projects, their milestones and their overview.
+ * 
+ * Interfaces:
+ * 
+ * - ProjectService: Interface defining methods for project management.
+ *   Methods:
+ *   - Create: Method to create a project.
+ *   - Update: Method to rename a project or change its description.
+ *   - Delete: Method to delete a project.
+ *   - GetByID: Method to retrieve a project by ID.
+ *   - GetList: Method to retrieve the projects of a workspace.
+ *   - AddCategory: Method to group a category under a project.
+ *   - RemoveCategory: Method to take a category out of a project.
+ *   - AddMilestone: Method to add a milestone to a project.
+ *   - UpdateMilestone: Method to change the title or due date of a milestone.
+ *   - DeleteMilestone: Method to delete a milestone.
+ *   - AttachTask: Method to attach a task to a milestone.
+ *   - DetachTask: Method to detach a task from its milestone.
+ *   - GetOverview: Method to retrieve a project with the progress of its categories and milestones.
+ * 
+ * Structs:
+ * 
+ * - projectService: Struct implementing the ProjectService interface.
+ *   Fields:
+ *   - projectRepository: Instance of repo.ProjectRepository for project repository operations.
+ *   - categoryService: Instance of CategoryService to read and group categories.
+ *   - taskService: Instance of TaskService to read tasks and attach them to milestones.
+ *   - userRepository: Instance of repo.UserRepository to load the time zone milestones are checked in.
+ *   Methods:
+ *   - NewProjectService: Function to create a new instance of projectService.
+ *   - Create: Method to store a project with a trimmed, non-empty name in the workspace set on it.
+ *   - Update: Method to change the name and description of a project of the workspace, keeping its owner, workspace and creation time.
+ *   - Delete: Method to delete a project of the workspace with its milestones. Its categories and tasks are kept,
+ *     the tasks detached from the milestones on behalf of the actor.
+ *   - GetByID: Method to retrieve a project, reporting projects of other workspaces as not found.
+ *   - GetList: Method to retrieve the projects of a workspace using the project repository.
+ *   - AddCategory: Method to group a category of the project's workspace under the project using the category service.
+ *   - RemoveCategory: Method to take a category of the project out of it using the category service.
+ *   - AddMilestone: Method to store a milestone with a trimmed, non-empty title under a project of the workspace.
+ *   - UpdateMilestone: Method to change the title and due date of a milestone of the project.
+ *   - DeleteMilestone: Method to delete a milestone of the project, detaching its tasks on behalf of the actor.
+ *   - AttachTask: Method to attach a task of the project's workspace to a milestone of the project using the task service on behalf of the actor.
+ *   - DetachTask: Method to detach a task from a milestone of the project using the task service on behalf of the actor.
+ *   - GetOverview: Method to compute the progress of the project's categories and milestones from the tasks of the workspace,
+ *     flagging milestones whose due date passed in the user's time zone before all of their tasks were completed.
+ *   - project: Method to retrieve a project of the workspace or ErrProjectNotFound.
+ *   - milestone: Method to retrieve a milestone of a project of the workspace or ErrMilestoneNotFound.
+ * 
+ * Functions:
+ * 
+ * - sortMilestones: Function to order milestones by due date, those without one last, then by ID.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ProjectService interface {
+	Create(project model.Project) (model.Project, error)
+	Update(id, workspaceID int, request model.ProjectRequest) (model.Project, error)
+	Delete(id, workspaceID int, actor string) error
+	GetByID(id, workspaceID int) (*model.Project, error)
+	GetList(workspaceID int) ([]model.Project, error)
+	AddCategory(id, workspaceID, categoryID int) error
+	RemoveCategory(id, workspaceID, categoryID int) error
+	AddMilestone(id, workspaceID int, request model.MilestoneRequest) (model.Milestone, error)
+	UpdateMilestone(id, workspaceID, milestoneID int, request model.MilestoneRequest) (model.Milestone, error)
+	DeleteMilestone(id, workspaceID, milestoneID int, actor string) error
+	AttachTask(id, workspaceID, milestoneID, taskID int, actor string) error
+	DetachTask(id, workspaceID, milestoneID, taskID int, actor string) error
+	GetOverview(id, workspaceID, userID int) (model.ProjectOverview, error)
+}
+
+type projectService struct {
+	projectRepository repo.ProjectRepository
+	categoryService   CategoryService
+	taskService       TaskService
+	userRepository    repo.UserRepository
+}
+
+func NewProjectService(projectRepository repo.ProjectRepository, categoryService CategoryService, taskService TaskService, userRepository repo.UserRepository) ProjectService {
+	return &projectService{projectRepository, categoryService, taskService, userRepository}
+}
+
+func (s *projectService) Create(project model.Project) (model.Project, error) {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return model.Project{}, model.ErrInvalidProject
+	}
+
+	project.ID = 0
+	project.CreatedAt = time.Now()
+	return s.projectRepository.Store(project)
+}
+
+func (s *projectService) Update(id, workspaceID int, request model.ProjectRequest) (model.Project, error) {
+	project, err := s.project(id, workspaceID)
+	if err != nil {
+		return model.Project{}, err
+	}
+
+	project.Name = strings.TrimSpace(request.Name)
+	if project.Name == "" {
+		return model.Project{}, model.ErrInvalidProject
+	}
+	project.Description = request.Description
+	return s.projectRepository.Store(*project)
+}
+
+func (s *projectService) Delete(id, workspaceID int, actor string) error {
+	if _, err := s.project(id, workspaceID); err != nil {
+		return err
+	}
+	return s.projectRepository.Delete(id, actor)
+}
+
+func (s *projectService) GetByID(id, workspaceID int) (*model.Project, error) {
+	return s.project(id, workspaceID)
+}
+
+func (s *projectService) GetList(workspaceID int) ([]model.Project, error) {
+	return s.projectRepository.GetList(workspaceID)
+}
+
+func (s *projectService) AddCategory(id, workspaceID, categoryID int) error {
+	if _, err := s.project(id, workspaceID); err != nil {
+		return err
+	}
+
+	category, err := s.categoryService.GetByID(categoryID)
+	if err != nil || category.WorkspaceID != workspaceID {
+		return fmt.Errorf("%w: category %d", model.ErrProjectNotFound, categoryID)
+	}
+	return s.categoryService.SetProject(categoryID, id)
+}
+
+func (s *projectService) RemoveCategory(id, workspaceID, categoryID int) error {
+	if _, err := s.project(id, workspaceID); err != nil {
+		return err
+	}
+
+	category, err := s.categoryService.GetByID(categoryID)
+	if err != nil || category.ProjectID != id {
+		return fmt.Errorf("%w: category %d", model.ErrProjectNotFound, categoryID)
+	}
+	return s.categoryService.SetProject(categoryID, 0)
+}
+
+func (s *projectService) AddMilestone(id, workspaceID int, request model.MilestoneRequest) (model.Milestone, error) {
+	if _, err := s.project(id, workspaceID); err != nil {
+		return model.Milestone{}, err
+	}
+
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return model.Milestone{}, model.ErrInvalidMilestone
+	}
+
+	return s.projectRepository.StoreMilestone(model.Milestone{
+		ProjectID: id,
+		Title:     title,
+		DueDate:   request.DueDate,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *projectService) UpdateMilestone(id, workspaceID, milestoneID int, request model.MilestoneRequest) (model.Milestone, error) {
+	milestone, err := s.milestone(id, workspaceID, milestoneID)
+	if err != nil {
+		return model.Milestone{}, err
+	}
+
+	milestone.Title = strings.TrimSpace(request.Title)
+	if milestone.Title == "" {
+		return model.Milestone{}, model.ErrInvalidMilestone
+	}
+	milestone.DueDate = request.DueDate
+	return s.projectRepository.StoreMilestone(*milestone)
+}
+
+func (s *projectService) DeleteMilestone(id, workspaceID, milestoneID int, actor string) error {
+	if _, err := s.milestone(id, workspaceID, milestoneID); err != nil {
+		return err
+	}
+	return s.projectRepository.DeleteMilestone(milestoneID, actor)
+}
+
+func (s *projectService) AttachTask(id, workspaceID, milestoneID, taskID int, actor string) error {
+	if _, err := s.milestone(id, workspaceID, milestoneID); err != nil {
+		return err
+	}
+
+	task, err := s.taskService.GetByID(taskID)
+	if err != nil || task.WorkspaceID != workspaceID {
+		return fmt.Errorf("%w: task %d", model.ErrMilestoneNotFound, taskID)
+	}
+	return s.taskService.As(actor).SetMilestone(taskID, milestoneID)
+}
+
+func (s *projectService) DetachTask(id, workspaceID, milestoneID, taskID int, actor string) error {
+	if _, err := s.milestone(id, workspaceID, milestoneID); err != nil {
+		return err
+	}
+
+	task, err := s.taskService.GetByID(taskID)
+	if err != nil || task.MilestoneID != milestoneID {
+		return fmt.Errorf("%w: task %d", model.ErrMilestoneNotFound, taskID)
+	}
+	return s.taskService.As(actor).SetMilestone(taskID, 0)
+}
+
+func (s *projectService) GetOverview(id, workspaceID, userID int) (model.ProjectOverview, error) {
+	project, err := s.project(id, workspaceID)
+	if err != nil {
+		return model.ProjectOverview{}, err
+	}
+
+	loc := time.UTC
+	if user, err := s.userRepository.GetUserByID(userID); err == nil {
+		loc = model.LoadLocation(user.TimeZone)
+	}
+
+	categories, err := s.categoryService.GetList(workspaceID)
+	if err != nil {
+		return model.ProjectOverview{}, err
+	}
+
+	milestones, err := s.projectRepository.GetMilestones(id)
+	if err != nil {
+		return model.ProjectOverview{}, err
+	}
+	sortMilestones(milestones)
+
+	tasks, err := s.taskService.GetList(workspaceID)
+	if err != nil {
+		return model.ProjectOverview{}, err
+	}
+
+	byCategory, byMilestone := map[int][]model.Task{}, map[int][]model.Task{}
+	inMilestone := map[int]bool{}
+	for _, milestone := range milestones {
+		inMilestone[milestone.ID] = true
+	}
+	inProject := map[int]bool{}
+	for _, category := range categories {
+		if category.ProjectID == id {
+			inProject[category.ID] = true
+		}
+	}
+
+	var projectTasks []model.Task
+	for _, task := range tasks {
+		if inProject[task.CategoryID] {
+			byCategory[task.CategoryID] = append(byCategory[task.CategoryID], task)
+		}
+		if inMilestone[task.MilestoneID] {
+			byMilestone[task.MilestoneID] = append(byMilestone[task.MilestoneID], task)
+		}
+		if inProject[task.CategoryID] || inMilestone[task.MilestoneID] {
+			projectTasks = append(projectTasks, task)
+		}
+	}
+
+	overview := model.ProjectOverview{
+		Project:    *project,
+		Categories: []model.CategoryProgress{},
+		Milestones: []model.MilestoneProgress{},
+		Progress:   model.TaskProgress(projectTasks),
+	}
+	for _, category := range categories {
+		if inProject[category.ID] {
+			overview.Categories = append(overview.Categories, model.CategoryProgress{
+				Category: category,
+				Progress: model.TaskProgress(byCategory[category.ID]),
+			})
+		}
+	}
+	sort.Slice(overview.Categories, func(i, j int) bool {
+		return overview.Categories[i].Category.ID < overview.Categories[j].Category.ID
+	})
+
+	now := time.Now()
+	for _, milestone := range milestones {
+		progress := model.TaskProgress(byMilestone[milestone.ID])
+		overdue := milestone.DueDate.Overdue(now, loc) && (progress.Total == 0 || progress.Done < progress.Total)
+		if overdue {
+			overview.OverdueMilestones++
+		}
+		overview.Milestones = append(overview.Milestones, model.MilestoneProgress{
+			Milestone: milestone,
+			Progress:  progress,
+			Overdue:   overdue,
+		})
+	}
+	return overview, nil
+}
+
+func (s *projectService) project(id, workspaceID int) (*model.Project, error) {
+	project, err := s.projectRepository.GetByID(id)
+	if err != nil || project.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("%w: %d", model.ErrProjectNotFound, id)
+	}
+	return project, nil
+}
+
+func (s *projectService) milestone(id, workspaceID, milestoneID int) (*model.Milestone, error) {
+	if _, err := s.project(id, workspaceID); err != nil {
+		return nil, err
+	}
+
+	milestone, err := s.projectRepository.GetMilestoneByID(milestoneID)
+	if err != nil || milestone.ProjectID != id {
+		return nil, fmt.Errorf("%w: %d", model.ErrMilestoneNotFound, milestoneID)
+	}
+	return milestone, nil
+}
+
+func sortMilestones(milestones []model.Milestone) {
+	sort.SliceStable(milestones, func(i, j int) bool {
+		a, b := milestones[i].DueDate, milestones[j].DueDate
+		switch {
+		case a.IsZero() != b.IsZero():
+			return b.IsZero()
+		case !a.IsZero() && a.Before(b):
+			return true
+		case !a.IsZero() && b.Before(a):
+			return false
+		}
+		return milestones[i].ID < milestones[j].ID
+	})
+}
@@ -0,0 +1,64 @@
+/**
+ * Package service provides interfaces and implementations for the full-text search over task titles and category names.
+ *
+ * Interfaces:
+ *
+ * - SearchService: Interface defining methods for the full-text search.
+ *   Methods:
+ *   - Search: Method to find the tasks and categories of a workspace whose title or name matches a text.
+ *   - Rebuild: Method to index every task and category again.
+ *
+ * Structs:
+ *
+ * - searchService: Struct implementing the SearchService interface.
+ *   Fields:
+ *   - searchRepository: Instance of repo.SearchRepository for search index operations.
+ *   Methods:
+ *   - NewSearchService: Function to create a new instance of searchService.
+ *   - Search: Method to parse the text and return at most limit hits, best first, DefaultSearchLimit when limit is 0.
+ *     Texts without words to search for return ErrEmptyTextSearch and limits above MaxPageSize ErrInvalidQuery.
+ *   - Rebuild: Method to rebuild the search index using the search repository, returning how many records were indexed.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+)
+
+// DefaultSearchLimit is the number of hits returned by a search that does not ask for a limit.
+const DefaultSearchLimit = 20
+
+type SearchService interface {
+	Search(workspaceID int, text string, limit int) ([]model.SearchHit, error)
+	Rebuild() (int, error)
+}
+
+type searchService struct {
+	searchRepository repo.SearchRepository
+}
+
+func NewSearchService(searchRepository repo.SearchRepository) SearchService {
+	return &searchService{searchRepository}
+}
+
+func (s *searchService) Search(workspaceID int, text string, limit int) ([]model.SearchHit, error) {
+	if limit < 0 || limit > model.MaxPageSize {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", model.ErrInvalidQuery, model.MaxPageSize)
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+
+	query, err := model.ParseTextSearch(text)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchRepository.Search(workspaceID, query, limit)
+}
+
+func (s *searchService) Rebuild() (int, error) {
+	return s.searchRepository.Rebuild()
+}
@@ -6,6 +6,7 @@
  * - SessionService: Interface defining methods for session management.
  *   Methods:
  *   - GetSessionByEmail: Method to retrieve a session by email.
+ *   - GetSessionByToken: Method to retrieve a session by token.
  * 
  * Structs:
  * 
@@ -15,6 +16,7 @@
  *   Methods:
  *   - NewSessionService: Function to create a new instance of sessionService.
  *   - GetSessionByEmail: Method to retrieve a session by email using the session repository.
+ *   - GetSessionByToken: Method to retrieve a session by token using the session repository.
  */
 
 package service
@@ -26,6 +28,7 @@ import (
 
 type SessionService interface {
 	GetSessionByEmail(email string) (model.Session, error)
+	GetSessionByToken(token string) (model.Session, error)
 }
 
 type sessionService struct {
@@ -39,3 +42,7 @@ func NewSessionService(sessionRepo repo.SessionRepository) *sessionService {
 func (c *sessionService) GetSessionByEmail(email string) (model.Session, error) {
 	return c.sessionRepo.SessionAvailEmail(email)
 }
+
+func (c *sessionService) GetSessionByToken(token string) (model.Session, error) {
+	return c.sessionRepo.SessionAvailToken(token)
+}
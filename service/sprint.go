@@ -0,0 +1,337 @@
+/** 
+ * Package service provides interfaces and implementations for managing sprints and computing their burndown.
+ * 
+ * Interfaces:
+ * 
+ * - SprintService: Interface defining methods for sprint management.
+ *   Methods:
+ *   - Create: Method to create a sprint.
+ *   - Update: Method to rename a sprint or change its goal and dates.
+ *   - Delete: Method to delete a sprint.
+ *   - GetByID: Method to retrieve a sprint by ID.
+ *   - GetList: Method to retrieve the sprints of a workspace.
+ *   - PlanTask: Method to plan a task into a sprint.
+ *   - UnplanTask: Method to return a task of a sprint to the backlog.
+ *   - Start: Method to start a sprint.
+ *   - Close: Method to close a sprint, carrying over its unfinished tasks.
+ *   - GetBurndown: Method to compute the burndown of a sprint.
+ * 
+ * Structs:
+ * 
+ * - sprintService: Struct implementing the SprintService interface.
+ *   Fields:
+ *   - sprintRepository: Instance of repo.SprintRepository for sprint repository operations.
+ *   - taskService: Instance of TaskService to read tasks, their status history, and plan them into sprints.
+ *   - userRepository: Instance of repo.UserRepository to load the time zone the days of a sprint are counted in.
+ *   Methods:
+ *   - NewSprintService: Function to create a new instance of sprintService.
+ *   - Create: Method to store a planned sprint with a trimmed, non-empty name and an end date on or after its start date.
+ *   - Update: Method to change the name, goal and dates of a sprint of the workspace that is not closed.
+ *   - Delete: Method to delete a sprint of the workspace that is not active, returning its tasks to the backlog on behalf of the actor.
+ *   - GetByID: Method to retrieve a sprint, reporting sprints of other workspaces as not found.
+ *   - GetList: Method to retrieve the sprints of a workspace using the sprint repository.
+ *   - PlanTask: Method to plan a task of the workspace into a sprint that is not closed using the task service on behalf of the actor.
+ *   - UnplanTask: Method to return a task of a sprint that is not closed to the backlog using the task service on behalf of the actor.
+ *   - Start: Method to make a planned sprint active. Only one sprint of a workspace can be active at a time.
+ *   - Close: Method to close an active sprint, moving the tasks that are not completed to the next sprint,
+ *     which must be a planned or active sprint of the same workspace, or to the backlog when nextSprintID is 0, on behalf of the actor.
+ *   - GetBurndown: Method to count, for each day of the sprint, the tasks and story points not completed at the end of the day
+ *     in the user's time zone, or now for the current day and the close for the day the sprint was closed, next to the ideal line
+ *     burning the total down evenly to zero on the last day. Days that have not started yet have no remaining work.
+ *     At most the first model.MaxSprintDays days are counted.
+ *     A task's status at a given moment is read from its status history, so tasks reopened after completion count as remaining again.
+ *   - sprint: Method to retrieve a sprint of the workspace or ErrSprintNotFound.
+ *   - statusAt: Method to find the status a task had at a given moment from its status history.
+ * 
+ * Functions:
+ * 
+ * - validateSprint: Function to trim the name of a sprint and check its name and dates, which may span at most model.MaxSprintDays days.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type SprintService interface {
+	Create(sprint model.Sprint) (model.Sprint, error)
+	Update(id, workspaceID int, request model.SprintRequest) (model.Sprint, error)
+	Delete(id, workspaceID int, actor string) error
+	GetByID(id, workspaceID int) (*model.Sprint, error)
+	GetList(workspaceID int) ([]model.Sprint, error)
+	PlanTask(id, workspaceID, taskID int, actor string) error
+	UnplanTask(id, workspaceID, taskID int, actor string) error
+	Start(id, workspaceID int) (model.Sprint, error)
+	Close(id, workspaceID, nextSprintID int, actor string) (model.Sprint, error)
+	GetBurndown(id, workspaceID, userID int) (model.Burndown, error)
+}
+
+type sprintService struct {
+	sprintRepository repo.SprintRepository
+	taskService      TaskService
+	userRepository   repo.UserRepository
+}
+
+func NewSprintService(sprintRepository repo.SprintRepository, taskService TaskService, userRepository repo.UserRepository) SprintService {
+	return &sprintService{sprintRepository, taskService, userRepository}
+}
+
+func (s *sprintService) Create(sprint model.Sprint) (model.Sprint, error) {
+	if err := validateSprint(&sprint); err != nil {
+		return model.Sprint{}, err
+	}
+
+	sprint.ID = 0
+	sprint.Status = model.SprintPlanned
+	sprint.StartedAt, sprint.ClosedAt, sprint.CarriedOver = nil, nil, nil
+	sprint.CreatedAt = time.Now()
+	return s.sprintRepository.Store(sprint)
+}
+
+func (s *sprintService) Update(id, workspaceID int, request model.SprintRequest) (model.Sprint, error) {
+	sprint, err := s.sprint(id, workspaceID)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	if sprint.Status == model.SprintClosed {
+		return model.Sprint{}, fmt.Errorf("%w: sprint %d is closed", model.ErrSprintState, id)
+	}
+
+	sprint.Name = request.Name
+	sprint.Goal = request.Goal
+	sprint.StartDate = request.StartDate
+	sprint.EndDate = request.EndDate
+	if err := validateSprint(sprint); err != nil {
+		return model.Sprint{}, err
+	}
+	return s.sprintRepository.Store(*sprint)
+}
+
+func (s *sprintService) Delete(id, workspaceID int, actor string) error {
+	sprint, err := s.sprint(id, workspaceID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == model.SprintActive {
+		return fmt.Errorf("%w: sprint %d is active", model.ErrSprintState, id)
+	}
+	return s.sprintRepository.Delete(id, actor)
+}
+
+func (s *sprintService) GetByID(id, workspaceID int) (*model.Sprint, error) {
+	return s.sprint(id, workspaceID)
+}
+
+func (s *sprintService) GetList(workspaceID int) ([]model.Sprint, error) {
+	return s.sprintRepository.GetList(workspaceID)
+}
+
+func (s *sprintService) PlanTask(id, workspaceID, taskID int, actor string) error {
+	sprint, err := s.sprint(id, workspaceID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == model.SprintClosed {
+		return fmt.Errorf("%w: sprint %d is closed", model.ErrSprintState, id)
+	}
+
+	task, err := s.taskService.GetByID(taskID)
+	if err != nil || task.WorkspaceID != workspaceID {
+		return fmt.Errorf("%w: task %d", model.ErrSprintNotFound, taskID)
+	}
+	return s.taskService.As(actor).SetSprint(taskID, id)
+}
+
+func (s *sprintService) UnplanTask(id, workspaceID, taskID int, actor string) error {
+	sprint, err := s.sprint(id, workspaceID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == model.SprintClosed {
+		return fmt.Errorf("%w: sprint %d is closed", model.ErrSprintState, id)
+	}
+
+	task, err := s.taskService.GetByID(taskID)
+	if err != nil || task.SprintID != id {
+		return fmt.Errorf("%w: task %d", model.ErrSprintNotFound, taskID)
+	}
+	return s.taskService.As(actor).SetSprint(taskID, 0)
+}
+
+func (s *sprintService) Start(id, workspaceID int) (model.Sprint, error) {
+	sprint, err := s.sprint(id, workspaceID)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	if sprint.Status != model.SprintPlanned {
+		return model.Sprint{}, fmt.Errorf("%w: sprint %d is %s", model.ErrSprintState, id, sprint.Status)
+	}
+
+	sprints, err := s.sprintRepository.GetList(workspaceID)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	for _, other := range sprints {
+		if other.Status == model.SprintActive {
+			return model.Sprint{}, fmt.Errorf("%w: %s", model.ErrSprintActive, other.Name)
+		}
+	}
+
+	now := time.Now()
+	sprint.Status = model.SprintActive
+	sprint.StartedAt = &now
+	return s.sprintRepository.Store(*sprint)
+}
+
+func (s *sprintService) Close(id, workspaceID, nextSprintID int, actor string) (model.Sprint, error) {
+	sprint, err := s.sprint(id, workspaceID)
+	if err != nil {
+		return model.Sprint{}, err
+	}
+	if sprint.Status != model.SprintActive {
+		return model.Sprint{}, fmt.Errorf("%w: sprint %d is %s", model.ErrSprintState, id, sprint.Status)
+	}
+
+	if nextSprintID != 0 {
+		next, err := s.sprint(nextSprintID, workspaceID)
+		if err != nil {
+			return model.Sprint{}, err
+		}
+		if next.ID == id || next.Status == model.SprintClosed {
+			return model.Sprint{}, fmt.Errorf("%w: cannot carry tasks over to sprint %d", model.ErrSprintState, nextSprintID)
+		}
+	}
+
+	now := time.Now()
+	sprint.Status = model.SprintClosed
+	sprint.ClosedAt = &now
+	return s.sprintRepository.Close(*sprint, nextSprintID, actor)
+}
+
+func (s *sprintService) GetBurndown(id, workspaceID, userID int) (model.Burndown, error) {
+	sprint, err := s.sprint(id, workspaceID)
+	if err != nil {
+		return model.Burndown{}, err
+	}
+
+	loc := time.UTC
+	if user, err := s.userRepository.GetUserByID(userID); err == nil {
+		loc = model.LoadLocation(user.TimeZone)
+	}
+
+	tasks, err := s.taskService.GetList(workspaceID)
+	if err != nil {
+		return model.Burndown{}, err
+	}
+
+	carried := map[int]bool{}
+	for _, taskID := range sprint.CarriedOver {
+		carried[taskID] = true
+	}
+
+	var scope []model.Task
+	histories := map[int][]model.StatusTransition{}
+	for _, task := range tasks {
+		if task.SprintID != id && !carried[task.ID] {
+			continue
+		}
+		history, err := s.taskService.GetTransitions(task.ID)
+		if err != nil {
+			return model.Burndown{}, err
+		}
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].ChangedAt.Before(history[j].ChangedAt)
+		})
+		histories[task.ID] = history
+		scope = append(scope, task)
+	}
+
+	burndown := model.Burndown{Sprint: *sprint, TotalTasks: len(scope), Points: []model.BurndownPoint{}}
+	for _, task := range scope {
+		burndown.TotalPoints += task.StoryPoints
+	}
+
+	start, end := sprint.StartDate.At, sprint.EndDate.At
+	days := int(end.Sub(start).Hours()/24) + 1
+	if days > model.MaxSprintDays {
+		days = model.MaxSprintDays
+	}
+	now := time.Now()
+	for day := 0; day < days; day++ {
+		date := start.AddDate(0, 0, day)
+		point := model.BurndownPoint{Date: date.Format(model.DateLayout)}
+		if days > 1 {
+			left := float64(days-1-day) / float64(days-1)
+			point.IdealTasks = float64(burndown.TotalTasks) * left
+			point.IdealPoints = float64(burndown.TotalPoints) * left
+		}
+
+		startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+		if !startOfDay.After(now) {
+			at := startOfDay.AddDate(0, 0, 1)
+			if at.After(now) {
+				at = now
+			}
+			if sprint.ClosedAt != nil && at.After(*sprint.ClosedAt) {
+				at = *sprint.ClosedAt
+			}
+
+			remainingTasks, remainingPoints := 0, 0
+			for _, task := range scope {
+				done := s.statusAt(task, histories[task.ID], at) == model.StatusCompleted
+				if !done {
+					remainingTasks++
+					remainingPoints += task.StoryPoints
+				}
+			}
+			point.RemainingTasks, point.RemainingPoints = &remainingTasks, &remainingPoints
+		}
+		burndown.Points = append(burndown.Points, point)
+	}
+	return burndown, nil
+}
+
+func (s *sprintService) sprint(id, workspaceID int) (*model.Sprint, error) {
+	sprint, err := s.sprintRepository.GetByID(id)
+	if err != nil || sprint.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("%w: %d", model.ErrSprintNotFound, id)
+	}
+	return sprint, nil
+}
+
+// statusAt replays the status history, sorted by time, up to the given moment. Before its first recorded change
+// a task had the status that change started from, and a task without history has always had its current status.
+func (s *sprintService) statusAt(task model.Task, history []model.StatusTransition, at time.Time) model.TaskStatus {
+	if len(history) == 0 {
+		return task.Status
+	}
+
+	status := history[0].From
+	for _, transition := range history {
+		if transition.ChangedAt.After(at) {
+			break
+		}
+		status = transition.To
+	}
+	return status
+}
+
+func validateSprint(sprint *model.Sprint) error {
+	sprint.Name = strings.TrimSpace(sprint.Name)
+	if sprint.Name == "" || sprint.StartDate.IsZero() || sprint.EndDate.IsZero() || sprint.EndDate.Before(sprint.StartDate) {
+		return model.ErrInvalidSprint
+	}
+	if !sprint.StartDate.DateOnly || !sprint.EndDate.DateOnly {
+		return fmt.Errorf("%w: use YYYY-MM-DD dates", model.ErrInvalidSprint)
+	}
+	if sprint.EndDate.At.Sub(sprint.StartDate.At) >= model.MaxSprintDays*24*time.Hour {
+		return fmt.Errorf("%w: a sprint spans at most %d days", model.ErrInvalidSprint, model.MaxSprintDays)
+	}
+	return nil
+}
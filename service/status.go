@@ -0,0 +1,137 @@
+/** 
+ * Package service provides interfaces and implementations for managing the task status workflow.
+ * 
+ * Interfaces:
+ * 
+ * - StatusService: Interface defining methods for status workflow management.
+ *   Methods:
+ *   - GetWorkflow: Method to retrieve the workflow of a user.
+ *   - GetList: Method to retrieve the statuses, transitions and custom statuses of a user.
+ *   - Store: Method to store a custom status.
+ *   - Delete: Method to delete a custom status.
+ * 
+ * Structs:
+ * 
+ * - statusService: Struct implementing the StatusService interface.
+ *   Fields:
+ *   - statusRepository: Instance of repo.StatusRepository for custom status repository operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to check whether a custom status is still in use.
+ *   Methods:
+ *   - NewStatusService: Function to create a new instance of statusService.
+ *   - GetWorkflow: Method to build the built-in workflow extended with the user's custom statuses.
+ *   - GetList: Method to describe the user's workflow using the status repository.
+ *   - Store: Method to validate a custom status and store it using the status repository.
+ *     The name must not clash with an existing status and its transitions must refer to existing statuses.
+ *   - Delete: Method to delete a custom status using the status repository, refused while tasks of the user still have it.
+ * 
+ * Functions:
+ * 
+ * - workflowFor: Function to build the workflow of a user from its custom statuses.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type StatusService interface {
+	GetWorkflow(userID int) (model.Workflow, error)
+	GetList(userID int) (model.StatusList, error)
+	Store(status *model.CustomStatus) error
+	Delete(id, userID int) error
+}
+
+type statusService struct {
+	statusRepository repo.StatusRepository
+	taskRepository   repo.TaskRepository
+}
+
+func NewStatusService(statusRepository repo.StatusRepository, taskRepository repo.TaskRepository) StatusService {
+	return &statusService{statusRepository, taskRepository}
+}
+
+func (s *statusService) GetWorkflow(userID int) (model.Workflow, error) {
+	return workflowFor(s.statusRepository, userID)
+}
+
+func (s *statusService) GetList(userID int) (model.StatusList, error) {
+	custom, err := s.statusRepository.GetList(userID)
+	if err != nil {
+		return model.StatusList{}, err
+	}
+
+	workflow := model.NewWorkflow(custom...)
+	if custom == nil {
+		custom = []model.CustomStatus{}
+	}
+
+	return model.StatusList{
+		Statuses:    workflow.Statuses(),
+		Transitions: workflow,
+		Custom:      custom,
+	}, nil
+}
+
+func (s *statusService) Store(status *model.CustomStatus) error {
+	status.Name = model.TaskStatus(strings.TrimSpace(string(status.Name)))
+	if status.Name == "" {
+		return errors.New("status name is required")
+	}
+
+	workflow, err := s.GetWorkflow(status.UserID)
+	if err != nil {
+		return err
+	}
+
+	if workflow.Has(status.Name) {
+		return fmt.Errorf("status %q already exists", status.Name)
+	}
+
+	for _, related := range append(append([]model.TaskStatus(nil), status.From...), status.To...) {
+		if !workflow.Has(related) {
+			return fmt.Errorf("%w: %q", model.ErrUnknownStatus, related)
+		}
+	}
+
+	return s.statusRepository.Store(status)
+}
+
+func (s *statusService) Delete(id, userID int) error {
+	custom, err := s.statusRepository.GetList(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range custom {
+		if status.ID != id {
+			continue
+		}
+
+		tasks, err := s.taskRepository.GetListByUser(userID)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if task.Status == status.Name {
+				return fmt.Errorf("status %q is still used by task %d", status.Name, task.ID)
+			}
+		}
+	}
+
+	return s.statusRepository.Delete(id, userID)
+}
+
+func workflowFor(statusRepository repo.StatusRepository, userID int) (model.Workflow, error) {
+	custom, err := statusRepository.GetList(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewWorkflow(custom...), nil
+}
@@ -0,0 +1,182 @@
+/** 
+ * Package service provides interfaces and implementations for managing task tags.
+ * 
+ * Interfaces:
+ * 
+ * - TagService: Interface defining methods for tag management.
+ *   Methods:
+ *   - GetList: Method to retrieve the tags of a user with their assignments.
+ *   - Store: Method to store a tag.
+ *   - Update: Method to update a tag.
+ *   - Delete: Method to delete a tag.
+ *   - Assign: Method to put a tag on a task.
+ *   - Unassign: Method to remove a tag from a task.
+ *   - GetTaskTags: Method to retrieve the tags of a task.
+ * 
+ * Structs:
+ * 
+ * - tagService: Struct implementing the TagService interface.
+ *   Fields:
+ *   - tagRepository: Instance of repo.TagRepository for tag repository operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to check that a tagged task exists.
+ *   Methods:
+ *   - NewTagService: Function to create a new instance of tagService.
+ *   - GetList: Method to retrieve the tags of a user and the assignments of these tags using the tag repository.
+ *   - Store: Method to validate a tag and store it using the tag repository. The name is required and must not clash,
+ *     regardless of case, with another tag of the user. Tags without a color get DefaultTagColor.
+ *   - Update: Method to rename or recolor a tag of the user. An empty color keeps the current one.
+ *   - Delete: Method to delete a tag of the user, removing it from every task.
+ *   - Assign: Method to put a tag of the user on an existing task.
+ *   - Unassign: Method to remove a tag of the user from a task.
+ *   - GetTaskTags: Method to retrieve the tags of a task using the tag repository.
+ *   - checkTag: Method to validate the name and color of a tag against the other tags of its owner.
+ *   - ownedTag: Method to retrieve a tag, returning ErrTagNotFound when it belongs to another user.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type TagService interface {
+	GetList(userID int) (model.TagList, error)
+	Store(tag *model.Tag) error
+	Update(id int, tag *model.Tag) error
+	Delete(id, userID int) error
+	Assign(taskID, tagID, userID int) error
+	Unassign(taskID, tagID, userID int) error
+	GetTaskTags(taskID int) ([]model.Tag, error)
+}
+
+type tagService struct {
+	tagRepository  repo.TagRepository
+	taskRepository repo.TaskRepository
+}
+
+func NewTagService(tagRepository repo.TagRepository, taskRepository repo.TaskRepository) TagService {
+	return &tagService{tagRepository, taskRepository}
+}
+
+func (s *tagService) GetList(userID int) (model.TagList, error) {
+	tags, err := s.tagRepository.GetList(userID)
+	if err != nil {
+		return model.TagList{}, err
+	}
+
+	assignments, err := s.tagRepository.GetAssignments()
+	if err != nil {
+		return model.TagList{}, err
+	}
+
+	owned := make(map[int]bool, len(tags))
+	for _, tag := range tags {
+		owned[tag.ID] = true
+	}
+
+	list := model.TagList{Tags: []model.Tag{}, Assignments: []model.TaskTag{}}
+	list.Tags = append(list.Tags, tags...)
+	for _, assignment := range assignments {
+		if owned[assignment.TagID] {
+			list.Assignments = append(list.Assignments, assignment)
+		}
+	}
+	return list, nil
+}
+
+func (s *tagService) Store(tag *model.Tag) error {
+	if tag.Color == "" {
+		tag.Color = model.DefaultTagColor
+	}
+
+	if err := s.checkTag(tag); err != nil {
+		return err
+	}
+
+	return s.tagRepository.Store(tag)
+}
+
+func (s *tagService) Update(id int, tag *model.Tag) error {
+	current, err := s.ownedTag(id, tag.UserID)
+	if err != nil {
+		return err
+	}
+
+	tag.ID = current.ID
+	if tag.Color == "" {
+		tag.Color = current.Color
+	}
+
+	if err := s.checkTag(tag); err != nil {
+		return err
+	}
+
+	return s.tagRepository.Update(tag)
+}
+
+func (s *tagService) Delete(id, userID int) error {
+	if _, err := s.ownedTag(id, userID); err != nil {
+		return err
+	}
+
+	return s.tagRepository.Delete(id, userID)
+}
+
+func (s *tagService) Assign(taskID, tagID, userID int) error {
+	if _, err := s.ownedTag(tagID, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return err
+	}
+
+	return s.tagRepository.Assign(taskID, tagID)
+}
+
+func (s *tagService) Unassign(taskID, tagID, userID int) error {
+	if _, err := s.ownedTag(tagID, userID); err != nil {
+		return err
+	}
+
+	return s.tagRepository.Unassign(taskID, tagID)
+}
+
+func (s *tagService) GetTaskTags(taskID int) ([]model.Tag, error) {
+	return s.tagRepository.GetTaskTags(taskID)
+}
+
+func (s *tagService) checkTag(tag *model.Tag) error {
+	tag.Name = strings.TrimSpace(tag.Name)
+	if tag.Name == "" {
+		return errors.New("tag name is required")
+	}
+
+	if !model.ValidTagColor(tag.Color) {
+		return fmt.Errorf("%w: %q", model.ErrInvalidTagColor, tag.Color)
+	}
+
+	tags, err := s.tagRepository.GetList(tag.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range tags {
+		if other.ID != tag.ID && strings.EqualFold(other.Name, tag.Name) {
+			return fmt.Errorf("%w: %q", model.ErrDuplicateTag, tag.Name)
+		}
+	}
+	return nil
+}
+
+func (s *tagService) ownedTag(id, userID int) (*model.Tag, error) {
+	tag, err := s.tagRepository.GetByID(id)
+	if err != nil || tag.UserID != userID {
+		return nil, fmt.Errorf("%w: %d", model.ErrTagNotFound, id)
+	}
+	return tag, nil
+}
@@ -6,25 +6,130 @@
  * - TaskService: Interface defining methods for task management.
  *   Methods:
  *   - Store: Method to store a task.
+ *   - StoreAll: Method to store several new tasks at once.
  *   - Update: Method to update a task.
- *   - Delete: Method to delete a task.
+ *   - Delete: Method to delete a task with its subtasks.
+ *   - DeleteKeepChildren: Method to delete a task, keeping its subtasks.
  *   - GetByID: Method to retrieve a task by ID.
- *   - GetList: Method to retrieve a list of tasks.
- *   - GetTaskCategory: Method to retrieve tasks by category.
+ *   - GetList: Method to retrieve the tasks of a workspace.
+ *   - GetTaskCategory: Method to retrieve the tasks of a workspace by category.
+ *   - Transition: Method to move a task to another status.
+ *   - GetTransitions: Method to retrieve the status history of a task.
+ *   - GetSubtasks: Method to retrieve the direct subtasks of a task.
+ *   - Detach: Method to make a subtask a top-level task.
+ *   - GetProgress: Method to compute the progress of a task.
+ *   - AddChecklistItem: Method to add an item to a task's checklist.
+ *   - ToggleChecklistItem: Method to check or uncheck a checklist item.
+ *   - ReorderChecklist: Method to change the order of a task's checklist.
+ *   - DeleteChecklistItem: Method to remove an item from a task's checklist.
+ *   - UpdateOccurrence: Method to update a single occurrence of a recurring task.
+ *   - UpdateSeries: Method to update an occurrence of a recurring task and the occurrences following it.
+ *   - GetSeries: Method to retrieve every occurrence of a recurring task.
+ *   - GetListByTags: Method to retrieve the tasks of a workspace carrying all of the given tags.
+ *   - SetMilestone: Method to attach a task to a project milestone or detach it.
+ *   - SetSprint: Method to plan a task into a sprint or return it to the backlog.
+ *   - Move: Method to move a task to another position on the board, optionally in another status column.
+ *   - GetBoard: Method to retrieve the Kanban board of a workspace.
+ *   - SetFields: Method to set or clear custom field values of a task.
+ *   - GetListByFields: Method to retrieve the tasks of a workspace carrying the given tags and custom field values.
+ *   - Archive: Method to archive a completed task.
+ *   - Unarchive: Method to bring an archived task back to the task lists.
+ *   - GetArchived: Method to retrieve the archived tasks of a workspace.
+ *   - Query: Method to retrieve a filtered, sorted page of the tasks of a workspace.
+ *   - Search: Method to retrieve the tasks of a workspace matching a search query.
+ *   - GetHistory: Method to retrieve the activity history of a task.
+ *   - As: Method to act on behalf of a user, recording them as the author of the changes.
  * 
  * Structs:
  * 
  * - taskService: Struct implementing the TaskService interface.
  *   Fields:
  *   - taskRepository: Instance of repo.TaskRepository for task repository operations.
+ *   - statusRepository: Instance of repo.StatusRepository used to build the workflow of the task's owner.
+ *   - fieldRepository: Instance of repo.FieldRepository used to validate the custom field values of tasks.
+ *   - userRepository: Instance of repo.UserRepository used to read the time zone searches are evaluated in.
+ *   - categoryRepository: Instance of repo.CategoryRepository used to check that the category of a task belongs to its workspace.
+ *   - actor: Email of the user the changes are made on behalf of, recorded in the history of the changed tasks.
  *   Methods:
  *   - NewTaskService: Function to create a new instance of taskService.
- *   - Store: Method to store a task using the task repository.
- *   - Update: Method to update a task using the task repository.
- *   - Delete: Method to delete a task using the task repository.
+ *   - Store: Method to store a task using the task repository. Tasks without a status start as Todo,
+ *     any other status must be part of the owner's workflow. Estimates cannot be negative. A subtask's parent must exist, and the subtask
+ *     takes the owner and category of its parent when it has none and always the workspace of its parent. A recurrence rule must be valid and is stored in its canonical form.
+ *     Custom field values must belong to fields of the task's workspace and fit their type, and a category must belong to that workspace too.
+ *     New tasks are placed at the bottom of their column on the board.
+ *   - StoreAll: Method to check every task like Store and store them all in one transaction using the task repository,
+ *     or none of them when one is invalid. The tasks are placed at the bottom of the board in the given order.
+ *   - Update: Method to update a task using the task repository. A status change must be allowed by the workflow
+ *     of the task's owner, an empty status keeps the current one and a missing checklist keeps the current items.
+ *     A missing parent keeps the current one; a new parent must exist in the same workspace and must not be the task itself or one of its subtasks. An empty recurrence
+ *     keeps the current rule. A task without a deadline keeps its legacy deadline. A task keeps its owner, stays in its workspace and keeps its milestone, sprint and position on the board.
+ *     Missing custom field values keep the current ones, given ones replace them and are validated like in Store. A new category must belong to the task's workspace.
+ *     A status change is recorded in the task's status history, with the acting user and the time it was made, together with the task.
+ *     Estimates cannot be negative. A task stays archived while it is completed.
+ *     Completing a recurring task generates its next occurrence. A task cannot be completed while a task blocking it is open.
+ *   - Delete: Method to move a task and, cascading, all of its subtasks to the trash using the task repository.
+ *   - DeleteKeepChildren: Method to move a task to the trash using the task repository, moving its direct subtasks up to its parent.
  *   - GetByID: Method to retrieve a task by ID using the task repository.
- *   - GetList: Method to retrieve a list of tasks using the task repository.
- *   - GetTaskCategory: Method to retrieve tasks by category using the task repository.
+ *   - GetList: Method to retrieve the tasks of a workspace that are not archived using the task repository.
+ *   - GetTaskCategory: Method to retrieve the tasks of a workspace by category using the task repository.
+ *   - Transition: Method to move a task to another status allowed by the owner's workflow and record who changed it and when,
+ *     also in the task's history.
+ *     Moving a task to the status it already has changes nothing. Completing a recurring task generates its next occurrence.
+ *     A task cannot be completed while a task blocking it is open. Reopening an archived task unarchives it.
+ *   - GetTransitions: Method to retrieve the status history of a task using the task repository.
+ *   - GetSubtasks: Method to retrieve the direct subtasks of a task using the task repository.
+ *   - Detach: Method to take a subtask out from under its parent using the task repository. Top-level tasks are left unchanged.
+ *   - GetProgress: Method to compute the progress of a task from its direct subtasks and its checklist.
+ *   - AddChecklistItem: Method to append an item to a task's checklist, giving it the next free item ID.
+ *   - ToggleChecklistItem: Method to flip the done state of a checklist item.
+ *   - ReorderChecklist: Method to reorder a task's checklist. The new order must list every item exactly once.
+ *   - DeleteChecklistItem: Method to remove an item from a task's checklist.
+ *   - UpdateOccurrence: Method to update one occurrence of a recurring task like Update, leaving its rule and the rest of the series untouched.
+ *   - UpdateSeries: Method to apply the title, priority, category and recurrence of the given task to an occurrence and every later
+ *     occurrence of its series that is not completed yet. A new deadline moves each of these occurrences by the same amount.
+ *     Returns the updated occurrences.
+ *   - GetSeries: Method to retrieve every occurrence of the series a task belongs to, oldest first.
+ *   - GetListByTags: Method to retrieve the tasks of a workspace carrying all of the given tags and not archived using the task repository.
+ *   - SetMilestone: Method to attach a task to a milestone, 0 detaching it, using the task repository.
+ *   - SetSprint: Method to plan a task into a sprint, 0 returning it to the backlog, using the task repository.
+ *   - Move: Method to place a task right after another task of the target column, or at its top, by giving it a rank between
+ *     its new neighbours, archived tasks not counting as neighbours. Only the moved task is written. Moving to another column changes the status like Transition.
+ *   - GetBoard: Method to group the tasks of a workspace that are not archived into one column per status of the user's workflow, ordered by rank.
+ *     Tasks whose status is not part of the workflow get extra columns after the workflow's.
+ *   - SetFields: Method to change only the given custom field values of a task, an empty value clearing the field.
+ *   - GetListByFields: Method to filter the tasks carrying the given tags and not archived by custom field values. Every filter must name a field
+ *     of the workspace and a value fitting its type; a task matches when each of its values equals the filter value.
+ *   - Archive: Method to mark a completed task as archived, refusing other tasks with ErrNotArchivable. Archiving an archived task changes nothing.
+ *   - Unarchive: Method to clear the archived mark of a task.
+ *   - GetArchived: Method to retrieve the archived tasks of a workspace, most recently archived first.
+ *   - Query: Method to check a task query, including that its custom field filters name fields of the workspace, and retrieve the page it
+ *     selects using the task repository. Archived tasks are listed most recently archived first unless another order is given.
+ *   - Search: Method to parse a search query, see model.ParseSearch, and evaluate it using the task repository, with deadlines
+ *     read in the time zone of the searching user. Queries that cannot be parsed return a *model.ParseError.
+ *   - GetHistory: Method to retrieve the events recorded for a task, oldest first, using the task repository.
+ *   - As: Method to return a copy of the service whose changes are recorded as made by the given user. Every write of the
+ *     task repository records the change in the history of the task in the same transaction.
+ *   - prepare: Method to apply the defaults and checks of Store to a new task.
+ *   - normalizeFields: Method to check custom field values against the fields of a workspace and return them in canonical form,
+ *     dropping empty values. Values equal to the current ones of the task are kept without checking them again.
+ *   - changeStatus: Method to store a task with its new status and the recorded transition, after the checks of Transition.
+ *   - checkCategory: Method to refuse with ErrInvalidCategory a category that does not exist or belongs to another workspace.
+ *   - checkParent: Method to refuse with ErrInvalidParent a parent outside the task's workspace or below the task itself.
+ *   - checkBlockers: Method to refuse completing a task with ErrTaskBlocked while one of its blockers is not completed.
+ *   - generateNext: Method to store the occurrence following a completed recurring task and link it from the completed task.
+ *     Nothing is generated when the task already has a next occurrence or the series has ended.
+ * 
+ * Functions:
+ * 
+ * - nextChecklistItemID: Function to find the next free checklist item ID of a task.
+ * 
+ * - seriesID: Function to find the ID of the series a task belongs to.
+ * 
+ * - normalizeRecurrence: Function to validate a recurrence rule and return its canonical form.
+ * 
+ * - checkEstimate: Function to refuse negative estimates with ErrInvalidEstimate.
+ * 
+ * - unarchived: Function to drop the archived tasks from a list of tasks.
  */
 
 package service
@@ -32,45 +137,980 @@ package service
 import (
 	"a21hc3NpZ25tZW50/model"
 	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 )
 
 type TaskService interface {
 	Store(task *model.Task) error
+	StoreAll(tasks []*model.Task) error
 	Update(id int, task *model.Task) error
 	Delete(id int) error
 	GetByID(id int) (*model.Task, error)
-	GetList() ([]model.Task, error)
-	GetTaskCategory(id int) ([]model.TaskCategory, error)
+	GetList(workspaceID int) ([]model.Task, error)
+	GetTaskCategory(workspaceID, id int) ([]model.TaskCategory, error)
+	Transition(id int, status model.TaskStatus) (*model.Task, error)
+	GetTransitions(id int) ([]model.StatusTransition, error)
+	DeleteKeepChildren(id int) error
+	GetSubtasks(id int) ([]model.Task, error)
+	Detach(id int) (*model.Task, error)
+	GetProgress(id int) (model.Progress, error)
+	AddChecklistItem(id int, item model.ChecklistItem) (*model.Task, error)
+	ToggleChecklistItem(id, itemID int) (*model.Task, error)
+	ReorderChecklist(id int, order []int) (*model.Task, error)
+	DeleteChecklistItem(id, itemID int) (*model.Task, error)
+	UpdateOccurrence(id int, task *model.Task) error
+	UpdateSeries(id int, task *model.Task) ([]model.Task, error)
+	GetSeries(id int) ([]model.Task, error)
+	GetListByTags(workspaceID int, tagIDs []int) ([]model.Task, error)
+	SetMilestone(id, milestoneID int) error
+	SetSprint(id, sprintID int) error
+	SetFields(id int, fields map[int]interface{}) (*model.Task, error)
+	GetListByFields(workspaceID int, tagIDs []int, filters map[int]string) ([]model.Task, error)
+	Move(id int, move model.MoveRequest) (*model.Task, error)
+	GetBoard(workspaceID, userID int) (model.Board, error)
+	Archive(id int) (*model.Task, error)
+	Unarchive(id int) (*model.Task, error)
+	GetArchived(workspaceID int) ([]model.Task, error)
+	Query(workspaceID int, query model.TaskQuery) (model.TaskPage, error)
+	Search(workspaceID, userID int, query string) ([]model.Task, error)
+	GetHistory(id int) ([]model.TaskEvent, error)
+	As(actor string) TaskService
 }
 
 type taskService struct {
-	taskRepository repo.TaskRepository
+	taskRepository   repo.TaskRepository
+	statusRepository repo.StatusRepository
+	fieldRepository  repo.FieldRepository
+	userRepository     repo.UserRepository
+	categoryRepository repo.CategoryRepository
+	actor              string
 }
 
-func NewTaskService(taskRepository repo.TaskRepository) TaskService {
-	return &taskService{taskRepository}
+func NewTaskService(taskRepository repo.TaskRepository, statusRepository repo.StatusRepository, fieldRepository repo.FieldRepository, userRepository repo.UserRepository, categoryRepository repo.CategoryRepository) TaskService {
+	return &taskService{taskRepository: taskRepository, statusRepository: statusRepository, fieldRepository: fieldRepository, userRepository: userRepository, categoryRepository: categoryRepository}
+}
+
+func (s *taskService) As(actor string) TaskService {
+	acting := *s
+	acting.actor = actor
+	return &acting
 }
 
 func (c *taskService) Store(task *model.Task) error {
-	return c.taskRepository.Store(task)
+	if err := c.prepare(task); err != nil {
+		return err
+	}
+	return c.taskRepository.Store(task, c.actor)
+}
+
+func (s *taskService) StoreAll(tasks []*model.Task) error {
+	for _, task := range tasks {
+		if err := s.prepare(task); err != nil {
+			return err
+		}
+	}
+	return s.taskRepository.StoreAll(tasks, s.actor)
+}
+
+func (c *taskService) prepare(task *model.Task) error {
+	if task.Status == "" {
+		task.Status = model.StatusTodo
+	}
+
+	if err := checkEstimate(*task); err != nil {
+		return err
+	}
+
+	if task.ParentID != 0 {
+		parent, err := c.taskRepository.GetByID(task.ParentID)
+		if err != nil {
+			return fmt.Errorf("%w: %d", model.ErrInvalidParent, task.ParentID)
+		}
+		if task.UserID == 0 {
+			task.UserID = parent.UserID
+		}
+		if task.CategoryID == 0 {
+			task.CategoryID = parent.CategoryID
+		}
+		task.WorkspaceID = parent.WorkspaceID
+	}
+	if err := c.checkCategory(task.CategoryID, task.WorkspaceID); err != nil {
+		return err
+	}
+
+	for i := range task.Checklist {
+		task.Checklist[i].ID = i + 1
+	}
+	task.LegacyDeadline = ""
+	task.Rank = ""
+
+	rule, err := normalizeRecurrence(task.Recurrence)
+	if err != nil {
+		return err
+	}
+	task.Recurrence = rule
+
+	fields, err := c.normalizeFields(task.WorkspaceID, task.Fields, nil)
+	if err != nil {
+		return err
+	}
+	task.Fields = fields
+
+	workflow, err := workflowFor(c.statusRepository, task.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !workflow.Has(task.Status) {
+		return fmt.Errorf("%w: %q", model.ErrUnknownStatus, task.Status)
+	}
+	return nil
 }
 
 func (s *taskService) Update(id int, task *model.Task) error {
-	return s.taskRepository.Update(id, task)
+	current, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if task.Status == "" {
+		task.Status = current.Status
+	}
+	if task.Checklist == nil {
+		task.Checklist = current.Checklist
+	}
+	if task.Recurrence == "" {
+		task.Recurrence = current.Recurrence
+	}
+	if task.ParentID == 0 {
+		task.ParentID = current.ParentID
+	}
+	task.SeriesID = current.SeriesID
+	task.NextID = current.NextID
+	task.LegacyDeadline = ""
+	if task.Deadline.IsZero() {
+		task.LegacyDeadline = current.LegacyDeadline
+	}
+	task.UserID = current.UserID
+	task.WorkspaceID = current.WorkspaceID
+	task.MilestoneID = current.MilestoneID
+	task.SprintID = current.SprintID
+	task.Rank = current.Rank
+	if task.Status == model.StatusCompleted {
+		task.ArchivedAt = current.ArchivedAt
+	} else {
+		task.ArchivedAt = nil
+	}
+	if task.Fields == nil {
+		task.Fields = current.Fields
+	}
+
+	if err := checkEstimate(*task); err != nil {
+		return err
+	}
+
+	fields, err := s.normalizeFields(current.WorkspaceID, task.Fields, current.Fields)
+	if err != nil {
+		return err
+	}
+	task.Fields = fields
+
+	rule, err := normalizeRecurrence(task.Recurrence)
+	if err != nil {
+		return err
+	}
+	task.Recurrence = rule
+
+	workflow, err := workflowFor(s.statusRepository, current.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := workflow.Validate(current.Status, task.Status); err != nil {
+		return err
+	}
+
+	if task.Status == model.StatusCompleted && current.Status != model.StatusCompleted {
+		if err := s.checkBlockers(id); err != nil {
+			return err
+		}
+	}
+
+	if task.CategoryID != current.CategoryID {
+		if err := s.checkCategory(task.CategoryID, current.WorkspaceID); err != nil {
+			return err
+		}
+	}
+
+	if task.ParentID != current.ParentID {
+		if err := s.checkParent(id, task.ParentID, current.WorkspaceID); err != nil {
+			return err
+		}
+	}
+
+	task.ID = id
+	if task.Status != current.Status {
+		err = s.taskRepository.Transition(task, model.StatusTransition{
+			TaskID:    id,
+			From:      current.Status,
+			To:        task.Status,
+			ChangedBy: s.actor,
+			ChangedAt: time.Now(),
+		})
+	} else {
+		err = s.taskRepository.Update(id, task, s.actor)
+	}
+	if err != nil {
+		return err
+	}
+
+	if task.Status == model.StatusCompleted && current.Status != model.StatusCompleted {
+		return s.generateNext(task)
+	}
+	return nil
+}
+
+func (s *taskService) checkBlockers(id int) error {
+	blockers, err := s.taskRepository.GetBlockers(id)
+	if err != nil {
+		return err
+	}
+
+	var open []string
+	for _, blocker := range blockers {
+		if blocker.Status != model.StatusCompleted {
+			open = append(open, fmt.Sprintf("%d", blocker.ID))
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%w: task %d is waiting for %s", model.ErrTaskBlocked, id, strings.Join(open, ", "))
+	}
+	return nil
+}
+
+// checkCategory makes sure a category other than none exists in the workspace of the task.
+func (s *taskService) checkCategory(categoryID, workspaceID int) error {
+	if categoryID == 0 {
+		return nil
+	}
+	category, err := s.categoryRepository.GetByID(categoryID)
+	if err != nil || category.WorkspaceID != workspaceID {
+		return fmt.Errorf("%w: %d", model.ErrInvalidCategory, categoryID)
+	}
+	return nil
+}
+
+// checkParent walks up from the new parent to make sure it exists in the workspace of the task and that
+// the task would not become one of its own ancestors.
+func (s *taskService) checkParent(id, parentID, workspaceID int) error {
+	seen := map[int]bool{}
+	for ancestorID := parentID; ancestorID != 0; {
+		if ancestorID == id || seen[ancestorID] {
+			return fmt.Errorf("%w: task %d cannot be moved under %d", model.ErrInvalidParent, id, parentID)
+		}
+		seen[ancestorID] = true
+
+		ancestor, err := s.taskRepository.GetByID(ancestorID)
+		if err != nil || ancestor.WorkspaceID != workspaceID {
+			return fmt.Errorf("%w: %d", model.ErrInvalidParent, ancestorID)
+		}
+		ancestorID = ancestor.ParentID
+	}
+	return nil
 }
 
 func (s *taskService) Delete(id int) error {
-	return s.taskRepository.Delete(id)
+	_, err := s.taskRepository.Trash(id, false, s.actor, time.Now())
+	return err
+}
+
+func (s *taskService) DeleteKeepChildren(id int) error {
+	_, err := s.taskRepository.Trash(id, true, s.actor, time.Now())
+	return err
 }
 
 func (s *taskService) GetByID(id int) (*model.Task, error) {
 	return s.taskRepository.GetByID(id)
 }
 
-func (s *taskService) GetList() ([]model.Task, error) {
-	return s.taskRepository.GetList()
+func (s *taskService) GetList(workspaceID int) ([]model.Task, error) {
+	tasks, err := s.taskRepository.GetList(workspaceID)
+	return unarchived(tasks), err
+}
+
+func (s *taskService) GetListByTags(workspaceID int, tagIDs []int) ([]model.Task, error) {
+	tasks, err := s.taskRepository.GetListByTags(workspaceID, tagIDs)
+	return unarchived(tasks), err
+}
+
+func (s *taskService) Archive(id int) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status != model.StatusCompleted {
+		return nil, fmt.Errorf("%w: task %d is %s", model.ErrNotArchivable, id, task.Status)
+	}
+	if task.ArchivedAt != nil {
+		return task, nil
+	}
+
+	now := time.Now()
+	task.ArchivedAt = &now
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) Unarchive(id int) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.ArchivedAt == nil {
+		return task, nil
+	}
+
+	task.ArchivedAt = nil
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) GetArchived(workspaceID int) ([]model.Task, error) {
+	page, err := s.Query(workspaceID, model.TaskQuery{Archived: true})
+	return page.Tasks, err
+}
+
+func (s *taskService) GetListByFields(workspaceID int, tagIDs []int, filters map[int]string) ([]model.Task, error) {
+	page, err := s.Query(workspaceID, model.TaskQuery{TagIDs: tagIDs, Fields: filters})
+	return page.Tasks, err
+}
+
+func (s *taskService) Query(workspaceID int, query model.TaskQuery) (model.TaskPage, error) {
+	if err := query.Validate(); err != nil {
+		return model.TaskPage{}, err
+	}
+
+	for fieldID, value := range query.Fields {
+		field, err := s.fieldRepository.GetByID(fieldID)
+		if err != nil || field.WorkspaceID != workspaceID {
+			return model.TaskPage{}, fmt.Errorf("%w: unknown field %d", model.ErrInvalidFieldValue, fieldID)
+		}
+		if _, err := field.Normalize(value); err != nil {
+			return model.TaskPage{}, err
+		}
+	}
+	if query.Archived && query.Sort == "" {
+		query.Sort = "-archived"
+	}
+
+	return s.taskRepository.Query(workspaceID, query)
+}
+
+func (s *taskService) Search(workspaceID, userID int, query string) ([]model.Task, error) {
+	parsed, err := model.ParseSearch(query)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := time.UTC
+	if user, err := s.userRepository.GetUserByID(userID); err == nil {
+		loc = model.LoadLocation(user.TimeZone)
+	}
+	return s.taskRepository.Search(workspaceID, *parsed, time.Now(), loc)
+}
+
+func (s *taskService) SetFields(id int, fields map[int]interface{}) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[int]interface{}{}
+	for fieldID, value := range task.Fields {
+		merged[fieldID] = value
+	}
+	for fieldID, value := range fields {
+		merged[fieldID] = value
+	}
+
+	task.Fields, err = s.normalizeFields(task.WorkspaceID, merged, task.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) normalizeFields(workspaceID int, values, current map[int]interface{}) (map[int]interface{}, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	fields, err := s.fieldRepository.GetList(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]model.CustomField, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+
+	normalized := map[int]interface{}{}
+	for fieldID, value := range values {
+		// A stored value stays valid even if its select option has been removed since.
+		if stored, ok := current[fieldID]; ok && stored == value {
+			normalized[fieldID] = value
+			continue
+		}
+
+		field, ok := byID[fieldID]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %d", model.ErrInvalidFieldValue, fieldID)
+		}
+
+		value, err := field.Normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			normalized[fieldID] = value
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+func (s *taskService) SetMilestone(id, milestoneID int) error {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	task.MilestoneID = milestoneID
+	return s.taskRepository.Update(id, task, s.actor)
+}
+
+func (s *taskService) SetSprint(id, sprintID int) error {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	task.SprintID = sprintID
+	return s.taskRepository.Update(id, task, s.actor)
 }
 
-func (s *taskService) GetTaskCategory(id int) ([]model.TaskCategory, error) {
-	return s.taskRepository.GetTaskCategory(id)
+func (s *taskService) GetTaskCategory(workspaceID, id int) ([]model.TaskCategory, error) {
+	return s.taskRepository.GetTaskCategory(workspaceID, id)
+}
+
+func (s *taskService) Transition(id int, status model.TaskStatus) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status == status {
+		return task, nil
+	}
+
+	if err := s.changeStatus(task, status); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// changeStatus moves the task to the status, storing it together with the transition, and generates the
+// next occurrence when a recurring task is completed.
+func (s *taskService) changeStatus(task *model.Task, status model.TaskStatus) error {
+	workflow, err := workflowFor(s.statusRepository, task.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := workflow.Validate(task.Status, status); err != nil {
+		return err
+	}
+
+	if status == model.StatusCompleted {
+		if err := s.checkBlockers(task.ID); err != nil {
+			return err
+		}
+	}
+
+	transition := model.StatusTransition{
+		TaskID:    task.ID,
+		From:      task.Status,
+		To:        status,
+		ChangedBy: s.actor,
+		ChangedAt: time.Now(),
+	}
+	task.Status = status
+	if status != model.StatusCompleted {
+		task.ArchivedAt = nil
+	}
+
+	if err := s.taskRepository.Transition(task, transition); err != nil {
+		return err
+	}
+
+	if status == model.StatusCompleted {
+		return s.generateNext(task)
+	}
+	return nil
+}
+
+func (s *taskService) Move(id int, move model.MoveRequest) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if move.Status == "" {
+		move.Status = task.Status
+	}
+
+	tasks, err := s.taskRepository.GetList(task.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var column []model.Task
+	for _, other := range unarchived(tasks) {
+		if other.Status == move.Status && other.ID != id {
+			column = append(column, other)
+		}
+	}
+	model.SortByRank(column)
+
+	before, after := "", ""
+	if move.AfterID == 0 {
+		if len(column) > 0 {
+			after = column[0].Rank
+		}
+	} else {
+		found := false
+		for i, other := range column {
+			if other.ID == move.AfterID {
+				before = other.Rank
+				if i+1 < len(column) {
+					after = column[i+1].Rank
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: task %d is not in column %q", model.ErrInvalidMove, move.AfterID, move.Status)
+		}
+	}
+	task.Rank = model.RankBetween(before, after)
+
+	if move.Status == task.Status {
+		if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+			return nil, err
+		}
+		return task, nil
+	}
+
+	if err := s.changeStatus(task, move.Status); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) GetBoard(workspaceID, userID int) (model.Board, error) {
+	workflow, err := workflowFor(s.statusRepository, userID)
+	if err != nil {
+		return model.Board{}, err
+	}
+
+	tasks, err := s.taskRepository.GetList(workspaceID)
+	if err != nil {
+		return model.Board{}, err
+	}
+	tasks = unarchived(tasks)
+	model.SortByRank(tasks)
+
+	var board model.Board
+	columns := map[model.TaskStatus]int{}
+	for _, status := range workflow.Statuses() {
+		columns[status] = len(board.Columns)
+		board.Columns = append(board.Columns, model.BoardColumn{Status: status, Tasks: []model.Task{}})
+	}
+
+	for _, task := range tasks {
+		i, ok := columns[task.Status]
+		if !ok {
+			i = len(board.Columns)
+			columns[task.Status] = i
+			board.Columns = append(board.Columns, model.BoardColumn{Status: task.Status})
+		}
+		board.Columns[i].Tasks = append(board.Columns[i].Tasks, task)
+	}
+	return board, nil
+}
+
+func (s *taskService) GetTransitions(id int) ([]model.StatusTransition, error) {
+	if _, err := s.taskRepository.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	return s.taskRepository.GetTransitions(id)
+}
+
+func (s *taskService) GetHistory(id int) ([]model.TaskEvent, error) {
+	if _, err := s.taskRepository.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	events, err := s.taskRepository.GetHistory(id)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []model.TaskEvent{}
+	}
+	return events, nil
+}
+
+func (s *taskService) GetSubtasks(id int) ([]model.Task, error) {
+	if _, err := s.taskRepository.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	return s.taskRepository.GetSubtasks(id)
+}
+
+func (s *taskService) Detach(id int) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.ParentID == 0 {
+		return task, nil
+	}
+
+	task.ParentID = 0
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) GetProgress(id int) (model.Progress, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return model.Progress{}, err
+	}
+
+	children, err := s.taskRepository.GetSubtasks(id)
+	if err != nil {
+		return model.Progress{}, err
+	}
+
+	return model.ComputeProgress(*task, children), nil
+}
+
+func (s *taskService) AddChecklistItem(id int, item model.ChecklistItem) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	item.ID = nextChecklistItemID(task)
+	task.Checklist = append(task.Checklist, item)
+
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) ToggleChecklistItem(id, itemID int) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for i := range task.Checklist {
+		if task.Checklist[i].ID == itemID {
+			task.Checklist[i].Done = !task.Checklist[i].Done
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %d", model.ErrChecklistItemNotFound, itemID)
+	}
+
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) ReorderChecklist(id int, order []int) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(order) != len(task.Checklist) {
+		return nil, model.ErrInvalidChecklistOrder
+	}
+
+	items := make(map[int]model.ChecklistItem, len(task.Checklist))
+	for _, item := range task.Checklist {
+		items[item.ID] = item
+	}
+
+	reordered := make([]model.ChecklistItem, 0, len(order))
+	for _, itemID := range order {
+		item, ok := items[itemID]
+		if !ok {
+			return nil, model.ErrInvalidChecklistOrder
+		}
+		delete(items, itemID)
+		reordered = append(reordered, item)
+	}
+	task.Checklist = reordered
+
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskService) DeleteChecklistItem(id, itemID int) (*model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]model.ChecklistItem, 0, len(task.Checklist))
+	for _, item := range task.Checklist {
+		if item.ID != itemID {
+			remaining = append(remaining, item)
+		}
+	}
+	if len(remaining) == len(task.Checklist) {
+		return nil, fmt.Errorf("%w: %d", model.ErrChecklistItemNotFound, itemID)
+	}
+	task.Checklist = remaining
+
+	if err := s.taskRepository.Update(id, task, s.actor); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func nextChecklistItemID(task *model.Task) int {
+	highest := 0
+	for _, item := range task.Checklist {
+		if item.ID > highest {
+			highest = item.ID
+		}
+	}
+	return highest + 1
+}
+
+func (s *taskService) UpdateOccurrence(id int, task *model.Task) error {
+	current, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	task.Recurrence = current.Recurrence
+	return s.Update(id, task)
+}
+
+func (s *taskService) UpdateSeries(id int, task *model.Task) ([]model.Task, error) {
+	current, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.CategoryID != current.CategoryID {
+		if err := s.checkCategory(task.CategoryID, current.WorkspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	rule, err := normalizeRecurrence(task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	if rule == "" {
+		rule = current.Recurrence
+	}
+
+	var shift time.Duration
+	if !task.Deadline.IsZero() && !current.Deadline.IsZero() {
+		shift = task.Deadline.At.Sub(current.Deadline.At)
+	}
+
+	series, err := s.GetSeries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []model.Task
+	for _, occurrence := range series {
+		if occurrence.ID < current.ID || (occurrence.Status == model.StatusCompleted && occurrence.ID != current.ID) {
+			continue
+		}
+
+		if task.Title != "" {
+			occurrence.Title = task.Title
+		}
+		if task.Priority != 0 {
+			occurrence.Priority = task.Priority
+		}
+		if task.CategoryID != 0 {
+			occurrence.CategoryID = task.CategoryID
+		}
+		occurrence.Recurrence = rule
+
+		switch {
+		case occurrence.ID == current.ID && !task.Deadline.IsZero():
+			occurrence.Deadline = task.Deadline
+		case !occurrence.Deadline.IsZero():
+			occurrence.Deadline.At = occurrence.Deadline.At.Add(shift)
+		}
+
+		if err := s.taskRepository.Update(occurrence.ID, &occurrence, s.actor); err != nil {
+			return nil, err
+		}
+		updated = append(updated, occurrence)
+	}
+	return updated, nil
+}
+
+func (s *taskService) GetSeries(id int) ([]model.Task, error) {
+	task, err := s.taskRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepository.GetList(task.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var series []model.Task
+	for _, other := range tasks {
+		if seriesID(&other) == seriesID(task) {
+			series = append(series, other)
+		}
+	}
+
+	sort.Slice(series, func(i, j int) bool { return series[i].ID < series[j].ID })
+	return series, nil
+}
+
+func (s *taskService) generateNext(task *model.Task) error {
+	if task.Recurrence == "" || task.NextID != 0 {
+		return nil
+	}
+
+	rule, err := model.ParseRecurrence(task.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	deadline := task.Deadline
+	if deadline.IsZero() {
+		now := time.Now().UTC()
+		deadline = model.DateDeadline(now.Year(), now.Month(), now.Day())
+	}
+
+	nextDeadline, ok := rule.Next(deadline)
+	if !ok {
+		return nil
+	}
+
+	checklist := make([]model.ChecklistItem, len(task.Checklist))
+	for i, item := range task.Checklist {
+		checklist[i] = model.ChecklistItem{ID: item.ID, Title: item.Title}
+	}
+
+	next := model.Task{
+		Title:      task.Title,
+		Deadline:   nextDeadline,
+		Priority:   task.Priority,
+		Status:     model.StatusTodo,
+		CategoryID: task.CategoryID,
+		UserID:     task.UserID,
+		ParentID:   task.ParentID,
+		Checklist:  checklist,
+		Recurrence: task.Recurrence,
+		SeriesID:   seriesID(task),
+
+		EstimateHours: task.EstimateHours,
+		StoryPoints:   task.StoryPoints,
+		WorkspaceID:   task.WorkspaceID,
+		MilestoneID:   task.MilestoneID,
+		Fields:        task.Fields,
+	}
+	if err := s.taskRepository.Store(&next, s.actor); err != nil {
+		return err
+	}
+
+	task.NextID = next.ID
+	return s.taskRepository.Update(task.ID, task, s.actor)
+}
+
+func seriesID(task *model.Task) int {
+	if task.SeriesID != 0 {
+		return task.SeriesID
+	}
+	return task.ID
+}
+
+func normalizeRecurrence(rule string) (string, error) {
+	if strings.TrimSpace(rule) == "" {
+		return "", nil
+	}
+
+	recurrence, err := model.ParseRecurrence(rule)
+	if err != nil {
+		return "", err
+	}
+	return recurrence.String(), nil
+}
+
+func checkEstimate(task model.Task) error {
+	if task.EstimateHours < 0 || task.StoryPoints < 0 {
+		return fmt.Errorf("%w: %g hours, %d points", model.ErrInvalidEstimate, task.EstimateHours, task.StoryPoints)
+	}
+	return nil
+}
+
+func unarchived(tasks []model.Task) []model.Task {
+	if tasks == nil {
+		return nil
+	}
+
+	kept := []model.Task{}
+	for _, task := range tasks {
+		if task.ArchivedAt == nil {
+			kept = append(kept, task)
+		}
+	}
+	return kept
 }
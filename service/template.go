@@ -0,0 +1,182 @@
+/** 
+ * Package service provides interfaces and implementations for managing task templates and instantiating them.
+ * 
+ * Interfaces:
+ * 
+ * - TemplateService: Interface defining methods for template management.
+ *   Methods:
+ *   - Create: Method to create a template.
+ *   - Update: Method to change the name, description and tasks of a template.
+ *   - Delete: Method to delete a template.
+ *   - GetByID: Method to retrieve a template by ID.
+ *   - GetList: Method to retrieve the templates of a workspace.
+ *   - Instantiate: Method to create the tasks of a template.
+ * 
+ * Structs:
+ * 
+ * - templateService: Struct implementing the TemplateService interface.
+ *   Fields:
+ *   - templateRepository: Instance of repo.TemplateRepository for template repository operations.
+ *   - categoryService: Instance of CategoryService to check that the categories of a template belong to its workspace.
+ *   - taskService: Instance of TaskService to check and store the tasks of an instantiated template.
+ *   Methods:
+ *   - NewTemplateService: Function to create a new instance of templateService.
+ *   - Create: Method to store a template with a trimmed, non-empty name and valid tasks.
+ *   - Update: Method to replace the name, description and tasks of a template of the workspace.
+ *   - Delete: Method to delete a template of the workspace. Tasks created from it are kept.
+ *   - GetByID: Method to retrieve a template, reporting templates of other workspaces as not found.
+ *   - GetList: Method to retrieve the templates of a workspace using the template repository.
+ *   - Instantiate: Method to create every task of a template in its workspace as Todo tasks of the user, due the number of days
+ *     of their deadline offset after the start date. The tasks are checked like any new task and created on behalf of the
+ *     acting user in one transaction, at the bottom of the board in template order, and returned.
+ *   - template: Method to retrieve a template of the workspace or ErrTemplateNotFound.
+ *   - validateTemplate: Method to trim the name and task titles of a template and check them, the deadline offsets and
+ *     that every category belongs to the workspace of the template.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TemplateService interface {
+	Create(template model.Template) (model.Template, error)
+	Update(id, workspaceID int, request model.TemplateRequest) (model.Template, error)
+	Delete(id, workspaceID int) error
+	GetByID(id, workspaceID int) (*model.Template, error)
+	GetList(workspaceID int) ([]model.Template, error)
+	Instantiate(id, workspaceID, userID int, actor string, request model.InstantiateRequest) ([]model.Task, error)
+}
+
+type templateService struct {
+	templateRepository repo.TemplateRepository
+	categoryService    CategoryService
+	taskService        TaskService
+}
+
+func NewTemplateService(templateRepository repo.TemplateRepository, categoryService CategoryService, taskService TaskService) TemplateService {
+	return &templateService{templateRepository, categoryService, taskService}
+}
+
+func (s *templateService) Create(template model.Template) (model.Template, error) {
+	if err := s.validateTemplate(&template); err != nil {
+		return model.Template{}, err
+	}
+
+	template.ID = 0
+	template.CreatedAt = time.Now()
+	return s.templateRepository.Store(template)
+}
+
+func (s *templateService) Update(id, workspaceID int, request model.TemplateRequest) (model.Template, error) {
+	template, err := s.template(id, workspaceID)
+	if err != nil {
+		return model.Template{}, err
+	}
+
+	template.Name = request.Name
+	template.Description = request.Description
+	template.Tasks = request.Tasks
+	if err := s.validateTemplate(template); err != nil {
+		return model.Template{}, err
+	}
+	return s.templateRepository.Store(*template)
+}
+
+func (s *templateService) Delete(id, workspaceID int) error {
+	if _, err := s.template(id, workspaceID); err != nil {
+		return err
+	}
+	return s.templateRepository.Delete(id)
+}
+
+func (s *templateService) GetByID(id, workspaceID int) (*model.Template, error) {
+	return s.template(id, workspaceID)
+}
+
+func (s *templateService) GetList(workspaceID int) ([]model.Template, error) {
+	return s.templateRepository.GetList(workspaceID)
+}
+
+func (s *templateService) Instantiate(id, workspaceID, userID int, actor string, request model.InstantiateRequest) ([]model.Task, error) {
+	template, err := s.template(id, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.StartDate.IsZero() {
+		return nil, fmt.Errorf("%w: missing start date", model.ErrInvalidTemplate)
+	}
+
+	// Categories may have been deleted or moved since the template was saved.
+	if err := s.validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*model.Task, len(template.Tasks))
+	for i, item := range template.Tasks {
+		task := &model.Task{
+			Title:       item.Title,
+			Priority:    item.Priority,
+			Status:      model.StatusTodo,
+			CategoryID:  item.CategoryID,
+			UserID:      userID,
+			WorkspaceID: template.WorkspaceID,
+		}
+		if item.DeadlineOffset != nil {
+			task.Deadline = model.Deadline{
+				At:       request.StartDate.At.AddDate(0, 0, *item.DeadlineOffset),
+				DateOnly: request.StartDate.DateOnly,
+			}
+		}
+		tasks[i] = task
+	}
+
+	if err := s.taskService.As(actor).StoreAll(tasks); err != nil {
+		return nil, err
+	}
+
+	created := make([]model.Task, len(tasks))
+	for i, task := range tasks {
+		created[i] = *task
+	}
+	return created, nil
+}
+
+func (s *templateService) template(id, workspaceID int) (*model.Template, error) {
+	template, err := s.templateRepository.GetByID(id)
+	if err != nil || template.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("%w: %d", model.ErrTemplateNotFound, id)
+	}
+	return template, nil
+}
+
+func (s *templateService) validateTemplate(template *model.Template) error {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Name == "" || len(template.Tasks) == 0 {
+		return model.ErrInvalidTemplate
+	}
+
+	for i := range template.Tasks {
+		task := &template.Tasks[i]
+		task.Title = strings.TrimSpace(task.Title)
+		if task.Title == "" {
+			return fmt.Errorf("%w: task %d has no title", model.ErrInvalidTemplate, i+1)
+		}
+		if task.DeadlineOffset != nil && *task.DeadlineOffset < 0 {
+			return fmt.Errorf("%w: task %q is due before the start date", model.ErrInvalidTemplate, task.Title)
+		}
+		if task.CategoryID != 0 {
+			category, err := s.categoryService.GetByID(task.CategoryID)
+			if err != nil || category.WorkspaceID != template.WorkspaceID {
+				return fmt.Errorf("%w: category %d", model.ErrInvalidTemplate, task.CategoryID)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+/** 
+ * Package service provides interfaces and implementations for tracking the time spent on tasks.
+ * 
+ * Interfaces:
+ * 
+ * - TimeService: Interface defining methods for time tracking.
+ *   Methods:
+ *   - Start: Method to start a timer on a task.
+ *   - Stop: Method to stop the running timer of a user.
+ *   - GetRunning: Method to retrieve the running timer of a user.
+ *   - AddEntry: Method to enter time spent on a task by hand.
+ *   - DeleteEntry: Method to delete a time entry.
+ *   - GetTaskTime: Method to retrieve the time spent on a task.
+ *   - Report: Method to build the timesheet of a user.
+ * 
+ * Structs:
+ * 
+ * - timeService: Struct implementing the TimeService interface.
+ *   Fields:
+ *   - timeEntryRepository: Instance of repo.TimeEntryRepository for time entry repository operations.
+ *   - taskRepository: Instance of repo.TaskRepository used to check tasks and find their categories.
+ *   - userRepository: Instance of repo.UserRepository used to find the time zone of a user.
+ *   Methods:
+ *   - NewTimeService: Function to create a new instance of timeService.
+ *   - Start: Method to start a timer for the user on the task. A user has at most one running timer, a second one
+ *     is refused with ErrTimerRunning.
+ *   - Stop: Method to stop the running timer of the user, ErrNoRunningTimer when none is running.
+ *   - GetRunning: Method to retrieve the running timer of the user, ErrNoRunningTimer when none is running.
+ *   - AddEntry: Method to enter time spent on a task by hand. The entry must end after it starts and not in the future.
+ *   - DeleteEntry: Method to delete a time entry of the user. Entries of other users are reported as ErrTimeEntryNotFound.
+ *   - GetTaskTime: Method to retrieve the time entries of a task by every user with their total, running timers
+ *     counted up to now.
+ *   - Report: Method to build the timesheet of the user between two days, both included, in the user's time zone.
+ *     Without days it covers the last seven days. Each entry counts for the day it started on.
+ * 
+ * Functions:
+ * 
+ * - timeTotals: Function to turn seconds per key into totals ordered by key.
+ * - sortTimeEntries: Function to order time entries by the time they started.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type TimeService interface {
+	Start(taskID, userID int, note string) (*model.TimeEntry, error)
+	Stop(userID int) (*model.TimeEntry, error)
+	GetRunning(userID int) (*model.TimeEntry, error)
+	AddEntry(taskID, userID int, request model.TimeEntryRequest) (*model.TimeEntry, error)
+	DeleteEntry(id, userID int) error
+	GetTaskTime(taskID int) (model.TaskTime, error)
+	Report(userID int, from, to string) (model.Timesheet, error)
+}
+
+type timeService struct {
+	timeEntryRepository repo.TimeEntryRepository
+	taskRepository      repo.TaskRepository
+	userRepository      repo.UserRepository
+}
+
+func NewTimeService(timeEntryRepository repo.TimeEntryRepository, taskRepository repo.TaskRepository, userRepository repo.UserRepository) TimeService {
+	return &timeService{timeEntryRepository, taskRepository, userRepository}
+}
+
+func (s *timeService) Start(taskID, userID int, note string) (*model.TimeEntry, error) {
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	entry := model.TimeEntry{
+		TaskID: taskID,
+		UserID: userID,
+		Start:  time.Now().UTC(),
+		Note:   strings.TrimSpace(note),
+	}
+	running, err := s.timeEntryRepository.Start(&entry)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		return nil, fmt.Errorf("%w: on task %d since %s", model.ErrTimerRunning, running.TaskID, running.Start.Format(time.RFC3339))
+	}
+	return &entry, nil
+}
+
+func (s *timeService) Stop(userID int) (*model.TimeEntry, error) {
+	entry, err := s.timeEntryRepository.Stop(userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, model.ErrNoRunningTimer
+	}
+	return entry, nil
+}
+
+func (s *timeService) GetRunning(userID int) (*model.TimeEntry, error) {
+	entries, err := s.timeEntryRepository.GetList(0, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Running() {
+			return &entry, nil
+		}
+	}
+	return nil, model.ErrNoRunningTimer
+}
+
+func (s *timeService) AddEntry(taskID, userID int, request model.TimeEntryRequest) (*model.TimeEntry, error) {
+	if !request.End.After(request.Start) || request.End.After(time.Now()) {
+		return nil, model.ErrInvalidTimeEntry
+	}
+
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	end := request.End.UTC()
+	entry := model.TimeEntry{
+		TaskID: taskID,
+		UserID: userID,
+		Start:  request.Start.UTC(),
+		End:    &end,
+		Note:   strings.TrimSpace(request.Note),
+		Manual: true,
+	}
+	if err := s.timeEntryRepository.Store(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *timeService) DeleteEntry(id, userID int) error {
+	entry, err := s.timeEntryRepository.GetByID(id)
+	if err != nil || entry.UserID != userID {
+		return fmt.Errorf("%w: %d", model.ErrTimeEntryNotFound, id)
+	}
+	return s.timeEntryRepository.Delete(id)
+}
+
+func (s *timeService) GetTaskTime(taskID int) (model.TaskTime, error) {
+	if _, err := s.taskRepository.GetByID(taskID); err != nil {
+		return model.TaskTime{}, err
+	}
+
+	entries, err := s.timeEntryRepository.GetList(taskID, 0)
+	if err != nil {
+		return model.TaskTime{}, err
+	}
+
+	result := model.TaskTime{TaskID: taskID, Entries: []model.TimeEntry{}}
+	now := time.Now()
+	for _, entry := range entries {
+		result.Entries = append(result.Entries, entry)
+		result.TotalSeconds += int64(entry.Duration(now).Seconds())
+	}
+	sortTimeEntries(result.Entries)
+	return result, nil
+}
+
+func (s *timeService) Report(userID int, from, to string) (model.Timesheet, error) {
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return model.Timesheet{}, err
+	}
+	loc := model.LoadLocation(user.TimeZone)
+	now := time.Now()
+
+	today := now.In(loc).Format(model.DateLayout)
+	if to == "" {
+		to = today
+	}
+	last, err := time.ParseInLocation(model.DateLayout, to, loc)
+	if err != nil {
+		return model.Timesheet{}, fmt.Errorf("%w: %s", model.ErrInvalidTimeRange, to)
+	}
+	if from == "" {
+		from = last.AddDate(0, 0, -6).Format(model.DateLayout)
+	}
+	first, err := time.ParseInLocation(model.DateLayout, from, loc)
+	if err != nil {
+		return model.Timesheet{}, fmt.Errorf("%w: %s", model.ErrInvalidTimeRange, from)
+	}
+	if first.After(last) {
+		return model.Timesheet{}, fmt.Errorf("%w: %s is after %s", model.ErrInvalidTimeRange, from, to)
+	}
+	end := last.AddDate(0, 0, 1)
+
+	entries, err := s.timeEntryRepository.GetList(0, userID)
+	if err != nil {
+		return model.Timesheet{}, err
+	}
+
+	sheet := model.Timesheet{
+		UserID:   userID,
+		From:     from,
+		To:       to,
+		TimeZone: loc.String(),
+		Entries:  []model.TimeEntry{},
+	}
+	byTask, byCategory, byDay := map[int]int64{}, map[int]int64{}, map[string]int64{}
+	categories := map[int]int{}
+	for _, entry := range entries {
+		if entry.Start.Before(first) || !entry.Start.Before(end) {
+			continue
+		}
+
+		categoryID, ok := categories[entry.TaskID]
+		if !ok {
+			if task, err := s.taskRepository.GetByID(entry.TaskID); err == nil {
+				categoryID = task.CategoryID
+			}
+			categories[entry.TaskID] = categoryID
+		}
+
+		seconds := int64(entry.Duration(now).Seconds())
+		sheet.Entries = append(sheet.Entries, entry)
+		sheet.TotalSeconds += seconds
+		byTask[entry.TaskID] += seconds
+		byCategory[categoryID] += seconds
+		byDay[entry.Start.In(loc).Format(model.DateLayout)] += seconds
+	}
+	sortTimeEntries(sheet.Entries)
+
+	sheet.ByTask = timeTotals(byTask, func(total *model.TimeTotal, id int) { total.TaskID = id })
+	sheet.ByCategory = timeTotals(byCategory, func(total *model.TimeTotal, id int) { total.CategoryID = id })
+
+	days := make([]string, 0, len(byDay))
+	for day := range byDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	sheet.ByDay = []model.TimeTotal{}
+	for _, day := range days {
+		sheet.ByDay = append(sheet.ByDay, model.TimeTotal{Date: day, Seconds: byDay[day]})
+	}
+	return sheet, nil
+}
+
+func timeTotals(seconds map[int]int64, set func(total *model.TimeTotal, id int)) []model.TimeTotal {
+	ids := make([]int, 0, len(seconds))
+	for id := range seconds {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	totals := []model.TimeTotal{}
+	for _, id := range ids {
+		total := model.TimeTotal{Seconds: seconds[id]}
+		set(&total, id)
+		totals = append(totals, total)
+	}
+	return totals
+}
+
+// sortTimeEntries orders entries by their start, since manual entries can be entered after later ones.
+func sortTimeEntries(entries []model.TimeEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Start.Before(entries[j].Start) })
+}
@@ -0,0 +1,122 @@
+/** 
+ * Package service provides interfaces and implementations for managing the trash of a workspace.
+ * 
+ * Interfaces:
+ * 
+ * - TrashService: Interface defining methods for trash management.
+ *   Methods:
+ *   - GetList: Method to retrieve the trash items of a workspace.
+ *   - GetByID: Method to retrieve a trash item by ID.
+ *   - Restore: Method to move a deleted task or category back.
+ *   - Purge: Method to remove a trash item for good.
+ *   - PurgeExpired: Method to remove the trash items whose retention has ended.
+ * 
+ * Structs:
+ * 
+ * - trashService: Struct implementing the TrashService interface.
+ *   Fields:
+ *   - trashRepository: Instance of repo.TrashRepository for trash repository operations.
+ *   - categoryRepository: Instance of repo.CategoryRepository used to check a category can be restored.
+ *   Methods:
+ *   - NewTrashService: Function to create a new instance of trashService.
+ *   - GetList: Method to retrieve the trash items of a workspace, most recently deleted first, with the time each is purged.
+ *   - GetByID: Method to retrieve a trash item, reporting items of other workspaces as not found.
+ *   - Restore: Method to put a trash item of the workspace back with its subtasks and related records. A category whose ID
+ *     has been taken in the meantime cannot be restored. Restored tasks lose their parent, sprint or milestone when these
+ *     have been deleted since, and related records pointing at deleted tags or users are dropped. The actor is recorded in the
+ *     history of the restored tasks.
+ *   - Purge: Method to remove a trash item of the workspace for good, queueing the content of its attachments for removal.
+ *   - PurgeExpired: Method to remove every trash item deleted longer than the retention ago, returning how many were removed.
+ *   - item: Method to retrieve a trash item of the workspace or ErrTrashItemNotFound.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/config"
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type TrashService interface {
+	GetList(workspaceID int) ([]model.TrashItem, error)
+	GetByID(id, workspaceID int) (*model.TrashItem, error)
+	Restore(id, workspaceID int, actor string) (model.TrashItem, error)
+	Purge(id, workspaceID int) error
+	PurgeExpired(now time.Time) (int, error)
+}
+
+type trashService struct {
+	trashRepository    repo.TrashRepository
+	categoryRepository repo.CategoryRepository
+}
+
+func NewTrashService(trashRepository repo.TrashRepository, categoryRepository repo.CategoryRepository) TrashService {
+	return &trashService{trashRepository, categoryRepository}
+}
+
+func (s *trashService) GetList(workspaceID int) ([]model.TrashItem, error) {
+	items, err := s.trashRepository.GetList(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := config.GetTrashRetention()
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(retention)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	if items == nil {
+		items = []model.TrashItem{}
+	}
+	return items, nil
+}
+
+func (s *trashService) GetByID(id, workspaceID int) (*model.TrashItem, error) {
+	item, err := s.item(id, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	item.PurgeAt = item.DeletedAt.Add(config.GetTrashRetention())
+	return item, nil
+}
+
+func (s *trashService) Restore(id, workspaceID int, actor string) (model.TrashItem, error) {
+	item, err := s.item(id, workspaceID)
+	if err != nil {
+		return model.TrashItem{}, err
+	}
+
+	if item.Category != nil {
+		if _, err := s.categoryRepository.GetByID(item.Category.ID); err == nil {
+			return model.TrashItem{}, fmt.Errorf("%w: category %d", model.ErrRestoreConflict, item.Category.ID)
+		}
+	}
+
+	return s.trashRepository.Restore(id, actor)
+}
+
+func (s *trashService) Purge(id, workspaceID int) error {
+	if _, err := s.item(id, workspaceID); err != nil {
+		return err
+	}
+	return s.trashRepository.Purge(id)
+}
+
+func (s *trashService) PurgeExpired(now time.Time) (int, error) {
+	return s.trashRepository.PurgeBefore(now.Add(-config.GetTrashRetention()))
+}
+
+func (s *trashService) item(id, workspaceID int) (*model.TrashItem, error) {
+	item, err := s.trashRepository.GetByID(id)
+	if err != nil || item.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("%w: %d", model.ErrTrashItemNotFound, id)
+	}
+	return item, nil
+}
@@ -7,7 +7,15 @@
  *   Methods:
  *   - Register: Method to register a new user.
  *   - Login: Method to authenticate and generate JWT token for a user.
- *   - GetUserTaskCategory: Method to retrieve user task categories.
+ *   - GetUserTaskCategory: Method to retrieve the user task categories of a workspace.
+ *   - GetByEmail: Method to retrieve a user by email.
+ *   - GetProfile: Method to retrieve the profile of a user.
+ *   - UpdateProfile: Method to update the full name and time zone of a user.
+ *   - RequestDeletion: Method to schedule the deletion of an account after the grace period.
+ *   - RestoreAccount: Method to cancel a pending deletion using the account's credentials.
+ *   - RestoreAccountByID: Method to cancel a pending deletion on behalf of the user.
+ *   - PurgeDeletedAccounts: Method to erase the accounts whose grace period has ended.
+ *   - GetAuditLog: Method to retrieve the audit log.
  * 
  * Structs:
  * 
@@ -19,15 +27,29 @@
  *   - NewUserService: Function to create a new instance of userService.
  *   - Register: Method to register a new user by checking email existence, creating a new user, and storing user session.
  *   - Login: Method to authenticate a user by email and password, generate JWT token, and manage user session.
- *   - GetUserTaskCategory: Method to retrieve user task categories using the user repository.
+ *   - GetUserTaskCategory: Method to retrieve the user task categories of a workspace, archived tasks left out, using the user repository.
+ *   - GetByEmail: Method to retrieve a user by email, returning model.ErrUserNotFound when no user matches.
+ *   - GetProfile: Method to retrieve a user by ID as a model.UserProfile, leaving out its password.
+ *   - UpdateProfile: Method to update the full name and time zone of a user, rejecting unknown IANA time zones.
+ *     Empty values keep the current ones.
+ *   - RequestDeletion: Method to mark a user for deletion after config.GetDeletionGracePeriod, signing it out of every session.
+ *     Returns model.ErrUserNotFound for unknown users and model.ErrDeletionScheduled when the deletion was already requested.
+ *   - RestoreAccount: Method to verify the user's credentials and clear its pending deletion.
+ *     Returns model.ErrWrongCredentials when the password does not match and model.ErrDeletionNotScheduled when no deletion is pending.
+ *   - RestoreAccountByID: Method to clear the pending deletion of a user by ID, used by admins.
+ *   - PurgeDeletedAccounts: Method to erase every user whose deletion is due, returning how many were erased.
+ *     Users owning a shared workspace without another admin are skipped until one is appointed.
+ *   - GetAuditLog: Method to retrieve the audit log using the user repository.
  */
 
 package service
 
 import (
+	"a21hc3NpZ25tZW50/config"
 	"a21hc3NpZ25tZW50/model"
 	repo "a21hc3NpZ25tZW50/repository"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt"
@@ -36,7 +58,15 @@ import (
 type UserService interface {
 	Register(user *model.User) (model.User, error)
 	Login(user *model.User) (token *string, err error)
-	GetUserTaskCategory() ([]model.UserTaskCategory, error)
+	GetUserTaskCategory(workspaceID int) ([]model.UserTaskCategory, error)
+	GetByEmail(email string) (model.User, error)
+	GetProfile(id int) (model.UserProfile, error)
+	UpdateProfile(id int, profile model.UserProfile) (model.UserProfile, error)
+	RequestDeletion(id int, actor string) (model.User, error)
+	RestoreAccount(user *model.User) (model.User, error)
+	RestoreAccountByID(id int, actor string) (model.User, error)
+	PurgeDeletedAccounts(now time.Time) (int, error)
+	GetAuditLog() ([]model.AuditRecord, error)
 }
 
 type userService struct {
@@ -82,9 +112,14 @@ func (s *userService) Login(user *model.User) (token *string, err error) {
 		return nil, errors.New("wrong email or password")
 	}
 
+	if dbUser.DeletionDueAt != nil {
+		return nil, errors.New("account is scheduled for deletion")
+	}
+
 	expirationTime := time.Now().Add(20 * time.Minute)
 	claims := &model.Claims{
-		Email: dbUser.Email,
+		Email:  dbUser.Email,
+		UserID: dbUser.ID,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expirationTime.Unix(),
 		},
@@ -111,6 +146,176 @@ func (s *userService) Login(user *model.User) (token *string, err error) {
 	return &tokenString, nil
 }
 
-func (s *userService) GetUserTaskCategory() ([]model.UserTaskCategory, error) {
-	return s.userRepo.GetUserTaskCategory()
+func (s *userService) GetUserTaskCategory(workspaceID int) ([]model.UserTaskCategory, error) {
+	return s.userRepo.GetUserTaskCategory(workspaceID)
+}
+
+func (s *userService) GetByEmail(email string) (model.User, error) {
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	if user.Email == "" || user.ID == 0 {
+		return model.User{}, model.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (s *userService) GetProfile(id int) (model.UserProfile, error) {
+	user, err := s.userRepo.GetUserByID(id)
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+
+	return toProfile(user), nil
+}
+
+func (s *userService) UpdateProfile(id int, profile model.UserProfile) (model.UserProfile, error) {
+	user, err := s.userRepo.GetUserByID(id)
+	if err != nil {
+		return model.UserProfile{}, err
+	}
+
+	if profile.TimeZone != "" {
+		if _, err := time.LoadLocation(profile.TimeZone); err != nil {
+			return model.UserProfile{}, fmt.Errorf("unknown time zone %q", profile.TimeZone)
+		}
+	}
+
+	if profile.Fullname != "" {
+		user.Fullname = profile.Fullname
+	}
+	if profile.TimeZone != "" {
+		user.TimeZone = profile.TimeZone
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.UpdateUser(user); err != nil {
+		return model.UserProfile{}, err
+	}
+
+	return toProfile(user), nil
+}
+
+func toProfile(user model.User) model.UserProfile {
+	return model.UserProfile{
+		ID:       user.ID,
+		Fullname: user.Fullname,
+		Email:    user.Email,
+		TimeZone: user.TimeZone,
+	}
+}
+
+func (s *userService) RequestDeletion(id int, actor string) (model.User, error) {
+	user, err := s.userRepo.GetUserByID(id)
+	if err != nil {
+		return model.User{}, fmt.Errorf("%w: %d", model.ErrUserNotFound, id)
+	}
+
+	if user.DeletionDueAt != nil {
+		return model.User{}, model.ErrDeletionScheduled
+	}
+
+	now := time.Now()
+	dueAt := now.Add(config.GetDeletionGracePeriod())
+	user.DeletionDueAt = &dueAt
+	user.UpdatedAt = now
+
+	record := model.AuditRecord{
+		Action:    "user.deletion_requested",
+		Actor:     actor,
+		UserID:    user.ID,
+		Detail:    fmt.Sprintf("erasure due at %s", dueAt.Format(time.RFC3339)),
+		CreatedAt: now,
+	}
+
+	if err := s.userRepo.ScheduleDeletion(user, record); err != nil {
+		return model.User{}, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) RestoreAccount(user *model.User) (model.User, error) {
+	dbUser, err := s.GetByEmail(user.Email)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	if user.Password != dbUser.Password {
+		return model.User{}, model.ErrWrongCredentials
+	}
+
+	return s.restore(dbUser, dbUser.Email)
+}
+
+func (s *userService) RestoreAccountByID(id int, actor string) (model.User, error) {
+	user, err := s.userRepo.GetUserByID(id)
+	if err != nil {
+		return model.User{}, fmt.Errorf("%w: %d", model.ErrUserNotFound, id)
+	}
+
+	return s.restore(user, actor)
+}
+
+func (s *userService) restore(user model.User, actor string) (model.User, error) {
+	if user.DeletionDueAt == nil {
+		return model.User{}, model.ErrDeletionNotScheduled
+	}
+
+	now := time.Now()
+	user.DeletionDueAt = nil
+	user.UpdatedAt = now
+
+	record := model.AuditRecord{
+		Action:    "user.restored",
+		Actor:     actor,
+		UserID:    user.ID,
+		Detail:    "pending deletion cancelled",
+		CreatedAt: now,
+	}
+
+	if err := s.userRepo.RestoreUser(user, record); err != nil {
+		return model.User{}, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) PurgeDeletedAccounts(now time.Time) (int, error) {
+	users, err := s.userRepo.GetUserList()
+	if err != nil {
+		return 0, err
+	}
+
+	erased := 0
+	for _, user := range users {
+		if user.DeletionDueAt == nil || user.DeletionDueAt.After(now) {
+			continue
+		}
+
+		record := model.AuditRecord{
+			Action:    "user.erased",
+			Actor:     "system",
+			UserID:    user.ID,
+			CreatedAt: now,
+		}
+
+		err := s.userRepo.EraseUser(user.ID, record)
+		if errors.Is(err, model.ErrNoWorkspaceSuccessor) {
+			continue
+		}
+		if err != nil {
+			return erased, err
+		}
+		erased++
+	}
+
+	return erased, nil
+}
+
+func (s *userService) GetAuditLog() ([]model.AuditRecord, error) {
+	return s.userRepo.GetAuditLog()
 }
@@ -0,0 +1,389 @@
+/** 
+ * Package service provides interfaces and implementations for workspaces, their members and invitations.
+ * 
+ * Interfaces:
+ * 
+ * - WorkspaceService: Interface defining methods for workspace management.
+ *   Methods:
+ *   - Create: Method to create a workspace owned by a user.
+ *   - GetList: Method to retrieve the workspaces a user can work in.
+ *   - Current: Method to retrieve the workspace a user selected to work in.
+ *   - Select: Method to select the workspace a user works in.
+ *   - GetMembers: Method to retrieve the members of a workspace.
+ *   - Invite: Method to invite a user to a workspace by email address.
+ *   - GetInvitations: Method to retrieve the pending invitations of a user.
+ *   - RespondInvitation: Method to accept or decline an invitation.
+ *   - ChangeRole: Method to change the role of a member.
+ *   - RemoveMember: Method to remove a member from a workspace.
+ *   - TaskWorkspace: Method to retrieve the workspace a task belongs to.
+ *   - CategoryWorkspace: Method to retrieve the workspace a category belongs to.
+ * 
+ * Structs:
+ * 
+ * - workspaceService: Struct implementing the WorkspaceService interface.
+ *   Fields:
+ *   - workspaceRepository: Instance of repo.WorkspaceRepository for workspace repository operations.
+ *   - userRepository: Instance of repo.UserRepository used to look users up and store their selected workspace.
+ *   - taskRepository: Instance of repo.TaskRepository used to find the workspace of a task.
+ *   - categoryRepository: Instance of repo.CategoryRepository used to find the workspace of a category.
+ *   Methods:
+ *   - NewWorkspaceService: Function to create a new instance of workspaceService.
+ *   - Create: Method to create a workspace with the trimmed name, making its creator the owner.
+ *   - GetList: Method to retrieve the default workspace followed by the workspaces the user is a member of, in ID order,
+ *     with the role of the user and the workspace the user works in marked as current.
+ *   - Current: Method to retrieve the workspace the user selected. A user who was removed from the selected workspace
+ *     falls back to the default workspace.
+ *   - Select: Method to store the workspace the user works in. Only the default workspace and workspaces the user is a
+ *     member of can be selected.
+ *   - GetMembers: Method to retrieve the profiles and roles of the members of a workspace, in user ID order. Only
+ *     members can list them; the default workspace has no member list.
+ *   - Invite: Method for the owner or an admin to invite an email address with a role, member by default. Inviting an
+ *     address with a pending invitation updates its role instead of sending a second one.
+ *   - GetInvitations: Method to retrieve the pending invitations sent to the user's email address, with the workspace names.
+ *   - RespondInvitation: Method to accept or decline a pending invitation addressed to the user. Accepting makes the
+ *     user a member with the invited role.
+ *   - ChangeRole: Method for the owner to give a member another role. Ownership cannot be given away.
+ *   - RemoveMember: Method for a member to leave a workspace or for the owner or an admin to remove a member. Admins
+ *     cannot remove other admins and nobody can remove the owner.
+ *   - TaskWorkspace: Method to retrieve the ID of the workspace a task belongs to.
+ *   - CategoryWorkspace: Method to retrieve the ID of the workspace a category belongs to.
+ */
+
+package service
+
+import (
+	"a21hc3NpZ25tZW50/model"
+	repo "a21hc3NpZ25tZW50/repository"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type WorkspaceService interface {
+	Create(name string, ownerID int) (model.Workspace, error)
+	GetList(userID int) ([]model.Membership, error)
+	Current(userID int) (model.Membership, error)
+	Select(userID, workspaceID int) error
+	GetMembers(workspaceID, userID int) ([]model.MemberProfile, error)
+	Invite(workspaceID, actorID int, email string, role model.WorkspaceRole) (model.Invitation, error)
+	GetInvitations(userID int) ([]model.Invitation, error)
+	RespondInvitation(invitationID, userID int, accept bool) (model.Invitation, error)
+	ChangeRole(workspaceID, actorID, userID int, role model.WorkspaceRole) error
+	RemoveMember(workspaceID, actorID, userID int) error
+	TaskWorkspace(taskID int) (int, error)
+	CategoryWorkspace(categoryID int) (int, error)
+}
+
+type workspaceService struct {
+	workspaceRepository repo.WorkspaceRepository
+	userRepository      repo.UserRepository
+	taskRepository      repo.TaskRepository
+	categoryRepository  repo.CategoryRepository
+}
+
+func NewWorkspaceService(workspaceRepository repo.WorkspaceRepository, userRepository repo.UserRepository, taskRepository repo.TaskRepository, categoryRepository repo.CategoryRepository) WorkspaceService {
+	return &workspaceService{workspaceRepository, userRepository, taskRepository, categoryRepository}
+}
+
+func (s *workspaceService) Create(name string, ownerID int) (model.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.Workspace{}, model.ErrInvalidWorkspace
+	}
+
+	return s.workspaceRepository.Store(model.Workspace{
+		Name:      name,
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *workspaceService) GetList(userID int) ([]model.Membership, error) {
+	current, err := s.Current(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.workspaceRepository.GetMembers(0, userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].WorkspaceID < members[j].WorkspaceID })
+
+	list := []model.Membership{defaultMembership()}
+	for _, member := range members {
+		workspace, err := s.workspaceRepository.GetByID(member.WorkspaceID)
+		if err != nil {
+			continue
+		}
+		list = append(list, model.Membership{Workspace: *workspace, Role: member.Role})
+	}
+
+	for i := range list {
+		list[i].Current = list[i].Workspace.ID == current.Workspace.ID
+	}
+	return list, nil
+}
+
+func (s *workspaceService) Current(userID int) (model.Membership, error) {
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return model.Membership{}, err
+	}
+
+	membership, err := s.membership(user.WorkspaceID, userID)
+	if err != nil {
+		membership = defaultMembership()
+	}
+	membership.Current = true
+	return membership, nil
+}
+
+func (s *workspaceService) Select(userID, workspaceID int) error {
+	if _, err := s.membership(workspaceID, userID); err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	user.WorkspaceID = workspaceID
+	return s.userRepository.UpdateUser(user)
+}
+
+func (s *workspaceService) GetMembers(workspaceID, userID int) ([]model.MemberProfile, error) {
+	if workspaceID == 0 {
+		return nil, fmt.Errorf("%w: %d", model.ErrWorkspaceNotFound, workspaceID)
+	}
+	if _, err := s.membership(workspaceID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.workspaceRepository.GetMembers(workspaceID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []model.MemberProfile{}
+	for _, member := range members {
+		user, err := s.userRepository.GetUserByID(member.UserID)
+		if err != nil {
+			continue
+		}
+		profiles = append(profiles, model.MemberProfile{UserProfile: toProfile(user), Role: member.Role, JoinedAt: member.JoinedAt})
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].ID < profiles[j].ID })
+	return profiles, nil
+}
+
+func (s *workspaceService) Invite(workspaceID, actorID int, email string, role model.WorkspaceRole) (model.Invitation, error) {
+	if role == "" {
+		role = model.RoleMember
+	}
+	if !role.Valid() || role == model.RoleOwner {
+		return model.Invitation{}, fmt.Errorf("%w: %q", model.ErrInvalidWorkspaceRole, role)
+	}
+
+	actor, err := s.membership(workspaceID, actorID)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	if workspaceID == 0 || !actor.Role.CanManageMembers() {
+		return model.Invitation{}, fmt.Errorf("%w: cannot invite to workspace %d", model.ErrWorkspaceForbidden, workspaceID)
+	}
+
+	email = strings.TrimSpace(email)
+	user, err := s.userRepository.GetUserByEmail(email)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	if user.ID != 0 {
+		if _, err := s.membership(workspaceID, user.ID); err == nil {
+			return model.Invitation{}, fmt.Errorf("%w: %s", model.ErrAlreadyMember, email)
+		}
+	}
+
+	invitations, err := s.workspaceRepository.GetInvitations(workspaceID, email)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	for _, invitation := range invitations {
+		if invitation.Status == model.InvitationPending {
+			invitation.Role = role
+			return s.workspaceRepository.StoreInvitation(invitation)
+		}
+	}
+
+	return s.workspaceRepository.StoreInvitation(model.Invitation{
+		WorkspaceID: workspaceID,
+		Email:       email,
+		Role:        role,
+		InvitedBy:   actorID,
+		Status:      model.InvitationPending,
+		CreatedAt:   time.Now(),
+	})
+}
+
+func (s *workspaceService) GetInvitations(userID int) ([]model.Invitation, error) {
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.workspaceRepository.GetInvitations(0, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []model.Invitation{}
+	for _, invitation := range invitations {
+		if invitation.Status != model.InvitationPending {
+			continue
+		}
+		workspace, err := s.workspaceRepository.GetByID(invitation.WorkspaceID)
+		if err != nil {
+			continue
+		}
+		invitation.WorkspaceName = workspace.Name
+		pending = append(pending, invitation)
+	}
+	return pending, nil
+}
+
+func (s *workspaceService) RespondInvitation(invitationID, userID int, accept bool) (model.Invitation, error) {
+	user, err := s.userRepository.GetUserByID(userID)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	invitation, err := s.workspaceRepository.GetInvitationByID(invitationID)
+	if err != nil || !strings.EqualFold(invitation.Email, user.Email) || invitation.Status != model.InvitationPending {
+		return model.Invitation{}, fmt.Errorf("%w: %d", model.ErrInvitationNotFound, invitationID)
+	}
+
+	now := time.Now()
+	invitation.RespondedAt = &now
+	if !accept {
+		invitation.Status = model.InvitationDeclined
+		return s.workspaceRepository.StoreInvitation(*invitation)
+	}
+
+	invitation.Status = model.InvitationAccepted
+	if _, err := s.membership(invitation.WorkspaceID, userID); err == nil {
+		return s.workspaceRepository.StoreInvitation(*invitation)
+	}
+
+	member := model.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      userID,
+		Role:        invitation.Role,
+		JoinedAt:    now,
+	}
+	if err := s.workspaceRepository.AcceptInvitation(*invitation, member); err != nil {
+		return model.Invitation{}, fmt.Errorf("%w: %d", model.ErrWorkspaceNotFound, invitation.WorkspaceID)
+	}
+	return *invitation, nil
+}
+
+func (s *workspaceService) ChangeRole(workspaceID, actorID, userID int, role model.WorkspaceRole) error {
+	if !role.Valid() || role == model.RoleOwner {
+		return fmt.Errorf("%w: %q", model.ErrInvalidWorkspaceRole, role)
+	}
+
+	actor, err := s.membership(workspaceID, actorID)
+	if err != nil {
+		return err
+	}
+	if actor.Role != model.RoleOwner {
+		return fmt.Errorf("%w: only the owner can change roles", model.ErrWorkspaceForbidden)
+	}
+
+	member, err := s.member(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == model.RoleOwner {
+		return model.ErrWorkspaceOwner
+	}
+
+	member.Role = role
+	return s.workspaceRepository.PutMember(member)
+}
+
+func (s *workspaceService) RemoveMember(workspaceID, actorID, userID int) error {
+	actor, err := s.membership(workspaceID, actorID)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.member(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == model.RoleOwner {
+		return model.ErrWorkspaceOwner
+	}
+
+	if actorID != userID {
+		if !actor.Role.CanManageMembers() || (member.Role == model.RoleAdmin && actor.Role != model.RoleOwner) {
+			return fmt.Errorf("%w: cannot remove user %d", model.ErrWorkspaceForbidden, userID)
+		}
+	}
+	return s.workspaceRepository.RemoveMember(workspaceID, userID)
+}
+
+func (s *workspaceService) TaskWorkspace(taskID int) (int, error) {
+	task, err := s.taskRepository.GetByID(taskID)
+	if err != nil {
+		return 0, err
+	}
+	return task.WorkspaceID, nil
+}
+
+func (s *workspaceService) CategoryWorkspace(categoryID int) (int, error) {
+	category, err := s.categoryRepository.GetByID(categoryID)
+	if err != nil {
+		return 0, err
+	}
+	return category.WorkspaceID, nil
+}
+
+// membership returns the workspace as seen by the user, refusing workspaces the user is not a member of.
+// Every user is a member of the default workspace.
+func (s *workspaceService) membership(workspaceID, userID int) (model.Membership, error) {
+	if workspaceID == 0 {
+		return defaultMembership(), nil
+	}
+
+	member, err := s.member(workspaceID, userID)
+	if err != nil {
+		return model.Membership{}, fmt.Errorf("%w: %d", model.ErrWorkspaceNotFound, workspaceID)
+	}
+
+	workspace, err := s.workspaceRepository.GetByID(workspaceID)
+	if err != nil {
+		return model.Membership{}, fmt.Errorf("%w: %d", model.ErrWorkspaceNotFound, workspaceID)
+	}
+	return model.Membership{Workspace: *workspace, Role: member.Role}, nil
+}
+
+func (s *workspaceService) member(workspaceID, userID int) (model.WorkspaceMember, error) {
+	if workspaceID == 0 {
+		return model.WorkspaceMember{}, fmt.Errorf("%w: user %d", model.ErrMemberNotFound, userID)
+	}
+
+	members, err := s.workspaceRepository.GetMembers(workspaceID, userID)
+	if err != nil {
+		return model.WorkspaceMember{}, err
+	}
+	if len(members) == 0 {
+		return model.WorkspaceMember{}, fmt.Errorf("%w: user %d", model.ErrMemberNotFound, userID)
+	}
+	return members[0], nil
+}
+
+func defaultMembership() model.Membership {
+	return model.Membership{Workspace: model.Workspace{Name: model.DefaultWorkspaceName}, Role: model.RoleMember}
+}